@@ -161,8 +161,8 @@ func Server(cfg *config.Config) *cli.Command {
 
 				gr.Add(func() error {
 					logger.Info().Str("service", server.Name()).Msg("Reporting settings bundles to settings service")
-					svc.RegisterSettingsBundles(&logger)
-					svc.RegisterPermissions(&logger)
+					svc.RegisterSettingsBundles(&logger, cfg.TokenManager.JWTSecret)
+					svc.RegisterPermissions(&logger, cfg.TokenManager.JWTSecret)
 					return server.Run()
 				}, func(_ error) {
 					logger.Info().
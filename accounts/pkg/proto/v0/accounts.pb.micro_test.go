@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/micro/go-micro/v2/client"
@@ -17,6 +21,7 @@ import (
 	"github.com/owncloud/ocis/accounts/pkg/config"
 	"github.com/owncloud/ocis/accounts/pkg/proto/v0"
 	svc "github.com/owncloud/ocis/accounts/pkg/service/v0"
+	"github.com/owncloud/ocis/ocis-pkg/middleware"
 	"github.com/owncloud/ocis/ocis-pkg/service/grpc"
 	settings "github.com/owncloud/ocis/settings/pkg/proto/v0"
 	"github.com/stretchr/testify/assert"
@@ -26,6 +31,8 @@ import (
 
 var service = grpc.Service{}
 
+const jwtSecret = "accounts-test-secret"
+
 var dataPath = createTmpDir()
 
 var newCreatedAccounts = []string{}
@@ -79,6 +86,7 @@ func init() {
 
 	cfg := config.New()
 	cfg.Repo.Disk.Path = dataPath
+	cfg.TokenManager.JWTSecret = jwtSecret
 	var hdlr *svc.Service
 	var err error
 
@@ -94,6 +102,10 @@ func init() {
 	if err != nil {
 		log.Fatal("could not register the Groups handler")
 	}
+	err = proto.RegisterBackupServiceHandler(service.Server(), hdlr)
+	if err != nil {
+		log.Fatal("could not register the Backup handler")
+	}
 
 	err = service.Server().Start()
 	if err != nil {
@@ -669,6 +681,31 @@ func TestListAccountsWithFilterQuery(t *testing.T) {
 			query:       "mail eq 'user1@example.com'",
 			expectedIDs: []string{user1.Id},
 		},
+		{
+			name:        "ListAccounts with exact match on on_premises_sam_account_name AND mail",
+			query:       "on_premises_sam_account_name eq 'user1' and mail eq 'user1@example.com'",
+			expectedIDs: []string{user1.Id},
+		},
+		{
+			name:        "ListAccounts without match on on_premises_sam_account_name AND mail",
+			query:       "on_premises_sam_account_name eq 'user1' and mail eq 'user2@example.com'",
+			expectedIDs: []string{},
+		},
+		{
+			name:        "ListAccounts with exact match on on_premises_sam_account_name OR mail",
+			query:       "on_premises_sam_account_name eq 'user1' or mail eq 'wololo@example.com'",
+			expectedIDs: []string{user1.Id},
+		},
+		{
+			name:        "ListAccounts with multiple matches on the start and end of mail",
+			query:       "startswith(mail,'user') and endswith(mail,'@example.com')",
+			expectedIDs: []string{user1.Id, user2.Id},
+		},
+		{
+			name:        "ListAccounts with a match inside of on_premises_sam_account_name",
+			query:       "contains(on_premises_sam_account_name,'er2')",
+			expectedIDs: []string{user2.Id},
+		},
 		//{
 		//	name:        "ListAccounts with exact match on id",
 		//	query:       "id eq 'f9149a32-2b8e-4f04-9e8d-937d81712b9a'",
@@ -737,12 +774,61 @@ func TestListAccountsWithFilterQuery(t *testing.T) {
 			for _, acc := range res.Accounts {
 				ids = append(ids, acc.Id)
 			}
-			assert.Equal(t, scenario.expectedIDs, ids)
+			assert.ElementsMatch(t, scenario.expectedIDs, ids)
 			cleanUp(t)
 		})
 	}
 }
 
+func TestListAccountsWithUnsupportedQuery(t *testing.T) {
+	_, err := createAccount(t, "user1")
+	assert.NoError(t, err)
+
+	cl := proto.NewAccountsService("com.owncloud.api.accounts", service.Client())
+	_, err = cl.ListAccounts(context.Background(), &proto.ListAccountsRequest{Query: "not (mail eq 'user1@example.com')"})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, int(merrors.Parse(err.Error()).Code))
+
+	cleanUp(t)
+}
+
+func TestListAccountsLogin(t *testing.T) {
+	account := &proto.Account{
+		Id:                       user1.Id,
+		AccountEnabled:           true,
+		PreferredName:            user1.PreferredName,
+		OnPremisesSamAccountName: user1.OnPremisesSamAccountName,
+		Mail:                     user1.Mail,
+		PasswordProfile:          &proto.PasswordProfile{Password: "it's 'quoted'"},
+	}
+	cl := proto.NewAccountsService("com.owncloud.api.accounts", service.Client())
+	_, err := cl.CreateAccount(context.Background(), &proto.CreateAccountRequest{Account: account})
+	assert.NoError(t, err)
+	newCreatedAccounts = append(newCreatedAccounts, account.Id)
+
+	// quotes are escaped by doubling them
+	res, err := cl.ListAccounts(context.Background(), &proto.ListAccountsRequest{
+		Query: "login eq 'user1' and password eq 'it''s ''quoted'''",
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, res.Accounts, 1) {
+		assert.Equal(t, user1.Id, res.Accounts[0].Id)
+	}
+
+	for _, query := range []string{
+		"login eq 'user1' and password eq 'it's 'quoted''",
+		"login eq 'user1' and password eq 'it''s' and password eq 'quoted'''",
+		"login eq 'user1'' and password eq ''x' and password eq 'it''s ''quoted'''",
+	} {
+		res, err = cl.ListAccounts(context.Background(), &proto.ListAccountsRequest{Query: query})
+		if err == nil {
+			assert.Empty(t, res.Accounts, query)
+		}
+	}
+
+	cleanUp(t)
+}
+
 func TestGetAccount(t *testing.T) {
 	createAccount(t, "user1")
 
@@ -1279,3 +1365,46 @@ func TestAccountUpdateReadOnlyField(t *testing.T) {
 
 	cleanUp(t)
 }
+
+func TestSnapshotRestore(t *testing.T) {
+	cl := proto.NewBackupService("com.owncloud.api.accounts", service.Client())
+	ctx, err := middleware.ContextWithServiceToken(context.Background(), "ocis", jwtSecret)
+	assert.NoError(t, err)
+
+	stream, err := cl.Snapshot(ctx, &proto.SnapshotRequest{})
+	assert.NoError(t, err)
+	var chunks []*proto.SnapshotResponse
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err) {
+			return
+		}
+		chunks = append(chunks, chunk)
+	}
+	assert.NotEmpty(t, chunks)
+
+	_, err = createAccount(t, "user1")
+	assert.NoError(t, err)
+
+	upload := strconv.FormatInt(time.Now().UnixNano(), 10)
+	for _, chunk := range chunks {
+		_, err := cl.Restore(ctx, &proto.RestoreRequest{Upload: upload, Path: chunk.Path, Data: chunk.Data})
+		assert.NoError(t, err)
+	}
+	_, err = cl.Restore(ctx, &proto.RestoreRequest{Upload: upload, Commit: true})
+	assert.NoError(t, err)
+
+	resp, err := listAccounts(t)
+	assert.NoError(t, err)
+	assertResponseNotContainsUser(t, resp, getAccount("user1"))
+	assertResponseContainsGroup(t, listGroups(t), getGroup("users"))
+
+	// the unique indexes were rebuilt without the account created after the snapshot
+	_, err = createAccount(t, "user1")
+	assert.NoError(t, err)
+
+	cleanUp(t)
+}
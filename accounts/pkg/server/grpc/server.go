@@ -29,6 +29,9 @@ func Server(opts ...Option) grpc.Service {
 	if err := proto.RegisterIndexServiceHandler(service.Server(), handler); err != nil {
 		options.Logger.Fatal().Err(err).Msg("could not register index handler")
 	}
+	if err := proto.RegisterBackupServiceHandler(service.Server(), handler); err != nil {
+		options.Logger.Fatal().Err(err).Msg("could not register backup handler")
+	}
 
 	service.Init()
 	return service
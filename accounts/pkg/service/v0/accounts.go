@@ -11,6 +11,7 @@ import (
 	"path"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/owncloud/ocis/ocis-pkg/log"
@@ -39,8 +40,9 @@ var passwordValidCache = sync.NewCache(1024)
 const passwordValidCacheExpiration = 10 * time.Minute
 
 // an auth request is currently hardcoded and has to match this regex
-// login eq \"teddy\" and password eq \"F&1!b90t111!\"
-var authQuery = regexp.MustCompile(`^login eq '(.*)' and password eq '(.*)'$`) // TODO how is ' escaped in the password?
+// login eq 'teddy' and password eq 'F&1!b90t111!'
+// quotes in the login and the password are escaped by doubling them, as in OData string literals
+var authQuery = regexp.MustCompile(`^login eq '((?:[^']|'')*)' and password eq '((?:[^']|'')*)'$`)
 
 func (s Service) expandMemberOf(a *proto.Account) {
 	if a == nil {
@@ -81,6 +83,19 @@ func (s Service) hasAccountManagementPermissions(ctx context.Context) bool {
 	return s.RoleManager.FindPermissionByID(ctx, roleIDs, AccountManagementPermissionID) != nil
 }
 
+// hasBackupPermission checks if the request may take or restore a snapshot, which contains the password hashes. Unlike
+// hasAccountManagementPermissions it doesn't trust requests without roles, only internal requests with a service token.
+func (s Service) hasBackupPermission(ctx context.Context) bool {
+	if _, ok := middleware.ServiceFromContext(ctx, s.Config.TokenManager.JWTSecret); ok {
+		return true
+	}
+	roleIDs, ok := roles.ReadRoleIDsFromContext(ctx)
+	if !ok {
+		return false
+	}
+	return s.RoleManager.FindPermissionByID(ctx, roleIDs, AccountManagementPermissionID) != nil
+}
+
 func (s Service) hasSelfManagementPermissions(ctx context.Context) bool {
 	// get roles from context
 	roleIDs, ok := roles.ReadRoleIDsFromContext(ctx)
@@ -232,6 +247,9 @@ func (s Service) ListAccounts(ctx context.Context, in *proto.ListAccountsRequest
 	}
 
 	searchResults, err := s.findAccountsByQuery(ctx, in.Query)
+	if err != nil {
+		return merrors.BadRequest(s.id, "could not query accounts: %v", err.Error())
+	}
 	out.Accounts = make([]*proto.Account, 0, len(searchResults))
 
 	for _, hit := range searchResults {
@@ -315,6 +333,9 @@ func (s Service) GetAccount(ctx context.Context, in *proto.GetAccountRequest, ou
 
 // CreateAccount implements the AccountsServiceHandler interface
 func (s Service) CreateAccount(ctx context.Context, in *proto.CreateAccountRequest, out *proto.Account) (err error) {
+	s.writes.RLock()
+	defer s.writes.RUnlock()
+
 	if !s.hasAccountManagementPermissions(ctx) {
 		return merrors.Forbidden(s.id, "no permission for CreateAccount")
 	}
@@ -421,7 +442,9 @@ func (s Service) CreateAccount(ctx context.Context, in *proto.CreateAccountReque
 	if s.RoleService == nil {
 		return merrors.InternalServerError(s.id, "could not assign role to account: roleService not configured")
 	}
-	if _, err = s.RoleService.AssignRoleToUser(ctx, &settings.AssignRoleToUserRequest{
+	// the permission to create the account was checked above, the settings service only accepts the assignment of
+	// other accounts from admins or other services
+	if _, err = s.RoleService.AssignRoleToUser(settingsContext(ctx, s.Config.TokenManager.JWTSecret), &settings.AssignRoleToUserRequest{
 		AccountUuid: out.Id,
 		RoleId:      settings_svc.BundleUUIDRoleUser,
 	}); err != nil {
@@ -447,6 +470,9 @@ func (s Service) rollbackCreateAccount(ctx context.Context, acc *proto.Account)
 // read only fields are ignored
 // TODO how can we unset specific values? using the update mask
 func (s Service) UpdateAccount(ctx context.Context, in *proto.UpdateAccountRequest, out *proto.Account) (err error) {
+	s.writes.RLock()
+	defer s.writes.RUnlock()
+
 	hasSelf := s.hasSelfManagementPermissions(ctx)
 	hasManagement := s.hasAccountManagementPermissions(ctx)
 	if !hasSelf && !hasManagement {
@@ -613,6 +639,9 @@ var updatableAccountPaths = map[string]struct{}{
 
 // DeleteAccount implements the AccountsServiceHandler interface
 func (s Service) DeleteAccount(ctx context.Context, in *proto.DeleteAccountRequest, out *empty.Empty) (err error) {
+	s.writes.RLock()
+	defer s.writes.RUnlock()
+
 	if !s.hasAccountManagementPermissions(ctx) {
 		return merrors.Forbidden(s.id, "no permission for DeleteAccount")
 	}
@@ -634,7 +663,7 @@ func (s Service) DeleteAccount(ctx context.Context, in *proto.DeleteAccountReque
 
 	// delete member relationship in groups
 	for i := range a.MemberOf {
-		err = s.RemoveMember(ctx, &proto.RemoveMemberRequest{
+		err = s.removeMember(ctx, &proto.RemoveMemberRequest{
 			GroupId:   a.MemberOf[i].Id,
 			AccountId: id,
 		}, a.MemberOf[i])
@@ -825,6 +854,9 @@ func (s Service) accountExists(ctx context.Context, username, mail, id string) (
 
 func getAuthQueryMatch(query string) (match []string, authRequest bool) {
 	match = authQuery.FindStringSubmatch(query)
+	for i := 1; i < len(match); i++ {
+		match[i] = strings.ReplaceAll(match[i], "''", "'")
+	}
 	return match, len(match) == 3
 }
 
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
@@ -25,6 +26,8 @@ import (
 
 const dataPath = "/var/tmp/ocis-accounts-tests"
 
+const testJWTSecret = "accounts-test-secret"
+
 var (
 	roleServiceMock settings.RoleService
 	s               *Service
@@ -34,6 +37,7 @@ func init() {
 	cfg := config.New()
 	cfg.Server.Name = "accounts"
 	cfg.Repo.Disk.Path = dataPath
+	cfg.TokenManager.JWTSecret = testJWTSecret
 	logger := olog.NewLogger(olog.Color(true), olog.Pretty(true))
 	roleServiceMock = buildRoleServiceMock()
 	roleManager := roles.NewManager(
@@ -303,6 +307,227 @@ func TestPermissionsDeleteAccount(t *testing.T) {
 	}
 }
 
+// TestPermissionsAddMember checks permission handling on AddMember
+func TestPermissionsAddMember(t *testing.T) {
+	var scenarios = []struct {
+		name            string
+		roleIDs         []string
+		permissionError error
+	}{
+		{
+			"AddMember succeeds when no role IDs in context",
+			nil,
+			nil,
+		},
+		{
+			"AddMember fails when no admin roleID in context",
+			[]string{ssvc.BundleUUIDRoleUser, ssvc.BundleUUIDRoleGuest},
+			merrors.Forbidden(s.id, "no permission for AddMember"),
+		},
+		{
+			"AddMember succeeds when admin roleID in context",
+			[]string{ssvc.BundleUUIDRoleAdmin},
+			nil,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			teardown := setup()
+			defer teardown()
+
+			ctx := buildTestCtx(t, scenario.roleIDs)
+			request := &proto.AddMemberRequest{}
+			response := &proto.Group{}
+			err := s.AddMember(ctx, request, response)
+			if scenario.permissionError != nil {
+				assert.Equal(t, scenario.permissionError, err)
+			} else if err != nil {
+				// we are only checking permissions here, so just check that the error code is not 403
+				merr := merrors.FromError(err)
+				assert.NotEqual(t, http.StatusForbidden, merr.GetCode())
+			}
+		})
+	}
+}
+
+// TestPermissionsRemoveMember checks permission handling on RemoveMember
+func TestPermissionsRemoveMember(t *testing.T) {
+	var scenarios = []struct {
+		name            string
+		roleIDs         []string
+		permissionError error
+	}{
+		{
+			"RemoveMember succeeds when no role IDs in context",
+			nil,
+			nil,
+		},
+		{
+			"RemoveMember fails when no admin roleID in context",
+			[]string{ssvc.BundleUUIDRoleUser, ssvc.BundleUUIDRoleGuest},
+			merrors.Forbidden(s.id, "no permission for RemoveMember"),
+		},
+		{
+			"RemoveMember succeeds when admin roleID in context",
+			[]string{ssvc.BundleUUIDRoleAdmin},
+			nil,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			teardown := setup()
+			defer teardown()
+
+			ctx := buildTestCtx(t, scenario.roleIDs)
+			request := &proto.RemoveMemberRequest{}
+			response := &proto.Group{}
+			err := s.RemoveMember(ctx, request, response)
+			if scenario.permissionError != nil {
+				assert.Equal(t, scenario.permissionError, err)
+			} else if err != nil {
+				// we are only checking permissions here, so just check that the error code is not 403
+				merr := merrors.FromError(err)
+				assert.NotEqual(t, http.StatusForbidden, merr.GetCode())
+			}
+		})
+	}
+}
+
+// snapshotStream collects the chunks of a snapshot.
+type snapshotStream struct {
+	proto.BackupService_SnapshotStream
+	chunks []*proto.SnapshotResponse
+}
+
+func (s *snapshotStream) Send(res *proto.SnapshotResponse) error {
+	s.chunks = append(s.chunks, res)
+	return nil
+}
+
+// TestPermissionsSnapshot checks permission handling on Snapshot
+func TestPermissionsSnapshot(t *testing.T) {
+	var scenarios = []struct {
+		name            string
+		roleIDs         []string
+		serviceToken    bool
+		permissionError error
+	}{
+		{
+			"Snapshot fails when no role IDs in context",
+			nil,
+			false,
+			merrors.Forbidden(s.id, "no permission for Snapshot"),
+		},
+		{
+			"Snapshot fails when no admin roleID in context",
+			[]string{ssvc.BundleUUIDRoleUser, ssvc.BundleUUIDRoleGuest},
+			false,
+			merrors.Forbidden(s.id, "no permission for Snapshot"),
+		},
+		{
+			"Snapshot succeeds when admin roleID in context",
+			[]string{ssvc.BundleUUIDRoleAdmin},
+			false,
+			nil,
+		},
+		{
+			"Snapshot succeeds with a service token",
+			nil,
+			true,
+			nil,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			teardown := setup()
+			defer teardown()
+
+			ctx := buildTestCtx(t, scenario.roleIDs)
+			if scenario.serviceToken {
+				var err error
+				ctx, err = middleware.ContextWithServiceToken(ctx, "ocis", testJWTSecret)
+				assert.NoError(t, err)
+			}
+			stream := &snapshotStream{}
+			err := s.Snapshot(ctx, &proto.SnapshotRequest{}, stream)
+			if scenario.permissionError != nil {
+				assert.Equal(t, scenario.permissionError, err)
+				assert.Empty(t, stream.chunks)
+			} else if err != nil {
+				// we are only checking permissions here, so just check that the error code is not 403
+				merr := merrors.FromError(err)
+				assert.NotEqual(t, http.StatusForbidden, merr.GetCode())
+			}
+		})
+	}
+}
+
+// TestPermissionsRestore checks permission handling on Restore
+func TestPermissionsRestore(t *testing.T) {
+	var scenarios = []struct {
+		name            string
+		roleIDs         []string
+		serviceToken    bool
+		permissionError error
+	}{
+		{
+			"Restore fails when no role IDs in context",
+			nil,
+			false,
+			merrors.Forbidden(s.id, "no permission for Restore"),
+		},
+		{
+			"Restore fails when no admin roleID in context",
+			[]string{ssvc.BundleUUIDRoleUser, ssvc.BundleUUIDRoleGuest},
+			false,
+			merrors.Forbidden(s.id, "no permission for Restore"),
+		},
+		{
+			"Restore succeeds when admin roleID in context",
+			[]string{ssvc.BundleUUIDRoleAdmin},
+			false,
+			nil,
+		},
+		{
+			"Restore succeeds with a service token",
+			nil,
+			true,
+			nil,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			teardown := setup()
+			defer teardown()
+
+			ctx := buildTestCtx(t, scenario.roleIDs)
+			if scenario.serviceToken {
+				var err error
+				ctx, err = middleware.ContextWithServiceToken(ctx, "ocis", testJWTSecret)
+				assert.NoError(t, err)
+			}
+			request := &proto.RestoreRequest{Upload: "permissions", Path: "accounts/new.json", Data: []byte("{}")}
+			response := &proto.RestoreResponse{}
+			err := s.Restore(ctx, request, response)
+			dir, _ := s.uploads.Take("permissions")
+			defer os.RemoveAll(dir)
+			if scenario.permissionError != nil {
+				assert.Equal(t, scenario.permissionError, err)
+				files, _ := ioutil.ReadDir(dir)
+				assert.Empty(t, files)
+			} else if err != nil {
+				// we are only checking permissions here, so just check that the error code is not 403
+				merr := merrors.FromError(err)
+				assert.NotEqual(t, http.StatusForbidden, merr.GetCode())
+			}
+		})
+	}
+}
+
 func buildTestCtx(t *testing.T, roleIDs []string) context.Context {
 	ctx := context.Background()
 	if roleIDs != nil {
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	merrors "github.com/micro/go-micro/v2/errors"
+	"github.com/owncloud/ocis/accounts/pkg/proto/v0"
+	"github.com/owncloud/ocis/accounts/pkg/storage"
+	"github.com/owncloud/ocis/ocis-pkg/backup"
+	"google.golang.org/protobuf/encoding/protojson"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// Snapshot implements the BackupServiceHandler interface. It exports every account and group as a json file, so the
+// snapshot works the same for all repos. The index is left out, it is rebuilt on restore.
+func (s Service) Snapshot(ctx context.Context, in *proto.SnapshotRequest, stream proto.BackupService_SnapshotStream) error {
+	if !s.hasBackupPermission(ctx) {
+		return merrors.Forbidden(s.id, "no permission for Snapshot")
+	}
+
+	dir, err := ioutil.TempDir("", "ocis-accounts-snapshot-")
+	if err != nil {
+		return merrors.InternalServerError(s.id, "could not create snapshot directory")
+	}
+	defer os.RemoveAll(dir)
+
+	if err := s.export(ctx, dir); err != nil {
+		s.log.Error().Err(err).Msg("could not export accounts and groups")
+		return merrors.InternalServerError(s.id, "could not export accounts and groups")
+	}
+	return backup.Send(dir, func(path string, data []byte) error {
+		return stream.Send(&proto.SnapshotResponse{Path: path, Data: data})
+	})
+}
+
+// export writes all accounts and groups into dir, changes are blocked meanwhile.
+func (s Service) export(ctx context.Context, dir string) error {
+	s.writes.Lock()
+	defer s.writes.Unlock()
+
+	accounts := make([]*proto.Account, 0)
+	if err := s.repo.LoadAccounts(ctx, &accounts); err != nil {
+		return err
+	}
+	for _, a := range accounts {
+		if err := writeRecord(filepath.Join(dir, "accounts", a.Id+".json"), a); err != nil {
+			return err
+		}
+	}
+
+	groups := make([]*proto.Group, 0)
+	if err := s.repo.LoadGroups(ctx, &groups); err != nil {
+		return err
+	}
+	for _, g := range groups {
+		if err := writeRecord(filepath.Join(dir, "groups", g.Id+".json"), g); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Restore implements the BackupServiceHandler interface. The files of a snapshot are staged until the restore is
+// committed, then the accounts and groups replace the existing ones and the index is rebuilt.
+func (s Service) Restore(ctx context.Context, in *proto.RestoreRequest, out *proto.RestoreResponse) error {
+	if !s.hasBackupPermission(ctx) {
+		return merrors.Forbidden(s.id, "no permission for Restore")
+	}
+
+	if !in.Commit {
+		if err := s.uploads.Stage(in.Upload, in.Path, in.Data); err != nil {
+			return merrors.BadRequest(s.id, "could not stage %v: %v", in.Path, err)
+		}
+		return nil
+	}
+
+	dir, err := s.uploads.Take(in.Upload)
+	if err != nil {
+		return merrors.BadRequest(s.id, "%v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// read the whole snapshot first, so that a broken snapshot doesn't leave a partial restore behind
+	accounts := make([]*proto.Account, 0)
+	groups := make([]*proto.Group, 0)
+	err = readRecords(filepath.Join(dir, "accounts"), func() protobuf.Message {
+		a := &proto.Account{}
+		accounts = append(accounts, a)
+		return a
+	})
+	if err == nil {
+		err = readRecords(filepath.Join(dir, "groups"), func() protobuf.Message {
+			g := &proto.Group{}
+			groups = append(groups, g)
+			return g
+		})
+	}
+	if err != nil {
+		return merrors.BadRequest(s.id, "could not read snapshot: %v", err)
+	}
+
+	if err := s.replace(ctx, accounts, groups); err != nil {
+		s.log.Error().Err(err).Msg("could not restore accounts and groups")
+		return merrors.InternalServerError(s.id, "could not restore accounts and groups: %v", err)
+	}
+	s.log.Info().Int("accounts", len(accounts)).Int("groups", len(groups)).Msg("restored accounts and groups")
+	return nil
+}
+
+// replace replaces all accounts and groups with the given ones and rebuilds the index. If that fails, the previous
+// accounts and groups are written back, so that a failed restore doesn't lose them.
+func (s Service) replace(ctx context.Context, accounts []*proto.Account, groups []*proto.Group) error {
+	s.writes.Lock()
+	defer s.writes.Unlock()
+
+	previousAccounts := make([]*proto.Account, 0)
+	if err := s.repo.LoadAccounts(ctx, &previousAccounts); err != nil {
+		return err
+	}
+	previousGroups := make([]*proto.Group, 0)
+	if err := s.repo.LoadGroups(ctx, &previousGroups); err != nil {
+		return err
+	}
+
+	err := s.overwrite(ctx, previousAccounts, previousGroups, accounts, groups)
+	if err == nil {
+		if err = s.rebuildIndex(ctx); err == nil {
+			return nil
+		}
+	}
+
+	s.log.Error().Err(err).Msg("could not replace accounts and groups, rolling back")
+	if rerr := s.overwrite(ctx, accounts, groups, previousAccounts, previousGroups); rerr != nil {
+		return fmt.Errorf("%v, the previous accounts and groups could not be written back: %v", err, rerr)
+	}
+	if rerr := s.rebuildIndex(ctx); rerr != nil {
+		return fmt.Errorf("%v, the index of the previous accounts and groups could not be rebuilt: %v", err, rerr)
+	}
+	return err
+}
+
+// overwrite writes the given accounts and groups, then deletes the previous ones that are not among them.
+func (s Service) overwrite(ctx context.Context, previousAccounts []*proto.Account, previousGroups []*proto.Group, accounts []*proto.Account, groups []*proto.Group) error {
+	keepAccounts := map[string]bool{}
+	for _, a := range accounts {
+		if err := s.repo.WriteAccount(ctx, a); err != nil {
+			return err
+		}
+		keepAccounts[a.Id] = true
+	}
+	keepGroups := map[string]bool{}
+	for _, g := range groups {
+		if err := s.repo.WriteGroup(ctx, g); err != nil {
+			return err
+		}
+		keepGroups[g.Id] = true
+	}
+
+	// previous records may not have been written at all when rolling back
+	for _, a := range previousAccounts {
+		if keepAccounts[a.Id] {
+			continue
+		}
+		if err := s.repo.DeleteAccount(ctx, a.Id); err != nil && !storage.IsNotFoundErr(err) {
+			return err
+		}
+	}
+	for _, g := range previousGroups {
+		if keepGroups[g.Id] {
+			continue
+		}
+		if err := s.repo.DeleteGroup(ctx, g.Id); err != nil && !storage.IsNotFoundErr(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeRecord(file string, m protobuf.Message) error {
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, data, 0600)
+}
+
+// readRecords unmarshals every file in dir into a new message. A missing dir contains no records.
+func readRecords(dir string, next func() protobuf.Message) error {
+	infos, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, info := range infos {
+		data, err := ioutil.ReadFile(filepath.Join(dir, info.Name()))
+		if err != nil {
+			return err
+		}
+		if err := protojson.Unmarshal(data, next()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
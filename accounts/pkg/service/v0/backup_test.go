@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/owncloud/ocis/accounts/pkg/proto/v0"
+	"github.com/owncloud/ocis/accounts/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingRepo fails to write the account with the id failAccount.
+type failingRepo struct {
+	storage.Repo
+	failAccount string
+}
+
+func (r failingRepo) WriteAccount(ctx context.Context, a *proto.Account) error {
+	if a.Id == r.failAccount {
+		return errors.New("disk full")
+	}
+	return r.Repo.WriteAccount(ctx, a)
+}
+
+func testAccount(id string) *proto.Account {
+	return &proto.Account{Id: id, PreferredName: id, OnPremisesSamAccountName: id, Mail: id + "@example.org"}
+}
+
+// setupRepo recreates the folders of the disk repo after an earlier teardown.
+func setupRepo(t *testing.T) (teardown func()) {
+	for _, folder := range []string{"accounts", "groups"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dataPath, folder), 0700))
+	}
+	return setup()
+}
+
+func loadAccountIDs(t *testing.T, svc Service) []string {
+	accounts := make([]*proto.Account, 0)
+	require.NoError(t, svc.repo.LoadAccounts(context.Background(), &accounts))
+	ids := make([]string, 0, len(accounts))
+	for _, a := range accounts {
+		ids = append(ids, a.Id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestReplace(t *testing.T) {
+	teardown := setupRepo(t)
+	defer teardown()
+
+	svc := *s
+	ctx := context.Background()
+	require.NoError(t, svc.replace(ctx, []*proto.Account{testAccount("old-1"), testAccount("old-2")}, nil))
+	assert.Equal(t, []string{"old-1", "old-2"}, loadAccountIDs(t, svc))
+
+	require.NoError(t, svc.replace(ctx, []*proto.Account{testAccount("old-2"), testAccount("new-1")}, nil))
+	assert.Equal(t, []string{"new-1", "old-2"}, loadAccountIDs(t, svc))
+}
+
+func TestReplaceRollsBack(t *testing.T) {
+	teardown := setupRepo(t)
+	defer teardown()
+
+	svc := *s
+	ctx := context.Background()
+	require.NoError(t, svc.replace(ctx, []*proto.Account{testAccount("old-1"), testAccount("old-2")}, nil))
+
+	svc.repo = failingRepo{Repo: s.repo, failAccount: "new-2"}
+	err := svc.replace(ctx, []*proto.Account{testAccount("new-1"), testAccount("new-2")}, nil)
+	assert.EqualError(t, err, "disk full")
+	assert.Equal(t, []string{"old-1", "old-2"}, loadAccountIDs(t, svc))
+}
@@ -68,6 +68,9 @@ func (s Service) ListGroups(ctx context.Context, in *proto.ListGroupsRequest, ou
 	}
 
 	searchResults, err := s.findGroupsByQuery(ctx, in.Query)
+	if err != nil {
+		return merrors.BadRequest(s.id, "could not query groups: %v", err.Error())
+	}
 	out.Groups = make([]*proto.Group, 0, len(searchResults))
 
 	for _, hit := range searchResults {
@@ -117,6 +120,9 @@ func (s Service) GetGroup(c context.Context, in *proto.GetGroupRequest, out *pro
 
 // CreateGroup implements the GroupsServiceHandler interface
 func (s Service) CreateGroup(c context.Context, in *proto.CreateGroupRequest, out *proto.Group) (err error) {
+	s.writes.RLock()
+	defer s.writes.RUnlock()
+
 	if in.Group == nil {
 		return merrors.InternalServerError(s.id, "invalid group: empty")
 	}
@@ -177,6 +183,9 @@ func (s Service) UpdateGroup(c context.Context, in *proto.UpdateGroupRequest, ou
 
 // DeleteGroup implements the GroupsServiceHandler interface
 func (s Service) DeleteGroup(c context.Context, in *proto.DeleteGroupRequest, out *empty.Empty) (err error) {
+	s.writes.RLock()
+	defer s.writes.RUnlock()
+
 	var id string
 	if id, err = cleanupID(in.Id); err != nil {
 		return merrors.InternalServerError(s.id, "could not clean up group id: %v", err.Error())
@@ -192,7 +201,7 @@ func (s Service) DeleteGroup(c context.Context, in *proto.DeleteGroupRequest, ou
 
 	// delete memberof relationship in users
 	for i := range g.Members {
-		err = s.RemoveMember(c, &proto.RemoveMemberRequest{
+		err = s.removeMember(c, &proto.RemoveMemberRequest{
 			AccountId: g.Members[i].Id,
 			GroupId:   id,
 		}, g)
@@ -220,6 +229,13 @@ func (s Service) DeleteGroup(c context.Context, in *proto.DeleteGroupRequest, ou
 
 // AddMember implements the GroupsServiceHandler interface
 func (s Service) AddMember(c context.Context, in *proto.AddMemberRequest, out *proto.Group) (err error) {
+	s.writes.RLock()
+	defer s.writes.RUnlock()
+
+	if !s.hasAccountManagementPermissions(c) {
+		return merrors.Forbidden(s.id, "no permission for AddMember")
+	}
+
 	// cleanup ids
 	var groupID string
 	if groupID, err = cleanupID(in.GroupId); err != nil {
@@ -293,7 +309,18 @@ func (s Service) AddMember(c context.Context, in *proto.AddMemberRequest, out *p
 
 // RemoveMember implements the GroupsServiceHandler interface
 func (s Service) RemoveMember(c context.Context, in *proto.RemoveMemberRequest, out *proto.Group) (err error) {
+	s.writes.RLock()
+	defer s.writes.RUnlock()
+
+	if !s.hasAccountManagementPermissions(c) {
+		return merrors.Forbidden(s.id, "no permission for RemoveMember")
+	}
+
+	return s.removeMember(c, in, out)
+}
 
+// removeMember removes the account from the group, for callers that already hold the read lock of writes.
+func (s Service) removeMember(c context.Context, in *proto.RemoveMemberRequest, out *proto.Group) (err error) {
 	// cleanup ids
 	var groupID string
 	if groupID, err = cleanupID(in.GroupId); err != nil {
@@ -14,6 +14,14 @@ import (
 
 // RebuildIndex deletes all indices (in memory and on storage) and rebuilds them from scratch.
 func (s Service) RebuildIndex(ctx context.Context, request *proto.RebuildIndexRequest, response *proto.RebuildIndexResponse) error {
+	s.writes.RLock()
+	defer s.writes.RUnlock()
+
+	return s.rebuildIndex(ctx)
+}
+
+// rebuildIndex deletes all indices and rebuilds them from the accounts and groups in the repo.
+func (s Service) rebuildIndex(ctx context.Context) error {
 	if err := s.index.Reset(); err != nil {
 		return fmt.Errorf("failed to delete index containers: %w", err)
 	}
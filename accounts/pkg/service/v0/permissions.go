@@ -26,12 +26,12 @@ const (
 )
 
 // RegisterPermissions registers permissions for account management and group management with the settings service.
-func RegisterPermissions(l *olog.Logger) {
+func RegisterPermissions(l *olog.Logger, jwtSecret string) {
 	service := settings.NewBundleService("com.owncloud.api.settings", grpc.DefaultClient)
 
 	permissionRequests := generateAccountManagementPermissionsRequests()
 	for i := range permissionRequests {
-		res, err := service.AddSettingToBundle(context.Background(), &permissionRequests[i])
+		res, err := service.AddSettingToBundle(settingsContext(context.Background(), jwtSecret), &permissionRequests[i])
 		bundleID := permissionRequests[i].BundleId
 		if err != nil {
 			l.Err(err).Str("bundle", bundleID).Str("setting", permissionRequests[i].Setting.Id).Msg("error adding permission to bundle")
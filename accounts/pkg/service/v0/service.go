@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/owncloud/ocis/ocis-pkg/service/grpc"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/owncloud/ocis/accounts/pkg/config"
 	"github.com/owncloud/ocis/accounts/pkg/proto/v0"
+	"github.com/owncloud/ocis/ocis-pkg/backup"
 	"github.com/owncloud/ocis/ocis-pkg/log"
 	"github.com/owncloud/ocis/ocis-pkg/roles"
 	settings "github.com/owncloud/ocis/settings/pkg/proto/v0"
@@ -55,6 +57,8 @@ func New(opts ...Option) (s *Service, err error) {
 		RoleService: roleService,
 		RoleManager: roleManager,
 		repo:        createMetadataStorage(cfg, logger),
+		writes:      &sync.RWMutex{},
+		uploads:     backup.NewUploads(cfg.Server.Name),
 	}
 
 	if s.index, err = s.buildIndex(); err != nil {
@@ -311,21 +315,21 @@ func (s Service) createDefaultAccounts() (err error) {
 	}
 
 	// set role for admin users and regular users
-	assignRoleToUser("058bff95-6708-4fe5-91e4-9ea3d377588b", settings_svc.BundleUUIDRoleAdmin, s.RoleService, s.log)
+	assignRoleToUser("058bff95-6708-4fe5-91e4-9ea3d377588b", settings_svc.BundleUUIDRoleAdmin, s.RoleService, s.Config.TokenManager.JWTSecret, s.log)
 	for _, accountID := range []string{
 		"058bff95-6708-4fe5-91e4-9ea3d377588b", //moss
 		"ddc2004c-0977-11eb-9d3f-a793888cd0f8", //admin
 		"820ba2a1-3f54-4538-80a4-2d73007e30bf", //idp
 		"bc596f3c-c955-4328-80a0-60d018b4ad57", //reva
 	} {
-		assignRoleToUser(accountID, settings_svc.BundleUUIDRoleAdmin, s.RoleService, s.log)
+		assignRoleToUser(accountID, settings_svc.BundleUUIDRoleAdmin, s.RoleService, s.Config.TokenManager.JWTSecret, s.log)
 	}
 	for _, accountID := range []string{
 		"4c510ada-c86b-4815-8820-42cdf82c3d51", //einstein
 		"f7fbf8c8-139b-4376-b307-cf0a8c2d0d9c", //marie
 		"932b4540-8d16-481e-8ef4-588e4b6b151c", //richard
 	} {
-		assignRoleToUser(accountID, settings_svc.BundleUUIDRoleUser, s.RoleService, s.log)
+		assignRoleToUser(accountID, settings_svc.BundleUUIDRoleUser, s.RoleService, s.Config.TokenManager.JWTSecret, s.log)
 	}
 	return nil
 }
@@ -403,8 +407,8 @@ func (s Service) createDefaultGroups() (err error) {
 	return nil
 }
 
-func assignRoleToUser(accountID, roleID string, rs settings.RoleService, logger log.Logger) (ok bool) {
-	_, err := rs.AssignRoleToUser(context.Background(), &settings.AssignRoleToUserRequest{
+func assignRoleToUser(accountID, roleID string, rs settings.RoleService, jwtSecret string, logger log.Logger) (ok bool) {
+	_, err := rs.AssignRoleToUser(settingsContext(context.Background(), jwtSecret), &settings.AssignRoleToUserRequest{
 		AccountUuid: accountID,
 		RoleId:      roleID,
 	})
@@ -437,6 +441,13 @@ type Service struct {
 	RoleService settings.RoleService
 	RoleManager *roles.Manager
 	repo        storage.Repo
+
+	// writes is read locked by every change of accounts and groups and write locked by snapshots and restores, so
+	// that they don't see changes that are only half done.
+	writes *sync.RWMutex
+
+	// uploads are the restore uploads that are not committed yet.
+	uploads *backup.Uploads
 }
 
 func cleanupID(id string) (string, error) {
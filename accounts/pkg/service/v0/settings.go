@@ -4,6 +4,7 @@ import (
 	"context"
 
 	olog "github.com/owncloud/ocis/ocis-pkg/log"
+	"github.com/owncloud/ocis/ocis-pkg/middleware"
 	"github.com/owncloud/ocis/ocis-pkg/service/grpc"
 	settings "github.com/owncloud/ocis/settings/pkg/proto/v0"
 	ssvc "github.com/owncloud/ocis/settings/pkg/service/v0"
@@ -14,7 +15,7 @@ const (
 )
 
 // RegisterSettingsBundles pushes the settings bundle definitions for this extension to the ocis-settings service.
-func RegisterSettingsBundles(l *olog.Logger) {
+func RegisterSettingsBundles(l *olog.Logger, jwtSecret string) {
 	service := settings.NewBundleService("com.owncloud.api.settings", grpc.DefaultClient)
 
 	bundleRequests := []settings.SaveBundleRequest{
@@ -22,7 +23,7 @@ func RegisterSettingsBundles(l *olog.Logger) {
 	}
 
 	for i := range bundleRequests {
-		res, err := service.SaveBundle(context.Background(), &bundleRequests[i])
+		res, err := service.SaveBundle(settingsContext(context.Background(), jwtSecret), &bundleRequests[i])
 		if err != nil {
 			l.Err(err).Str("bundle", bundleRequests[i].Bundle.Id).Msg("Error registering bundle")
 		} else {
@@ -32,7 +33,7 @@ func RegisterSettingsBundles(l *olog.Logger) {
 
 	permissionRequests := generateProfilePermissionsRequests()
 	for i := range permissionRequests {
-		res, err := service.AddSettingToBundle(context.Background(), &permissionRequests[i])
+		res, err := service.AddSettingToBundle(settingsContext(context.Background(), jwtSecret), &permissionRequests[i])
 		bundleID := permissionRequests[i].BundleId
 		if err != nil {
 			l.Err(err).Str("bundle", bundleID).Str("setting", permissionRequests[i].Setting.Id).Msg("Error adding setting to bundle")
@@ -42,6 +43,16 @@ func RegisterSettingsBundles(l *olog.Logger) {
 	}
 }
 
+// settingsContext returns the context for internal requests of the accounts service to the settings service.
+func settingsContext(ctx context.Context, jwtSecret string) context.Context {
+	serviceCtx, err := middleware.ContextWithServiceToken(ctx, "accounts", jwtSecret)
+	if err != nil {
+		// the settings service rejects the request without the token
+		return ctx
+	}
+	return serviceCtx
+}
+
 var languageSetting = settings.Setting_SingleChoiceValue{
 	SingleChoiceValue: &settings.SingleChoiceList{
 		Options: []*settings.ListOption{
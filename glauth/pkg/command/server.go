@@ -27,6 +27,7 @@ import (
 	"github.com/owncloud/ocis/glauth/pkg/flagset"
 	"github.com/owncloud/ocis/glauth/pkg/server/debug"
 	"github.com/owncloud/ocis/glauth/pkg/server/glauth"
+	settings "github.com/owncloud/ocis/settings/pkg/proto/v0"
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/trace"
 )
@@ -44,6 +45,7 @@ func Server(cfg *config.Config) *cli.Command {
 
 			cfg.Backend.Servers = c.StringSlice("backend-server")
 			cfg.Fallback.Servers = c.StringSlice("fallback-server")
+			cfg.ACL.ServiceAccounts = c.StringSlice("acl-service-account")
 
 			return ParseConfig(c, cfg)
 		},
@@ -187,22 +189,43 @@ func Server(cfg *config.Config) *cli.Command {
 					},
 				}
 
-				if lscfg.Enabled {
+				if lscfg.Enabled || cfg.Ldap.StartTLS {
 					if err := crypto.GenCert(cfg.Ldaps.Cert, cfg.Ldaps.Key, logger); err != nil {
 						logger.Fatal().Err(err).Msgf("Could not generate test-certificate")
 					}
 				}
 
+				schema, err := glauth.NewSchema(map[string]string{
+					"cn":            cfg.Schema.CN,
+					"sn":            cfg.Schema.SN,
+					"givenName":     cfg.Schema.GivenName,
+					"gecos":         cfg.Schema.Gecos,
+					"homeDirectory": cfg.Schema.HomeDirectory,
+					"loginShell":    cfg.Schema.LoginShell,
+				})
+				if err != nil {
+					logger.Error().
+						Err(err).
+						Msg("Invalid account schema")
+					return err
+				}
+
 				as, gs := getAccountsServices()
 				server, err := glauth.Server(
 					glauth.AccountsService(as),
 					glauth.GroupsService(gs),
+					glauth.RoleService(settings.NewRoleService("com.owncloud.api.settings", grpc.DefaultClient)),
 					glauth.Logger(logger),
 					glauth.LDAP(&lcfg),
 					glauth.LDAPS(&lscfg),
 					glauth.Backend(&bcfg),
 					glauth.Fallback(&fcfg),
 					glauth.RoleBundleUUID(cfg.RoleBundleUUID),
+					glauth.AllowAnonymous(cfg.ACL.AllowAnonymous),
+					glauth.ServiceAccounts(cfg.ACL.ServiceAccounts),
+					glauth.SelfOnly(cfg.ACL.SelfOnly),
+					glauth.AccountSchema(schema),
+					glauth.StartTLS(cfg.Ldap.StartTLS),
 				)
 
 				if err != nil {
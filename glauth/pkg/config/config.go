@@ -33,8 +33,9 @@ type Tracing struct {
 
 // Ldap defined the available LDAP configuration.
 type Ldap struct {
-	Address string
-	Enabled bool
+	Address  string
+	Enabled  bool
+	StartTLS bool
 }
 
 // Ldaps defined the available LDAPS configuration.
@@ -56,6 +57,23 @@ type Backend struct {
 	UseGraphAPI bool
 }
 
+// ACL defines who may read which entries.
+type ACL struct {
+	AllowAnonymous  bool
+	ServiceAccounts []string
+	SelfOnly        bool
+}
+
+// Schema defines the templates used to render account attributes.
+type Schema struct {
+	CN            string
+	SN            string
+	GivenName     string
+	Gecos         string
+	HomeDirectory string
+	LoginShell    string
+}
+
 // Config combines all available configuration parts.
 type Config struct {
 	File           string
@@ -67,6 +85,8 @@ type Config struct {
 	Ldaps          Ldaps
 	Backend        Backend
 	Fallback       Backend
+	ACL            ACL
+	Schema         Schema
 	Version        string
 	RoleBundleUUID string
 }
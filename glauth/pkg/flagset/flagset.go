@@ -122,6 +122,68 @@ func ServerWithConfig(cfg *config.Config) []cli.Flag {
 			EnvVars:     []string{"GLAUTH_ROLE_BUNDLE_ID"},
 			Destination: &cfg.RoleBundleUUID,
 		},
+		&cli.BoolFlag{
+			Name:        "acl-allow-anonymous",
+			Value:       false,
+			Usage:       "Allow anonymous binds to search",
+			EnvVars:     []string{"GLAUTH_ACL_ALLOW_ANONYMOUS"},
+			Destination: &cfg.ACL.AllowAnonymous,
+		},
+		&cli.StringSliceFlag{
+			Name:    "acl-service-account",
+			Value:   cli.NewStringSlice("idp", "reva"),
+			Usage:   `accounts that may read all entries --acl-service-account idp [--acl-service-account reva]`,
+			EnvVars: []string{"GLAUTH_ACL_SERVICE_ACCOUNTS"},
+		},
+		&cli.BoolFlag{
+			Name:        "acl-self-only",
+			Value:       false,
+			Usage:       "Only allow accounts to read their own entry and groups",
+			EnvVars:     []string{"GLAUTH_ACL_SELF_ONLY"},
+			Destination: &cfg.ACL.SelfOnly,
+		},
+		&cli.StringFlag{
+			Name:        "schema-cn",
+			Value:       "{{.DisplayName}}",
+			Usage:       "Template for the common name of accounts, the username is always included",
+			EnvVars:     []string{"GLAUTH_SCHEMA_CN"},
+			Destination: &cfg.Schema.CN,
+		},
+		&cli.StringFlag{
+			Name:        "schema-sn",
+			Value:       "{{surname .DisplayName}}",
+			Usage:       "Template for the surname of accounts",
+			EnvVars:     []string{"GLAUTH_SCHEMA_SN"},
+			Destination: &cfg.Schema.SN,
+		},
+		&cli.StringFlag{
+			Name:        "schema-given-name",
+			Value:       "{{givenName .DisplayName}}",
+			Usage:       "Template for the given name of accounts",
+			EnvVars:     []string{"GLAUTH_SCHEMA_GIVEN_NAME"},
+			Destination: &cfg.Schema.GivenName,
+		},
+		&cli.StringFlag{
+			Name:        "schema-gecos",
+			Value:       "{{.DisplayName}}",
+			Usage:       "Template for the gecos field of accounts",
+			EnvVars:     []string{"GLAUTH_SCHEMA_GECOS"},
+			Destination: &cfg.Schema.Gecos,
+		},
+		&cli.StringFlag{
+			Name:        "schema-home-directory",
+			Value:       "/home/{{.PreferredName}}",
+			Usage:       "Template for the home directory of accounts",
+			EnvVars:     []string{"GLAUTH_SCHEMA_HOME_DIRECTORY"},
+			Destination: &cfg.Schema.HomeDirectory,
+		},
+		&cli.StringFlag{
+			Name:        "schema-login-shell",
+			Value:       "/bin/bash",
+			Usage:       "Template for the login shell of accounts",
+			EnvVars:     []string{"GLAUTH_SCHEMA_LOGIN_SHELL"},
+			Destination: &cfg.Schema.LoginShell,
+		},
 
 		&cli.StringFlag{
 			Name:        "ldap-addr",
@@ -137,6 +199,13 @@ func ServerWithConfig(cfg *config.Config) []cli.Flag {
 			EnvVars:     []string{"GLAUTH_LDAP_ENABLED"},
 			Destination: &cfg.Ldap.Enabled,
 		},
+		&cli.BoolFlag{
+			Name:        "ldap-start-tls",
+			Value:       false,
+			Usage:       "Allow upgrading ldap connections with StartTLS, uses the ldaps certificate and key",
+			EnvVars:     []string{"GLAUTH_LDAP_START_TLS"},
+			Destination: &cfg.Ldap.StartTLS,
+		},
 
 		&cli.StringFlag{
 			Name:        "ldaps-addr",
@@ -85,19 +85,27 @@ func (h chainHandler) Close(boundDN string, conn net.Conn) error {
 	return nil
 }
 
-// Add is not yet supported for the chain backend
+// Add creates entries in the backend, the fallback is read only
 func (h chainHandler) Add(boundDN string, req ldap.AddRequest, conn net.Conn) (result ldap.LDAPResultCode, err error) {
-	return ldap.LDAPResultInsufficientAccessRights, nil
+	return h.b.Add(boundDN, req, conn)
 }
 
-// Modify is not yet supported for the chain backend
+// Modify changes entries in the backend, the fallback is read only
 func (h chainHandler) Modify(boundDN string, req ldap.ModifyRequest, conn net.Conn) (result ldap.LDAPResultCode, err error) {
-	return ldap.LDAPResultInsufficientAccessRights, nil
+	return h.b.Modify(boundDN, req, conn)
 }
 
-// Delete is not yet supported for the chain backend
+// Delete removes entries from the backend, the fallback is read only
 func (h chainHandler) Delete(boundDN string, deleteDN string, conn net.Conn) (result ldap.LDAPResultCode, err error) {
-	return ldap.LDAPResultInsufficientAccessRights, nil
+	return h.b.Delete(boundDN, deleteDN, conn)
+}
+
+// Extended passes extended operations to the backend, if it supports them
+func (h chainHandler) Extended(boundDN string, req ldap.ExtendedRequest, conn net.Conn) (result ldap.LDAPResultCode, err error) {
+	if e, ok := h.b.(ldap.Extender); ok {
+		return e.Extended(boundDN, req, conn)
+	}
+	return ldap.LDAPResultUnwillingToPerform, nil
 }
 
 // NewChainHandler implements a chain backend with two backends
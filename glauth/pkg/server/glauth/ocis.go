@@ -5,17 +5,23 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"net/http"
+	"reflect"
 	"strconv"
 	"strings"
 
 	"github.com/glauth/glauth/pkg/handler"
 	"github.com/glauth/glauth/pkg/stats"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	merrors "github.com/micro/go-micro/v2/errors"
 	"github.com/micro/go-micro/v2/metadata"
 	ber "github.com/nmcclain/asn1-ber"
 	"github.com/nmcclain/ldap"
 	accounts "github.com/owncloud/ocis/accounts/pkg/proto/v0"
 	"github.com/owncloud/ocis/ocis-pkg/log"
 	"github.com/owncloud/ocis/ocis-pkg/middleware"
+	settings "github.com/owncloud/ocis/settings/pkg/proto/v0"
+	"google.golang.org/genproto/protobuf/field_mask"
 )
 
 type queryType string
@@ -25,14 +31,23 @@ const (
 	groupsQuery queryType = "groups"
 )
 
+// passwordModifyOID identifies the password modify extended operation
+const passwordModifyOID = "1.3.6.1.4.1.4203.1.11.1"
+
 type ocisHandler struct {
-	as          accounts.AccountsService
-	gs          accounts.GroupsService
-	log         log.Logger
-	basedn      string
-	nameFormat  string
-	groupFormat string
-	rbid        string
+	as              accounts.AccountsService
+	gs              accounts.GroupsService
+	rs              settings.RoleService
+	log             log.Logger
+	basedn          string
+	nameFormat      string
+	groupFormat     string
+	rbid            string
+	sessions        *sessions
+	allowAnonymous  bool
+	serviceAccounts []string
+	selfOnly        bool
+	schema          *Schema
 }
 
 func (h ocisHandler) Bind(bindDN, bindSimplePw string, conn net.Conn) (ldap.LDAPResultCode, error) {
@@ -68,7 +83,7 @@ func (h ocisHandler) Bind(bindDN, bindSimplePw string, conn net.Conn) (ldap.LDAP
 			Msg("BindDN should have only one or two parts")
 		return ldap.LDAPResultInvalidCredentials, nil
 	}
-	userName := strings.TrimPrefix(parts[0], "cn=")
+	userName := strings.TrimPrefix(parts[0], strings.ToLower(h.nameFormat)+"=")
 
 	// TODO make glauth context aware
 	ctx := context.Background()
@@ -90,7 +105,7 @@ func (h ocisHandler) Bind(bindDN, bindSimplePw string, conn net.Conn) (ldap.LDAP
 		// TODO this allows lookung up users when you know the username using basic auth
 		// adding the password to the query is an option but sending the sover the wira a la scim seems ugly
 		// but to set passwords our accounts need it anyway
-		Query: fmt.Sprintf("login eq '%s' and password eq '%s'", userName, bindSimplePw),
+		Query: fmt.Sprintf("login eq '%s' and password eq '%s'", escapeValue(userName), escapeValue(bindSimplePw)),
 	})
 	if err != nil || len(res.Accounts) == 0 {
 		h.log.Error().
@@ -103,6 +118,19 @@ func (h ocisHandler) Bind(bindDN, bindSimplePw string, conn net.Conn) (ldap.LDAP
 		return ldap.LDAPResultInvalidCredentials, nil
 	}
 
+	sess, err := h.newSession(bindDN, res.Accounts[0])
+	if err != nil {
+		h.log.Error().
+			Err(err).
+			Str("handler", "ocis").
+			Str("username", userName).
+			Str("binddn", bindDN).
+			Interface("src", conn.RemoteAddr()).
+			Msg("could not look up roles")
+		return ldap.LDAPResultOperationsError, nil
+	}
+	h.sessions.set(conn, sess)
+
 	stats.Frontend.Add("bind_successes", 1)
 	h.log.Debug().
 		Str("handler", "ocis").
@@ -126,15 +154,25 @@ func (h ocisHandler) Search(bindDN string, searchReq ldap.SearchRequest, conn ne
 	stats.Frontend.Add("search_reqs", 1)
 
 	// validate the user is authenticated and has appropriate access
+	var sess *session
 	if len(bindDN) < 1 {
-		return ldap.ServerSearchResult{
-			ResultCode: ldap.LDAPResultInsufficientAccessRights,
-		}, fmt.Errorf("search error: Anonymous BindDN not allowed %s", bindDN)
-	}
-	if !strings.HasSuffix(bindDN, baseDN) {
-		return ldap.ServerSearchResult{
-			ResultCode: ldap.LDAPResultInsufficientAccessRights,
-		}, fmt.Errorf("search error: BindDN %s not in our BaseDN %s", bindDN, h.basedn)
+		if !h.allowAnonymous {
+			return ldap.ServerSearchResult{
+				ResultCode: ldap.LDAPResultInsufficientAccessRights,
+			}, fmt.Errorf("search error: Anonymous BindDN not allowed %s", bindDN)
+		}
+	} else {
+		if !strings.HasSuffix(bindDN, baseDN) {
+			return ldap.ServerSearchResult{
+				ResultCode: ldap.LDAPResultInsufficientAccessRights,
+			}, fmt.Errorf("search error: BindDN %s not in our BaseDN %s", bindDN, h.basedn)
+		}
+		var err error
+		if sess, err = h.session(bindDN, conn); err != nil {
+			return ldap.ServerSearchResult{
+				ResultCode: ldap.LDAPResultInsufficientAccessRights,
+			}, fmt.Errorf("search error: no session for BindDN %s: %s", bindDN, err.Error())
+		}
 	}
 	if !strings.HasSuffix(searchBaseDN, h.basedn) {
 		return ldap.ServerSearchResult{
@@ -173,29 +211,23 @@ func (h ocisHandler) Search(bindDN string, searchReq ldap.SearchRequest, conn ne
 
 		// check if the searchBaseDN already has a username and add it to the query
 		parts := strings.Split(strings.TrimSuffix(searchBaseDN, baseDN), ",")
-		if len(parts) > 0 && strings.HasPrefix(parts[0], "cn=") {
+		namePrefix := strings.ToLower(h.nameFormat) + "="
+		if len(parts) > 0 && strings.HasPrefix(parts[0], namePrefix) {
 			if len(query) > 0 {
-				query += " AND "
+				query = group(query) + " and "
 			}
-			query += fmt.Sprintf("on_premises_sam_account_name eq '%s'", escapeValue(strings.TrimPrefix(parts[0], "cn=")))
+			query += fmt.Sprintf("on_premises_sam_account_name eq '%s'", escapeValue(strings.TrimPrefix(parts[0], namePrefix)))
 		}
 	}
 
-	// TODO make glauth context aware
-	ctx := context.Background()
-
-	// use a session with the bound user?
-	roleIDs, err := json.Marshal([]string{h.rbid})
-	if err != nil {
-		h.log.Error().
-			Err(err).
-			Str("handler", "ocis").
-			Msg("could not marshal roleid json")
-		return ldap.ServerSearchResult{
-			ResultCode: ldap.LDAPResultOperationsError,
-		}, nil
+	// anonymous binds, if allowed, and service accounts read all entries, all other accounts search with their roles
+	ctx := h.serviceContext()
+	if sess != nil {
+		ctx = h.readContext(sess)
 	}
-	ctx = metadata.Set(ctx, middleware.RoleIDs, string(roleIDs))
+
+	operational := wantsOperational(searchReq.Attributes)
+	includeOperational(searchReq.Attributes)
 
 	entries := []*ldap.Entry{}
 	h.log.Debug().
@@ -226,7 +258,23 @@ func (h ocisHandler) Search(bindDN string, searchReq ldap.SearchRequest, conn ne
 				ResultCode: ldap.LDAPResultOperationsError,
 			}, fmt.Errorf("search error: error listing users")
 		}
-		entries = append(entries, h.mapAccounts(accounts.Accounts)...)
+		if h.selfOnly && sess != nil && !sess.service {
+			accounts.Accounts = ownAccount(accounts.Accounts, sess.account.Id)
+		}
+		accountEntries, err := h.mapAccounts(accounts.Accounts, operational)
+		if err != nil {
+			h.log.Error().
+				Err(err).
+				Str("handler", "ocis").
+				Str("binddn", bindDN).
+				Interface("src", conn.RemoteAddr()).
+				Msg("Could not map accounts")
+
+			return ldap.ServerSearchResult{
+				ResultCode: ldap.LDAPResultOperationsError,
+			}, fmt.Errorf("search error: error mapping users")
+		}
+		entries = append(entries, accountEntries...)
 	case groupsQuery:
 		groups, err := h.gs.ListGroups(ctx, &accounts.ListGroupsRequest{
 			Query: query,
@@ -246,7 +294,10 @@ func (h ocisHandler) Search(bindDN string, searchReq ldap.SearchRequest, conn ne
 				ResultCode: ldap.LDAPResultOperationsError,
 			}, fmt.Errorf("search error: error listing groups")
 		}
-		entries = append(entries, h.mapGroups(groups.Groups)...)
+		if h.selfOnly && sess != nil && !sess.service {
+			groups.Groups = ownGroups(groups.Groups, sess.account.Id)
+		}
+		entries = append(entries, h.mapGroups(groups.Groups, operational)...)
 	}
 
 	stats.Frontend.Add("search_successes", 1)
@@ -267,6 +318,30 @@ func (h ocisHandler) Search(bindDN string, searchReq ldap.SearchRequest, conn ne
 	}, nil
 }
 
+// ownAccount filters the accounts down to the one with the given id
+func ownAccount(list []*accounts.Account, id string) []*accounts.Account {
+	for _, a := range list {
+		if a.Id == id {
+			return []*accounts.Account{a}
+		}
+	}
+	return nil
+}
+
+// ownGroups filters the groups down to the ones the account with the given id is a member of
+func ownGroups(list []*accounts.Group, id string) []*accounts.Group {
+	var groups []*accounts.Group
+	for _, g := range list {
+		for _, m := range g.Members {
+			if m.Id == id {
+				groups = append(groups, g)
+				break
+			}
+		}
+	}
+	return groups
+}
+
 func attribute(name string, values ...string) *ldap.EntryAttribute {
 	return &ldap.EntryAttribute{
 		Name:   name,
@@ -274,17 +349,44 @@ func attribute(name string, values ...string) *ldap.EntryAttribute {
 	}
 }
 
-func (h ocisHandler) mapAccounts(accounts []*accounts.Account) []*ldap.Entry {
+// userDN returns the distinguished name of the account with the given username
+func (h ocisHandler) userDN(name string) string {
+	return fmt.Sprintf("%s=%s,%s=%s,%s", h.nameFormat, name, h.groupFormat, "users", h.basedn)
+}
+
+// groupDN returns the distinguished name of the group with the given name
+func (h ocisHandler) groupDN(name string) string {
+	return fmt.Sprintf("%s=%s,%s=%s,%s", h.nameFormat, name, h.groupFormat, "groups", h.basedn)
+}
+
+func (h ocisHandler) mapAccounts(accounts []*accounts.Account, operational bool) ([]*ldap.Entry, error) {
 	var entries []*ldap.Entry
 	for i := range accounts {
 		attrs := []*ldap.EntryAttribute{
 			attribute("objectClass", "posixAccount", "inetOrgPerson", "organizationalPerson", "Person", "top"),
-			attribute("cn", accounts[i].PreferredName),
 			attribute("uid", accounts[i].PreferredName),
-			attribute("sn", accounts[i].PreferredName),
-			attribute("homeDirectory", ""),
 			attribute("ownCloudUUID", accounts[i].Id), // see https://github.com/butonic/owncloud-ldap-schema/blob/master/owncloud.schema#L28-L34
 		}
+		templated, err := h.schema.render(accounts[i])
+		if err != nil {
+			return nil, err
+		}
+		// the naming attribute must contain the value used in the dn, sn is required by the person object class
+		cn, sn := attribute("cn", accounts[i].PreferredName), attribute("sn", accounts[i].PreferredName)
+		for _, a := range templated {
+			switch a.Name {
+			case "cn":
+				if a.Values[0] != accounts[i].PreferredName {
+					cn.Values = append(cn.Values, a.Values[0])
+				}
+			case "sn":
+				sn = a
+			default:
+				attrs = append(attrs, a)
+			}
+		}
+		attrs = append(attrs, cn, sn)
+
 		if accounts[i].DisplayName != "" {
 			attrs = append(attrs, attribute("displayName", accounts[i].DisplayName))
 		}
@@ -300,20 +402,43 @@ func (h ocisHandler) mapAccounts(accounts []*accounts.Account) []*ldap.Entry {
 		if accounts[i].Description != "" {
 			attrs = append(attrs, attribute("description", accounts[i].Description))
 		}
+		memberOf := []string{}
+		for _, g := range accounts[i].MemberOf {
+			// groups that could not be expanded by the accounts service have no name
+			if g.OnPremisesSamAccountName != "" {
+				memberOf = append(memberOf, h.groupDN(g.OnPremisesSamAccountName))
+			}
+		}
+		if len(memberOf) > 0 {
+			attrs = append(attrs, attribute("memberOf", memberOf...))
+		}
+
+		if operational {
+			attrs = append(attrs, attribute("entryUUID", accounts[i].Id))
+			if ts := latest(accounts[i].CreatedDateTime); ts != "" {
+				attrs = append(attrs, attribute("createTimestamp", ts))
+			}
+			// accounts do not track modifications, use the most recent change we know of
+			var pwChanged *timestamp.Timestamp
+			if accounts[i].PasswordProfile != nil {
+				pwChanged = accounts[i].PasswordProfile.LastPasswordChangeDateTime
+			}
+			if ts := latest(
+				accounts[i].CreatedDateTime,
+				pwChanged,
+				accounts[i].ExternalUserStateChangeDateTime,
+				accounts[i].OnPremisesLastSyncDateTime,
+			); ts != "" {
+				attrs = append(attrs, attribute("modifyTimestamp", ts))
+			}
+		}
 
-		dn := fmt.Sprintf("%s=%s,%s=%s,%s",
-			h.nameFormat,
-			accounts[i].PreferredName,
-			h.groupFormat,
-			"users",
-			h.basedn,
-		)
-		entries = append(entries, &ldap.Entry{DN: dn, Attributes: attrs})
+		entries = append(entries, &ldap.Entry{DN: h.userDN(accounts[i].PreferredName), Attributes: attrs})
 	}
-	return entries
+	return entries, nil
 }
 
-func (h ocisHandler) mapGroups(groups []*accounts.Group) []*ldap.Entry {
+func (h ocisHandler) mapGroups(groups []*accounts.Group, operational bool) []*ldap.Entry {
 	var entries []*ldap.Entry
 	for i := range groups {
 		attrs := []*ldap.EntryAttribute{
@@ -331,20 +456,25 @@ func (h ocisHandler) mapGroups(groups []*accounts.Group) []*ldap.Entry {
 			attrs = append(attrs, attribute("description", groups[i].Description))
 		}
 
-		dn := fmt.Sprintf("%s=%s,%s=%s,%s",
-			h.nameFormat,
-			groups[i].OnPremisesSamAccountName,
-			h.groupFormat,
-			"groups",
-			h.basedn,
-		)
-
 		memberUids := make([]string, len(groups[i].Members))
+		members := make([]string, len(groups[i].Members))
 		for j := range groups[i].Members {
 			memberUids[j] = groups[i].Members[j].PreferredName
+			members[j] = h.userDN(groups[i].Members[j].PreferredName)
 		}
 		attrs = append(attrs, attribute("memberuid", memberUids...))
-		entries = append(entries, &ldap.Entry{DN: dn, Attributes: attrs})
+		if len(members) > 0 {
+			attrs = append(attrs, attribute("member", members...))
+		}
+
+		if operational {
+			attrs = append(attrs, attribute("entryUUID", groups[i].Id))
+			if ts := latest(groups[i].CreatedDateTime); ts != "" {
+				attrs = append(attrs, attribute("createTimestamp", ts), attribute("modifyTimestamp", ts))
+			}
+		}
+
+		entries = append(entries, &ldap.Entry{DN: h.groupDN(groups[i].OnPremisesSamAccountName), Attributes: attrs})
 	}
 	return entries
 }
@@ -360,144 +490,245 @@ func (h ocisHandler) mapGroups(groups []*accounts.Group) []*ldap.Entry {
 // "" not determined
 // "users"
 // "groups"
+//
+// The query only uses the operators and indexed fields the accounts service can resolve. Other parts of the filter
+// widen the query, eg. (!(cn=einstein)) lists all entries. The ldap server applies the complete filter to the
+// returned entries.
 func parseFilter(f *ber.Packet) (queryType, string, ldap.LDAPResultCode, error) {
-	var qtype queryType
-	var q string
-	var code ldap.LDAPResultCode
-	var err error
+	qtype, code, err := filterQueryType(f)
+	if err != nil {
+		return "", "", code, err
+	}
+	q, code, err := filterQuery(f, indexedFields[qtype])
+	if err != nil {
+		return "", "", code, err
+	}
+	return qtype, q, ldap.LDAPResultSuccess, nil
+}
+
+// filterQueryType determines if a filter asks for users or groups
+func filterQueryType(f *ber.Packet) (queryType, ldap.LDAPResultCode, error) {
+	switch ldap.FilterMap[f.Tag] {
+	case "Present":
+		if strings.ToLower(f.Data.String()) == "objectclass" {
+			// TODO implement proper present odata query, for now fall back to listing users
+			return usersQuery, ldap.LDAPResultSuccess, nil
+		}
+	case "Equality Match":
+		if len(f.Children) == 2 && strings.ToLower(f.Children[0].Value.(string)) == "objectclass" {
+			return objectClassQueryType(f.Children[1].Value.(string)), ldap.LDAPResultSuccess, nil
+		}
+	case "And", "Or", "Not":
+		var qtype queryType
+		for i := range f.Children {
+			qt, code, err := filterQueryType(f.Children[i])
+			if err != nil {
+				return "", code, err
+			}
+			if qtype == "" {
+				qtype = qt
+			} else if qt != "" && qt != qtype {
+				return "", ldap.LDAPResultUnwillingToPerform, fmt.Errorf("mixing user and group filters not supported")
+			}
+		}
+		return qtype, ldap.LDAPResultSuccess, nil
+	}
+	return "", ldap.LDAPResultSuccess, nil
+}
+
+// filterQuery translates a filter into a query on the indexed fields, an empty query does not restrict the result
+func filterQuery(f *ber.Packet, indexed map[string]bool) (string, ldap.LDAPResultCode, error) {
 	switch ldap.FilterMap[f.Tag] {
 	case "Present":
 		if len(f.Children) != 0 {
-			return "", "", ldap.LDAPResultOperationsError, fmt.Errorf("equality match must have no children, got %+v", f)
+			return "", ldap.LDAPResultOperationsError, fmt.Errorf("present filter must have no children, got %+v", f)
 		}
 		attribute := strings.ToLower(f.Data.String())
-
-		if attribute == "objectclass" {
-			// TODO implement proper present odata query, for now fall back to listing users
-			return "users", q, code, err
+		if _, ok := filterFields[attribute]; !ok && attribute != "objectclass" {
+			return "", ldap.LDAPResultUndefinedAttributeType, fmt.Errorf("unrecognized assertion type '%s' in filter item", attribute)
 		}
-		return qtype, q, ldap.LDAPResultUnwillingToPerform, fmt.Errorf("%s filter match for %s not implemented", ldap.FilterMap[f.Tag], attribute)
+		// the indexer cannot list the entries that have a value
+		return "", ldap.LDAPResultSuccess, nil
 	case "Equality Match":
 		if len(f.Children) != 2 {
-			return "", "", ldap.LDAPResultOperationsError, fmt.Errorf("equality match must have exactly two children")
+			return "", ldap.LDAPResultOperationsError, fmt.Errorf("equality match must have exactly two children")
 		}
 		attribute := strings.ToLower(f.Children[0].Value.(string))
 		value := f.Children[1].Value.(string)
 
-		// replace attributes
-		switch attribute {
-		case "objectclass":
-			switch strings.ToLower(value) {
-			case "posixaccount", "shadowaccount", "users", "person", "inetorgperson", "organizationalperson":
-				qtype = usersQuery
-			case "posixgroup", "groups":
-				qtype = groupsQuery
-			case "*":
-				// TODO not implemented yet
-				qtype = usersQuery
-			default:
-				qtype = ""
-			}
-		case "ownclouduuid":
-			q = fmt.Sprintf("id eq '%s'", escapeValue(value))
-		case "cn", "uid":
-			// on_premises_sam_account_name is indexed using the lowercase analyzer in ocis-accounts
-			// TODO use "tolower(on_premises_sam_account_name) eq '%s'" to be clear about the case insensitive comparison
-			q = fmt.Sprintf("on_premises_sam_account_name eq '%s'", escapeValue(value))
-		case "mail":
-			q = fmt.Sprintf("mail eq '%s'", escapeValue(value))
-		case "displayname":
-			q = fmt.Sprintf("display_name eq '%s'", escapeValue(value))
-		case "uidnumber":
-			if i, err := strconv.ParseUint(value, 10, 64); err != nil {
-				code = ldap.LDAPResultInvalidAttributeSyntax
-			} else {
-				q = fmt.Sprintf("uid_number eq %d", i)
+		if attribute == "objectclass" {
+			return "", ldap.LDAPResultSuccess, nil
+		}
+		field, ok := filterFields[attribute]
+		if !ok {
+			return "", ldap.LDAPResultUndefinedAttributeType, fmt.Errorf("unrecognized assertion type '%s' in filter item", attribute)
+		}
+		if strings.Contains(value, "*") {
+			// the filter compiler only detects leading and trailing wildcards, the ldap server would compare eg. ein*ein
+			// literally with the values of the entries
+			return "", ldap.LDAPResultUnwillingToPerform, fmt.Errorf("wildcards inside of values are not supported")
+		}
+		if numericAttributes[attribute] {
+			i, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return "", ldap.LDAPResultInvalidAttributeSyntax, fmt.Errorf("invalid number '%s' for %s", value, attribute)
 			}
-		case "gidnumber":
-			if i, err := strconv.ParseUint(value, 10, 64); err != nil {
-				code = ldap.LDAPResultInvalidAttributeSyntax
-			} else {
-				q = fmt.Sprintf("gid_number eq %d", i)
+			if !indexed[field] {
+				return "", ldap.LDAPResultSuccess, nil
 			}
-		case "description":
-			q = fmt.Sprintf("description eq '%s'", escapeValue(value))
-		default:
-			code = ldap.LDAPResultUndefinedAttributeType
-			err = fmt.Errorf("unrecognized assertion type '%s' in filter item", attribute)
+			return fmt.Sprintf("%s eq %d", field, i), ldap.LDAPResultSuccess, nil
+		}
+		if !indexed[field] {
+			return "", ldap.LDAPResultSuccess, nil
 		}
-		return qtype, q, code, err
+		// on_premises_sam_account_name is indexed using the lowercase analyzer in ocis-accounts
+		// TODO use "tolower(on_premises_sam_account_name) eq '%s'" to be clear about the case insensitive comparison
+		return fmt.Sprintf("%s eq '%s'", field, escapeValue(value)), ldap.LDAPResultSuccess, nil
 	case "Substrings":
 		if len(f.Children) != 2 {
-			return "", "", ldap.LDAPResultOperationsError, fmt.Errorf("substrings filter must have exactly two children")
+			return "", ldap.LDAPResultOperationsError, fmt.Errorf("substrings filter must have exactly two children")
 		}
 		attribute := strings.ToLower(f.Children[0].Value.(string))
+
+		if attribute == "objectclass" {
+			// object classes are not indexed, partial matches do not restrict the query
+			return "", ldap.LDAPResultSuccess, nil
+		}
+		field, ok := filterFields[attribute]
+		if !ok {
+			return "", ldap.LDAPResultUndefinedAttributeType, fmt.Errorf("unrecognized assertion type '%s' in filter item", attribute)
+		}
+		if numericAttributes[attribute] {
+			return "", ldap.LDAPResultUnwillingToPerform, fmt.Errorf("substrings filter not supported for %s", attribute)
+		}
+		// the ldap server only passes on the first part of a substrings filter, eg. (cn=*al*ein*) arrives as (cn=*al*)
 		if len(f.Children[1].Children) != 1 {
-			return "", "", ldap.LDAPResultUnwillingToPerform, fmt.Errorf("substrings filter only supports prefix match")
-		}
-		value := f.Children[1].Children[0].Value.(string)
-
-		// replace attributes
-		switch attribute {
-		case "objectclass":
-			switch strings.ToLower(value) {
-			case "posixaccount", "shadowaccount", "users", "person", "inetorgperson", "organizationalperson":
-				qtype = usersQuery
-			case "posixgroup", "groups":
-				qtype = groupsQuery
-			default:
-				qtype = ""
-			}
-		case "ownclouduuid":
-			q = fmt.Sprintf("startswith(id,'%s')", escapeValue(value))
-		case "cn", "uid":
-			// on_premises_sam_account_name is indexed using the lowercase analyzer in ocis-accounts
-			// TODO use "tolower(on_premises_sam_account_name) eq '%s'" to be clear about the case insensitive comparison
-			q = fmt.Sprintf("startswith(on_premises_sam_account_name,'%s')", escapeValue(value))
-		case "mail":
-			q = fmt.Sprintf("startswith(mail,'%s')", escapeValue(value))
-		case "displayname":
-			q = fmt.Sprintf("startswith(display_name,'%s')", escapeValue(value))
-		case "description":
-			q = fmt.Sprintf("startswith(description,'%s')", escapeValue(value))
-		default:
-			code = ldap.LDAPResultUndefinedAttributeType
-			err = fmt.Errorf("unrecognized assertion type '%s' in filter item", attribute)
-		}
-		return qtype, q, code, err
+			return "", ldap.LDAPResultUnwillingToPerform, fmt.Errorf("substrings filter with several parts not supported")
+		}
+		s := f.Children[1].Children[0]
+		value := s.Data.String()
+		if strings.Contains(value, "*") {
+			return "", ldap.LDAPResultUnwillingToPerform, fmt.Errorf("wildcards inside of values are not supported")
+		}
+		if !indexed[field] {
+			return "", ldap.LDAPResultSuccess, nil
+		}
+		function := "contains"
+		switch s.Tag {
+		case ldap.FilterSubstringsInitial:
+			function = "startswith"
+		case ldap.FilterSubstringsFinal:
+			function = "endswith"
+		}
+		return fmt.Sprintf("%s(%s,'%s')", function, field, escapeValue(value)), ldap.LDAPResultSuccess, nil
 	case "And", "Or":
 		subQueries := []string{}
+		unrestricted := false
 		for i := range f.Children {
-			var subQuery string
-			var qt queryType
-			qt, subQuery, code, err = parseFilter(f.Children[i])
+			subQuery, code, err := filterQuery(f.Children[i], indexed)
 			if err != nil {
-				return "", "", code, err
+				return "", code, err
 			}
-			if qtype == "" {
-				qtype = qt
-			} else if qt != "" && qt != qtype {
-				return "", "", ldap.LDAPResultUnwillingToPerform, fmt.Errorf("mixing user and group filters not supported")
+			if subQuery == "" {
+				unrestricted = true
+				continue
 			}
-			if subQuery != "" {
-				subQueries = append(subQueries, subQuery)
+			subQueries = append(subQueries, subQuery)
+		}
+		if f.Tag == ldap.FilterOr && unrestricted {
+			// one of the alternatives matches all entries
+			return "", ldap.LDAPResultSuccess, nil
+		}
+		if len(subQueries) > 1 {
+			for i := range subQueries {
+				subQueries[i] = group(subQueries[i])
 			}
 		}
-		return qtype, strings.Join(subQueries, " "+strings.ToLower(ldap.FilterMap[f.Tag])+" "), ldap.LDAPResultSuccess, nil
+		return strings.Join(subQueries, " "+strings.ToLower(ldap.FilterMap[f.Tag])+" "), ldap.LDAPResultSuccess, nil
 	case "Not":
 		if len(f.Children) != 1 {
-			return "", "", ldap.LDAPResultOperationsError, fmt.Errorf("not filter match must have exactly one child")
+			return "", ldap.LDAPResultOperationsError, fmt.Errorf("not filter match must have exactly one child")
 		}
-		qtype, subQuery, code, err := parseFilter(f.Children[0])
-		if err != nil {
-			return "", "", code, err
-		}
-		if subQuery != "" {
-			q = fmt.Sprintf("not %s", subQuery)
+		// the indexer cannot negate a query, but the filter has to be valid
+		if _, code, err := filterQuery(f.Children[0], indexed); err != nil {
+			return "", code, err
 		}
-		return qtype, q, code, nil
+		return "", ldap.LDAPResultSuccess, nil
+	case "Greater Or Equal", "Less Or Equal", "Approx Match":
+		// neither the indexer nor the ldap server can evaluate these filters
+		return "", ldap.LDAPResultUnwillingToPerform, fmt.Errorf("%s filter not supported", ldap.FilterMap[f.Tag])
 	}
-	return qtype, q, ldap.LDAPResultUnwillingToPerform, fmt.Errorf("%s filter not implemented", ldap.FilterMap[f.Tag])
+	return "", ldap.LDAPResultUnwillingToPerform, fmt.Errorf("%s filter not implemented", ldap.FilterMap[f.Tag])
+}
+
+// filterFields maps the ldap attributes to the account and group fields used in OData queries, the memberships can
+// only be filtered by the ldap server
+var filterFields = map[string]string{
+	"ownclouduuid": "id",
+	"entryuuid":    "id",
+	"cn":           "on_premises_sam_account_name",
+	"uid":          "on_premises_sam_account_name",
+	"mail":         "mail",
+	"displayname":  "display_name",
+	"description":  "description",
+	"uidnumber":    "uid_number",
+	"gidnumber":    "gid_number",
+	"memberuid":    "",
+	"member":       "",
+	"memberof":     "",
+}
+
+// indexedFields are the fields the accounts service has an index for, other fields can not be used in queries
+var indexedFields = map[queryType]map[string]bool{
+	usersQuery: {
+		"id":                           true,
+		"on_premises_sam_account_name": true,
+		"mail":                         true,
+		"display_name":                 true,
+		"uid_number":                   true,
+	},
+	groupsQuery: {
+		"on_premises_sam_account_name": true,
+		"display_name":                 true,
+		"gid_number":                   true,
+	},
+	// no entries are returned if the query type is not determined
+	"": {
+		"id":                           true,
+		"on_premises_sam_account_name": true,
+		"mail":                         true,
+		"display_name":                 true,
+		"uid_number":                   true,
+		"gid_number":                   true,
+	},
+}
+
+// numericAttributes are compared as numbers instead of strings
+var numericAttributes = map[string]bool{
+	"uidnumber": true,
+	"gidnumber": true,
+}
+
+// objectClassQueryType determines if a filter on the object class asks for users or groups
+func objectClassQueryType(value string) queryType {
+	switch strings.ToLower(value) {
+	case "posixaccount", "shadowaccount", "users", "person", "inetorgperson", "organizationalperson":
+		return usersQuery
+	case "posixgroup", "groups", "groupofnames":
+		return groupsQuery
+	case "*":
+		// TODO not implemented yet
+		return usersQuery
+	}
+	return ""
+}
+
+// group puts a query that combines several conditions into parentheses
+func group(query string) string {
+	if strings.Contains(query, " and ") || strings.Contains(query, " or ") {
+		return "(" + query + ")"
+	}
+	return query
 }
 
 // escapeValue escapes all special characters in the value
@@ -507,22 +738,516 @@ func escapeValue(value string) string {
 
 func (h ocisHandler) Close(boundDN string, conn net.Conn) error {
 	stats.Frontend.Add("closes", 1)
+	h.sessions.delete(conn)
 	return nil
 }
 
-// Add is not yet supported for the ocis backend
-func (h ocisHandler) Add(boundDN string, req ldap.AddRequest, conn net.Conn) (result ldap.LDAPResultCode, err error) {
-	return ldap.LDAPResultInsufficientAccessRights, nil
+// Add creates an account for entries below the users ou. The bound user needs the permission to manage accounts.
+func (h ocisHandler) Add(boundDN string, req ldap.AddRequest, conn net.Conn) (ldap.LDAPResultCode, error) {
+	dn, attrs := addRequestContent(req)
+	h.log.Debug().
+		Str("handler", "ocis").
+		Str("binddn", boundDN).
+		Str("dn", dn).
+		Interface("src", conn.RemoteAddr()).
+		Msg("Add request")
+	stats.Frontend.Add("add_reqs", 1)
+
+	name, qtype, err := h.parseEntryDN(dn)
+	if err != nil {
+		h.log.Debug().Err(err).Str("handler", "ocis").Str("dn", dn).Msg("Add rejected")
+		return ldap.LDAPResultUnwillingToPerform, nil
+	}
+	if qtype != usersQuery {
+		h.log.Debug().Str("handler", "ocis").Str("dn", dn).Msg("only accounts can be added")
+		return ldap.LDAPResultUnwillingToPerform, nil
+	}
+
+	sess, err := h.session(boundDN, conn)
+	if err != nil {
+		h.log.Debug().Err(err).Str("handler", "ocis").Str("binddn", boundDN).Msg("Add not allowed")
+		return ldap.LDAPResultInsufficientAccessRights, nil
+	}
+
+	account := &accounts.Account{
+		AccountEnabled:           true,
+		PreferredName:            name,
+		OnPremisesSamAccountName: name,
+	}
+	for attr, values := range attrs {
+		switch attr {
+		case "objectclass", "cn", "uid", "sn", "givenname":
+			// structural attributes, the name is taken from the dn
+			continue
+		}
+		if code, err := setAccountAttribute(account, attr, values); err != nil {
+			h.log.Debug().Err(err).Str("handler", "ocis").Str("dn", dn).Msg("Add rejected")
+			return code, nil
+		}
+	}
+
+	_, err = h.as.CreateAccount(sess.ctx, &accounts.CreateAccountRequest{Account: account})
+	if err != nil {
+		h.log.Error().Err(err).Str("handler", "ocis").Str("dn", dn).Msg("could not create account")
+		return resultCode(err), nil
+	}
+
+	stats.Frontend.Add("add_successes", 1)
+	return ldap.LDAPResultSuccess, nil
+}
+
+// Modify updates the attributes of an account or the memberUid attribute of a group
+func (h ocisHandler) Modify(boundDN string, req ldap.ModifyRequest, conn net.Conn) (ldap.LDAPResultCode, error) {
+	h.log.Debug().
+		Str("handler", "ocis").
+		Str("binddn", boundDN).
+		Str("dn", req.Dn).
+		Interface("src", conn.RemoteAddr()).
+		Msg("Modify request")
+	stats.Frontend.Add("modify_reqs", 1)
+
+	name, qtype, err := h.parseEntryDN(req.Dn)
+	if err != nil {
+		h.log.Debug().Err(err).Str("handler", "ocis").Str("dn", req.Dn).Msg("Modify rejected")
+		return ldap.LDAPResultNoSuchObject, nil
+	}
+
+	sess, err := h.session(boundDN, conn)
+	if err != nil {
+		h.log.Debug().Err(err).Str("handler", "ocis").Str("binddn", boundDN).Msg("Modify not allowed")
+		return ldap.LDAPResultInsufficientAccessRights, nil
+	}
+
+	var code ldap.LDAPResultCode
+	switch qtype {
+	case usersQuery:
+		code, err = h.modifyAccount(sess.ctx, name, req)
+	case groupsQuery:
+		code, err = h.modifyGroupMembers(sess.ctx, name, req)
+	}
+	if err != nil {
+		h.log.Error().Err(err).Str("handler", "ocis").Str("dn", req.Dn).Msg("could not modify entry")
+		return code, nil
+	}
+
+	stats.Frontend.Add("modify_successes", 1)
+	return ldap.LDAPResultSuccess, nil
+}
+
+// modifyAccount translates the changes into an account update with a field mask
+func (h ocisHandler) modifyAccount(ctx context.Context, name string, req ldap.ModifyRequest) (ldap.LDAPResultCode, error) {
+	current, err := h.getAccount(name)
+	if err != nil {
+		return resultCode(err), err
+	}
+
+	account := &accounts.Account{Id: current.Id}
+	paths := []string{}
+	changes := append(append([]ldap.PartialAttribute{}, req.AddAttributes...), req.ReplaceAttributes...)
+	for _, c := range changes {
+		if code, err := setAccountAttribute(account, strings.ToLower(c.AttrType), c.AttrVals); err != nil {
+			return code, err
+		}
+		paths = append(paths, accountAttributes[strings.ToLower(c.AttrType)].path)
+	}
+	for _, c := range req.DeleteAttributes {
+		if code, err := setAccountAttribute(account, strings.ToLower(c.AttrType), nil); err != nil {
+			return code, err
+		}
+		paths = append(paths, accountAttributes[strings.ToLower(c.AttrType)].path)
+	}
+	if len(paths) == 0 {
+		return ldap.LDAPResultSuccess, nil
+	}
+
+	_, err = h.as.UpdateAccount(ctx, &accounts.UpdateAccountRequest{
+		Account:    account,
+		UpdateMask: &field_mask.FieldMask{Paths: paths},
+	})
+	return resultCode(err), err
+}
+
+// modifyGroupMembers adds and removes the accounts listed in the memberUid attribute to and from the group
+func (h ocisHandler) modifyGroupMembers(ctx context.Context, name string, req ldap.ModifyRequest) (ldap.LDAPResultCode, error) {
+	group, err := h.getGroup(name)
+	if err != nil {
+		return resultCode(err), err
+	}
+
+	members := map[string]string{}
+	for _, m := range group.Members {
+		members[strings.ToLower(m.PreferredName)] = m.Id
+	}
+
+	add := map[string]struct{}{}
+	remove := map[string]struct{}{}
+	for _, c := range req.AddAttributes {
+		if !strings.EqualFold(c.AttrType, "memberuid") {
+			return ldap.LDAPResultUnwillingToPerform, fmt.Errorf("only memberUid can be modified on groups, got %s", c.AttrType)
+		}
+		for _, v := range c.AttrVals {
+			add[strings.ToLower(v)] = struct{}{}
+		}
+	}
+	for _, c := range req.DeleteAttributes {
+		if !strings.EqualFold(c.AttrType, "memberuid") {
+			return ldap.LDAPResultUnwillingToPerform, fmt.Errorf("only memberUid can be modified on groups, got %s", c.AttrType)
+		}
+		if len(c.AttrVals) == 0 {
+			// deleting the attribute removes all members
+			for m := range members {
+				remove[m] = struct{}{}
+			}
+		}
+		for _, v := range c.AttrVals {
+			remove[strings.ToLower(v)] = struct{}{}
+		}
+	}
+	for _, c := range req.ReplaceAttributes {
+		if !strings.EqualFold(c.AttrType, "memberuid") {
+			return ldap.LDAPResultUnwillingToPerform, fmt.Errorf("only memberUid can be modified on groups, got %s", c.AttrType)
+		}
+		for m := range members {
+			remove[m] = struct{}{}
+		}
+		for _, v := range c.AttrVals {
+			delete(remove, strings.ToLower(v))
+			add[strings.ToLower(v)] = struct{}{}
+		}
+	}
+
+	// look up the new members first, so unknown accounts do not leave the group half modified
+	added := []string{}
+	for uid := range add {
+		if _, ok := members[uid]; ok {
+			continue
+		}
+		account, err := h.getAccount(uid)
+		if err != nil {
+			return resultCode(err), err
+		}
+		added = append(added, account.Id)
+	}
+	for uid := range remove {
+		id, ok := members[uid]
+		if !ok {
+			continue
+		}
+		if _, err := h.gs.RemoveMember(ctx, &accounts.RemoveMemberRequest{GroupId: group.Id, AccountId: id}); err != nil {
+			return resultCode(err), err
+		}
+	}
+	for _, id := range added {
+		if _, err := h.gs.AddMember(ctx, &accounts.AddMemberRequest{GroupId: group.Id, AccountId: id}); err != nil {
+			return resultCode(err), err
+		}
+	}
+	return ldap.LDAPResultSuccess, nil
+}
+
+// Delete removes an account. The bound user needs the permission to manage accounts.
+func (h ocisHandler) Delete(boundDN string, deleteDN string, conn net.Conn) (ldap.LDAPResultCode, error) {
+	h.log.Debug().
+		Str("handler", "ocis").
+		Str("binddn", boundDN).
+		Str("dn", deleteDN).
+		Interface("src", conn.RemoteAddr()).
+		Msg("Delete request")
+	stats.Frontend.Add("delete_reqs", 1)
+
+	name, qtype, err := h.parseEntryDN(deleteDN)
+	if err != nil {
+		h.log.Debug().Err(err).Str("handler", "ocis").Str("dn", deleteDN).Msg("Delete rejected")
+		return ldap.LDAPResultNoSuchObject, nil
+	}
+	if qtype != usersQuery {
+		h.log.Debug().Str("handler", "ocis").Str("dn", deleteDN).Msg("only accounts can be deleted")
+		return ldap.LDAPResultUnwillingToPerform, nil
+	}
+
+	sess, err := h.session(boundDN, conn)
+	if err != nil {
+		h.log.Debug().Err(err).Str("handler", "ocis").Str("binddn", boundDN).Msg("Delete not allowed")
+		return ldap.LDAPResultInsufficientAccessRights, nil
+	}
+
+	account, err := h.getAccount(name)
+	if err == nil {
+		_, err = h.as.DeleteAccount(sess.ctx, &accounts.DeleteAccountRequest{Id: account.Id})
+	}
+	if err != nil {
+		h.log.Error().Err(err).Str("handler", "ocis").Str("dn", deleteDN).Msg("could not delete account")
+		return resultCode(err), nil
+	}
+
+	stats.Frontend.Add("delete_successes", 1)
+	return ldap.LDAPResultSuccess, nil
+}
+
+// Extended implements the password modify extended operation, see https://tools.ietf.org/html/rfc3062
+func (h ocisHandler) Extended(boundDN string, req ldap.ExtendedRequest, conn net.Conn) (ldap.LDAPResultCode, error) {
+	oid, value := extendedRequestContent(req)
+	h.log.Debug().
+		Str("handler", "ocis").
+		Str("binddn", boundDN).
+		Str("oid", oid).
+		Interface("src", conn.RemoteAddr()).
+		Msg("Extended request")
+
+	if oid != passwordModifyOID {
+		return ldap.LDAPResultProtocolError, nil
+	}
+	stats.Frontend.Add("password_modify_reqs", 1)
+
+	pm, err := parsePasswordModify(value)
+	if err != nil {
+		h.log.Debug().Err(err).Str("handler", "ocis").Msg("could not parse password modify request")
+		return ldap.LDAPResultProtocolError, nil
+	}
+	if pm.newPassword == "" {
+		// the response cannot carry a generated password
+		return ldap.LDAPResultUnwillingToPerform, nil
+	}
+
+	sess, err := h.session(boundDN, conn)
+	if err != nil {
+		h.log.Debug().Err(err).Str("handler", "ocis").Str("binddn", boundDN).Msg("Password modify not allowed")
+		return ldap.LDAPResultInsufficientAccessRights, nil
+	}
+
+	target := sess.account
+	if pm.userIdentity != "" {
+		name, qtype, err := h.parseEntryDN(pm.userIdentity)
+		if err != nil || qtype != usersQuery {
+			return ldap.LDAPResultNoSuchObject, nil
+		}
+		if target, err = h.getAccount(name); err != nil {
+			return resultCode(err), nil
+		}
+	}
+
+	if pm.oldPassword != "" {
+		res, err := h.as.ListAccounts(h.serviceContext(), &accounts.ListAccountsRequest{
+			Query: fmt.Sprintf("login eq '%s' and password eq '%s'", escapeValue(target.PreferredName), escapeValue(pm.oldPassword)),
+		})
+		if err != nil || len(res.Accounts) == 0 {
+			return ldap.LDAPResultInvalidCredentials, nil
+		}
+	}
+
+	// the accounts service only allows users to change their own password unless they may manage accounts
+	_, err = h.as.UpdateAccount(sess.ctx, &accounts.UpdateAccountRequest{
+		Account: &accounts.Account{
+			Id:              target.Id,
+			PasswordProfile: &accounts.PasswordProfile{Password: pm.newPassword},
+		},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"PasswordProfile.Password"}},
+	})
+	if err != nil {
+		h.log.Error().Err(err).Str("handler", "ocis").Str("account", target.Id).Msg("could not modify password")
+		return resultCode(err), nil
+	}
+
+	stats.Frontend.Add("password_modify_successes", 1)
+	return ldap.LDAPResultSuccess, nil
 }
 
-// Modify is not yet supported for the ocis backend
-func (h ocisHandler) Modify(boundDN string, req ldap.ModifyRequest, conn net.Conn) (result ldap.LDAPResultCode, err error) {
-	return ldap.LDAPResultInsufficientAccessRights, nil
+// getAccount looks up an account by its username
+func (h ocisHandler) getAccount(name string) (*accounts.Account, error) {
+	res, err := h.as.ListAccounts(h.serviceContext(), &accounts.ListAccountsRequest{
+		Query: fmt.Sprintf("on_premises_sam_account_name eq '%s'", escapeValue(name)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Accounts) != 1 {
+		return nil, merrors.NotFound("glauth", "account %s not found", name)
+	}
+	return res.Accounts[0], nil
 }
 
-// Delete is not yet supported for the ocis backend
-func (h ocisHandler) Delete(boundDN string, deleteDN string, conn net.Conn) (result ldap.LDAPResultCode, err error) {
-	return ldap.LDAPResultInsufficientAccessRights, nil
+// getGroup looks up a group by its name
+func (h ocisHandler) getGroup(name string) (*accounts.Group, error) {
+	res, err := h.gs.ListGroups(h.serviceContext(), &accounts.ListGroupsRequest{
+		Query: fmt.Sprintf("on_premises_sam_account_name eq '%s'", escapeValue(name)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Groups) != 1 {
+		return nil, merrors.NotFound("glauth", "group %s not found", name)
+	}
+	return res.Groups[0], nil
+}
+
+// parseEntryDN splits a dn like cn=einstein,ou=users,dc=example,dc=com into the name and the type of the entry
+func (h ocisHandler) parseEntryDN(dn string) (string, queryType, error) {
+	dn = strings.ToLower(strings.TrimSpace(dn))
+	baseDN := strings.ToLower("," + h.basedn)
+	if !strings.HasSuffix(dn, baseDN) {
+		return "", "", fmt.Errorf("dn %s not in our base dn %s", dn, h.basedn)
+	}
+	parts := strings.Split(strings.TrimSuffix(dn, baseDN), ",")
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("dn %s should have exactly two parts below the base dn", dn)
+	}
+	namePrefix := strings.ToLower(h.nameFormat) + "="
+	if !strings.HasPrefix(parts[0], namePrefix) || len(parts[0]) == len(namePrefix) {
+		return "", "", fmt.Errorf("dn %s does not start with %s", dn, namePrefix)
+	}
+	name := strings.TrimPrefix(parts[0], namePrefix)
+
+	switch parts[1] {
+	case strings.ToLower(h.groupFormat) + "=users":
+		return name, usersQuery, nil
+	case strings.ToLower(h.groupFormat) + "=groups":
+		return name, groupsQuery, nil
+	}
+	return "", "", fmt.Errorf("dn %s is neither below the users nor the groups %s", dn, h.groupFormat)
+}
+
+// accountAttribute maps a writable ldap attribute to the account field and its update mask path
+type accountAttribute struct {
+	path string
+	set  func(a *accounts.Account, value string) error
+}
+
+var accountAttributes = map[string]accountAttribute{
+	"displayname": {"DisplayName", func(a *accounts.Account, v string) error {
+		a.DisplayName = v
+		return nil
+	}},
+	"mail": {"Mail", func(a *accounts.Account, v string) error {
+		a.Mail = v
+		return nil
+	}},
+	"description": {"Description", func(a *accounts.Account, v string) error {
+		a.Description = v
+		return nil
+	}},
+	"uidnumber": {"UidNumber", func(a *accounts.Account, v string) (err error) {
+		a.UidNumber, err = parseNumber(v)
+		return
+	}},
+	"gidnumber": {"GidNumber", func(a *accounts.Account, v string) (err error) {
+		a.GidNumber, err = parseNumber(v)
+		return
+	}},
+	"userpassword": {"PasswordProfile.Password", func(a *accounts.Account, v string) error {
+		a.PasswordProfile = &accounts.PasswordProfile{Password: v}
+		return nil
+	}},
+}
+
+// setAccountAttribute sets the account field of a single valued attribute. No values reset the field.
+func setAccountAttribute(a *accounts.Account, attr string, values []string) (ldap.LDAPResultCode, error) {
+	aa, ok := accountAttributes[attr]
+	if !ok {
+		return ldap.LDAPResultUnwillingToPerform, fmt.Errorf("attribute %s can not be written", attr)
+	}
+	if len(values) > 1 {
+		return ldap.LDAPResultConstraintViolation, fmt.Errorf("attribute %s is single valued", attr)
+	}
+	value := ""
+	if len(values) == 1 {
+		value = values[0]
+	}
+	if err := aa.set(a, value); err != nil {
+		return ldap.LDAPResultInvalidAttributeSyntax, err
+	}
+	return ldap.LDAPResultSuccess, nil
+}
+
+func parseNumber(v string) (int64, error) {
+	if v == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(v, 10, 64)
+}
+
+// resultCode maps errors of the accounts service to ldap result codes
+func resultCode(err error) ldap.LDAPResultCode {
+	if err == nil {
+		return ldap.LDAPResultSuccess
+	}
+	switch merrors.Parse(err.Error()).Code {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ldap.LDAPResultInsufficientAccessRights
+	case http.StatusNotFound:
+		return ldap.LDAPResultNoSuchObject
+	case http.StatusConflict:
+		return ldap.LDAPResultEntryAlreadyExists
+	case http.StatusBadRequest:
+		return ldap.LDAPResultConstraintViolation
+	}
+	return ldap.LDAPResultOperationsError
+}
+
+// passwordModify holds the fields of a PasswdModifyRequestValue
+type passwordModify struct {
+	userIdentity string
+	oldPassword  string
+	newPassword  string
+}
+
+// parsePasswordModify decodes the value of a password modify request
+//
+//	PasswdModifyRequestValue ::= SEQUENCE {
+//	  userIdentity    [0]  OCTET STRING OPTIONAL
+//	  oldPasswd       [1]  OCTET STRING OPTIONAL
+//	  newPasswd       [2]  OCTET STRING OPTIONAL }
+func parsePasswordModify(value string) (pm passwordModify, err error) {
+	if value == "" {
+		// all fields are optional, so is the value
+		return pm, nil
+	}
+	defer func() {
+		// the ber package panics on truncated packets
+		if r := recover(); r != nil {
+			err = fmt.Errorf("malformed password modify request: %v", r)
+		}
+	}()
+
+	p := ber.DecodePacket([]byte(value))
+	if p.ClassType != ber.ClassUniversal || p.Tag != ber.TagSequence {
+		return pm, fmt.Errorf("password modify request must be a sequence")
+	}
+	for _, c := range p.Children {
+		if c.ClassType != ber.ClassContext {
+			return pm, fmt.Errorf("unexpected element in password modify request")
+		}
+		switch c.Tag {
+		case 0:
+			pm.userIdentity = c.Data.String()
+		case 1:
+			pm.oldPassword = c.Data.String()
+		case 2:
+			pm.newPassword = c.Data.String()
+		}
+	}
+	return pm, nil
+}
+
+// addRequestContent returns the dn and the attributes of the request. The nmcclain/ldap package does not export them.
+func addRequestContent(req ldap.AddRequest) (string, map[string][]string) {
+	v := reflect.ValueOf(req)
+	attrs := map[string][]string{}
+	list := v.FieldByName("attributes")
+	for i := 0; i < list.Len(); i++ {
+		attr := list.Index(i)
+		name := strings.ToLower(attr.FieldByName("attrType").String())
+		values := attr.FieldByName("attrVals")
+		for j := 0; j < values.Len(); j++ {
+			attrs[name] = append(attrs[name], values.Index(j).String())
+		}
+	}
+	return v.FieldByName("dn").String(), attrs
+}
+
+// extendedRequestContent returns the oid and value of the request. The nmcclain/ldap package does not export them.
+func extendedRequestContent(req ldap.ExtendedRequest) (string, string) {
+	v := reflect.ValueOf(req)
+	return v.FieldByName("requestName").String(), v.FieldByName("requestValue").String()
 }
 
 // NewOCISHandler implements a glauth backend with ocis-accounts as the datasource
@@ -533,10 +1258,21 @@ func NewOCISHandler(opts ...Option) handler.Handler {
 		log:         options.Logger,
 		as:          options.AccountsService,
 		gs:          options.GroupsService,
+		rs:          options.RoleService,
 		basedn:      options.BaseDN,
 		nameFormat:  options.NameFormat,
 		groupFormat: options.GroupFormat,
 		rbid:        options.RoleBundleUUID,
+
+		sessions:        newSessions(),
+		allowAnonymous:  options.AllowAnonymous,
+		serviceAccounts: options.ServiceAccounts,
+		selfOnly:        options.SelfOnly,
+		schema:          options.Schema,
+	}
+	if handler.schema == nil {
+		// the default templates are known to parse
+		handler.schema, _ = NewSchema(DefaultAccountAttributes)
 	}
 	return handler
 }
@@ -0,0 +1,241 @@
+package glauth
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/nmcclain/ldap"
+	accounts "github.com/owncloud/ocis/accounts/pkg/proto/v0"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		qtype  queryType
+		query  string
+		code   ldap.LDAPResultCode
+	}{
+		// basic filters
+		{"equality", "(cn=einstein)", "", "on_premises_sam_account_name eq 'einstein'", ldap.LDAPResultSuccess},
+		{"quotes are escaped", "(displayname=O'Brien)", "", "display_name eq 'O''Brien'", ldap.LDAPResultSuccess},
+		{"numeric equality", "(uidnumber=20000)", "", "uid_number eq 20000", ldap.LDAPResultSuccess},
+		{"invalid number", "(uidnumber=abc)", "", "", ldap.LDAPResultInvalidAttributeSyntax},
+		{"prefix", "(cn=ein*)", "", "startswith(on_premises_sam_account_name,'ein')", ldap.LDAPResultSuccess},
+		{"suffix", "(mail=*@example.org)", "", "endswith(mail,'@example.org')", ldap.LDAPResultSuccess},
+		{"contains", "(displayname=*bert*)", "", "contains(display_name,'bert')", ldap.LDAPResultSuccess},
+		{"inner wildcard", "(cn=ein*ein)", "", "", ldap.LDAPResultUnwillingToPerform},
+		{"several wildcards", "(displayname=*al*ein*)", "", "", ldap.LDAPResultUnwillingToPerform},
+		{"substrings on numbers", "(uidnumber=2*)", "", "", ldap.LDAPResultUnwillingToPerform},
+		{"presence", "(mail=*)", "", "", ldap.LDAPResultSuccess},
+		{"numeric presence", "(gidnumber=*)", "", "", ldap.LDAPResultSuccess},
+		{"presence of an unknown attribute", "(shoesize=*)", "", "", ldap.LDAPResultUndefinedAttributeType},
+		{"presence of the name", "(uid=*)", "", "", ldap.LDAPResultSuccess},
+		{"greater or equal", "(uidnumber>=1000)", "", "", ldap.LDAPResultUnwillingToPerform},
+		{"less or equal", "(displayname<=M)", "", "", ldap.LDAPResultUnwillingToPerform},
+		{"approx match", "(mail~=einstein@example.org)", "", "", ldap.LDAPResultUnwillingToPerform},
+		{"not", "(!(uidnumber=0))", "", "", ldap.LDAPResultSuccess},
+		{"invalid not", "(!(shoesize=42))", "", "", ldap.LDAPResultUndefinedAttributeType},
+		{"and", "(&(cn=ein*)(mail=*@example.org))", "", "startswith(on_premises_sam_account_name,'ein') and endswith(mail,'@example.org')", ldap.LDAPResultSuccess},
+		{"or with an unrestricted alternative", "(|(cn=einstein)(!(mail=marie@example.org)))", "", "", ldap.LDAPResultSuccess},
+		{"and with an unrestricted part", "(&(cn=einstein)(!(mail=marie@example.org)))", "", "on_premises_sam_account_name eq 'einstein'", ldap.LDAPResultSuccess},
+		{"user fields without an index", "(&(objectclass=posixaccount)(|(description=physicist)(gidnumber=30000)))", usersQuery, "", ldap.LDAPResultSuccess},
+		{"group fields without an index", "(&(objectclass=posixgroup)(|(entryuuid=abc)(mail=physics@example.org)))", groupsQuery, "", ldap.LDAPResultSuccess},
+		{"sssd group memberships", "(&(memberuid=einstein)(objectclass=posixGroup)(cn=*)(&(gidNumber=*)(!(gidNumber=0))))", groupsQuery, "", ldap.LDAPResultSuccess},
+		{"indexed group fields", "(&(objectclass=posixgroup)(|(cn=physics*)(gidnumber=30000)))", groupsQuery, "startswith(on_premises_sam_account_name,'physics') or gid_number eq 30000", ldap.LDAPResultSuccess},
+		{"unknown attribute", "(shoesize=42)", "", "", ldap.LDAPResultUndefinedAttributeType},
+		{"mixing users and groups", "(|(objectclass=posixaccount)(objectclass=posixgroup))", "", "", ldap.LDAPResultUnwillingToPerform},
+
+		// SSSD
+		{
+			"sssd user by name",
+			"(&(uid=einstein)(objectclass=posixAccount)(&(uidNumber=*)(!(uidNumber=0))))",
+			usersQuery,
+			"on_premises_sam_account_name eq 'einstein'",
+			ldap.LDAPResultSuccess,
+		},
+		{
+			"sssd user by id",
+			"(&(uidNumber=20000)(objectclass=posixAccount)(uid=*)(&(uidNumber=*)(!(uidNumber=0))))",
+			usersQuery,
+			"uid_number eq 20000",
+			ldap.LDAPResultSuccess,
+		},
+		{
+			"sssd enumerate groups",
+			"(&(objectclass=posixGroup)(cn=*)(&(gidNumber=*)(!(gidNumber=0))))",
+			groupsQuery,
+			"",
+			ldap.LDAPResultSuccess,
+		},
+
+		// Nextcloud user_ldap
+		{
+			"nextcloud login",
+			"(&(|(objectclass=inetOrgPerson))(|(uid=einstein)(mail=einstein)))",
+			usersQuery,
+			"on_premises_sam_account_name eq 'einstein' or mail eq 'einstein'",
+			ldap.LDAPResultSuccess,
+		},
+		{
+			"nextcloud user search",
+			"(&(|(objectclass=inetOrgPerson))(|(uid=ein*)(displayname=*ein*)(mail=*ein*)))",
+			usersQuery,
+			"startswith(on_premises_sam_account_name,'ein') or contains(display_name,'ein') or contains(mail,'ein')",
+			ldap.LDAPResultSuccess,
+		},
+		{
+			"nextcloud groups",
+			"(&(|(objectclass=groupOfNames)))",
+			groupsQuery,
+			"",
+			ldap.LDAPResultSuccess,
+		},
+
+		// Apache mod_authnz_ldap
+		{
+			"apache require valid-user",
+			"(&(objectClass=*)(uid=einstein))",
+			usersQuery,
+			"on_premises_sam_account_name eq 'einstein'",
+			ldap.LDAPResultSuccess,
+		},
+		{
+			"apache custom filter",
+			"(&(objectClass=inetOrgPerson)(mail=*@example.org)(uid=einstein))",
+			usersQuery,
+			"endswith(mail,'@example.org') and on_premises_sam_account_name eq 'einstein'",
+			ldap.LDAPResultSuccess,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ldap.CompileFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("could not compile filter %s: %v", tt.filter, err)
+			}
+
+			qtype, query, code, err := parseFilter(f)
+			if code != tt.code {
+				t.Fatalf("expected result code %d, got %d (%v)", tt.code, code, err)
+			}
+			if tt.code != ldap.LDAPResultSuccess {
+				if err == nil {
+					t.Errorf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if qtype != tt.qtype {
+				t.Errorf("expected query type %q, got %q", tt.qtype, qtype)
+			}
+			if query != tt.query {
+				t.Errorf("expected query\n%s\ngot\n%s", tt.query, query)
+			}
+		})
+	}
+}
+
+func TestMapAccounts(t *testing.T) {
+	schema, err := NewSchema(DefaultAccountAttributes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := ocisHandler{basedn: "dc=example,dc=org", nameFormat: "cn", groupFormat: "ou", schema: schema}
+
+	einstein := &accounts.Account{
+		Id:              "4c510ada-c86b-4815-8820-42cdf82c3d51",
+		PreferredName:   "einstein",
+		DisplayName:     "Albert Einstein",
+		UidNumber:       20000,
+		GidNumber:       30000,
+		CreatedDateTime: &timestamp.Timestamp{Seconds: 1600000000},
+		PasswordProfile: &accounts.PasswordProfile{LastPasswordChangeDateTime: &timestamp.Timestamp{Seconds: 1600086400}},
+		MemberOf: []*accounts.Group{
+			{Id: "509a9dcd-bb37-4f4f-a01a-19dca27d9cfa", OnPremisesSamAccountName: "sailing-lovers"},
+			{Id: "unexpanded"},
+		},
+	}
+
+	entries, err := h.mapAccounts([]*accounts.Account{einstein}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected one entry, got %d", len(entries))
+	}
+	e := entries[0]
+	if e.DN != "cn=einstein,ou=users,dc=example,dc=org" {
+		t.Errorf("unexpected dn %s", e.DN)
+	}
+	expected := map[string][]string{
+		"cn":              {"einstein", "Albert Einstein"},
+		"sn":              {"Einstein"},
+		"givenName":       {"Albert"},
+		"gecos":           {"Albert Einstein"},
+		"homeDirectory":   {"/home/einstein"},
+		"loginShell":      {"/bin/bash"},
+		"memberOf":        {"cn=sailing-lovers,ou=groups,dc=example,dc=org"},
+		"entryUUID":       {"4c510ada-c86b-4815-8820-42cdf82c3d51"},
+		"createTimestamp": {"20200913122640Z"},
+		"modifyTimestamp": {"20200914122640Z"},
+	}
+	for name, values := range expected {
+		if got := e.GetAttributeValues(name); !reflect.DeepEqual(got, values) {
+			t.Errorf("expected %s %v, got %v", name, values, got)
+		}
+	}
+
+	entries, err = h.mapAccounts([]*accounts.Account{{PreferredName: "moss"}}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"givenName", "gecos", "memberOf", "entryUUID", "modifyTimestamp"} {
+		if got := entries[0].GetAttributeValues(name); len(got) != 0 {
+			t.Errorf("expected no %s, got %v", name, got)
+		}
+	}
+	// sn is required by the person object class
+	if got := entries[0].GetAttributeValues("sn"); !reflect.DeepEqual(got, []string{"moss"}) {
+		t.Errorf("expected sn to fall back to the username, got %v", got)
+	}
+}
+
+func TestMapGroups(t *testing.T) {
+	h := ocisHandler{basedn: "dc=example,dc=org", nameFormat: "cn", groupFormat: "ou"}
+
+	entries := h.mapGroups([]*accounts.Group{{
+		Id:                       "509a9dcd-bb37-4f4f-a01a-19dca27d9cfa",
+		OnPremisesSamAccountName: "sailing-lovers",
+		Members:                  []*accounts.Account{{PreferredName: "einstein"}, {PreferredName: "marie"}},
+	}}, false)
+
+	if got := entries[0].GetAttributeValues("memberuid"); !reflect.DeepEqual(got, []string{"einstein", "marie"}) {
+		t.Errorf("unexpected memberuid %v", got)
+	}
+	expected := []string{"cn=einstein,ou=users,dc=example,dc=org", "cn=marie,ou=users,dc=example,dc=org"}
+	if got := entries[0].GetAttributeValues("member"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected member %v", got)
+	}
+}
+
+func TestNewSchema(t *testing.T) {
+	if _, err := NewSchema(map[string]string{"homeDirectory": "/home/{{.PreferredName"}); err == nil {
+		t.Error("expected an error for an invalid template")
+	}
+	s, err := NewSchema(map[string]string{"loginShell": "", "homeDirectory": "/home/{{lower .PreferredName}}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	attrs, err := s.render(&accounts.Account{PreferredName: "Einstein"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attrs) != 1 || attrs[0].Name != "homeDirectory" || attrs[0].Values[0] != "/home/einstein" {
+		t.Errorf("unexpected attributes %+v", attrs)
+	}
+}
@@ -0,0 +1,639 @@
+package glauth
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	ldapclient "github.com/go-ldap/ldap/v3"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/micro/go-micro/v2/client"
+	merrors "github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/metadata"
+	accounts "github.com/owncloud/ocis/accounts/pkg/proto/v0"
+	"github.com/owncloud/ocis/ocis-pkg/indexer"
+	"github.com/owncloud/ocis/ocis-pkg/indexer/config"
+	"github.com/owncloud/ocis/ocis-pkg/indexer/option"
+	"github.com/owncloud/ocis/ocis-pkg/middleware"
+	settings "github.com/owncloud/ocis/settings/pkg/proto/v0"
+)
+
+const (
+	// testBundleID is the role bundle glauth uses for its own requests
+	testBundleID = "71881883-1768-46bd-a24d-a356a2afdf7f"
+	adminRoleID  = "admin"
+	userRoleID   = "user"
+)
+
+// loginQuery matches the login requests like the accounts service, quotes are escaped by doubling them
+var loginQuery = regexp.MustCompile(`^login eq '((?:[^']|'')*)' and password eq '((?:[^']|'')*)'$`)
+
+// directory is an in-memory accounts and groups service. Like the accounts service it only allows writes, including
+// group memberships, with the admin role or the internal role bundle, other accounts may only change their own password.
+// Queries are resolved by an indexer with the indices of the accounts service.
+type directory struct {
+	mu       sync.Mutex
+	accounts map[string]*accounts.Account
+	groups   map[string]*accounts.Group
+	roles    map[string]string
+	// masks records the update masks of all account updates
+	masks [][]string
+	// err is returned by all writes if set
+	err error
+}
+
+// newDirectory returns a directory with the users einstein, marie, moss and reva and the group physics-lovers.
+// moss is an admin, reva is meant to be used as a service account.
+func newDirectory() *directory {
+	d := &directory{
+		accounts: map[string]*accounts.Account{},
+		groups:   map[string]*accounts.Group{},
+		roles:    map[string]string{},
+	}
+	for i, name := range []string{"einstein", "marie", "moss", "reva"} {
+		d.accounts[name] = &accounts.Account{
+			Id:                       "id-" + name,
+			AccountEnabled:           true,
+			PreferredName:            name,
+			OnPremisesSamAccountName: name,
+			DisplayName:              strings.Title(name),
+			Mail:                     name + "@example.org",
+			UidNumber:                int64(20000 + i),
+			GidNumber:                30000,
+			PasswordProfile:          &accounts.PasswordProfile{Password: passwords[name]},
+		}
+		d.roles["id-"+name] = userRoleID
+	}
+	d.accounts["einstein"].Description = "physicist"
+	d.roles["id-moss"] = adminRoleID
+	d.groups["physics-lovers"] = &accounts.Group{
+		Id:                       "id-physics-lovers",
+		DisplayName:              "physics-lovers",
+		OnPremisesSamAccountName: "physics-lovers",
+		GidNumber:                30000,
+		Members:                  []*accounts.Account{d.accounts["einstein"]},
+	}
+	return d
+}
+
+var passwords = map[string]string{"einstein": "relativity", "marie": "radioactivity", "moss": "vista", "reva": "reva"}
+
+// query resolves the query with an indexer that has the same indices as the accounts service, see
+// accounts/pkg/service/v0/index.go
+func (d *directory) query(t interface{}, q string) ([]string, error) {
+	dir, err := ioutil.TempDir("", "glauth-index")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"accounts", "groups"} {
+		if err := os.Mkdir(path.Join(dir, name), 0700); err != nil {
+			return nil, err
+		}
+	}
+
+	idx := indexer.CreateIndexer(&config.Config{Repo: config.Repo{Disk: config.Disk{Path: dir}}})
+	uids := &option.Bound{Lower: 0, Upper: 1000}
+	for _, i := range []struct {
+		t                   interface{}
+		field, dir, idxType string
+		bound               *option.Bound
+	}{
+		{&accounts.Account{}, "Id", "accounts", "non_unique", nil},
+		{&accounts.Account{}, "DisplayName", "accounts", "non_unique", nil},
+		{&accounts.Account{}, "Mail", "accounts", "unique", nil},
+		{&accounts.Account{}, "OnPremisesSamAccountName", "accounts", "unique", nil},
+		{&accounts.Account{}, "PreferredName", "accounts", "unique", nil},
+		{&accounts.Account{}, "UidNumber", "accounts", "autoincrement", uids},
+		{&accounts.Group{}, "OnPremisesSamAccountName", "groups", "unique", nil},
+		{&accounts.Group{}, "DisplayName", "groups", "non_unique", nil},
+		{&accounts.Group{}, "GidNumber", "groups", "autoincrement", uids},
+	} {
+		if err := idx.AddIndex(i.t, i.field, "Id", i.dir, i.idxType, i.bound, i.bound == nil); err != nil {
+			return nil, err
+		}
+	}
+	for _, a := range d.accounts {
+		if _, err := idx.Add(a); err != nil {
+			return nil, err
+		}
+	}
+	for _, g := range d.groups {
+		if _, err := idx.Add(g); err != nil {
+			return nil, err
+		}
+	}
+	return idx.Query(t, q)
+}
+
+// roleIDs returns the role ids and the account id the request is made with
+func roleIDs(ctx context.Context) ([]string, string) {
+	ids := []string{}
+	if v, ok := metadata.Get(ctx, middleware.RoleIDs); ok {
+		_ = json.Unmarshal([]byte(v), &ids)
+	}
+	accountID, _ := metadata.Get(ctx, middleware.AccountID)
+	return ids, accountID
+}
+
+// canWrite checks the request is made with the admin role or the internal role bundle
+func canWrite(ctx context.Context) bool {
+	ids, _ := roleIDs(ctx)
+	for _, id := range ids {
+		if id == adminRoleID || id == testBundleID {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *directory) write(ctx context.Context) error {
+	if d.err != nil {
+		return d.err
+	}
+	if !canWrite(ctx) {
+		return merrors.Forbidden("accounts", "no permission")
+	}
+	return nil
+}
+
+func (d *directory) byID(id string) *accounts.Account {
+	for _, a := range d.accounts {
+		if a.Id == id {
+			return a
+		}
+	}
+	return nil
+}
+
+// account returns the account with the given name or nil
+func (d *directory) account(name string) *accounts.Account {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.accounts[name]
+}
+
+// members returns the names of the members of a group
+func (d *directory) members(name string) []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	names := []string{}
+	for _, m := range d.groups[name].Members {
+		names = append(names, m.PreferredName)
+	}
+	return names
+}
+
+func (d *directory) ListAccounts(ctx context.Context, in *accounts.ListAccountsRequest, opts ...client.CallOption) (*accounts.ListAccountsResponse, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	res := &accounts.ListAccountsResponse{}
+	switch {
+	case in.Query == "":
+		for _, a := range d.accounts {
+			res.Accounts = append(res.Accounts, a)
+		}
+	case loginQuery.MatchString(in.Query):
+		m := loginQuery.FindStringSubmatch(in.Query)
+		login, password := strings.ReplaceAll(m[1], "''", "'"), strings.ReplaceAll(m[2], "''", "'")
+		if a, ok := d.accounts[login]; ok && a.PasswordProfile.Password == password {
+			res.Accounts = append(res.Accounts, a)
+		}
+	default:
+		ids, err := d.query(&accounts.Account{}, in.Query)
+		if err != nil {
+			return nil, merrors.BadRequest("accounts", "could not query accounts: %v", err.Error())
+		}
+		for _, id := range ids {
+			res.Accounts = append(res.Accounts, d.byID(id))
+		}
+	}
+	return res, nil
+}
+
+func (d *directory) GetAccount(ctx context.Context, in *accounts.GetAccountRequest, opts ...client.CallOption) (*accounts.Account, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if a := d.byID(in.Id); a != nil {
+		return a, nil
+	}
+	return nil, merrors.NotFound("accounts", "account %s not found", in.Id)
+}
+
+func (d *directory) CreateAccount(ctx context.Context, in *accounts.CreateAccountRequest, opts ...client.CallOption) (*accounts.Account, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.write(ctx); err != nil {
+		return nil, err
+	}
+	if _, ok := d.accounts[in.Account.OnPremisesSamAccountName]; ok {
+		return nil, merrors.Conflict("accounts", "account %s exists", in.Account.OnPremisesSamAccountName)
+	}
+	in.Account.Id = "id-" + in.Account.OnPremisesSamAccountName
+	d.accounts[in.Account.OnPremisesSamAccountName] = in.Account
+	d.roles[in.Account.Id] = userRoleID
+	return in.Account, nil
+}
+
+func (d *directory) UpdateAccount(ctx context.Context, in *accounts.UpdateAccountRequest, opts ...client.CallOption) (*accounts.Account, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	paths := in.UpdateMask.GetPaths()
+	if err := d.write(ctx); err != nil {
+		// users may change their own password
+		_, accountID := roleIDs(ctx)
+		if d.err != nil || accountID != in.Account.Id || len(paths) != 1 || paths[0] != "PasswordProfile.Password" {
+			return nil, err
+		}
+	}
+	a := d.byID(in.Account.Id)
+	if a == nil {
+		return nil, merrors.NotFound("accounts", "account %s not found", in.Account.Id)
+	}
+	for _, p := range paths {
+		switch p {
+		case "DisplayName":
+			a.DisplayName = in.Account.DisplayName
+		case "Mail":
+			a.Mail = in.Account.Mail
+		case "Description":
+			a.Description = in.Account.Description
+		case "UidNumber":
+			a.UidNumber = in.Account.UidNumber
+		case "GidNumber":
+			a.GidNumber = in.Account.GidNumber
+		case "PasswordProfile.Password":
+			a.PasswordProfile = in.Account.PasswordProfile
+		default:
+			return nil, merrors.BadRequest("accounts", "unsupported update mask path %s", p)
+		}
+	}
+	d.masks = append(d.masks, paths)
+	return a, nil
+}
+
+func (d *directory) DeleteAccount(ctx context.Context, in *accounts.DeleteAccountRequest, opts ...client.CallOption) (*empty.Empty, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.write(ctx); err != nil {
+		return nil, err
+	}
+	a := d.byID(in.Id)
+	if a == nil {
+		return nil, merrors.NotFound("accounts", "account %s not found", in.Id)
+	}
+	delete(d.accounts, a.OnPremisesSamAccountName)
+	return &empty.Empty{}, nil
+}
+
+func (d *directory) ListGroups(ctx context.Context, in *accounts.ListGroupsRequest, opts ...client.CallOption) (*accounts.ListGroupsResponse, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	res := &accounts.ListGroupsResponse{}
+	switch {
+	case in.Query == "":
+		for _, g := range d.groups {
+			res.Groups = append(res.Groups, g)
+		}
+	default:
+		ids, err := d.query(&accounts.Group{}, in.Query)
+		if err != nil {
+			return nil, merrors.BadRequest("accounts", "could not query groups: %v", err.Error())
+		}
+		for _, g := range d.groups {
+			for _, id := range ids {
+				if g.Id == id {
+					res.Groups = append(res.Groups, g)
+				}
+			}
+		}
+	}
+	return res, nil
+}
+
+func (d *directory) GetGroup(ctx context.Context, in *accounts.GetGroupRequest, opts ...client.CallOption) (*accounts.Group, error) {
+	return nil, merrors.InternalServerError("accounts", "not implemented")
+}
+
+func (d *directory) CreateGroup(ctx context.Context, in *accounts.CreateGroupRequest, opts ...client.CallOption) (*accounts.Group, error) {
+	return nil, merrors.InternalServerError("accounts", "not implemented")
+}
+
+func (d *directory) UpdateGroup(ctx context.Context, in *accounts.UpdateGroupRequest, opts ...client.CallOption) (*accounts.Group, error) {
+	return nil, merrors.InternalServerError("accounts", "not implemented")
+}
+
+func (d *directory) DeleteGroup(ctx context.Context, in *accounts.DeleteGroupRequest, opts ...client.CallOption) (*empty.Empty, error) {
+	return nil, merrors.InternalServerError("accounts", "not implemented")
+}
+
+func (d *directory) AddMember(ctx context.Context, in *accounts.AddMemberRequest, opts ...client.CallOption) (*accounts.Group, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.write(ctx); err != nil {
+		return nil, err
+	}
+	for _, g := range d.groups {
+		if g.Id == in.GroupId {
+			g.Members = append(g.Members, d.byID(in.AccountId))
+			return g, nil
+		}
+	}
+	return nil, merrors.NotFound("accounts", "group %s not found", in.GroupId)
+}
+
+func (d *directory) RemoveMember(ctx context.Context, in *accounts.RemoveMemberRequest, opts ...client.CallOption) (*accounts.Group, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.write(ctx); err != nil {
+		return nil, err
+	}
+	for _, g := range d.groups {
+		if g.Id != in.GroupId {
+			continue
+		}
+		for i, m := range g.Members {
+			if m.Id == in.AccountId {
+				g.Members = append(g.Members[:i], g.Members[i+1:]...)
+				break
+			}
+		}
+		return g, nil
+	}
+	return nil, merrors.NotFound("accounts", "group %s not found", in.GroupId)
+}
+
+func (d *directory) ListMembers(ctx context.Context, in *accounts.ListMembersRequest, opts ...client.CallOption) (*accounts.ListMembersResponse, error) {
+	return nil, merrors.InternalServerError("accounts", "not implemented")
+}
+
+// serveDirectory runs glauth with the directory as the accounts backend and returns its address
+func serveDirectory(t *testing.T, d *directory, opts ...Option) string {
+	return serve(t, append([]Option{
+		AccountsService(d),
+		GroupsService(d),
+		RoleService(settings.MockRoleService{
+			ListRoleAssignmentsFunc: func(ctx context.Context, req *settings.ListRoleAssignmentsRequest, opts ...client.CallOption) (*settings.ListRoleAssignmentsResponse, error) {
+				d.mu.Lock()
+				defer d.mu.Unlock()
+				return &settings.ListRoleAssignmentsResponse{
+					Assignments: []*settings.UserRoleAssignment{{AccountUuid: req.AccountUuid, RoleId: d.roles[req.AccountUuid]}},
+				}, nil
+			},
+		}),
+		RoleBundleUUID(testBundleID),
+	}, opts...)...)
+}
+
+func userDN(name string) string {
+	return "cn=" + name + ",ou=users,dc=example,dc=org"
+}
+
+func groupDN(name string) string {
+	return "cn=" + name + ",ou=groups,dc=example,dc=org"
+}
+
+// bind connects to glauth as the given user
+func bind(t *testing.T, addr, name, password string) *ldapclient.Conn {
+	conn := dial(t, addr)
+	if err := conn.Bind(userDN(name), password); err != nil {
+		t.Fatal(err)
+	}
+	return conn
+}
+
+// checkCode fails the test unless err is an ldap error with the code, or nil for success
+func checkCode(t *testing.T, name string, err error, code uint16) {
+	t.Helper()
+	if code == ldapclient.LDAPResultSuccess {
+		if err != nil {
+			t.Errorf("%s: expected success, got %v", name, err)
+		}
+		return
+	}
+	if !ldapclient.IsErrorWithCode(err, code) {
+		t.Errorf("%s: expected %s, got %v", name, ldapclient.LDAPResultCodeMap[code], err)
+	}
+}
+
+func TestAdd(t *testing.T) {
+	d := newDirectory()
+	addr := serveDirectory(t, d)
+	conn := bind(t, addr, "moss", "vista")
+
+	req := ldapclient.NewAddRequest(userDN("feynman"), nil)
+	req.Attribute("objectClass", []string{"posixAccount", "inetOrgPerson"})
+	req.Attribute("cn", []string{"feynman"})
+	req.Attribute("displayName", []string{"Richard Feynman"})
+	req.Attribute("mail", []string{"feynman@example.org"})
+	req.Attribute("uidNumber", []string{"20005"})
+	req.Attribute("userPassword", []string{"superfluidity"})
+	if err := conn.Add(req); err != nil {
+		t.Fatal(err)
+	}
+	a := d.account("feynman")
+	if a == nil {
+		t.Fatal("expected the account to be created")
+	}
+	if !a.AccountEnabled || a.PreferredName != "feynman" || a.DisplayName != "Richard Feynman" || a.Mail != "feynman@example.org" || a.UidNumber != 20005 {
+		t.Errorf("unexpected account %+v", a)
+	}
+	// the new account can bind with its password
+	bind(t, addr, "feynman", "superfluidity")
+
+	for _, tc := range []struct {
+		name  string
+		dn    string
+		attrs map[string][]string
+		err   error
+		code  uint16
+	}{
+		{name: "dn outside of the base dn", dn: "cn=bohr,ou=users,dc=example,dc=com", code: ldapclient.LDAPResultUnwillingToPerform},
+		{name: "dn without a name", dn: "cn=,ou=users,dc=example,dc=org", code: ldapclient.LDAPResultUnwillingToPerform},
+		{name: "dn with too many parts", dn: "cn=bohr,ou=physicists,ou=users,dc=example,dc=org", code: ldapclient.LDAPResultUnwillingToPerform},
+		{name: "group dn", dn: groupDN("chemists"), code: ldapclient.LDAPResultUnwillingToPerform},
+		{name: "unwritable attribute", dn: userDN("bohr"), attrs: map[string][]string{"telephoneNumber": {"42"}}, code: ldapclient.LDAPResultUnwillingToPerform},
+		{name: "multiple values", dn: userDN("bohr"), attrs: map[string][]string{"mail": {"bohr@example.org", "niels@example.org"}}, code: ldapclient.LDAPResultConstraintViolation},
+		{name: "invalid number", dn: userDN("bohr"), attrs: map[string][]string{"uidNumber": {"many"}}, code: ldapclient.LDAPResultInvalidAttributeSyntax},
+		{name: "existing account", dn: userDN("einstein"), code: ldapclient.LDAPResultEntryAlreadyExists},
+		{name: "invalid account", dn: userDN("bohr"), err: merrors.BadRequest("accounts", "invalid"), code: ldapclient.LDAPResultConstraintViolation},
+		{name: "backend error", dn: userDN("bohr"), err: merrors.InternalServerError("accounts", "unavailable"), code: ldapclient.LDAPResultOperationsError},
+	} {
+		d.err = tc.err
+		req := ldapclient.NewAddRequest(tc.dn, nil)
+		for attr, values := range tc.attrs {
+			req.Attribute(attr, values)
+		}
+		checkCode(t, tc.name, conn.Add(req), tc.code)
+		if d.account("bohr") != nil {
+			t.Fatalf("%s: expected no account to be created", tc.name)
+		}
+	}
+}
+
+func TestModify(t *testing.T) {
+	d := newDirectory()
+	conn := bind(t, serveDirectory(t, d), "moss", "vista")
+
+	req := ldapclient.NewModifyRequest(userDN("einstein"), nil)
+	req.Replace("displayName", []string{"Albert Einstein"})
+	req.Add("description", []string{"theoretical physicist"})
+	req.Delete("mail", nil)
+	if err := conn.Modify(req); err != nil {
+		t.Fatal(err)
+	}
+	a := d.account("einstein")
+	if a.DisplayName != "Albert Einstein" || a.Description != "theoretical physicist" || a.Mail != "" {
+		t.Errorf("unexpected account %+v", a)
+	}
+	if mask := strings.Join(d.masks[len(d.masks)-1], ","); mask != "Description,DisplayName,Mail" {
+		t.Errorf("unexpected update mask %s", mask)
+	}
+
+	req = ldapclient.NewModifyRequest(groupDN("physics-lovers"), nil)
+	req.Add("memberUid", []string{"marie"})
+	req.Delete("memberUid", []string{"einstein"})
+	if err := conn.Modify(req); err != nil {
+		t.Fatal(err)
+	}
+	if members := d.members("physics-lovers"); len(members) != 1 || members[0] != "marie" {
+		t.Errorf("unexpected members %v", members)
+	}
+
+	req = ldapclient.NewModifyRequest(groupDN("physics-lovers"), nil)
+	req.Replace("memberUid", []string{"moss"})
+	if err := conn.Modify(req); err != nil {
+		t.Fatal(err)
+	}
+	if members := d.members("physics-lovers"); len(members) != 1 || members[0] != "moss" {
+		t.Errorf("unexpected members %v", members)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		dn     string
+		attr   string
+		values []string
+		err    error
+		code   uint16
+	}{
+		{name: "dn outside of the base dn", dn: "cn=einstein,ou=users,dc=example,dc=com", attr: "mail", values: []string{"a@example.org"}, code: ldapclient.LDAPResultNoSuchObject},
+		{name: "dn below neither users nor groups", dn: "cn=einstein,ou=people,dc=example,dc=org", attr: "mail", values: []string{"a@example.org"}, code: ldapclient.LDAPResultNoSuchObject},
+		{name: "unknown account", dn: userDN("bohr"), attr: "mail", values: []string{"bohr@example.org"}, code: ldapclient.LDAPResultNoSuchObject},
+		{name: "unwritable attribute", dn: userDN("einstein"), attr: "uid", values: []string{"albert"}, code: ldapclient.LDAPResultUnwillingToPerform},
+		{name: "multiple values", dn: userDN("einstein"), attr: "mail", values: []string{"a@example.org", "b@example.org"}, code: ldapclient.LDAPResultConstraintViolation},
+		{name: "unknown group", dn: groupDN("chemists"), attr: "memberUid", values: []string{"marie"}, code: ldapclient.LDAPResultNoSuchObject},
+		{name: "group attribute other than memberUid", dn: groupDN("physics-lovers"), attr: "description", values: []string{"physics"}, code: ldapclient.LDAPResultUnwillingToPerform},
+		{name: "unknown member", dn: groupDN("physics-lovers"), attr: "memberUid", values: []string{"bohr"}, code: ldapclient.LDAPResultNoSuchObject},
+		{name: "account backend error", dn: userDN("einstein"), attr: "mail", values: []string{"a@example.org"}, err: merrors.InternalServerError("accounts", "unavailable"), code: ldapclient.LDAPResultOperationsError},
+		{name: "group backend error", dn: groupDN("physics-lovers"), attr: "memberUid", values: []string{"marie"}, err: merrors.InternalServerError("accounts", "unavailable"), code: ldapclient.LDAPResultOperationsError},
+	} {
+		d.err = tc.err
+		req := ldapclient.NewModifyRequest(tc.dn, nil)
+		req.Replace(tc.attr, tc.values)
+		checkCode(t, tc.name, conn.Modify(req), tc.code)
+	}
+	if a := d.account("einstein"); a.Mail != "" {
+		t.Errorf("expected failed modifications to keep the account, got %+v", a)
+	}
+	if members := d.members("physics-lovers"); len(members) != 1 || members[0] != "moss" {
+		t.Errorf("expected failed modifications to keep the members, got %v", members)
+	}
+}
+
+func TestModifyGroupMembersPermission(t *testing.T) {
+	d := newDirectory()
+	conn := bind(t, serveDirectory(t, d), "marie", "radioactivity")
+
+	// users cannot add themselves to a group or remove others from it
+	req := ldapclient.NewModifyRequest(groupDN("physics-lovers"), nil)
+	req.Add("memberUid", []string{"marie"})
+	checkCode(t, "add member", conn.Modify(req), ldapclient.LDAPResultInsufficientAccessRights)
+
+	req = ldapclient.NewModifyRequest(groupDN("physics-lovers"), nil)
+	req.Delete("memberUid", []string{"einstein"})
+	checkCode(t, "remove member", conn.Modify(req), ldapclient.LDAPResultInsufficientAccessRights)
+
+	if members := d.members("physics-lovers"); len(members) != 1 || members[0] != "einstein" {
+		t.Errorf("expected the members to be unchanged, got %v", members)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	d := newDirectory()
+	conn := bind(t, serveDirectory(t, d), "moss", "vista")
+
+	if err := conn.Del(ldapclient.NewDelRequest(userDN("marie"), nil)); err != nil {
+		t.Fatal(err)
+	}
+	if d.account("marie") != nil {
+		t.Error("expected the account to be deleted")
+	}
+
+	for _, tc := range []struct {
+		name string
+		dn   string
+		err  error
+		code uint16
+	}{
+		{name: "dn outside of the base dn", dn: "cn=einstein,ou=users,dc=example,dc=com", code: ldapclient.LDAPResultNoSuchObject},
+		{name: "dn without a name", dn: "ou=users,dc=example,dc=org", code: ldapclient.LDAPResultNoSuchObject},
+		{name: "group dn", dn: groupDN("physics-lovers"), code: ldapclient.LDAPResultUnwillingToPerform},
+		{name: "unknown account", dn: userDN("marie"), code: ldapclient.LDAPResultNoSuchObject},
+		{name: "backend error", dn: userDN("einstein"), err: merrors.InternalServerError("accounts", "unavailable"), code: ldapclient.LDAPResultOperationsError},
+	} {
+		d.err = tc.err
+		checkCode(t, tc.name, conn.Del(ldapclient.NewDelRequest(tc.dn, nil)), tc.code)
+	}
+	if d.account("einstein") == nil {
+		t.Error("expected failed deletes to keep the account")
+	}
+}
+
+func TestPasswordModify(t *testing.T) {
+	d := newDirectory()
+	addr := serveDirectory(t, d)
+
+	// admins may set the password of other accounts without knowing the old one
+	admin := bind(t, addr, "moss", "vista")
+	if _, err := admin.PasswordModify(ldapclient.NewPasswordModifyRequest(userDN("marie"), "", "polonium")); err != nil {
+		t.Fatal(err)
+	}
+	bind(t, addr, "marie", "polonium")
+
+	// users change their own password, the user identity defaults to the bound account
+	user := bind(t, addr, "einstein", "relativity")
+	if _, err := user.PasswordModify(ldapclient.NewPasswordModifyRequest("", "relativity", "photons")); err != nil {
+		t.Fatal(err)
+	}
+	bind(t, addr, "einstein", "photons")
+
+	for _, tc := range []struct {
+		name     string
+		identity string
+		old      string
+		new      string
+		err      error
+		code     uint16
+	}{
+		{name: "wrong old password", old: "relativity", new: "gravity", code: ldapclient.LDAPResultInvalidCredentials},
+		{name: "missing new password", old: "photons", code: ldapclient.LDAPResultUnwillingToPerform},
+		{name: "identity outside of the base dn", identity: "cn=einstein,ou=users,dc=example,dc=com", new: "gravity", code: ldapclient.LDAPResultNoSuchObject},
+		{name: "group identity", identity: groupDN("physics-lovers"), new: "gravity", code: ldapclient.LDAPResultNoSuchObject},
+		{name: "unknown identity", identity: userDN("bohr"), new: "gravity", code: ldapclient.LDAPResultNoSuchObject},
+		{name: "backend error", old: "photons", new: "gravity", err: merrors.InternalServerError("accounts", "unavailable"), code: ldapclient.LDAPResultOperationsError},
+	} {
+		d.err = tc.err
+		_, err := user.PasswordModify(ldapclient.NewPasswordModifyRequest(tc.identity, tc.old, tc.new))
+		checkCode(t, tc.name, err, tc.code)
+	}
+	if a := d.account("einstein"); a.PasswordProfile.Password != "photons" {
+		t.Errorf("expected failed password changes to keep the password, got %s", a.PasswordProfile.Password)
+	}
+}
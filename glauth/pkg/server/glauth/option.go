@@ -6,6 +6,7 @@ import (
 	"github.com/glauth/glauth/pkg/config"
 	accounts "github.com/owncloud/ocis/accounts/pkg/proto/v0"
 	"github.com/owncloud/ocis/ocis-pkg/log"
+	settings "github.com/owncloud/ocis/settings/pkg/proto/v0"
 )
 
 // Option defines a single option function.
@@ -25,6 +26,12 @@ type Options struct {
 	RoleBundleUUID  string
 	AccountsService accounts.AccountsService
 	GroupsService   accounts.GroupsService
+	RoleService     settings.RoleService
+	AllowAnonymous  bool
+	ServiceAccounts []string
+	SelfOnly        bool
+	Schema          *Schema
+	StartTLS        bool
 }
 
 // newOptions initializes the available default options.
@@ -121,3 +128,45 @@ func RoleBundleUUID(val string) Option {
 		o.RoleBundleUUID = val
 	}
 }
+
+// RoleService provides a settings RoleService client to set the RoleService option.
+func RoleService(val settings.RoleService) Option {
+	return func(o *Options) {
+		o.RoleService = val
+	}
+}
+
+// AllowAnonymous provides a function to allow anonymous binds to search.
+func AllowAnonymous(val bool) Option {
+	return func(o *Options) {
+		o.AllowAnonymous = val
+	}
+}
+
+// ServiceAccounts provides a function to set the usernames of the accounts that may read all entries.
+func ServiceAccounts(val []string) Option {
+	return func(o *Options) {
+		o.ServiceAccounts = val
+	}
+}
+
+// SelfOnly provides a function to restrict accounts to read only their own entry and groups.
+func SelfOnly(val bool) Option {
+	return func(o *Options) {
+		o.SelfOnly = val
+	}
+}
+
+// AccountSchema provides a function to set the templates used to render account attributes.
+func AccountSchema(val *Schema) Option {
+	return func(o *Options) {
+		o.Schema = val
+	}
+}
+
+// StartTLS provides a function to enable StartTLS on the ldap listener.
+func StartTLS(val bool) Option {
+	return func(o *Options) {
+		o.StartTLS = val
+	}
+}
@@ -0,0 +1,184 @@
+package glauth
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/nmcclain/ldap"
+	"github.com/owncloud/ocis/glauth/pkg/version"
+	"github.com/owncloud/ocis/ocis-pkg/log"
+)
+
+// subschemaDN is the entry that publishes the schema, see https://tools.ietf.org/html/rfc4512#section-4.2
+const subschemaDN = "cn=subschema"
+
+// allOperationalAttributesOID announces support for requesting all operational attributes with "+"
+const allOperationalAttributesOID = "1.3.6.1.4.1.4203.1.5.1"
+
+// rootHandler answers the root DSE and subschema searches and the StartTLS extended operation.
+// Requests outside of the naming contexts are routed to it by the ldap server.
+type rootHandler struct {
+	log        log.Logger
+	basedn     string
+	startTLS   bool
+	extensions []string
+	// next handles all other extended operations
+	next ldap.Extender
+}
+
+func (h rootHandler) Search(boundDN string, req ldap.SearchRequest, conn net.Conn) (ldap.ServerSearchResult, error) {
+	h.log.Debug().
+		Str("handler", "root").
+		Str("binddn", boundDN).
+		Str("basedn", req.BaseDN).
+		Str("filter", req.Filter).
+		Interface("src", conn.RemoteAddr()).
+		Msg("Search request")
+
+	includeOperational(req.Attributes)
+
+	var entry *ldap.Entry
+	switch {
+	case req.BaseDN == "" && req.Scope == ldap.ScopeBaseObject:
+		entry = h.rootDSE()
+	case req.BaseDN == "":
+		// the root DSE is only returned for base object searches
+		return ldap.ServerSearchResult{ResultCode: ldap.LDAPResultSuccess}, nil
+	case strings.EqualFold(req.BaseDN, subschemaDN):
+		entry = subschema()
+	default:
+		return ldap.ServerSearchResult{
+			ResultCode: ldap.LDAPResultNoSuchObject,
+		}, fmt.Errorf("search error: %s is not in our naming context %s", req.BaseDN, h.basedn)
+	}
+	// the ldap server compares the dn with the requested base dn when applying the scope
+	entry.DN = req.BaseDN
+
+	return ldap.ServerSearchResult{
+		Entries:    []*ldap.Entry{entry},
+		Referrals:  []string{},
+		Controls:   []ldap.Control{},
+		ResultCode: ldap.LDAPResultSuccess,
+	}, nil
+}
+
+// rootDSE describes the server, see https://tools.ietf.org/html/rfc4512#section-5.1
+func (h rootHandler) rootDSE() *ldap.Entry {
+	extensions := h.extensions
+	if h.startTLS {
+		extensions = append([]string{startTLSOID}, extensions...)
+	}
+	attrs := []*ldap.EntryAttribute{
+		attribute("objectClass", "top"),
+		attribute("namingContexts", h.basedn),
+		attribute("subschemaSubentry", subschemaDN),
+		attribute("supportedLDAPVersion", "3"),
+		attribute("supportedFeatures", allOperationalAttributesOID),
+		attribute("vendorName", "ownCloud"),
+		attribute("vendorVersion", version.String),
+	}
+	// request controls are ignored by the ldap server, so there is no supportedControl to announce
+	if len(extensions) > 0 {
+		attrs = append(attrs, attribute("supportedExtension", extensions...))
+	}
+	return &ldap.Entry{Attributes: attrs}
+}
+
+func (h rootHandler) Extended(boundDN string, req ldap.ExtendedRequest, conn net.Conn) (ldap.LDAPResultCode, error) {
+	oid, _ := extendedRequestContent(req)
+	if oid != startTLSOID {
+		if h.next != nil {
+			return h.next.Extended(boundDN, req, conn)
+		}
+		return ldap.LDAPResultProtocolError, nil
+	}
+
+	h.log.Debug().
+		Str("handler", "root").
+		Str("binddn", boundDN).
+		Interface("src", conn.RemoteAddr()).
+		Msg("StartTLS request")
+
+	c, ok := conn.(*startTLSConn)
+	if !ok {
+		// StartTLS is disabled or the connection came in on the ldaps listener
+		return ldap.LDAPResultUnavailable, nil
+	}
+	if !c.startTLS() {
+		return ldap.LDAPResultOperationsError, nil
+	}
+	return ldap.LDAPResultSuccess, nil
+}
+
+// includeOperational maps the "+" selector for all operational attributes (RFC 3673) to "*".
+// The ldap server filters the attributes of the returned entries and does not know about "+". It reads the same
+// backing array, so the selector is replaced in place.
+func includeOperational(attributes []string) {
+	for i := range attributes {
+		if attributes[i] == "+" {
+			attributes[i] = "*"
+		}
+	}
+}
+
+// subschema publishes the attribute types and object classes of the entries glauth returns
+func subschema() *ldap.Entry {
+	return &ldap.Entry{
+		Attributes: []*ldap.EntryAttribute{
+			attribute("objectClass", "top", "subentry", "subschema", "extensibleObject"),
+			attribute("cn", "subschema"),
+			attribute("attributeTypes", attributeTypes...),
+			attribute("objectClasses", objectClasses...),
+		},
+	}
+}
+
+// attributeTypes as defined in RFC 4512, RFC 4519, RFC 2798, RFC 2307, RFC 4530 and the ownCloud schema
+var attributeTypes = []string{
+	"( 2.5.4.0 NAME 'objectClass' EQUALITY objectIdentifierMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.38 )",
+	"( 2.5.4.41 NAME 'name' EQUALITY caseIgnoreMatch SUBSTR caseIgnoreSubstringsMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.15{32768} )",
+	"( 2.5.4.3 NAME ( 'cn' 'commonName' ) SUP name )",
+	"( 2.5.4.4 NAME ( 'sn' 'surname' ) SUP name )",
+	"( 2.5.4.42 NAME 'givenName' SUP name )",
+	"( 2.5.4.13 NAME 'description' EQUALITY caseIgnoreMatch SUBSTR caseIgnoreSubstringsMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.15{1024} )",
+	"( 2.5.4.31 NAME 'member' SUP distinguishedName )",
+	"( 2.5.4.49 NAME 'distinguishedName' EQUALITY distinguishedNameMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.12 )",
+	"( 2.5.4.11 NAME ( 'ou' 'organizationalUnitName' ) SUP name )",
+	"( 0.9.2342.19200300.100.1.1 NAME ( 'uid' 'userid' ) EQUALITY caseIgnoreMatch SUBSTR caseIgnoreSubstringsMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.15{256} )",
+	"( 0.9.2342.19200300.100.1.3 NAME ( 'mail' 'rfc822Mailbox' ) EQUALITY caseIgnoreIA5Match SUBSTR caseIgnoreIA5SubstringsMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.26{256} )",
+	"( 0.9.2342.19200300.100.1.25 NAME ( 'dc' 'domainComponent' ) EQUALITY caseIgnoreIA5Match SUBSTR caseIgnoreIA5SubstringsMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.26 SINGLE-VALUE )",
+	"( 2.16.840.1.113730.3.1.241 NAME 'displayName' EQUALITY caseIgnoreMatch SUBSTR caseIgnoreSubstringsMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 SINGLE-VALUE )",
+	"( 1.3.6.1.1.1.1.0 NAME 'uidNumber' EQUALITY integerMatch ORDERING integerOrderingMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.27 SINGLE-VALUE )",
+	"( 1.3.6.1.1.1.1.1 NAME 'gidNumber' EQUALITY integerMatch ORDERING integerOrderingMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.27 SINGLE-VALUE )",
+	"( 1.3.6.1.1.1.1.2 NAME 'gecos' EQUALITY caseIgnoreIA5Match SUBSTR caseIgnoreIA5SubstringsMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.26 SINGLE-VALUE )",
+	"( 1.3.6.1.1.1.1.3 NAME 'homeDirectory' EQUALITY caseExactIA5Match SYNTAX 1.3.6.1.4.1.1466.115.121.1.26 SINGLE-VALUE )",
+	"( 1.3.6.1.1.1.1.4 NAME 'loginShell' EQUALITY caseExactIA5Match SYNTAX 1.3.6.1.4.1.1466.115.121.1.26 SINGLE-VALUE )",
+	"( 1.3.6.1.1.1.1.12 NAME 'memberUid' EQUALITY caseExactIA5Match SUBSTR caseExactIA5SubstringsMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.26 )",
+	"( 1.2.840.113556.1.2.102 NAME 'memberOf' EQUALITY distinguishedNameMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.12 NO-USER-MODIFICATION USAGE dSAOperation )",
+	"( 1.3.6.1.4.1.39430.1.1.2 NAME 'ownCloudUUID' EQUALITY caseIgnoreMatch SUBSTR caseIgnoreSubstringsMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 SINGLE-VALUE )",
+	"( 1.3.6.1.1.16.4 NAME 'entryUUID' EQUALITY UUIDMatch ORDERING UUIDOrderingMatch SYNTAX 1.3.6.1.1.16.1 SINGLE-VALUE NO-USER-MODIFICATION USAGE directoryOperation )",
+	"( 2.5.18.1 NAME 'createTimestamp' EQUALITY generalizedTimeMatch ORDERING generalizedTimeOrderingMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.24 SINGLE-VALUE NO-USER-MODIFICATION USAGE directoryOperation )",
+	"( 2.5.18.2 NAME 'modifyTimestamp' EQUALITY generalizedTimeMatch ORDERING generalizedTimeOrderingMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.24 SINGLE-VALUE NO-USER-MODIFICATION USAGE directoryOperation )",
+	"( 2.5.18.10 NAME 'subschemaSubentry' EQUALITY distinguishedNameMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.12 SINGLE-VALUE NO-USER-MODIFICATION USAGE directoryOperation )",
+	"( 1.3.6.1.4.1.1466.101.120.5 NAME 'namingContexts' SYNTAX 1.3.6.1.4.1.1466.115.121.1.12 USAGE dSAOperation )",
+	"( 1.3.6.1.4.1.1466.101.120.7 NAME 'supportedExtension' SYNTAX 1.3.6.1.4.1.1466.115.121.1.38 USAGE dSAOperation )",
+	"( 1.3.6.1.4.1.1466.101.120.13 NAME 'supportedControl' SYNTAX 1.3.6.1.4.1.1466.115.121.1.38 USAGE dSAOperation )",
+	"( 1.3.6.1.4.1.4203.1.3.5 NAME 'supportedFeatures' EQUALITY objectIdentifierMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.38 USAGE dSAOperation )",
+	"( 1.3.6.1.4.1.1466.101.120.15 NAME 'supportedLDAPVersion' SYNTAX 1.3.6.1.4.1.1466.115.121.1.27 USAGE dSAOperation )",
+	"( 2.5.21.5 NAME 'attributeTypes' EQUALITY objectIdentifierFirstComponentMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.3 USAGE directoryOperation )",
+	"( 2.5.21.6 NAME 'objectClasses' EQUALITY objectIdentifierFirstComponentMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.37 USAGE directoryOperation )",
+}
+
+// objectClasses as defined in RFC 4512, RFC 4519, RFC 2798, RFC 2307 and the ownCloud schema
+var objectClasses = []string{
+	"( 2.5.6.0 NAME 'top' ABSTRACT MUST objectClass )",
+	"( 2.5.6.6 NAME 'person' SUP top STRUCTURAL MUST ( sn $ cn ) MAY ( description ) )",
+	"( 2.5.6.7 NAME 'organizationalPerson' SUP person STRUCTURAL MAY ( ou ) )",
+	"( 2.16.840.1.113730.3.2.2 NAME 'inetOrgPerson' SUP organizationalPerson STRUCTURAL MAY ( displayName $ givenName $ mail $ uid ) )",
+	"( 1.3.6.1.1.1.2.0 NAME 'posixAccount' SUP top AUXILIARY MUST ( cn $ uid $ uidNumber $ gidNumber $ homeDirectory ) MAY ( loginShell $ gecos $ description ) )",
+	"( 1.3.6.1.1.1.2.2 NAME 'posixGroup' SUP top STRUCTURAL MUST ( cn $ gidNumber ) MAY ( memberUid $ description ) )",
+	"( 2.5.6.9 NAME 'groupOfNames' SUP top STRUCTURAL MUST ( member $ cn ) MAY ( description ) )",
+	"( 1.3.6.1.4.1.39430.1.2.1 NAME 'ownCloud' SUP top AUXILIARY MAY ( ownCloudUUID ) )",
+	"( 2.5.20.1 NAME 'subschema' AUXILIARY MAY ( attributeTypes $ objectClasses ) )",
+}
@@ -0,0 +1,122 @@
+package glauth
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/nmcclain/ldap"
+	accounts "github.com/owncloud/ocis/accounts/pkg/proto/v0"
+)
+
+// DefaultAccountAttributes are the templates used to render the configurable attributes of account entries.
+// The templates are executed with the account as data.
+var DefaultAccountAttributes = map[string]string{
+	"cn":            "{{.DisplayName}}",
+	"sn":            "{{surname .DisplayName}}",
+	"givenName":     "{{givenName .DisplayName}}",
+	"gecos":         "{{.DisplayName}}",
+	"homeDirectory": "/home/{{.PreferredName}}",
+	"loginShell":    "/bin/bash",
+}
+
+// generalizedTime is the LDAP syntax for timestamps, see https://tools.ietf.org/html/rfc4517#section-3.3.13
+const generalizedTime = "20060102150405Z"
+
+// Schema renders the configurable attributes of account entries
+type Schema struct {
+	names     []string
+	templates map[string]*template.Template
+}
+
+var schemaFuncs = template.FuncMap{
+	"givenName": func(name string) string {
+		fields := strings.Fields(name)
+		if len(fields) < 2 {
+			return ""
+		}
+		return strings.Join(fields[:len(fields)-1], " ")
+	},
+	"surname": func(name string) string {
+		fields := strings.Fields(name)
+		if len(fields) == 0 {
+			return ""
+		}
+		return fields[len(fields)-1]
+	},
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// NewSchema parses the attribute templates. Attributes with an empty template are not rendered.
+func NewSchema(attributes map[string]string) (*Schema, error) {
+	s := &Schema{
+		templates: map[string]*template.Template{},
+	}
+	for name, text := range attributes {
+		if text == "" {
+			continue
+		}
+		t, err := template.New(name).Funcs(schemaFuncs).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template for attribute %s: %w", name, err)
+		}
+		s.names = append(s.names, name)
+		s.templates[name] = t
+	}
+	sort.Strings(s.names)
+	return s, nil
+}
+
+// render executes the attribute templates for the account. Attributes that render to an empty string are left out.
+func (s *Schema) render(a *accounts.Account) ([]*ldap.EntryAttribute, error) {
+	attrs := make([]*ldap.EntryAttribute, 0, len(s.names))
+	for _, name := range s.names {
+		var b bytes.Buffer
+		if err := s.templates[name].Execute(&b, a); err != nil {
+			return nil, fmt.Errorf("could not render attribute %s: %w", name, err)
+		}
+		if b.Len() > 0 {
+			attrs = append(attrs, attribute(name, b.String()))
+		}
+	}
+	return attrs, nil
+}
+
+// operationalAttributes are only returned when they are requested by name or with "+", see https://tools.ietf.org/html/rfc3673
+var operationalAttributes = map[string]bool{
+	"entryuuid":       true,
+	"createtimestamp": true,
+	"modifytimestamp": true,
+}
+
+// wantsOperational checks if the search request asks for operational attributes
+func wantsOperational(requested []string) bool {
+	for _, r := range requested {
+		if r == "+" || operationalAttributes[strings.ToLower(r)] {
+			return true
+		}
+	}
+	return false
+}
+
+// latest returns the most recent of the given timestamps formatted as generalized time, or an empty string
+func latest(timestamps ...*timestamp.Timestamp) string {
+	var t time.Time
+	for _, ts := range timestamps {
+		if ts == nil {
+			continue
+		}
+		if u := time.Unix(ts.Seconds, int64(ts.Nanos)).UTC(); u.After(t) {
+			t = u
+		}
+	}
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(generalizedTime)
+}
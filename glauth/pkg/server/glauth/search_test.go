@@ -0,0 +1,90 @@
+package glauth
+
+import (
+	"strings"
+	"testing"
+
+	ldapclient "github.com/go-ldap/ldap/v3"
+)
+
+func TestSearchFilters(t *testing.T) {
+	addr := serveDirectory(t, newDirectory(), AllowAnonymous(true))
+	conn := dial(t, addr)
+
+	tests := []struct {
+		name     string
+		filter   string
+		expected []string
+	}{
+		{"presence", "(&(objectClass=posixAccount)(mail=*))", []string{"einstein", "marie", "moss", "reva"}},
+		{"not", "(&(objectClass=posixAccount)(!(cn=einstein)))", []string{"marie", "moss", "reva"}},
+		{"prefix", "(&(objectClass=posixAccount)(cn=m*))", []string{"marie", "moss"}},
+		{"suffix", "(&(objectClass=posixAccount)(displayName=*ss))", []string{"moss"}},
+		{"and", "(&(objectClass=posixAccount)(mail=*@example.org)(cn=*e*))", []string{"einstein", "marie", "reva"}},
+		{"or", "(&(objectClass=posixAccount)(|(mail=marie@example.org)(uidNumber=20003)))", []string{"marie", "reva"}},
+		{"no match", "(&(objectClass=posixAccount)(cn=bohr))", []string{}},
+		{"field without an index", "(&(objectClass=posixAccount)(|(cn=marie)(description=physicist)))", []string{"einstein", "marie"}},
+		{
+			"sssd user by name",
+			"(&(uid=einstein)(objectclass=posixAccount)(&(uidNumber=*)(!(uidNumber=0))))",
+			[]string{"einstein"},
+		},
+		{
+			"sssd user by id",
+			"(&(uidNumber=20001)(objectclass=posixAccount)(uid=*)(&(uidNumber=*)(!(uidNumber=0))))",
+			[]string{"marie"},
+		},
+		{
+			"sssd group by id",
+			"(&(gidNumber=30000)(objectclass=posixGroup)(cn=*)(&(gidNumber=*)(!(gidNumber=0))))",
+			[]string{"physics-lovers"},
+		},
+		{
+			"sssd group memberships",
+			"(&(memberuid=einstein)(objectclass=posixGroup)(cn=*)(&(gidNumber=*)(!(gidNumber=0))))",
+			[]string{"physics-lovers"},
+		},
+		{
+			"sssd group memberships without groups",
+			"(&(memberuid=marie)(objectclass=posixGroup)(cn=*)(&(gidNumber=*)(!(gidNumber=0))))",
+			[]string{},
+		},
+		{
+			"nextcloud login",
+			"(&(|(objectclass=inetOrgPerson))(|(uid=einstein@example.org)(mail=einstein@example.org)))",
+			[]string{"einstein"},
+		},
+		{
+			"nextcloud user search",
+			"(&(|(objectclass=inetOrgPerson))(|(uid=mo*)(displayName=*ei*)(mail=*reva*)))",
+			[]string{"einstein", "moss", "reva"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names, err := searchFilter(conn, tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected %v, got %v", tt.expected, names)
+			}
+		})
+	}
+}
+
+func TestSearchUnsupportedFilters(t *testing.T) {
+	addr := serveDirectory(t, newDirectory(), AllowAnonymous(true))
+
+	for _, filter := range []string{
+		"(&(objectClass=posixAccount)(uidNumber>=20001))",
+		"(&(objectClass=posixAccount)(displayName<=M))",
+		"(&(objectClass=posixAccount)(mail~=einstein@example.org))",
+	} {
+		t.Run(filter, func(t *testing.T) {
+			// the ldap server closes the connection after a failed search
+			_, err := searchFilter(dial(t, addr), filter)
+			checkCode(t, "search", err, ldapclient.LDAPResultUnwillingToPerform)
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package glauth
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"net"
 
 	"github.com/GeertJohan/yubigo"
 	"github.com/glauth/glauth/pkg/config"
@@ -20,6 +22,7 @@ type LdapSvc struct {
 	backend  *config.Config
 	fallback *config.Config
 	yubiAuth *yubigo.YubiAuth
+	startTLS bool
 	l        *ldap.Server
 }
 
@@ -34,6 +37,7 @@ func Server(opts ...Option) (*LdapSvc, error) {
 		fallback: options.Fallback,
 		ldap:     options.LDAP,
 		ldaps:    options.LDAPS,
+		startTLS: options.StartTLS,
 	}
 
 	var err error
@@ -74,11 +78,16 @@ func Server(opts ...Option) (*LdapSvc, error) {
 		bh = NewOCISHandler(
 			AccountsService(options.AccountsService),
 			GroupsService(options.GroupsService),
+			RoleService(options.RoleService),
 			Logger(options.Logger),
 			BaseDN(s.backend.Backend.BaseDN),
 			NameFormat(s.backend.Backend.NameFormat),
 			GroupFormat(s.backend.Backend.GroupFormat),
 			RoleBundleUUID(options.RoleBundleUUID),
+			AllowAnonymous(options.AllowAnonymous),
+			ServiceAccounts(options.ServiceAccounts),
+			SelfOnly(options.SelfOnly),
+			AccountSchema(options.Schema),
 		)
 	default:
 		return nil, fmt.Errorf("unsupported backend %s - must be 'ldap', 'owncloud' or 'accounts'", s.backend.Backend.Datastore)
@@ -112,11 +121,16 @@ func Server(opts ...Option) (*LdapSvc, error) {
 			fh = NewOCISHandler(
 				AccountsService(options.AccountsService),
 				GroupsService(options.GroupsService),
+				RoleService(options.RoleService),
 				Logger(options.Logger),
 				BaseDN(s.fallback.Backend.BaseDN),
 				NameFormat(s.fallback.Backend.NameFormat),
 				GroupFormat(s.fallback.Backend.GroupFormat),
 				RoleBundleUUID(options.RoleBundleUUID),
+				AllowAnonymous(options.AllowAnonymous),
+				ServiceAccounts(options.ServiceAccounts),
+				SelfOnly(options.SelfOnly),
+				AccountSchema(options.Schema),
 			)
 		default:
 			return nil, fmt.Errorf("unsupported fallback %s - must be 'ldap', 'owncloud' or 'accounts'", s.fallback.Backend.Datastore)
@@ -129,14 +143,57 @@ func Server(opts ...Option) (*LdapSvc, error) {
 	s.l.BindFunc(s.backend.Backend.BaseDN, bh)
 	s.l.SearchFunc(s.backend.Backend.BaseDN, bh)
 	s.l.CloseFunc(s.backend.Backend.BaseDN, bh)
+	s.l.AddFunc(s.backend.Backend.BaseDN, bh)
+	s.l.ModifyFunc(s.backend.Backend.BaseDN, bh)
+	s.l.DeleteFunc(s.backend.Backend.BaseDN, bh)
+	// writes are routed by the bound dn, anonymous writes have to be rejected by the backend as well
+	s.l.AddFunc("", bh)
+	s.l.ModifyFunc("", bh)
+	s.l.DeleteFunc("", bh)
+
+	// the root dse, the subschema and StartTLS requests are not part of the naming context of the backend
+	rh := rootHandler{
+		log:      options.Logger,
+		basedn:   s.backend.Backend.BaseDN,
+		startTLS: s.startTLS,
+	}
+	if eh, ok := bh.(ldap.Extender); ok {
+		rh.next = eh
+		if s.backend.Backend.Datastore == "accounts" {
+			rh.extensions = append(rh.extensions, passwordModifyOID)
+		}
+	}
+	s.l.SearchFunc("", rh)
+	s.l.ExtendedFunc("", rh)
+	s.l.ExtendedFunc(s.backend.Backend.BaseDN, rh)
 
 	return &s, nil
 }
 
 // ListenAndServe listens on the TCP network address s.c.LDAP.Listen
 func (s *LdapSvc) ListenAndServe() error {
-	s.log.V(3).Info("ldap server listening", "address", s.ldap.Listen)
-	return s.l.ListenAndServe(s.ldap.Listen)
+	s.log.V(3).Info("ldap server listening", "address", s.ldap.Listen, "starttls", s.startTLS)
+	ln, err := net.Listen("tcp", s.ldap.Listen)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ln)
+}
+
+// Serve accepts ldap connections on the listener, they can be upgraded with StartTLS if it is enabled
+func (s *LdapSvc) Serve(ln net.Listener) error {
+	if s.startTLS {
+		cert, err := tls.LoadX509KeyPair(s.ldaps.Cert, s.ldaps.Key)
+		if err != nil {
+			ln.Close()
+			return err
+		}
+		ln = startTLSListener{
+			Listener: ln,
+			config:   &tls.Config{Certificates: []tls.Certificate{cert}},
+		}
+	}
+	return s.l.Serve(ln)
 }
 
 // ListenAndServeTLS listens on the TCP network address s.c.LDAPS.Listen
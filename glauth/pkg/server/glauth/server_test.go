@@ -0,0 +1,191 @@
+package glauth
+
+import (
+	"context"
+	"crypto/tls"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/glauth/glauth/pkg/config"
+	ldapclient "github.com/go-ldap/ldap/v3"
+	"github.com/micro/go-micro/v2/client"
+	accounts "github.com/owncloud/ocis/accounts/pkg/proto/v0"
+	"github.com/owncloud/ocis/glauth/pkg/crypto"
+	"github.com/owncloud/ocis/ocis-pkg/log"
+	settings "github.com/owncloud/ocis/settings/pkg/proto/v0"
+)
+
+// startServer runs glauth with the accounts backend on a random port and returns its address
+func startServer(t *testing.T, startTLS bool) string {
+	einstein := &accounts.Account{Id: "4c510ada-c86b-4815-8820-42cdf82c3d51", PreferredName: "einstein", OnPremisesSamAccountName: "einstein"}
+	return serve(t,
+		AccountsService(accounts.MockAccountsService{
+			ListFunc: func(ctx context.Context, in *accounts.ListAccountsRequest, opts ...client.CallOption) (*accounts.ListAccountsResponse, error) {
+				if in.Query == "login eq 'einstein' and password eq 'relativity'" || in.Query == "" {
+					return &accounts.ListAccountsResponse{Accounts: []*accounts.Account{einstein}}, nil
+				}
+				return &accounts.ListAccountsResponse{}, nil
+			},
+		}),
+		RoleService(settings.MockRoleService{
+			ListRoleAssignmentsFunc: func(ctx context.Context, req *settings.ListRoleAssignmentsRequest, opts ...client.CallOption) (*settings.ListRoleAssignmentsResponse, error) {
+				return &settings.ListRoleAssignmentsResponse{}, nil
+			},
+		}),
+		StartTLS(startTLS),
+	)
+}
+
+// serve runs glauth with the accounts backend and the given options on a random port and returns its address
+func serve(t *testing.T, opts ...Option) string {
+	dir, err := ioutil.TempDir("", "glauth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	logger := log.NewLogger()
+	cert, key := filepath.Join(dir, "ldap.crt"), filepath.Join(dir, "ldap.key")
+	if err := crypto.GenCert(cert, key, logger); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := Server(append([]Option{
+		Logger(logger),
+		LDAPS(&config.LDAPS{Cert: cert, Key: key}),
+		Backend(&config.Config{Backend: config.Backend{
+			Datastore:   "accounts",
+			BaseDN:      "dc=example,dc=org",
+			NameFormat:  "cn",
+			GroupFormat: "ou",
+		}}),
+	}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		_ = s.Serve(ln)
+	}()
+	t.Cleanup(s.Shutdown)
+	return ln.Addr().String()
+}
+
+func dial(t *testing.T, addr string) *ldapclient.Conn {
+	conn, err := ldapclient.DialURL("ldap://" + addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(conn.Close)
+	return conn
+}
+
+func TestRootDSE(t *testing.T) {
+	conn := dial(t, startServer(t, true))
+
+	res, err := conn.Search(ldapclient.NewSearchRequest(
+		"", ldapclient.ScopeBaseObject, ldapclient.NeverDerefAliases, 0, 0, false,
+		"(objectClass=*)", []string{"+"}, nil,
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Entries) != 1 {
+		t.Fatalf("expected the root dse, got %d entries", len(res.Entries))
+	}
+	dse := res.Entries[0]
+	if got := dse.GetAttributeValue("namingContexts"); got != "dc=example,dc=org" {
+		t.Errorf("unexpected namingContexts %s", got)
+	}
+	if got := dse.GetAttributeValue("subschemaSubentry"); got != subschemaDN {
+		t.Errorf("unexpected subschemaSubentry %s", got)
+	}
+	extensions := dse.GetAttributeValues("supportedExtension")
+	if len(extensions) != 2 || extensions[0] != startTLSOID || extensions[1] != passwordModifyOID {
+		t.Errorf("unexpected supportedExtension %v", extensions)
+	}
+
+	// the root dse is only returned for base object searches
+	res, err = conn.Search(ldapclient.NewSearchRequest(
+		"", ldapclient.ScopeSingleLevel, ldapclient.NeverDerefAliases, 0, 0, false,
+		"(objectClass=*)", nil, nil,
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Entries) != 0 {
+		t.Errorf("expected no entries, got %d", len(res.Entries))
+	}
+}
+
+func TestSubschema(t *testing.T) {
+	conn := dial(t, startServer(t, false))
+
+	res, err := conn.Search(ldapclient.NewSearchRequest(
+		"cn=Subschema", ldapclient.ScopeBaseObject, ldapclient.NeverDerefAliases, 0, 0, false,
+		"(objectClass=subschema)", []string{"attributeTypes", "objectClasses"}, nil,
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Entries) != 1 {
+		t.Fatalf("expected the subschema, got %d entries", len(res.Entries))
+	}
+	if got := len(res.Entries[0].GetAttributeValues("attributeTypes")); got != len(attributeTypes) {
+		t.Errorf("expected %d attribute types, got %d", len(attributeTypes), got)
+	}
+	if got := len(res.Entries[0].GetAttributeValues("objectClasses")); got != len(objectClasses) {
+		t.Errorf("expected %d object classes, got %d", len(objectClasses), got)
+	}
+	if got := res.Entries[0].GetAttributeValues("cn"); len(got) != 0 {
+		t.Errorf("expected only the requested attributes, got cn %v", got)
+	}
+
+	_, err = conn.Search(ldapclient.NewSearchRequest(
+		"dc=example,dc=com", ldapclient.ScopeBaseObject, ldapclient.NeverDerefAliases, 0, 0, false,
+		"(objectClass=*)", nil, nil,
+	))
+	if !ldapclient.IsErrorWithCode(err, ldapclient.LDAPResultNoSuchObject) {
+		t.Errorf("expected no such object for a foreign naming context, got %v", err)
+	}
+}
+
+func TestStartTLS(t *testing.T) {
+	conn := dial(t, startServer(t, true))
+
+	if err := conn.StartTLS(&tls.Config{InsecureSkipVerify: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := conn.TLSConnectionState(); !ok {
+		t.Fatal("expected a tls connection")
+	}
+	if err := conn.Bind("cn=einstein,ou=users,dc=example,dc=org", "relativity"); err != nil {
+		t.Fatal(err)
+	}
+	res, err := conn.Search(ldapclient.NewSearchRequest(
+		"dc=example,dc=org", ldapclient.ScopeWholeSubtree, ldapclient.NeverDerefAliases, 0, 0, false,
+		"(objectClass=posixAccount)", []string{"uid"}, nil,
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Entries) != 1 || res.Entries[0].GetAttributeValue("uid") != "einstein" {
+		t.Errorf("unexpected search result %+v", res.Entries)
+	}
+}
+
+func TestStartTLSDisabled(t *testing.T) {
+	conn := dial(t, startServer(t, false))
+
+	err := conn.StartTLS(&tls.Config{InsecureSkipVerify: true})
+	if !ldapclient.IsErrorWithCode(err, ldapclient.LDAPResultUnavailable) {
+		t.Fatalf("expected unavailable, got %v", err)
+	}
+}
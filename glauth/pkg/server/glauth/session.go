@@ -0,0 +1,120 @@
+package glauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/micro/go-micro/v2/metadata"
+	accounts "github.com/owncloud/ocis/accounts/pkg/proto/v0"
+	"github.com/owncloud/ocis/ocis-pkg/middleware"
+	settings "github.com/owncloud/ocis/settings/pkg/proto/v0"
+)
+
+// session is the identity bound to a connection
+type session struct {
+	bindDN  string
+	account *accounts.Account
+	// ctx carries the role ids and the account id of the bound account to make requests on its behalf
+	ctx context.Context
+	// service accounts may read all entries
+	service bool
+}
+
+// sessions tracks the bound identity per connection. The roles are looked up once per bind.
+type sessions struct {
+	mu sync.RWMutex
+	m  map[net.Conn]*session
+}
+
+func newSessions() *sessions {
+	return &sessions{m: map[net.Conn]*session{}}
+}
+
+func (s *sessions) get(conn net.Conn) *session {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.m[conn]
+}
+
+func (s *sessions) set(conn net.Conn, sess *session) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m[conn] = sess
+}
+
+func (s *sessions) delete(conn net.Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.m, conn)
+}
+
+// serviceContext returns a context to make requests to the accounts service with the internal role bundle
+func (h ocisHandler) serviceContext() context.Context {
+	roleIDs, _ := json.Marshal([]string{h.rbid})
+	return metadata.Set(context.Background(), middleware.RoleIDs, string(roleIDs))
+}
+
+// readContext returns the context to search the accounts service with. Service accounts use the internal role
+// bundle, all other accounts their own roles, so the accounts service only returns what they may see.
+func (h ocisHandler) readContext(s *session) context.Context {
+	if s.service {
+		return h.serviceContext()
+	}
+	return s.ctx
+}
+
+// session returns the session of the connection. It is created if the connection was bound by another handler.
+func (h ocisHandler) session(bindDN string, conn net.Conn) (*session, error) {
+	if s := h.sessions.get(conn); s != nil && strings.EqualFold(s.bindDN, bindDN) {
+		return s, nil
+	}
+
+	lowerDN := strings.ToLower(bindDN)
+	baseDN := strings.ToLower("," + h.basedn)
+	if !strings.HasSuffix(lowerDN, baseDN) {
+		return nil, fmt.Errorf("bind dn %s not in our base dn %s", bindDN, h.basedn)
+	}
+	userName := strings.TrimPrefix(strings.Split(strings.TrimSuffix(lowerDN, baseDN), ",")[0], strings.ToLower(h.nameFormat)+"=")
+
+	account, err := h.getAccount(userName)
+	if err != nil {
+		return nil, err
+	}
+	return h.newSession(bindDN, account)
+}
+
+// newSession looks up the roles assigned to the account in the settings service
+func (h ocisHandler) newSession(bindDN string, account *accounts.Account) (*session, error) {
+	res, err := h.rs.ListRoleAssignments(h.serviceContext(), &settings.ListRoleAssignmentsRequest{AccountUuid: account.Id})
+	if err != nil {
+		return nil, err
+	}
+	roleIDs := make([]string, 0, len(res.Assignments))
+	for _, a := range res.Assignments {
+		roleIDs = append(roleIDs, a.RoleId)
+	}
+	roleIDsJSON, err := json.Marshal(roleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := metadata.Set(context.Background(), middleware.RoleIDs, string(roleIDsJSON))
+	ctx = metadata.Set(ctx, middleware.AccountID, account.Id)
+
+	s := &session{
+		bindDN:  bindDN,
+		account: account,
+		ctx:     ctx,
+	}
+	for _, name := range h.serviceAccounts {
+		if strings.EqualFold(name, account.OnPremisesSamAccountName) {
+			s.service = true
+			break
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,191 @@
+package glauth
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/glauth/glauth/pkg/config"
+	ldapclient "github.com/go-ldap/ldap/v3"
+)
+
+// search returns the sorted cn values of all entries with the object class
+func search(conn *ldapclient.Conn, objectClass string) ([]string, error) {
+	return searchFilter(conn, "(objectClass="+objectClass+")")
+}
+
+// searchFilter returns the sorted cn values of all entries matching the filter
+func searchFilter(conn *ldapclient.Conn, filter string) ([]string, error) {
+	res, err := conn.Search(ldapclient.NewSearchRequest(
+		"dc=example,dc=org", ldapclient.ScopeWholeSubtree, ldapclient.NeverDerefAliases, 0, 0, false,
+		filter, []string{"cn"}, nil,
+	))
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, e := range res.Entries {
+		names = append(names, e.GetAttributeValue("cn"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// checkEntries fails the test unless the search returns exactly the given names
+func checkEntries(t *testing.T, conn *ldapclient.Conn, objectClass string, expected ...string) {
+	t.Helper()
+	names, err := search(conn, objectClass)
+	if err != nil {
+		t.Fatalf("searching %s: %v", objectClass, err)
+	}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %s entries %v, got %v", objectClass, expected, names)
+	}
+}
+
+// checkReadOnly fails the test if the connection may modify entries other than its own
+func checkReadOnly(t *testing.T, d *directory, conn *ldapclient.Conn) {
+	t.Helper()
+	add := ldapclient.NewAddRequest(userDN("bohr"), nil)
+	add.Attribute("mail", []string{"bohr@example.org"})
+	checkCode(t, "add", conn.Add(add), ldapclient.LDAPResultInsufficientAccessRights)
+
+	modify := ldapclient.NewModifyRequest(userDN("marie"), nil)
+	modify.Replace("mail", []string{"curie@example.org"})
+	checkCode(t, "modify account", conn.Modify(modify), ldapclient.LDAPResultInsufficientAccessRights)
+
+	modify = ldapclient.NewModifyRequest(groupDN("physics-lovers"), nil)
+	modify.Add("memberUid", []string{"marie"})
+	checkCode(t, "modify group", conn.Modify(modify), ldapclient.LDAPResultInsufficientAccessRights)
+
+	checkCode(t, "delete", conn.Del(ldapclient.NewDelRequest(userDN("marie"), nil)), ldapclient.LDAPResultInsufficientAccessRights)
+
+	_, err := conn.PasswordModify(ldapclient.NewPasswordModifyRequest(userDN("marie"), "", "polonium"))
+	checkCode(t, "password modify", err, ldapclient.LDAPResultInsufficientAccessRights)
+
+	if d.account("bohr") != nil {
+		t.Error("expected no account to be created")
+	}
+	if a := d.account("marie"); a == nil || a.Mail != "marie@example.org" || a.PasswordProfile.Password != "radioactivity" {
+		t.Errorf("expected marie to be unchanged, got %+v", a)
+	}
+	if members := d.members("physics-lovers"); len(members) != 1 || members[0] != "einstein" {
+		t.Errorf("expected the members to be unchanged, got %v", members)
+	}
+}
+
+func TestAnonymousDenied(t *testing.T) {
+	d := newDirectory()
+	addr := serveDirectory(t, d)
+
+	_, err := search(dial(t, addr), "posixAccount")
+	checkCode(t, "search", err, ldapclient.LDAPResultInsufficientAccessRights)
+	// the ldap server closes the connection after a failed search
+	checkReadOnly(t, d, dial(t, addr))
+}
+
+func TestAnonymousAllowed(t *testing.T) {
+	d := newDirectory()
+	conn := dial(t, serveDirectory(t, d, AllowAnonymous(true)))
+
+	// anonymous binds may read, but not write
+	checkEntries(t, conn, "posixAccount", "einstein", "marie", "moss", "reva")
+	checkReadOnly(t, d, conn)
+}
+
+func TestUserBind(t *testing.T) {
+	d := newDirectory()
+	addr := serveDirectory(t, d, SelfOnly(true), ServiceAccounts([]string{"reva"}))
+
+	einstein := bind(t, addr, "einstein", "relativity")
+	checkEntries(t, einstein, "posixAccount", "einstein")
+	checkEntries(t, einstein, "posixGroup", "physics-lovers")
+	checkReadOnly(t, d, einstein)
+
+	// marie is not a member of any group
+	marie := bind(t, addr, "marie", "radioactivity")
+	checkEntries(t, marie, "posixAccount", "marie")
+	checkEntries(t, marie, "posixGroup")
+
+	// rebinding replaces the session of the connection
+	if err := marie.Bind(userDN("reva"), "reva"); err != nil {
+		t.Fatal(err)
+	}
+	checkEntries(t, marie, "posixAccount", "einstein", "marie", "moss", "reva")
+}
+
+func TestServiceBind(t *testing.T) {
+	d := newDirectory()
+	addr := serveDirectory(t, d, SelfOnly(true), ServiceAccounts([]string{"reva"}))
+
+	// service accounts read all entries
+	reva := bind(t, addr, "reva", "reva")
+	checkEntries(t, reva, "posixAccount", "einstein", "marie", "moss", "reva")
+	checkEntries(t, reva, "posixGroup", "physics-lovers")
+
+	// writes are made with the roles of the service account
+	checkReadOnly(t, d, reva)
+	d.mu.Lock()
+	d.roles["id-reva"] = adminRoleID
+	d.mu.Unlock()
+	reva = bind(t, addr, "reva", "reva")
+	modify := ldapclient.NewModifyRequest(userDN("marie"), nil)
+	modify.Replace("mail", []string{"curie@example.org"})
+	if err := reva.Modify(modify); err != nil {
+		t.Fatal(err)
+	}
+	if err := reva.Del(ldapclient.NewDelRequest(userDN("einstein"), nil)); err != nil {
+		t.Fatal(err)
+	}
+	if a := d.account("marie"); a.Mail != "curie@example.org" {
+		t.Errorf("expected the mail to be modified, got %s", a.Mail)
+	}
+	if d.account("einstein") != nil {
+		t.Error("expected the account to be deleted")
+	}
+}
+
+func TestBindEscapesQuotes(t *testing.T) {
+	d := newDirectory()
+	d.accounts["marie"].PasswordProfile.Password = "it's radioactive"
+	addr := serveDirectory(t, d)
+
+	marie := bind(t, addr, "marie", "it's radioactive")
+	checkEntries(t, marie, "posixAccount", "einstein", "marie", "moss", "reva")
+
+	// quotes must not change the login query
+	for _, c := range []struct{ name, password string }{
+		{"marie", "x' and password eq 'it''s radioactive"},
+		{"marie' and password eq 'x", "it's radioactive"},
+		{"marie", "it''s radioactive"},
+	} {
+		err := dial(t, addr).Bind(userDN(c.name), c.password)
+		checkCode(t, c.name+"/"+c.password, err, ldapclient.LDAPResultInvalidCredentials)
+	}
+}
+
+func TestNameFormat(t *testing.T) {
+	d := newDirectory()
+	addr := serveDirectory(t, d, SelfOnly(true), Backend(&config.Config{Backend: config.Backend{
+		Datastore:   "accounts",
+		BaseDN:      "dc=example,dc=org",
+		NameFormat:  "uid",
+		GroupFormat: "ou",
+	}}))
+
+	conn := dial(t, addr)
+	if err := conn.Bind("uid=einstein,ou=users,dc=example,dc=org", "relativity"); err != nil {
+		t.Fatal(err)
+	}
+	// the session is looked up by the name in the bind dn
+	res, err := conn.Search(ldapclient.NewSearchRequest(
+		"dc=example,dc=org", ldapclient.ScopeWholeSubtree, ldapclient.NeverDerefAliases, 0, 0, false,
+		"(objectClass=posixAccount)", []string{"cn"}, nil,
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Entries) != 1 || res.Entries[0].DN != "uid=einstein,ou=users,dc=example,dc=org" {
+		t.Errorf("expected only the entry of einstein, got %v", res.Entries)
+	}
+}
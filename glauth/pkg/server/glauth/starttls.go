@@ -0,0 +1,73 @@
+package glauth
+
+import (
+	"crypto/tls"
+	"net"
+	"sync"
+)
+
+// startTLSOID is the name of the StartTLS extended operation, see https://tools.ietf.org/html/rfc4511#section-4.14
+const startTLSOID = "1.3.6.1.4.1.1466.20037"
+
+// startTLSListener wraps the accepted connections so they can be upgraded to TLS
+type startTLSListener struct {
+	net.Listener
+	config *tls.Config
+}
+
+func (l startTLSListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &startTLSConn{Conn: c, config: l.config}, nil
+}
+
+// startTLSConn is a connection that switches to TLS after the StartTLS response has been written.
+// The ldap server reads and writes the connection it accepted, so the upgrade has to happen underneath it.
+type startTLSConn struct {
+	net.Conn
+	config *tls.Config
+
+	mu      sync.Mutex
+	pending bool
+	tls     bool
+}
+
+// startTLS schedules the upgrade, the next write carries the StartTLS response and is still sent in plain text
+func (c *startTLSConn) startTLS() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tls || c.pending {
+		return false
+	}
+	c.pending = true
+	return true
+}
+
+func (c *startTLSConn) Write(b []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n, err := c.Conn.Write(b)
+	if c.pending {
+		c.pending = false
+		if err == nil {
+			c.Conn = tls.Server(c.Conn, c.config)
+			c.tls = true
+		}
+	}
+	return n, err
+}
+
+func (c *startTLSConn) Read(b []byte) (int, error) {
+	c.mu.Lock()
+	conn := c.Conn
+	c.mu.Unlock()
+	return conn.Read(b)
+}
+
+func (c *startTLSConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Conn.Close()
+}
@@ -0,0 +1,153 @@
+// Package apptoken contains the data model shared by the services issuing and accepting app tokens. App tokens are
+// per user secrets that clients without OIDC support, e.g. CalDAV or WebDAV desktop tools, can use instead of the
+// password for basic auth. Only a hash of the secret is persisted.
+package apptoken
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	// Database is the store database app tokens are persisted in.
+	Database = "proxy"
+	// Table is the store table app tokens are persisted in.
+	Table = "app-tokens"
+
+	// AccountIDField is the indexed record metadata field holding the account id of the owner.
+	AccountIDField = "account_id"
+	// HashField is the indexed record metadata field holding the hash of the secret.
+	HashField = "hash"
+)
+
+// Token describes an app token. The record key in the store is the ID. Tokens without ExpiresAt don't expire.
+type Token struct {
+	ID         string    `json:"id"`
+	AccountID  string    `json:"account_id"`
+	Label      string    `json:"label"`
+	Hash       string    `json:"hash"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at,omitempty"`
+	ExpiresAt  time.Time `json:"expires_at,omitempty"`
+	Scope      Scope     `json:"scope"`
+}
+
+// Expired checks if the token has expired at the given time.
+func (t *Token) Expired(now time.Time) bool {
+	return !t.ExpiresAt.IsZero() && !now.Before(t.ExpiresAt)
+}
+
+// StoreExpiry returns the expiry of the record of the token as unix timestamp for the store write options, 0 if the
+// token does not expire. The store removes expired records, so they can't be found anymore.
+func (t *Token) StoreExpiry() int64 {
+	if t.ExpiresAt.IsZero() {
+		return 0
+	}
+	return t.ExpiresAt.Unix()
+}
+
+// Scope restricts what a request authenticated with an app token may do.
+type Scope struct {
+	// ReadOnly only allows requests that do not modify anything.
+	ReadOnly bool `json:"read_only,omitempty"`
+	// PathPrefix only allows requests below this path, e.g. /remote.php/dav/files/einstein/Documents
+	PathPrefix string `json:"path_prefix,omitempty"`
+}
+
+// readOnlyMethods are the http and webdav methods allowed for read only tokens.
+var readOnlyMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	"PROPFIND":         true,
+	"REPORT":           true,
+}
+
+// Allows checks if a request with the given method and path is covered by the scope. The path is cleaned first, so
+// that dot segments can't leave the path prefix.
+func (s Scope) Allows(method, p string) bool {
+	if s.ReadOnly && !readOnlyMethods[strings.ToUpper(method)] {
+		return false
+	}
+	if s.PathPrefix != "" {
+		prefix := strings.TrimSuffix(path.Clean("/"+s.PathPrefix), "/")
+		p = path.Clean("/" + p)
+		if p != prefix && !strings.HasPrefix(p, prefix+"/") {
+			return false
+		}
+	}
+	return true
+}
+
+// AllowsRequest checks if the method and path of a request are covered by the scope. The Destination header of
+// webdav MOVE and COPY requests has to be covered as well.
+func (s Scope) AllowsRequest(r *http.Request) bool {
+	if !s.Allows(r.Method, r.URL.Path) {
+		return false
+	}
+	if destination := r.Header.Get("Destination"); destination != "" {
+		u, err := url.Parse(destination)
+		if err != nil {
+			return false
+		}
+		return s.Allows(r.Method, u.Path)
+	}
+	return true
+}
+
+// New creates a token for the account and returns it together with the secret, which is only known at this point.
+func New(accountID, label string, scope Scope) (*Token, string, error) {
+	id, err := random(16)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := random(32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &Token{
+		ID:        id,
+		AccountID: accountID,
+		Label:     label,
+		Hash:      Hash(secret),
+		CreatedAt: time.Now().UTC(),
+		Scope:     scope,
+	}, secret, nil
+}
+
+// Hash returns the hash of a secret as it is persisted and looked up. The secrets have 256 bit of entropy, so a
+// plain SHA-256 is sufficient.
+func Hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Marshal encodes the token as record value.
+func (t *Token) Marshal() ([]byte, error) {
+	return json.Marshal(t)
+}
+
+// Unmarshal decodes a token from a record value.
+func Unmarshal(value []byte) (*Token, error) {
+	t := &Token{}
+	if err := json.Unmarshal(value, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func random(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
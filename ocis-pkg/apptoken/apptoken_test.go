@@ -0,0 +1,112 @@
+package apptoken
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestScopeAllows(t *testing.T) {
+	tests := []struct {
+		scope   Scope
+		method  string
+		path    string
+		allowed bool
+	}{
+		{Scope{}, "PUT", "/remote.php/dav/files/einstein/a.txt", true},
+		{Scope{ReadOnly: true}, "PROPFIND", "/remote.php/dav/files/einstein/", true},
+		{Scope{ReadOnly: true}, "get", "/remote.php/dav/files/einstein/a.txt", true},
+		{Scope{ReadOnly: true}, "PUT", "/remote.php/dav/files/einstein/a.txt", false},
+		{Scope{ReadOnly: true}, "MKCOL", "/remote.php/dav/files/einstein/new", false},
+		{Scope{PathPrefix: "/remote.php/dav/files/einstein/Calendars"}, "PUT", "/remote.php/dav/files/einstein/Calendars/a.ics", true},
+		{Scope{PathPrefix: "/remote.php/dav/files/einstein/Calendars/"}, "PROPFIND", "/remote.php/dav/files/einstein/Calendars", true},
+		{Scope{PathPrefix: "/remote.php/dav/files/einstein/Calendars"}, "GET", "/remote.php/dav/files/einstein/CalendarsOld/a.ics", false},
+		{Scope{PathPrefix: "/remote.php/dav/files/einstein/Calendars"}, "GET", "/ocs/v1.php/cloud/user", false},
+		{Scope{ReadOnly: true, PathPrefix: "/remote.php/dav/files/einstein"}, "DELETE", "/remote.php/dav/files/einstein/a.txt", false},
+		{Scope{PathPrefix: "/remote.php/dav/files/einstein/Calendars"}, "GET", "/remote.php/dav/files/einstein/Calendars/../Documents/a.txt", false},
+		{Scope{PathPrefix: "/remote.php/dav/files/einstein/Calendars"}, "GET", "/remote.php/dav/files/einstein/Calendars/..", false},
+		{Scope{PathPrefix: "/remote.php/dav/files/einstein/Calendars"}, "GET", "/remote.php/dav/files/einstein/Calendars/./a/../b.ics", true},
+		{Scope{PathPrefix: "/remote.php/dav/files/einstein/Calendars/../Documents"}, "GET", "/remote.php/dav/files/einstein/Calendars/a.ics", false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.scope.Allows(tt.method, tt.path); got != tt.allowed {
+			t.Errorf("%+v.Allows(%s, %s) = %t, expected %t", tt.scope, tt.method, tt.path, got, tt.allowed)
+		}
+	}
+}
+
+func TestScopeAllowsRequest(t *testing.T) {
+	scope := Scope{PathPrefix: "/remote.php/dav/files/einstein/Calendars"}
+	tests := []struct {
+		method      string
+		path        string
+		destination string
+		allowed     bool
+	}{
+		{"MOVE", "/remote.php/dav/files/einstein/Calendars/a.ics", "https://localhost:9200/remote.php/dav/files/einstein/Calendars/b.ics", true},
+		{"COPY", "/remote.php/dav/files/einstein/Calendars/a.ics", "/remote.php/dav/files/einstein/Calendars/old/a.ics", true},
+		{"MOVE", "/remote.php/dav/files/einstein/Calendars/a.ics", "https://localhost:9200/remote.php/dav/files/einstein/Documents/a.ics", false},
+		{"COPY", "/remote.php/dav/files/einstein/Calendars/a.ics", "https://localhost:9200/remote.php/dav/files/einstein/Calendars/../Documents/a.ics", false},
+		{"MOVE", "/remote.php/dav/files/einstein/Calendars/a.ics", "https://localhost:9200/remote.php/dav/files/einstein/Calendars/%2e%2e/Documents/a.ics", false},
+		{"MOVE", "/remote.php/dav/files/einstein/Calendars/a.ics", "https://localhost:9200/%zz", false},
+		{"MOVE", "/remote.php/dav/files/einstein/Documents/a.ics", "https://localhost:9200/remote.php/dav/files/einstein/Calendars/a.ics", false},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(tt.method, "https://localhost:9200"+tt.path, nil)
+		r.Header.Set("Destination", tt.destination)
+		if got := scope.AllowsRequest(r); got != tt.allowed {
+			t.Errorf("AllowsRequest(%s %s -> %s) = %t, expected %t", tt.method, tt.path, tt.destination, got, tt.allowed)
+		}
+	}
+}
+
+func TestNewToken(t *testing.T) {
+	token, secret, err := New("4c510ada-c86b-4815-8820-42cdf82c3d51", "calendar", Scope{ReadOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(secret) != 64 || token.ID == "" {
+		t.Errorf("expected a 256 bit secret and an id, got %q and %q", secret, token.ID)
+	}
+	if token.Hash != Hash(secret) || token.Hash == secret {
+		t.Errorf("expected the token to carry the hash of the secret")
+	}
+
+	value, err := token.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := Unmarshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.ID != token.ID || decoded.AccountID != token.AccountID || !decoded.Scope.ReadOnly || !decoded.CreatedAt.Equal(token.CreatedAt) {
+		t.Errorf("expected %+v, got %+v", token, decoded)
+	}
+}
+
+func TestTokenExpired(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		expiresAt time.Time
+		expired   bool
+		expiry    int64
+	}{
+		{time.Time{}, false, 0},
+		{now.Add(time.Hour), false, now.Add(time.Hour).Unix()},
+		{now, true, now.Unix()},
+		{now.Add(-time.Hour), true, now.Add(-time.Hour).Unix()},
+	}
+
+	for _, tt := range tests {
+		token := &Token{ExpiresAt: tt.expiresAt}
+		if got := token.Expired(now); got != tt.expired {
+			t.Errorf("token expiring at %v expired = %t, expected %t", tt.expiresAt, got, tt.expired)
+		}
+		if got := token.StoreExpiry(); got != tt.expiry {
+			t.Errorf("token expiring at %v has store expiry %d, expected %d", tt.expiresAt, got, tt.expiry)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+// Package backup implements the archive format of ocis backups and the helpers the services use to stream
+// snapshots of their data and to receive restores.
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Version is the version of the archive format. Archives of newer versions are rejected.
+const Version = 1
+
+// manifestName is the name of the manifest within the archive, it is always the first entry.
+const manifestName = "manifest.json"
+
+// Manifest describes the content of an archive.
+type Manifest struct {
+	Version  int       `json:"version"`
+	Created  time.Time `json:"created"`
+	Services []Service `json:"services"`
+}
+
+// Service lists the files of the snapshot of a service.
+type Service struct {
+	Name  string `json:"name"`
+	Files []File `json:"files"`
+}
+
+// File is a file of a snapshot, the path is relative to the data of the service.
+type File struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Write writes an archive of the snapshots in dir to w. Every directory in dir holds the snapshot of the service it is
+// named after.
+func Write(w io.Writer, dir string) (*Manifest, error) {
+	m := &Manifest{Version: Version, Created: time.Now().UTC()}
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+		svc := Service{Name: info.Name(), Files: []File{}}
+		err := walkFiles(filepath.Join(dir, info.Name()), func(rel, file string) error {
+			sum, size, err := checksum(file)
+			if err != nil {
+				return err
+			}
+			svc.Files = append(svc.Files, File{Path: rel, Size: size, SHA256: sum})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		m.Services = append(m.Services, svc)
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	if err := tw.WriteHeader(&tar.Header{Name: manifestName, Mode: 0600, Size: int64(len(data)), ModTime: m.Created}); err != nil {
+		return nil, err
+	}
+	if _, err := tw.Write(data); err != nil {
+		return nil, err
+	}
+	for _, svc := range m.Services {
+		for _, f := range svc.Files {
+			if err := writeEntry(tw, path.Join(svc.Name, f.Path), filepath.Join(dir, svc.Name, filepath.FromSlash(f.Path)), f, m.Created); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return m, gw.Close()
+}
+
+func writeEntry(tw *tar.Writer, name, file string, f File, modTime time.Time) error {
+	src, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: f.Size, ModTime: modTime}); err != nil {
+		return err
+	}
+	// a file that changed since the checksum was calculated fails here instead of producing a broken archive
+	if _, err := io.CopyN(tw, src, f.Size); err != nil {
+		return fmt.Errorf("could not archive %v: %w", name, err)
+	}
+	return nil
+}
+
+// Read extracts the archive from r into dir and verifies the files against the checksums of the manifest. The
+// snapshot of every service ends up in a directory named after the service.
+func Read(r io.Reader, dir string) (*Manifest, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a backup archive: %w", err)
+	}
+	defer gr.Close()
+	tr := tar.NewReader(gr)
+
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != manifestName {
+		return nil, fmt.Errorf("not a backup archive: the manifest is missing")
+	}
+	m := &Manifest{}
+	if err := json.NewDecoder(tr).Decode(m); err != nil {
+		return nil, fmt.Errorf("could not read the manifest: %w", err)
+	}
+	if m.Version < 1 || m.Version > Version {
+		return nil, fmt.Errorf("unsupported archive version %d, this version of ocis supports up to version %d", m.Version, Version)
+	}
+
+	expected := map[string]File{}
+	for _, svc := range m.Services {
+		if err := checkPath(svc.Name); err != nil || strings.Contains(svc.Name, "/") {
+			return nil, fmt.Errorf("invalid service name %q in the manifest", svc.Name)
+		}
+		for _, f := range svc.Files {
+			if err := checkPath(f.Path); err != nil {
+				return nil, fmt.Errorf("invalid file %q of the service %v in the manifest", f.Path, svc.Name)
+			}
+			expected[path.Join(svc.Name, f.Path)] = f
+		}
+	}
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		f, ok := expected[hdr.Name]
+		if !ok {
+			return nil, fmt.Errorf("%v is not listed in the manifest", hdr.Name)
+		}
+		delete(expected, hdr.Name)
+		file, err := within(dir, hdr.Name)
+		if err != nil {
+			return nil, err
+		}
+		if err := extract(tr, file, f); err != nil {
+			return nil, fmt.Errorf("could not extract %v: %w", hdr.Name, err)
+		}
+	}
+	for name := range expected {
+		return nil, fmt.Errorf("%v is missing in the archive", name)
+	}
+	return m, nil
+}
+
+func extract(r io.Reader, file string, f File) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+	dst, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(dst, h), r)
+	if err != nil {
+		return err
+	}
+	if size != f.Size || hex.EncodeToString(h.Sum(nil)) != f.SHA256 {
+		return fmt.Errorf("checksum mismatch")
+	}
+	return dst.Close()
+}
+
+func checksum(file string) (string, int64, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+// walkFiles calls fn for every regular file below dir in lexical order, with the slash separated path relative to dir.
+func walkFiles(dir string, fn func(rel, file string) error) error {
+	var files []string
+	err := filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			files = append(files, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		if err := fn(filepath.ToSlash(rel), file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkPath makes sure that a slash separated path from an archive or a restore stays within its directory.
+func checkPath(p string) error {
+	if p == "" || path.IsAbs(p) || strings.Contains(p, `\`) || path.Clean(p) != p || p == "." || p == ".." || strings.HasPrefix(p, "../") {
+		return fmt.Errorf("invalid path %q", p)
+	}
+	return nil
+}
+
+// within returns the file of the slash separated path p below dir. It fails if the file would end up outside of dir.
+func within(dir, p string) (string, error) {
+	if err := checkPath(p); err != nil {
+		return "", err
+	}
+	dir = filepath.Clean(dir)
+	file := filepath.Join(dir, filepath.FromSlash(p))
+	if !strings.HasPrefix(file, dir+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid path %q", p)
+	}
+	return file, nil
+}
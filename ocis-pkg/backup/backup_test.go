@@ -0,0 +1,155 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, file string, data []byte) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(file), 0700))
+	require.NoError(t, ioutil.WriteFile(file, data, 0600))
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "ocis-backup-test-")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+// rawArchive builds an archive without the checks of Write.
+func rawArchive(t *testing.T, entries ...[2]string) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for _, e := range entries {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: e[0], Mode: 0600, Size: int64(len(e[1]))}))
+		_, err := tw.Write([]byte(e[1]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf
+}
+
+func TestRoundTrip(t *testing.T) {
+	src := tempDir(t)
+	writeFile(t, filepath.Join(src, "store", "databases", "db", "tbl", "key"), []byte("value"))
+	writeFile(t, filepath.Join(src, "store", "revision"), []byte("3\n"))
+	writeFile(t, filepath.Join(src, "settings", "settings.db"), bytes.Repeat([]byte("x"), 3*ChunkSize))
+	writeFile(t, filepath.Join(src, "accounts", "empty"), nil)
+
+	buf := &bytes.Buffer{}
+	written, err := Write(buf, src)
+	require.NoError(t, err)
+	assert.Equal(t, Version, written.Version)
+	assert.Len(t, written.Services, 3)
+
+	dst := tempDir(t)
+	read, err := Read(buf, dst)
+	require.NoError(t, err)
+	assert.Equal(t, written.Services, read.Services)
+
+	for _, rel := range []string{"store/databases/db/tbl/key", "store/revision", "settings/settings.db", "accounts/empty"} {
+		expected, err := ioutil.ReadFile(filepath.Join(src, rel))
+		require.NoError(t, err)
+		actual, err := ioutil.ReadFile(filepath.Join(dst, rel))
+		if assert.NoError(t, err, rel) {
+			assert.Equal(t, expected, actual, rel)
+		}
+	}
+}
+
+func TestReadRejectsBrokenArchives(t *testing.T) {
+	scenarios := []struct {
+		name    string
+		archive *bytes.Buffer
+	}{
+		{"not gzipped", bytes.NewBufferString("plain text")},
+		{"no manifest", rawArchive(t, [2]string{"store/revision", "1"})},
+		{"newer version", rawArchive(t, [2]string{manifestName, `{"version": 2}`})},
+		{"checksum mismatch", rawArchive(t,
+			[2]string{manifestName, `{"version": 1, "services": [{"name": "store", "files": [{"path": "revision", "size": 1, "sha256": "00"}]}]}`},
+			[2]string{"store/revision", "1"},
+		)},
+		{"missing file", rawArchive(t,
+			[2]string{manifestName, `{"version": 1, "services": [{"name": "store", "files": [{"path": "revision", "size": 1, "sha256": "00"}]}]}`},
+		)},
+		{"unlisted file", rawArchive(t,
+			[2]string{manifestName, `{"version": 1, "services": [{"name": "store", "files": []}]}`},
+			[2]string{"store/revision", "1"},
+		)},
+		{"path traversal", rawArchive(t,
+			[2]string{manifestName, `{"version": 1, "services": [{"name": "..", "files": [{"path": "revision", "size": 1, "sha256": "00"}]}]}`},
+			[2]string{"../revision", "1"},
+		)},
+		{"file path traversal", rawArchive(t,
+			[2]string{manifestName, `{"version": 1, "services": [{"name": "store", "files": [{"path": "../../escaped", "size": 1, "sha256": "6b86b273ff34fce19d6b804eff5a3f5747ada4eaa22f1d49c01e52ddb7875b4b"}]}]}`},
+			[2]string{"../escaped", "1"},
+		)},
+		{"absolute file path", rawArchive(t,
+			[2]string{manifestName, `{"version": 1, "services": [{"name": "store", "files": [{"path": "/escaped", "size": 1, "sha256": "6b86b273ff34fce19d6b804eff5a3f5747ada4eaa22f1d49c01e52ddb7875b4b"}]}]}`},
+			[2]string{"store/escaped", "1"},
+		)},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			parent := tempDir(t)
+			dir := filepath.Join(parent, "a", "b")
+			require.NoError(t, os.MkdirAll(dir, 0700))
+			_, err := Read(scenario.archive, dir)
+			assert.Error(t, err)
+			_, err = os.Stat(filepath.Join(parent, "a", "escaped"))
+			assert.True(t, os.IsNotExist(err), "a file was written outside of the restore directory")
+		})
+	}
+}
+
+func TestSendAndStage(t *testing.T) {
+	src := tempDir(t)
+	writeFile(t, filepath.Join(src, "a", "b"), bytes.Repeat([]byte("y"), 2*ChunkSize+1))
+	writeFile(t, filepath.Join(src, "empty"), nil)
+
+	uploads := NewUploads("test")
+	chunks := 0
+	err := Send(src, func(path string, data []byte) error {
+		chunks++
+		return uploads.Stage("1234", path, data)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 4, chunks)
+
+	assert.Error(t, uploads.Stage("1234", "../escape", nil))
+	assert.Error(t, uploads.Stage("../escape", "file", nil))
+
+	dir, err := uploads.Take("1234")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NotContains(t, filepath.Base(dir), "1234", "the staging directory must not be predictable")
+
+	for _, rel := range []string{"a/b", "empty"} {
+		expected, err := ioutil.ReadFile(filepath.Join(src, rel))
+		require.NoError(t, err)
+		actual, err := ioutil.ReadFile(filepath.Join(dir, rel))
+		if assert.NoError(t, err, rel) {
+			assert.Equal(t, expected, actual, rel)
+		}
+	}
+
+	// a taken upload starts over with an empty directory
+	empty, err := uploads.Take("1234")
+	require.NoError(t, err)
+	defer os.RemoveAll(empty)
+	assert.NotEqual(t, dir, empty)
+	infos, err := ioutil.ReadDir(empty)
+	require.NoError(t, err)
+	assert.Empty(t, infos)
+}
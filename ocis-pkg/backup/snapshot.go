@@ -0,0 +1,151 @@
+package backup
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+)
+
+// ChunkSize is the maximum size of the data of a single snapshot or restore message.
+const ChunkSize = 64 * 1024
+
+var uploadID = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+// Send streams the files below dir in chunks. Every file is sent with at least one chunk, so that empty files are
+// part of the snapshot as well.
+func Send(dir string, send func(path string, data []byte) error) error {
+	buf := make([]byte, ChunkSize)
+	return walkFiles(dir, func(rel, file string) error {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		for first := true; ; first = false {
+			n, err := io.ReadFull(f, buf)
+			if n > 0 || first {
+				if err := send(rel, buf[:n]); err != nil {
+					return err
+				}
+			}
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+	})
+}
+
+// Uploads tracks the staging directories the chunks of restore uploads are collected in. Every directory gets a
+// random name when the first chunk of its upload arrives, so that it can't be guessed or prepared by others.
+type Uploads struct {
+	prefix string
+
+	mu   sync.Mutex
+	dirs map[string]string
+}
+
+// NewUploads returns the restore uploads of a service.
+func NewUploads(service string) *Uploads {
+	return &Uploads{prefix: "ocis-restore-" + service + "-", dirs: map[string]string{}}
+}
+
+// Stage appends a chunk of a restore upload to its file in the staging directory of the upload.
+func (u *Uploads) Stage(upload, path string, data []byte) error {
+	dir, err := u.dir(upload)
+	if err != nil {
+		return err
+	}
+	return Append(dir, path, data)
+}
+
+// Take returns the staging directory of an upload and forgets about it, the caller has to remove it. An upload
+// without any chunks gets an empty directory.
+func (u *Uploads) Take(upload string) (string, error) {
+	dir, err := u.dir(upload)
+	if err != nil {
+		return "", err
+	}
+	u.mu.Lock()
+	delete(u.dirs, upload)
+	u.mu.Unlock()
+	return dir, nil
+}
+
+// dir returns the staging directory of an upload and creates it if there is none yet.
+func (u *Uploads) dir(upload string) (string, error) {
+	if !uploadID.MatchString(upload) {
+		return "", fmt.Errorf("invalid upload id %q", upload)
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if dir, ok := u.dirs[upload]; ok {
+		return dir, nil
+	}
+	dir, err := ioutil.TempDir("", u.prefix)
+	if err != nil {
+		return "", err
+	}
+	u.dirs[upload] = dir
+	return dir, nil
+}
+
+// Append appends a chunk of a snapshot to its file below dir. The chunks of a file have to be appended in order.
+func Append(dir, path string, data []byte) error {
+	file, err := within(dir, path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// CopyDir copies the regular files below src to dst. Files for which skip returns true are left out, skip may be nil.
+// A missing src is treated like an empty one.
+func CopyDir(src, dst string, skip func(rel string) bool) error {
+	if _, err := os.Stat(src); os.IsNotExist(err) {
+		return nil
+	}
+	return walkFiles(src, func(rel, file string) error {
+		if skip != nil && skip(rel) {
+			return nil
+		}
+		return copyFile(file, filepath.Join(dst, filepath.FromSlash(rel)))
+	})
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	if err := os.MkdirAll(filepath.Dir(dst), 0700); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
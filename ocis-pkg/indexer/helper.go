@@ -13,3 +13,19 @@ func dedup(s []string) []string {
 	}
 	return s
 }
+
+// intersect returns the values of a that are also in b.
+func intersect(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, v := range b {
+		in[v] = true
+	}
+	r := make([]string, 0, len(a))
+	for _, v := range a {
+		if in[v] {
+			r = append(r, v)
+			delete(in, v)
+		}
+	}
+	return r
+}
@@ -274,65 +274,77 @@ func (i *Indexer) Query(t interface{}, q string) ([]string, error) {
 		return nil, err
 	}
 
-	results := make([]string, 0)
-	if err := i.resolveTree(t, &tree, &results); err != nil {
-		return nil, err
-	}
-
-	return results, nil
+	return i.resolveTree(t, &tree)
 }
 
 // t is used to infer the indexed field names. When building an index search query, field names have to respect Golang
 // conventions and be in PascalCase. For a better overview on this contemplate reading the reflection package under the
-// indexer directory. Traversal of the tree happens in a pre-order fashion.
-// TODO implement logic for `and` operators.
-func (i *Indexer) resolveTree(t interface{}, tree *queryTree, partials *[]string) error {
-	if partials == nil {
-		return fmt.Errorf("return value cannot be nil: partials")
-	}
-
-	if tree.left != nil {
-		_ = i.resolveTree(t, tree.left, partials)
-	}
-
-	if tree.right != nil {
-		_ = i.resolveTree(t, tree.right, partials)
+// indexer directory. Leaves are resolved on the indices, `or` nodes return the union and `and` nodes the intersection
+// of the results of their children.
+func (i *Indexer) resolveTree(t interface{}, tree *queryTree) ([]string, error) {
+	if tree.root {
+		if tree.left == nil {
+			return []string{}, nil
+		}
+		return i.resolveTree(t, tree.left)
 	}
 
-	// by the time we're here we reached a leaf node.
-	if tree.token != nil {
-		switch tree.token.filterType {
-		case "FindBy":
-			operand, err := sanitizeInput(tree.token.operands)
-			if err != nil {
-				return err
-			}
-
-			r, err := i.FindBy(t, operand.field, operand.value)
-			if err != nil {
-				return err
-			}
-
-			*partials = append(*partials, r...)
-		case "FindByPartial":
-			operand, err := sanitizeInput(tree.token.operands)
-			if err != nil {
-				return err
-			}
+	switch tree.token.filterType {
+	case "FindBy":
+		operand, err := sanitizeInput(tree.token.operands)
+		if err != nil {
+			return nil, err
+		}
 
-			r, err := i.FindByPartial(t, operand.field, fmt.Sprintf("%v*", operand.value))
-			if err != nil {
-				return err
-			}
+		r, err := i.FindBy(t, operand.field, operand.value)
+		if err != nil {
+			return nil, err
+		}
+		return dedup(r), nil
+	case "FindByPartial":
+		operand, err := sanitizeInput(tree.token.operands)
+		if err != nil {
+			return nil, err
+		}
 
-			*partials = append(*partials, r...)
+		var pattern string
+		switch tree.token.operator {
+		case "startswith":
+			pattern = escapePattern(operand.value) + "*"
+		case "endswith":
+			pattern = "*" + escapePattern(operand.value)
+		case "contains":
+			pattern = "*" + escapePattern(operand.value) + "*"
 		default:
-			return fmt.Errorf("unsupported filter: %v", tree.token.filterType)
+			return nil, fmt.Errorf("unsupported function: %v", tree.token.operator)
 		}
+
+		r, err := i.FindByPartial(t, operand.field, pattern)
+		if err != nil {
+			return nil, err
+		}
+		return dedup(r), nil
 	}
 
-	*partials = dedup(*partials)
-	return nil
+	if tree.left == nil || tree.right == nil {
+		return nil, fmt.Errorf("missing operand for operator: %v", tree.token.operator)
+	}
+	left, err := i.resolveTree(t, tree.left)
+	if err != nil {
+		return nil, err
+	}
+	right, err := i.resolveTree(t, tree.right)
+	if err != nil {
+		return nil, err
+	}
+
+	switch tree.token.operator {
+	case "or":
+		return dedup(append(left, right...)), nil
+	case "and":
+		return intersect(left, right), nil
+	}
+	return nil, fmt.Errorf("unsupported operator: %v", tree.token.operator)
 }
 
 type indexerTuple struct {
@@ -349,63 +361,94 @@ func sanitizeInput(operands []string) (*indexerTuple, error) {
 	// for further information on this have a look at the reflection package.
 	f := strcase.ToCamel(operands[0])
 
-	// remove single quotes from value.
-	v := strings.ReplaceAll(operands[1], "'", "")
+	// remove the single quotes around string values, quotes inside of them are escaped by doubling them.
+	v := operands[1]
+	if len(v) > 1 && strings.HasPrefix(v, "'") && strings.HasSuffix(v, "'") {
+		v = strings.ReplaceAll(v[1:len(v)-1], "''", "'")
+	}
 	return &indexerTuple{
 		field: f,
 		value: v,
 	}, nil
 }
 
+// escapePattern escapes the characters that have a special meaning in the glob patterns used by Search.
+func escapePattern(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		switch r {
+		case '*', '?', '[', '\\':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
 // buildTreeFromOdataQuery builds an indexer.queryTree out of a GOData ParseNode. The purpose of this intermediate tree
 // is to transform godata operators and functions into supported operations on our index. At the time of this writing
-// we only support `FindBy` and `FindByPartial` queries as these are the only implemented filters on indexer.Index(es).
+// we only support `FindBy` and `FindByPartial` queries as these are the only implemented filters on indexer.Index(es),
+// combined with `and` and `or`.
 func buildTreeFromOdataQuery(root *godata.ParseNode, tree *queryTree) error {
-	if root.Token.Type == godata.FilterTokenFunc { // i.e "startswith", "contains"
+	switch root.Token.Type {
+	case godata.FilterTokenFunc: // i.e "startswith", "contains"
 		switch root.Token.Value {
-		case "startswith":
-			token := token{
+		case "startswith", "contains", "endswith":
+			operands, err := fieldOperands(root)
+			if err != nil {
+				return err
+			}
+			if tree.insert(&token{
 				operator:   root.Token.Value,
 				filterType: "FindByPartial",
-				// TODO sanitize the number of operands it the expected one.
-				operands: []string{
-					root.Children[0].Token.Value, // field name, i.e: Name
-					root.Children[1].Token.Value, // field value, i.e: Jac
-				},
+				operands:   operands,
+			}) == nil {
+				return fmt.Errorf("too many operands")
 			}
-
-			tree.insert(&token)
 		default:
-			return fmt.Errorf("operation not supported")
+			return fmt.Errorf("function not supported: %v", root.Token.Value)
 		}
-	}
-
-	if root.Token.Type == godata.FilterTokenLogical {
+	case godata.FilterTokenLogical:
 		switch root.Token.Value {
-		case "or":
-			tree.insert(&token{operator: root.Token.Value})
+		case "or", "and":
+			node := tree.insert(&token{operator: root.Token.Value})
+			if node == nil {
+				return fmt.Errorf("too many operands")
+			}
 			for _, child := range root.Children {
-				if err := buildTreeFromOdataQuery(child, tree.left); err != nil {
+				if err := buildTreeFromOdataQuery(child, node); err != nil {
 					return err
 				}
 			}
 		case "eq":
-			tree.insert(&token{
+			operands, err := fieldOperands(root)
+			if err != nil {
+				return err
+			}
+			if tree.insert(&token{
 				operator:   root.Token.Value,
 				filterType: "FindBy",
-				operands: []string{
-					root.Children[0].Token.Value,
-					root.Children[1].Token.Value,
-				},
-			})
-			for _, child := range root.Children {
-				if err := buildTreeFromOdataQuery(child, tree.left); err != nil {
-					return err
-				}
+				operands:   operands,
+			}) == nil {
+				return fmt.Errorf("too many operands")
 			}
 		default:
-			return fmt.Errorf("operator not supported")
+			return fmt.Errorf("operator not supported: %v", root.Token.Value)
 		}
+	default:
+		return fmt.Errorf("query not supported: %v", root.Token.Value)
 	}
 	return nil
 }
+
+// fieldOperands returns the field name, i.e: Name, and the value, i.e: Jac, a function or comparison is applied to.
+func fieldOperands(root *godata.ParseNode) ([]string, error) {
+	if len(root.Children) != 2 {
+		return nil, fmt.Errorf("invalid number of operands for %v: got %v expected 2", root.Token.Value, len(root.Children))
+	}
+	field, value := root.Children[0], root.Children[1]
+	if field.Token.Type != godata.FilterTokenLiteral || len(field.Children) != 0 || len(value.Children) != 0 {
+		return nil, fmt.Errorf("%v only supports a field and a value as operands", root.Token.Value)
+	}
+	return []string{field.Token.Value, value.Token.Value}, nil
+}
@@ -297,6 +297,58 @@ func TestQueryDiskImpl(t *testing.T) {
 	_ = os.RemoveAll(dataDir)
 }
 
+func TestQueryDiskImplOperators(t *testing.T) {
+	dataDir, err := WriteIndexTestData(Data, "ID", "")
+	assert.NoError(t, err)
+	indexer := createDiskIndexer(dataDir)
+
+	err = indexer.AddIndex(&Account{}, "OnPremisesSamAccountName", "ID", "accounts", "unique", nil, true)
+	assert.NoError(t, err)
+
+	err = indexer.AddIndex(&Account{}, "Mail", "ID", "accounts", "non_unique", nil, true)
+	assert.NoError(t, err)
+
+	for _, acc := range []Account{
+		{ID: "1", Mail: "einstein@example.org", OnPremisesSamAccountName: "einstein"},
+		{ID: "2", Mail: "marie@example.org", OnPremisesSamAccountName: "marie"},
+		{ID: "3", Mail: "o'brien@example.com", OnPremisesSamAccountName: "o'brien"},
+		{ID: "4", Mail: "star@example.org", OnPremisesSamAccountName: "*star*"},
+	} {
+		_, err = indexer.Add(acc)
+		assert.NoError(t, err)
+	}
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"endswith(mail,'@example.org')", []string{"1", "2", "4"}},
+		{"contains(on_premises_sam_account_name,'ri')", []string{"2", "3"}},
+		{"startswith(on_premises_sam_account_name,'*')", []string{"4"}},
+		{"on_premises_sam_account_name eq 'O''Brien'", []string{"3"}},
+		{"contains(mail,'example') and startswith(on_premises_sam_account_name,'ein')", []string{"1"}},
+		{"endswith(mail,'.org') and (on_premises_sam_account_name eq 'marie' or on_premises_sam_account_name eq 'o''brien')", []string{"2"}},
+		{"mail eq 'marie@example.org' or (startswith(mail,'ein') and endswith(mail,'.com'))", []string{"2"}},
+	}
+	for _, tt := range tests {
+		r, err := indexer.Query(&Account{}, tt.query)
+		assert.NoError(t, err, tt.query)
+		assert.ElementsMatch(t, tt.expected, r, tt.query)
+	}
+
+	for _, q := range []string{
+		"not (mail eq 'marie@example.org')",
+		"mail ne ''",
+		"on_premises_sam_account_name ge 'm'",
+		"mail eq 'marie@example.org' and tolower(mail) eq 'marie@example.org'",
+	} {
+		_, err := indexer.Query(&Account{}, q)
+		assert.Error(t, err, q)
+	}
+
+	_ = os.RemoveAll(dataDir)
+}
+
 func createDiskIndexer(dataDir string) *Indexer {
 	return CreateIndexer(&config.Config{
 		Repo: config.Repo{
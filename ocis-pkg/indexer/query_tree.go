@@ -21,20 +21,23 @@ func newQueryTree() queryTree {
 	}
 }
 
-// insert populates first the LHS of the tree first, if this is not possible it fills the RHS.
-func (t *queryTree) insert(tkn *token) {
+// insert populates first the LHS of the tree first, if this is not possible it fills the RHS. It returns the inserted
+// node, so the operands of a logical operator can be inserted below it.
+func (t *queryTree) insert(tkn *token) *queryTree {
 	if t != nil && t.root {
 		t.left = &queryTree{token: tkn}
-		return
+		return t.left
 	}
 
 	if t.left == nil {
 		t.left = &queryTree{token: tkn}
-		return
+		return t.left
 	}
 
 	if t.left != nil && t.right == nil {
 		t.right = &queryTree{token: tkn}
-		return
+		return t.right
 	}
+
+	return nil
 }
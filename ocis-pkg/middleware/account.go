@@ -28,6 +28,9 @@ const AccountID string = "Account-Id"
 // RoleIDs serves as key for the roles in the context
 const RoleIDs string = "Role-Ids"
 
+// GroupIDs serves as key for the groups in the context
+const GroupIDs string = "Group-Ids"
+
 // UUIDKey serves as key for the account uuid in the context
 // Deprecated: UUIDKey exists for compatibility reasons. Use AccountID instead.
 var UUIDKey struct{}
@@ -71,6 +74,10 @@ func ExtractAccountUUID(opts ...account.Option) func(http.Handler) http.Handler
 				if roles, ok := u.Opaque.Map["roles"]; ok {
 					ctx = metadata.Set(ctx, RoleIDs, string(roles.Value))
 				}
+				// u.Groups holds the group names, the ids are only known for accounts of the accounts service
+				if groupIDs, ok := u.Opaque.Map["group-ids"]; ok {
+					ctx = metadata.Set(ctx, GroupIDs, string(groupIDs.Value))
+				}
 			}
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/micro/go-micro/v2/metadata"
+)
+
+// ServiceToken serves as key for the token of internal requests between services in the context
+const ServiceToken string = "Service-Token"
+
+// serviceTokenTTL is how long a service token is valid. Tokens are minted per request.
+const serviceTokenTTL = time.Minute
+
+// ContextWithServiceToken returns a context that authenticates requests as internal requests of the given service.
+// The token is signed with the jwt secret shared by the services and expires after a minute, so the context has to be
+// created per request.
+func ContextWithServiceToken(ctx context.Context, service, secret string) (context.Context, error) {
+	if secret == "" {
+		return nil, errors.New("no jwt secret to sign the service token with")
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{
+		Subject:   service,
+		ExpiresAt: time.Now().Add(serviceTokenTTL).Unix(),
+	}).SignedString([]byte(secret))
+	if err != nil {
+		return nil, err
+	}
+	return metadata.Set(ctx, ServiceToken, token), nil
+}
+
+// ServiceFromContext returns the service that sent an internal request. It is only returned if the service token
+// of the request is signed with the given jwt secret and has not expired.
+func ServiceFromContext(ctx context.Context, secret string) (string, bool) {
+	token, ok := metadata.Get(ctx, ServiceToken)
+	if !ok || token == "" || secret == "" {
+		return "", false
+	}
+	claims := &jwt.StandardClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return []byte(secret), nil
+	})
+	if err != nil || claims.Subject == "" {
+		return "", false
+	}
+	return claims.Subject, true
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/micro/go-micro/v2/metadata"
+)
+
+func TestServiceToken(t *testing.T) {
+	ctx, err := ContextWithServiceToken(context.Background(), "accounts", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if service, ok := ServiceFromContext(ctx, "secret"); !ok || service != "accounts" {
+		t.Errorf("expected the accounts service, got %q", service)
+	}
+	if _, ok := ServiceFromContext(ctx, "other secret"); ok {
+		t.Error("accepted a token signed with another secret")
+	}
+	if _, ok := ServiceFromContext(ctx, ""); ok {
+		t.Error("accepted a token without a secret")
+	}
+	if _, ok := ServiceFromContext(context.Background(), "secret"); ok {
+		t.Error("accepted a request without a token")
+	}
+
+	expired, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{
+		Subject:   "accounts",
+		ExpiresAt: time.Now().Add(-time.Second).Unix(),
+	}).SignedString([]byte("secret"))
+	if _, ok := ServiceFromContext(metadata.Set(context.Background(), ServiceToken, expired), "secret"); ok {
+		t.Error("accepted an expired token")
+	}
+
+	if _, err := ContextWithServiceToken(context.Background(), "accounts", ""); err == nil {
+		t.Error("signed a token without a secret")
+	}
+}
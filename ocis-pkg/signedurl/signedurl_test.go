@@ -0,0 +1,103 @@
+package signedurl
+
+import (
+	"crypto/tls"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestSignature(t *testing.T) {
+	expected := "27d2ebea381384af3179235114801dcd00f91e46f99fca72575301cf3948101d"
+	if s := Signature("something", []byte("somerandomkey")); s != expected {
+		t.Errorf("expected %s got %s", expected, s)
+	}
+}
+
+func TestSign(t *testing.T) {
+	u, _ := url.Parse("https://cloud.example.com/remote.php/dav/files/einstein/a.txt?preview=1")
+	date := time.Date(2020, 2, 2, 12, 29, 0, 0, time.UTC)
+	signed := Sign(u, "einstein", "get", date, time.Hour, []byte("somerandomkey"))
+
+	q := signed.Query()
+	for k, v := range map[string]string{
+		"OC-Credential": "einstein",
+		"OC-Date":       "2020-02-02T12:29:00Z",
+		"OC-Expires":    "3600",
+		"OC-Verb":       "GET",
+		"preview":       "1",
+	} {
+		if q.Get(k) != v {
+			t.Errorf("expected %s to be %s, got %s", k, v, q.Get(k))
+		}
+	}
+
+	// verify the same way the proxy does
+	signature := q.Get("OC-Signature")
+	q.Del("OC-Signature")
+	signed.RawQuery = q.Encode()
+	if Signature(signed.String(), []byte("somerandomkey")) != signature {
+		t.Errorf("signature of %s does not verify", signed.String())
+	}
+	if Signature(signed.String(), []byte("rotatedkey")) == signature {
+		t.Errorf("signature must not verify with another key")
+	}
+
+	if u.RawQuery != "preview=1" {
+		t.Errorf("expected the original url to be unchanged, got %s", u.String())
+	}
+}
+
+func TestScheme(t *testing.T) {
+	trusted := []string{"10.0.0.1", "192.168.0.0/16"}
+	tests := []struct {
+		name, remoteAddr, header string
+		tls                      bool
+		expected                 string
+	}{
+		{"no header", "10.0.0.1:1234", "", false, "http"},
+		{"no header with tls", "10.0.0.1:1234", "", true, "https"},
+		{"trusted proxy", "10.0.0.1:1234", "http", true, "http"},
+		{"trusted network", "192.168.3.4:1234", "HTTPS", false, "https"},
+		{"several proxies", "10.0.0.1:1234", "http, https", true, "http"},
+		{"untrusted client", "10.0.0.2:1234", "https", false, "http"},
+		{"untrusted client with tls", "10.0.0.2:1234", "http", true, "https"},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/remote.php/dav/files/einstein", nil)
+		r.RemoteAddr = tt.remoteAddr
+		if !tt.tls {
+			r.TLS = nil
+		} else {
+			r.TLS = &tls.ConnectionState{}
+		}
+		if tt.header != "" {
+			r.Header.Set("X-Forwarded-Proto", tt.header)
+		}
+		if s := Scheme(r, trusted); s != tt.expected {
+			t.Errorf("%s: expected %s got %s", tt.name, tt.expected, s)
+		}
+	}
+}
+
+func TestForwardedScheme(t *testing.T) {
+	tests := []struct {
+		header, expected string
+	}{
+		{"", "https"},
+		{"http", "http"},
+		{"HTTPS", "https"},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/remote.php/dav/files/einstein", nil)
+		if tt.header != "" {
+			r.Header.Set("X-Forwarded-Proto", tt.header)
+		}
+		if s := ForwardedScheme(r); s != tt.expected {
+			t.Errorf("with X-Forwarded-Proto %q expected %s got %s", tt.header, tt.expected, s)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package command
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2/client"
+	accounts "github.com/owncloud/ocis/accounts/pkg/proto/v0"
+	"github.com/owncloud/ocis/ocis-pkg/backup"
+	"github.com/owncloud/ocis/ocis-pkg/middleware"
+	"github.com/owncloud/ocis/ocis-pkg/service/grpc"
+	"github.com/owncloud/ocis/ocis/pkg/config"
+	"github.com/owncloud/ocis/ocis/pkg/register"
+	settings "github.com/owncloud/ocis/settings/pkg/proto/v0"
+	store "github.com/owncloud/ocis/store/pkg/proto/v0"
+)
+
+// snapshotter is the client side of the snapshot and restore rpcs of a service that takes part in backups.
+type snapshotter struct {
+	name     string
+	snapshot func(ctx context.Context, opts ...client.CallOption) (func() (string, []byte, error), error)
+	restore  func(ctx context.Context, upload, path string, data []byte, commit bool, opts ...client.CallOption) error
+}
+
+// snapshotters returns the services that take part in backups, their names are used within the archive. The
+// requests are made with a service token signed with the jwt secret, a new one for every request.
+func snapshotters(jwtSecret string) []snapshotter {
+	storeSvc := store.NewStoreService("com.owncloud.api.store", grpc.DefaultClient)
+	settingsSvc := settings.NewBackupService("com.owncloud.api.settings", grpc.DefaultClient)
+	accountsSvc := accounts.NewBackupService("com.owncloud.api.accounts", grpc.DefaultClient)
+
+	return []snapshotter{
+		{
+			name: "store",
+			snapshot: func(ctx context.Context, opts ...client.CallOption) (func() (string, []byte, error), error) {
+				ctx, err := middleware.ContextWithServiceToken(ctx, "ocis", jwtSecret)
+				if err != nil {
+					return nil, err
+				}
+				stream, err := storeSvc.Snapshot(ctx, &store.SnapshotRequest{}, opts...)
+				if err != nil {
+					return nil, err
+				}
+				return func() (string, []byte, error) {
+					rsp, err := stream.Recv()
+					if err != nil {
+						return "", nil, err
+					}
+					return rsp.Path, rsp.Data, nil
+				}, nil
+			},
+			restore: func(ctx context.Context, upload, path string, data []byte, commit bool, opts ...client.CallOption) error {
+				ctx, err := middleware.ContextWithServiceToken(ctx, "ocis", jwtSecret)
+				if err != nil {
+					return err
+				}
+				_, err = storeSvc.Restore(ctx, &store.RestoreRequest{Upload: upload, Path: path, Data: data, Commit: commit}, opts...)
+				return err
+			},
+		},
+		{
+			name: "settings",
+			snapshot: func(ctx context.Context, opts ...client.CallOption) (func() (string, []byte, error), error) {
+				ctx, err := middleware.ContextWithServiceToken(ctx, "ocis", jwtSecret)
+				if err != nil {
+					return nil, err
+				}
+				stream, err := settingsSvc.Snapshot(ctx, &settings.SnapshotRequest{}, opts...)
+				if err != nil {
+					return nil, err
+				}
+				return func() (string, []byte, error) {
+					rsp, err := stream.Recv()
+					if err != nil {
+						return "", nil, err
+					}
+					return rsp.Path, rsp.Data, nil
+				}, nil
+			},
+			restore: func(ctx context.Context, upload, path string, data []byte, commit bool, opts ...client.CallOption) error {
+				ctx, err := middleware.ContextWithServiceToken(ctx, "ocis", jwtSecret)
+				if err != nil {
+					return err
+				}
+				_, err = settingsSvc.Restore(ctx, &settings.RestoreRequest{Upload: upload, Path: path, Data: data, Commit: commit}, opts...)
+				return err
+			},
+		},
+		{
+			name: "accounts",
+			snapshot: func(ctx context.Context, opts ...client.CallOption) (func() (string, []byte, error), error) {
+				ctx, err := middleware.ContextWithServiceToken(ctx, "ocis", jwtSecret)
+				if err != nil {
+					return nil, err
+				}
+				stream, err := accountsSvc.Snapshot(ctx, &accounts.SnapshotRequest{}, opts...)
+				if err != nil {
+					return nil, err
+				}
+				return func() (string, []byte, error) {
+					rsp, err := stream.Recv()
+					if err != nil {
+						return "", nil, err
+					}
+					return rsp.Path, rsp.Data, nil
+				}, nil
+			},
+			restore: func(ctx context.Context, upload, path string, data []byte, commit bool, opts ...client.CallOption) error {
+				ctx, err := middleware.ContextWithServiceToken(ctx, "ocis", jwtSecret)
+				if err != nil {
+					return err
+				}
+				_, err = accountsSvc.Restore(ctx, &accounts.RestoreRequest{Upload: upload, Path: path, Data: data, Commit: commit}, opts...)
+				return err
+			},
+		},
+	}
+}
+
+// BackupCommand is the entrypoint for the backup command.
+func BackupCommand(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:      "backup",
+		Usage:     "Write a backup of the store, settings and accounts data of a running ocis to an archive",
+		ArgsUsage: "archive",
+		Category:  "Runtime",
+		Flags: []cli.Flag{
+			&cli.DurationFlag{
+				Name:    "timeout",
+				Value:   5 * time.Minute,
+				Usage:   "Maximum time the snapshot of a single service may take",
+				EnvVars: []string{"OCIS_BACKUP_TIMEOUT"},
+			},
+		},
+		Action: func(c *cli.Context) error {
+			logger := NewLogger(cfg)
+			if c.NArg() != 1 {
+				logger.Fatal().Msg("Please provide the path of the archive")
+			}
+
+			dir, err := ioutil.TempDir("", "ocis-backup-")
+			if err != nil {
+				logger.Fatal().Err(err).Msg("Could not create a temporary directory")
+			}
+			defer os.RemoveAll(dir)
+
+			timeout := c.Duration("timeout")
+			for _, s := range snapshotters(cfg.TokenManager.JWTSecret) {
+				if err := receiveSnapshot(c.Context, s, filepath.Join(dir, s.name), timeout); err != nil {
+					os.RemoveAll(dir)
+					logger.Fatal().Err(err).Str("service", s.name).Msg("Could not take a snapshot")
+				}
+				logger.Info().Str("service", s.name).Msg("Took a snapshot")
+			}
+
+			archive := c.Args().First()
+			f, err := os.OpenFile(archive, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+			if err != nil {
+				os.RemoveAll(dir)
+				logger.Fatal().Err(err).Msg("Could not create the archive")
+			}
+			m, err := backup.Write(f, dir)
+			if err == nil {
+				err = f.Close()
+			}
+			if err != nil {
+				f.Close()
+				os.Remove(archive)
+				os.RemoveAll(dir)
+				logger.Fatal().Err(err).Msg("Could not write the archive")
+			}
+
+			logger.Info().Str("archive", archive).Int("services", len(m.Services)).Msg("Backup written")
+			return nil
+		},
+	}
+}
+
+// receiveSnapshot writes the snapshot of a service into dir.
+func receiveSnapshot(ctx context.Context, s snapshotter, dir string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	recv, err := s.snapshot(ctx, client.WithRequestTimeout(timeout))
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	for {
+		path, data, err := recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := backup.Append(dir, path, data); err != nil {
+			return err
+		}
+	}
+}
+
+func init() {
+	register.AddCommand(BackupCommand)
+}
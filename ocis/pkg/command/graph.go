@@ -0,0 +1,45 @@
+// +build !simple
+
+package command
+
+import (
+	"github.com/micro/cli/v2"
+	"github.com/owncloud/ocis-graph/pkg/command"
+	svcconfig "github.com/owncloud/ocis-graph/pkg/config"
+	"github.com/owncloud/ocis-graph/pkg/flagset"
+	"github.com/owncloud/ocis/ocis/pkg/config"
+	"github.com/owncloud/ocis/ocis/pkg/register"
+)
+
+// GraphCommand is the entrypoint for the graph command.
+func GraphCommand(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:     "graph",
+		Usage:    "Start graph server",
+		Category: "Extensions",
+		Flags:    flagset.ServerWithConfig(cfg.Graph),
+		Action: func(c *cli.Context) error {
+			origCmd := command.Server(configureGraph(cfg))
+			return handleOriginalAction(c, origCmd)
+		},
+	}
+}
+
+func configureGraph(cfg *config.Config) *svcconfig.Config {
+	cfg.Graph.Log.Level = cfg.Log.Level
+	cfg.Graph.Log.Pretty = cfg.Log.Pretty
+	cfg.Graph.Log.Color = cfg.Log.Color
+
+	if cfg.Tracing.Enabled {
+		cfg.Graph.Tracing.Enabled = cfg.Tracing.Enabled
+		cfg.Graph.Tracing.Type = cfg.Tracing.Type
+		cfg.Graph.Tracing.Endpoint = cfg.Tracing.Endpoint
+		cfg.Graph.Tracing.Collector = cfg.Tracing.Collector
+	}
+
+	return cfg.Graph
+}
+
+func init() {
+	register.AddCommand(GraphCommand)
+}
@@ -0,0 +1,45 @@
+// +build !simple
+
+package command
+
+import (
+	"github.com/micro/cli/v2"
+	"github.com/owncloud/ocis-graph-explorer/pkg/command"
+	svcconfig "github.com/owncloud/ocis-graph-explorer/pkg/config"
+	"github.com/owncloud/ocis-graph-explorer/pkg/flagset"
+	"github.com/owncloud/ocis/ocis/pkg/config"
+	"github.com/owncloud/ocis/ocis/pkg/register"
+)
+
+// GraphExplorerCommand is the entrypoint for the graph-explorer command.
+func GraphExplorerCommand(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:     "graph-explorer",
+		Usage:    "Start graph explorer",
+		Category: "Extensions",
+		Flags:    flagset.ServerWithConfig(cfg.GraphExplorer),
+		Action: func(c *cli.Context) error {
+			origCmd := command.Server(configureGraphExplorer(cfg))
+			return handleOriginalAction(c, origCmd)
+		},
+	}
+}
+
+func configureGraphExplorer(cfg *config.Config) *svcconfig.Config {
+	cfg.GraphExplorer.Log.Level = cfg.Log.Level
+	cfg.GraphExplorer.Log.Pretty = cfg.Log.Pretty
+	cfg.GraphExplorer.Log.Color = cfg.Log.Color
+
+	if cfg.Tracing.Enabled {
+		cfg.GraphExplorer.Tracing.Enabled = cfg.Tracing.Enabled
+		cfg.GraphExplorer.Tracing.Type = cfg.Tracing.Type
+		cfg.GraphExplorer.Tracing.Endpoint = cfg.Tracing.Endpoint
+		cfg.GraphExplorer.Tracing.Collector = cfg.Tracing.Collector
+	}
+
+	return cfg.GraphExplorer
+}
+
+func init() {
+	register.AddCommand(GraphExplorerCommand)
+}
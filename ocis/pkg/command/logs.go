@@ -0,0 +1,88 @@
+package command
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/rpc"
+	"os"
+
+	"github.com/micro/cli/v2"
+	"github.com/owncloud/ocis/ocis/pkg/config"
+	"github.com/owncloud/ocis/ocis/pkg/register"
+	"github.com/owncloud/ocis/ocis/pkg/runtime/service"
+)
+
+// LogsCommand is the entrypoint for the logs command.
+func LogsCommand(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:      "logs",
+		Usage:     "Print the output of an extension",
+		ArgsUsage: "extension",
+		Category:  "Runtime",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "hostname",
+				Value:       "localhost",
+				EnvVars:     []string{"OCIS_RUNTIME_HOSTNAME"},
+				Destination: &cfg.Runtime.Hostname,
+			},
+			&cli.StringFlag{
+				Name:        "port",
+				Value:       "10666",
+				EnvVars:     []string{"OCIS_RUNTIME_PORT"},
+				Destination: &cfg.Runtime.Port,
+			},
+			&cli.BoolFlag{
+				Name:    "follow",
+				Aliases: []string{"f"},
+				Usage:   "Keep printing new output",
+			},
+			&cli.IntFlag{
+				Name:    "tail",
+				Aliases: []string{"n"},
+				Value:   100,
+				Usage:   "Number of lines to print from the end of the output",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() != 1 {
+				log.Fatal("Please provide the name of an extension")
+			}
+
+			client, err := rpc.DialHTTP("tcp", net.JoinHostPort(cfg.Runtime.Hostname, cfg.Runtime.Port))
+			if err != nil {
+				log.Fatal("dialing:", err)
+			}
+
+			args := service.LogsRequest{
+				Extension: c.Args().First(),
+				Since:     -1,
+				Tail:      c.Int("tail"),
+			}
+			for {
+				var reply service.LogsReply
+				if err := client.Call("Service.Logs", args, &reply); err != nil {
+					log.Fatal(err)
+				}
+				for _, l := range reply.Lines {
+					if l.Stderr {
+						fmt.Fprintln(os.Stderr, l.Text)
+					} else {
+						fmt.Fprintln(os.Stdout, l.Text)
+					}
+				}
+
+				if !c.Bool("follow") {
+					return nil
+				}
+				args.Since = reply.Next
+				args.Wait = true
+			}
+		},
+	}
+}
+
+func init() {
+	register.AddCommand(LogsCommand)
+}
@@ -0,0 +1,60 @@
+package command
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/rpc"
+
+	"github.com/micro/cli/v2"
+	"github.com/owncloud/ocis/ocis/pkg/config"
+	"github.com/owncloud/ocis/ocis/pkg/register"
+)
+
+// RestartCommand is the entrypoint for the restart command.
+func RestartCommand(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:      "restart",
+		Usage:     "Restart extensions by name, one after another",
+		ArgsUsage: "extension [extension...]",
+		Category:  "Runtime",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "hostname",
+				Value:       "localhost",
+				EnvVars:     []string{"OCIS_RUNTIME_HOSTNAME"},
+				Destination: &cfg.Runtime.Hostname,
+			},
+			&cli.StringFlag{
+				Name:        "port",
+				Value:       "10666",
+				EnvVars:     []string{"OCIS_RUNTIME_PORT"},
+				Destination: &cfg.Runtime.Port,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			client, err := rpc.DialHTTP("tcp", net.JoinHostPort(cfg.Runtime.Hostname, cfg.Runtime.Port))
+			if err != nil {
+				log.Fatal("dialing:", err)
+			}
+
+			if c.NArg() == 0 {
+				log.Fatal("Please provide the name of an extension")
+			}
+
+			for _, extension := range c.Args().Slice() {
+				var reply int
+				if err := client.Call("Service.Restart", extension, &reply); err != nil {
+					log.Fatal(err)
+				}
+				fmt.Printf("process %v restarted\n", extension)
+			}
+
+			return nil
+		},
+	}
+}
+
+func init() {
+	register.AddCommand(RestartCommand)
+}
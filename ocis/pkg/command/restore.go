@@ -0,0 +1,141 @@
+package command
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2/client"
+	"github.com/owncloud/ocis/ocis-pkg/backup"
+	"github.com/owncloud/ocis/ocis/pkg/config"
+	"github.com/owncloud/ocis/ocis/pkg/register"
+)
+
+// RestoreCommand is the entrypoint for the restore command.
+func RestoreCommand(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:      "restore",
+		Usage:     "Restore the store, settings and accounts data of a running ocis from a backup archive",
+		ArgsUsage: "archive",
+		Category:  "Runtime",
+		Flags: []cli.Flag{
+			&cli.DurationFlag{
+				Name:    "timeout",
+				Value:   5 * time.Minute,
+				Usage:   "Maximum time the restore of a single service may take",
+				EnvVars: []string{"OCIS_BACKUP_TIMEOUT"},
+			},
+		},
+		Action: func(c *cli.Context) error {
+			logger := NewLogger(cfg)
+			if c.NArg() != 1 {
+				logger.Fatal().Msg("Please provide the path of the archive")
+			}
+
+			f, err := os.Open(c.Args().First())
+			if err != nil {
+				logger.Fatal().Err(err).Msg("Could not open the archive")
+			}
+			defer f.Close()
+
+			dir, err := ioutil.TempDir("", "ocis-restore-")
+			if err != nil {
+				logger.Fatal().Err(err).Msg("Could not create a temporary directory")
+			}
+			defer os.RemoveAll(dir)
+
+			// the whole archive is verified before any service is touched
+			m, err := backup.Read(f, dir)
+			if err != nil {
+				os.RemoveAll(dir)
+				logger.Fatal().Err(err).Msg("Could not read the archive")
+			}
+
+			services := map[string]snapshotter{}
+			for _, s := range snapshotters(cfg.TokenManager.JWTSecret) {
+				services[s.name] = s
+			}
+			for _, svc := range m.Services {
+				if _, ok := services[svc.Name]; !ok {
+					os.RemoveAll(dir)
+					logger.Fatal().Str("service", svc.Name).Msg("The archive contains a service that can't be restored")
+				}
+			}
+
+			upload, err := newUploadID()
+			if err != nil {
+				os.RemoveAll(dir)
+				logger.Fatal().Err(err).Msg("Could not generate an upload id")
+			}
+
+			// all snapshots are uploaded before the first restore is committed, so a failed upload leaves every
+			// service untouched
+			timeout := c.Duration("timeout")
+			for _, svc := range m.Services {
+				if err := sendSnapshot(c.Context, services[svc.Name], upload, filepath.Join(dir, svc.Name), timeout); err != nil {
+					os.RemoveAll(dir)
+					logger.Fatal().Err(err).Str("service", svc.Name).Msg("Could not upload the snapshot, no service was restored")
+				}
+				logger.Info().Str("service", svc.Name).Msg("Uploaded the snapshot")
+			}
+
+			// a commit can still fail, e.g. if a service is restarted meanwhile. There is no rollback across services,
+			// so the partial state is reported. Running the restore again restores all services.
+			var restored []string
+			for i, svc := range m.Services {
+				if err := commitSnapshot(c.Context, services[svc.Name], upload, timeout); err != nil {
+					var pending []string
+					for _, p := range m.Services[i:] {
+						pending = append(pending, p.Name)
+					}
+					os.RemoveAll(dir)
+					logger.Fatal().Err(err).Str("service", svc.Name).Strs("restored", restored).Strs("not_restored", pending).
+						Msg("Could not restore the snapshot, the backup is only partially restored. Run the restore again")
+				}
+				restored = append(restored, svc.Name)
+				logger.Info().Str("service", svc.Name).Msg("Restored the snapshot")
+			}
+
+			logger.Info().Time("created", m.Created).Int("services", len(m.Services)).Msg("Backup restored")
+			return nil
+		},
+	}
+}
+
+// newUploadID returns a random id for the restore uploads. A service appends the chunks of an upload to a staging
+// directory, a new id starts with an empty one. The id must not be guessable, otherwise other clients could add
+// chunks to the upload.
+func newUploadID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// sendSnapshot uploads the snapshot in dir to a service, the service stages it until the restore is committed.
+func sendSnapshot(ctx context.Context, s snapshotter, upload, dir string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return backup.Send(dir, func(path string, data []byte) error {
+		return s.restore(ctx, upload, path, data, false)
+	})
+}
+
+// commitSnapshot makes a service replace its data with the staged snapshot.
+func commitSnapshot(ctx context.Context, s snapshotter, upload string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return s.restore(ctx, upload, "", nil, true, client.WithRequestTimeout(timeout))
+}
+
+func init() {
+	register.AddCommand(RestoreCommand)
+}
@@ -25,6 +25,10 @@ func Server(cfg *config.Config) *cli.Command {
 				cfg.HTTP.Root = strings.TrimSuffix(cfg.HTTP.Root, "/")
 			}
 
+			cfg.Startup.Extensions = c.StringSlice("extensions")
+			cfg.Startup.ExcludeExtensions = c.StringSlice("exclude-extensions")
+			cfg.Startup.Instances = c.StringSlice("instance")
+
 			return nil
 		},
 		Action: func(c *cli.Context) error {
@@ -42,6 +42,10 @@ func configureStore(cfg *config.Config) *svcconfig.Config {
 		cfg.Store.Tracing.Collector = cfg.Tracing.Collector
 	}
 
+	if cfg.TokenManager.JWTSecret != "" {
+		cfg.Store.TokenManager.JWTSecret = cfg.TokenManager.JWTSecret
+	}
+
 	return cfg.Store
 }
 
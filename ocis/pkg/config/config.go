@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	graphExplorer "github.com/owncloud/ocis-graph-explorer/pkg/config"
 	graph "github.com/owncloud/ocis-graph/pkg/config"
 	hello "github.com/owncloud/ocis-hello/pkg/config"
@@ -59,6 +61,18 @@ type TokenManager struct {
 	JWTSecret string
 }
 
+// Startup defines the available startup configuration of the runtime.
+type Startup struct {
+	Timeout time.Duration
+
+	// Extensions to start, all if empty. ExcludeExtensions are not started.
+	Extensions        []string
+	ExcludeExtensions []string
+
+	// Instances are additional instances of extensions, given as name=extension.
+	Instances []string
+}
+
 // Config combines all available configuration parts.
 type Config struct {
 	Registry     string
@@ -68,6 +82,7 @@ type Config struct {
 	GRPC         GRPC
 	Tracing      Tracing
 	TokenManager TokenManager
+	Startup      Startup
 
 	Accounts      *accounts.Config
 	GLAuth        *glauth.Config
@@ -1,6 +1,8 @@
 package flagset
 
 import (
+	"time"
+
 	"github.com/micro/cli/v2"
 	"github.com/owncloud/ocis/ocis/pkg/config"
 )
@@ -136,5 +138,27 @@ func ServerWithConfig(cfg *config.Config) []cli.Flag {
 			EnvVars:     []string{"OCIS_GRPC_ADDR"},
 			Destination: &cfg.GRPC.Addr,
 		},
+		&cli.DurationFlag{
+			Name:        "startup-timeout",
+			Value:       2 * time.Minute,
+			Usage:       "Maximum time to wait for an extension to become ready before starting the extensions depending on it",
+			EnvVars:     []string{"OCIS_STARTUP_TIMEOUT"},
+			Destination: &cfg.Startup.Timeout,
+		},
+		&cli.StringSliceFlag{
+			Name:    "extensions",
+			Usage:   "Extensions to start, all but graph and graph-explorer if not set: --extensions proxy [--extensions web]",
+			EnvVars: []string{"OCIS_RUN_EXTENSIONS"},
+		},
+		&cli.StringSliceFlag{
+			Name:    "exclude-extensions",
+			Usage:   "Extensions not to start: --exclude-extensions onlyoffice [--exclude-extensions thumbnails]",
+			EnvVars: []string{"OCIS_EXCLUDE_EXTENSIONS"},
+		},
+		&cli.StringSliceFlag{
+			Name:    "instance",
+			Usage:   "Additional instance of an extension, configured with OCIS_INSTANCE_<NAME>_ variables: --instance storage-users-2=storage-users",
+			EnvVars: []string{"OCIS_RUN_INSTANCES"},
+		},
 	}
 }
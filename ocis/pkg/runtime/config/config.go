@@ -1,5 +1,25 @@
 package config
 
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RestartPolicy determines whether a supervised process is restarted after it terminated.
+type RestartPolicy string
+
+const (
+	// RestartAlways restarts a process however it terminated.
+	RestartAlways RestartPolicy = "always"
+
+	// RestartOnFailure restarts a process unless it exited with code 0.
+	RestartOnFailure RestartPolicy = "on-failure"
+
+	// RestartNever never restarts a process.
+	RestartNever RestartPolicy = "never"
+)
+
 // Config determines behavior across the tool.
 type Config struct {
 	// Hostname where the runtime is running. When using PMAN in cli mode, it determines where the host runtime is.
@@ -10,19 +30,118 @@ type Config struct {
 	Port string
 
 	// KeepAlive configures if restart attempts are made if the process supervised terminates. Default is false.
+	// It is superseded by Restart.
 	KeepAlive bool
+
+	// Restart is the restart policy of supervised processes without a policy of their own. When empty, KeepAlive
+	// decides between always and never.
+	Restart RestartPolicy
+
+	// RestartPolicies are the restart policies of single extensions.
+	RestartPolicies map[string]RestartPolicy
+
+	// RestartBackoff is the delay before a restart. It doubles with every restart within RestartWindow, up to
+	// MaxRestartBackoff. Default is 1s.
+	RestartBackoff time.Duration
+
+	// MaxRestartBackoff is the maximum delay before a restart. Default is 1m.
+	MaxRestartBackoff time.Duration
+
+	// MaxRestarts is the number of restarts within RestartWindow after which a process is considered to be crash
+	// looping and not restarted anymore. Zero allows any number of restarts. Default is 5.
+	MaxRestarts int
+
+	// RestartWindow is the period in which restarts are counted. Default is 10m.
+	RestartWindow time.Duration
+
+	// GracePeriod is the time a process has to terminate after it received SIGTERM, before it is killed. Default is
+	// 10s.
+	GracePeriod time.Duration
+
+	// LogLines is the number of output lines per extension the runtime keeps in memory. Default is 1000.
+	LogLines int
+
+	// LogDir is the directory the runtime writes a log file per extension to. No log files are written if empty.
+	LogDir string
+
+	// LogMaxSize is the size in megabytes after which a log file is rotated. Default is 10.
+	LogMaxSize int
+
+	// LogMaxFiles is the number of rotated log files that are kept per extension. Default is 5.
+	LogMaxFiles int
 }
 
 var (
-	defaultHostname = "localhost"
-	defaultPort     = "10666"
+	defaultHostname          = "localhost"
+	defaultPort              = "10666"
+	defaultRestartBackoff    = time.Second
+	defaultMaxRestartBackoff = time.Minute
+	defaultMaxRestarts       = 5
+	defaultRestartWindow     = 10 * time.Minute
+	defaultGracePeriod       = 10 * time.Second
+	defaultLogLines          = 1000
+	defaultLogMaxSize        = 10
+	defaultLogMaxFiles       = 5
 )
 
 // NewConfig returns a new config with a set of defaults.
 func NewConfig() *Config {
 	return &Config{
-		Hostname:  defaultHostname,
-		Port:      defaultPort,
-		KeepAlive: false,
+		Hostname:          defaultHostname,
+		Port:              defaultPort,
+		KeepAlive:         false,
+		RestartPolicies:   map[string]RestartPolicy{},
+		RestartBackoff:    defaultRestartBackoff,
+		MaxRestartBackoff: defaultMaxRestartBackoff,
+		MaxRestarts:       defaultMaxRestarts,
+		RestartWindow:     defaultRestartWindow,
+		GracePeriod:       defaultGracePeriod,
+		LogLines:          defaultLogLines,
+		LogMaxSize:        defaultLogMaxSize,
+		LogMaxFiles:       defaultLogMaxFiles,
+	}
+}
+
+// RestartPolicy returns the restart policy of an extension.
+func (c *Config) RestartPolicy(extension string) RestartPolicy {
+	if p, ok := c.RestartPolicies[extension]; ok {
+		return p
+	}
+	if c.Restart != "" {
+		return c.Restart
+	}
+	if c.KeepAlive {
+		return RestartAlways
+	}
+	return RestartNever
+}
+
+// ParseRestartPolicy parses always, on-failure or never.
+func ParseRestartPolicy(s string) (RestartPolicy, error) {
+	switch p := RestartPolicy(strings.TrimSpace(s)); p {
+	case RestartAlways, RestartOnFailure, RestartNever:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown restart policy %q, expected always, on-failure or never", s)
+	}
+}
+
+// ParseRestartPolicies parses a comma separated list of extension=policy pairs, e.g. "proxy=always,web=never".
+func ParseRestartPolicies(s string) (map[string]RestartPolicy, error) {
+	policies := map[string]RestartPolicy{}
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf("invalid restart policy %q, expected extension=policy", pair)
+		}
+		p, err := ParseRestartPolicy(kv[1])
+		if err != nil {
+			return nil, err
+		}
+		policies[strings.TrimSpace(kv[0])] = p
 	}
+	return policies, nil
 }
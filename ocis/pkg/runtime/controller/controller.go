@@ -2,6 +2,7 @@ package controller
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"sort"
@@ -11,6 +12,7 @@ import (
 	"time"
 
 	"github.com/owncloud/ocis/ocis/pkg/runtime/config"
+	"github.com/owncloud/ocis/ocis/pkg/runtime/output"
 	"github.com/owncloud/ocis/ocis/pkg/runtime/process"
 	"github.com/owncloud/ocis/ocis/pkg/runtime/storage"
 	"github.com/owncloud/ocis/ocis/pkg/runtime/watcher"
@@ -19,9 +21,13 @@ import (
 	"github.com/olekukonko/tablewriter"
 )
 
+// killWait is the time a killed process has to exit.
+const killWait = 5 * time.Second
+
 // Controller supervises processes.
 type Controller struct {
 	m       *sync.RWMutex
+	once    *sync.Once
 	options Options
 	log     zerolog.Logger
 	Config  *config.Config
@@ -35,14 +41,23 @@ type Controller struct {
 	// The Controller needs to know the binary location in order to spawn new extensions.
 	BinPath string
 
-	// Terminated facilitates communication from Watcher <-> Controller. Writes to this
-	// channel WILL always attempt to restart the crashed process.
-	Terminated chan process.ProcEntry
-}
+	// Terminated facilitates communication from Watcher <-> Controller. The controller restarts terminated processes
+	// according to their restart policy.
+	Terminated chan watcher.Termination
 
-var (
-	once = sync.Once{}
-)
+	// supervised holds the state of every extension that was started, guarded by m.
+	supervised map[string]*supervision
+
+	// shuttingDown prevents restarts once the runtime shuts down, guarded by m.
+	shuttingDown bool
+
+	// starts counts the extensions that were started, guarded by m.
+	starts int
+
+	// stdout and stderr receive the tagged output of all extensions.
+	stdout io.Writer
+	stderr io.Writer
+}
 
 // NewController initializes a new controller.
 func NewController(o ...Option) Controller {
@@ -54,11 +69,15 @@ func NewController(o ...Option) Controller {
 
 	c := Controller{
 		m:          &sync.RWMutex{},
+		once:       &sync.Once{},
 		options:    *opts,
 		log:        *opts.Log,
 		Bin:        "ocis",
-		Terminated: make(chan process.ProcEntry),
+		Terminated: make(chan watcher.Termination),
 		Store:      storage.NewMapStorage(),
+		supervised: map[string]*supervision{},
+		stdout:     output.Synchronized(os.Stdout),
+		stderr:     output.Synchronized(os.Stderr),
 
 		Config: opts.Config,
 	}
@@ -77,26 +96,42 @@ func NewController(o ...Option) Controller {
 	return c
 }
 
-// Start and watches a process.
+// Start and watches a process. Starting an extension that stopped restarting because it was crash looping gives it a
+// fresh set of restarts.
 func (c *Controller) Start(pe process.ProcEntry) error {
+	c.m.Lock()
+	defer c.m.Unlock()
+
 	if pid := c.Store.Load(pe.Extension); pid != 0 {
 		c.log.Debug().Msg(fmt.Sprintf("extension already running: %s", pe.Extension))
 		return nil
 	}
 
-	w := watcher.NewWatcher()
-	if err := pe.Start(c.BinPath); err != nil {
-		return err
+	s, ok := c.supervised[pe.Extension]
+	if !ok {
+		out, err := output.New(pe.Extension, output.Options{
+			Stdout:   c.stdout,
+			Stderr:   c.stderr,
+			Lines:    c.Config.LogLines,
+			Dir:      c.Config.LogDir,
+			MaxSize:  int64(c.Config.LogMaxSize) * 1024 * 1024,
+			MaxFiles: c.Config.LogMaxFiles,
+		})
+		if err != nil {
+			return err
+		}
+		c.starts++
+		s = &supervision{seq: c.starts, output: out}
+		c.supervised[pe.Extension] = s
 	}
+	s.cancelRestart()
+	s.recent = nil
 
-	// store the spawned child process PID.
-	if err := c.Store.Store(pe); err != nil {
+	if err := c.start(s, pe); err != nil {
 		return err
 	}
 
-	w.Follow(pe, c.Terminated, c.options.Config.KeepAlive)
-
-	once.Do(func() {
+	c.once.Do(func() {
 		j := janitor{
 			time.Second,
 			c.Store,
@@ -108,59 +143,202 @@ func (c *Controller) Start(pe process.ProcEntry) error {
 	return nil
 }
 
-// Kill a managed process.
-// Should a process managed by the runtime be allowed to be killed if the runtime is configured not to?
-func (c *Controller) Kill(pe process.ProcEntry) error {
-	// load stored PID
-	pid := c.Store.Load(pe.Extension)
-
-	// find process in host by PID
-	p, err := os.FindProcess(pid)
+// start spawns the process of an extension and follows it. c.m has to be locked.
+func (c *Controller) start(s *supervision, pe process.ProcEntry) error {
+	w := watcher.NewWatcher()
+	// the entry is kept even if the process can't be started, so that the extension can be restarted.
+	pe.Pid = 0
+	s.entry = pe
+	stdout, stderr, err := s.output.Pipes()
 	if err != nil {
+		s.status = statusFailed
+		return err
+	}
+	err = pe.Start(c.BinPath, stdout, stderr)
+	// the process has its own copies of the pipes now
+	stdout.Close()
+	stderr.Close()
+	if err != nil {
+		s.status = statusFailed
 		return err
 	}
 
-	if err := c.Store.Delete(pe); err != nil {
+	// store the spawned child process PID.
+	if err := c.Store.Store(pe); err != nil {
 		return err
 	}
+
+	s.entry = pe
+	s.started = time.Now()
+	s.status = statusRunning
+	s.exited = make(chan struct{})
+	w.Follow(pe, c.Terminated)
+	return nil
+}
+
+// Kill a managed process gracefully. The process is sent SIGTERM and killed if it did not terminate within the grace
+// period. A killed process is not restarted, neither is an extension that is waiting to be restarted.
+// Should a process managed by the runtime be allowed to be killed if the runtime is configured not to?
+func (c *Controller) Kill(pe process.ProcEntry) error {
+	c.m.Lock()
+	s, supervised := c.supervised[pe.Extension]
+	if supervised && s.status == statusRestarting {
+		s.cancelRestart()
+		s.status = statusStopped
+		c.m.Unlock()
+		return nil
+	}
+	if !supervised || s.status != statusRunning {
+		c.m.Unlock()
+		return fmt.Errorf("extension %v is not running", pe.Extension)
+	}
+	s.status = statusStopped
+	entry, exited := s.entry, s.exited
+	c.m.Unlock()
+
+	return c.stop(entry, exited)
+}
+
+// Restart stops an extension gracefully and starts it again with the same arguments and environment, without
+// touching other extensions. An extension that is not running is just started.
+func (c *Controller) Restart(extension string) error {
+	c.m.Lock()
+	s, ok := c.supervised[extension]
+	if !ok || len(s.entry.Args) == 0 {
+		c.m.Unlock()
+		return fmt.Errorf("extension %v was not started", extension)
+	}
+	running := s.status == statusRunning
+	if running {
+		s.status = statusStopped
+	}
+	entry, exited := s.entry, s.exited
+	c.m.Unlock()
+
+	if running {
+		if err := c.stop(entry, exited); err != nil {
+			return err
+		}
+	}
+	c.log.Info().Str("package", "watcher").Msgf("restarting %v", extension)
+	return c.Start(entry)
+}
+
+// stop terminates a process and waits until it exited, it is killed if it does not terminate within the grace period.
+// c.m must not be locked, the termination of the process is only noticed with it.
+func (c *Controller) stop(pe process.ProcEntry, exited <-chan struct{}) error {
 	c.log.Info().Str("package", "watcher").Msgf("terminating %v", pe.Extension)
+	if err := pe.Terminate(); err != nil {
+		c.log.Err(err).Str("package", "watcher").Msgf("could not terminate %v", pe.Extension)
+	}
 
-	// terminate child process
-	return p.Kill()
+	select {
+	case <-exited:
+		return nil
+	case <-time.After(c.Config.GracePeriod):
+	}
+
+	c.log.Warn().Str("package", "watcher").Msgf("%v did not terminate within %v, killing it", pe.Extension, c.Config.GracePeriod)
+	if err := pe.Kill(); err != nil {
+		return err
+	}
+	select {
+	case <-exited:
+		return nil
+	case <-time.After(killWait):
+		return fmt.Errorf("extension %v did not exit after it was killed", pe.Extension)
+	}
 }
 
-// Shutdown a running runtime.
+// Shutdown a running runtime. The running extensions are stopped one after another, in the reverse order of their
+// first start. As the runtime starts extensions in dependency order, an extension is stopped before the extensions it
+// depends on.
 func (c *Controller) Shutdown(ch chan struct{}) error {
-	entries := c.Store.LoadAll()
-	for cmd, pid := range entries {
-		c.log.Info().Str("package", "watcher").Msgf("gracefully terminating %v", cmd)
-		p, _ := os.FindProcess(pid)
-		if err := p.Kill(); err != nil {
-			return err
+	c.m.Lock()
+	c.shuttingDown = true
+	var running []*supervision
+	for _, s := range c.supervised {
+		s.cancelRestart()
+		if s.status == statusRunning {
+			s.status = statusStopped
+			running = append(running, s)
+		}
+	}
+	c.m.Unlock()
+
+	sort.Slice(running, func(i, j int) bool {
+		return running[i].seq > running[j].seq
+	})
+
+	var err error
+	for _, s := range running {
+		c.log.Info().Str("package", "watcher").Msgf("gracefully terminating %v", s.entry.Extension)
+		if stopErr := c.stop(s.entry, s.exited); stopErr != nil && err == nil {
+			err = stopErr
 		}
 	}
 
 	ch <- struct{}{}
-	return nil
+	return err
+}
+
+// History returns the last exits of an extension, the most recent one last.
+func (c *Controller) History(extension string) []Exit {
+	c.m.RLock()
+	defer c.m.RUnlock()
+
+	s, ok := c.supervised[extension]
+	if !ok {
+		return nil
+	}
+	return append([]Exit(nil), s.history...)
+}
+
+// Logs returns the captured output of an extension, starting with the line with the sequence number since, or the
+// last tail lines if since is negative. next is the sequence number of the following line, changed is closed once
+// there is a new line.
+func (c *Controller) Logs(extension string, since int64, tail int) (lines []output.Line, next int64, changed <-chan struct{}, err error) {
+	c.m.RLock()
+	defer c.m.RUnlock()
+
+	s, ok := c.supervised[extension]
+	if !ok {
+		return nil, 0, nil, fmt.Errorf("extension %v was not started", extension)
+	}
+	lines, next, changed = s.output.Since(since, tail)
+	return lines, next, changed, nil
 }
 
 // List managed processes.
 func (c *Controller) List() string {
+	c.m.RLock()
+	defer c.m.RUnlock()
+
 	tableString := &strings.Builder{}
 	table := tablewriter.NewWriter(tableString)
-	table.SetHeader([]string{"Extension", "PID"})
-
-	entries := c.Store.LoadAll()
+	table.SetHeader([]string{"Extension", "PID", "Status", "Uptime", "Restarts", "Last Exit"})
 
-	keys := make([]string, 0, len(entries))
-	for k := range entries {
+	keys := make([]string, 0, len(c.supervised))
+	for k := range c.supervised {
 		keys = append(keys, k)
 	}
 
 	sort.Strings(keys)
 
+	now := time.Now()
 	for _, v := range keys {
-		table.Append([]string{v, strconv.Itoa(entries[v])})
+		s := c.supervised[v]
+		pid, uptime := "", ""
+		if s.status == statusRunning {
+			pid = strconv.Itoa(s.entry.Pid)
+			uptime = now.Sub(s.started).Round(time.Second).String()
+		}
+		lastExit := ""
+		if n := len(s.history); n > 0 {
+			e := s.history[n-1]
+			lastExit = fmt.Sprintf("%v, %v ago", e.Reason, now.Sub(e.Exited).Round(time.Second))
+		}
+		table.Append([]string{v, pid, string(s.status), uptime, strconv.Itoa(s.restarts), lastExit})
 	}
 
 	table.Render()
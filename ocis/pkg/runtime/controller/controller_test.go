@@ -0,0 +1,168 @@
+// +build !windows
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/owncloud/ocis/ocis/pkg/runtime/config"
+	"github.com/owncloud/ocis/ocis/pkg/runtime/process"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newController returns a controller that runs shell scripts instead of extensions.
+func newController(cfg *config.Config) *Controller {
+	l := zerolog.Nop()
+	cfg.RestartBackoff = 10 * time.Millisecond
+	cfg.MaxRestartBackoff = 40 * time.Millisecond
+	c := NewController(WithConfig(cfg), WithLog(&l))
+	c.BinPath = "/bin/sh"
+	return &c
+}
+
+func script(extension, s string) process.ProcEntry {
+	return process.NewProcEntry(extension, nil, "-c", s)
+}
+
+func TestRestartOnFailure(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Restart = config.RestartOnFailure
+	cfg.MaxRestarts = 3
+	c := newController(cfg)
+
+	require.NoError(t, c.Start(script("failing", "exit 3")))
+	require.NoError(t, c.Start(script("succeeding", "exit 0")))
+
+	assert.Eventually(t, func() bool {
+		return len(c.History("failing")) == 4 && len(c.History("succeeding")) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	c.m.RLock()
+	assert.Equal(t, statusCrashLoop, c.supervised["failing"].status)
+	assert.Equal(t, 3, c.supervised["failing"].restarts)
+	assert.Equal(t, statusExited, c.supervised["succeeding"].status)
+	assert.Equal(t, 0, c.supervised["succeeding"].restarts)
+	c.m.RUnlock()
+
+	for _, e := range c.History("failing") {
+		assert.Equal(t, 3, e.Code)
+		assert.Equal(t, "exit status 3", e.Reason)
+	}
+	assert.Contains(t, c.List(), "crash loop")
+
+	// a manual start ends the crash loop
+	require.NoError(t, c.Start(script("failing", "exit 3")))
+	assert.Eventually(t, func() bool {
+		return len(c.History("failing")) == 8
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestRestartPolicies(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.KeepAlive = true
+	cfg.MaxRestarts = 2
+	cfg.RestartPolicies = map[string]config.RestartPolicy{"never": config.RestartNever}
+	c := newController(cfg)
+
+	require.NoError(t, c.Start(script("always", "exit 0")))
+	require.NoError(t, c.Start(script("never", "exit 1")))
+
+	assert.Eventually(t, func() bool {
+		return len(c.History("always")) == 3 && len(c.History("never")) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestKillIsNotRestarted(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Restart = config.RestartAlways
+	c := newController(cfg)
+
+	require.NoError(t, c.Start(script("sleeping", "exec sleep 60")))
+	assert.Contains(t, c.List(), "running")
+	require.NoError(t, c.Kill(process.ProcEntry{Extension: "sleeping"}))
+
+	assert.Eventually(t, func() bool {
+		return len(c.History("sleeping")) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+
+	c.m.RLock()
+	assert.Equal(t, statusStopped, c.supervised["sleeping"].status)
+	assert.Equal(t, 0, c.supervised["sleeping"].restarts)
+	c.m.RUnlock()
+	assert.Equal(t, "signal: terminated", c.History("sleeping")[0].Reason)
+
+	assert.Error(t, c.Kill(process.ProcEntry{Extension: "sleeping"}))
+	assert.Error(t, c.Kill(process.ProcEntry{Extension: "unknown"}))
+}
+
+func TestKillAfterGracePeriod(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.GracePeriod = 100 * time.Millisecond
+	c := newController(cfg)
+
+	require.NoError(t, c.Start(script("stubborn", "trap '' TERM; exec sleep 60")))
+	// give the shell time to ignore SIGTERM
+	time.Sleep(100 * time.Millisecond)
+
+	started := time.Now()
+	require.NoError(t, c.Kill(process.ProcEntry{Extension: "stubborn"}))
+	assert.True(t, time.Since(started) >= cfg.GracePeriod)
+	assert.Equal(t, "signal: killed", c.History("stubborn")[0].Reason)
+}
+
+func TestRestart(t *testing.T) {
+	cfg := config.NewConfig()
+	c := newController(cfg)
+
+	require.NoError(t, c.Start(script("restarted", "exec sleep 60")))
+	require.NoError(t, c.Start(script("untouched", "exec sleep 60")))
+	pid := c.Store.Load("untouched")
+
+	require.NoError(t, c.Restart("restarted"))
+	assert.Len(t, c.History("restarted"), 1)
+	assert.NotZero(t, c.Store.Load("restarted"))
+	assert.Equal(t, pid, c.Store.Load("untouched"))
+	assert.Empty(t, c.History("untouched"))
+
+	// an extension that is not running is just started
+	require.NoError(t, c.Kill(process.ProcEntry{Extension: "restarted"}))
+	require.NoError(t, c.Restart("restarted"))
+	assert.Len(t, c.History("restarted"), 2)
+	assert.NotZero(t, c.Store.Load("restarted"))
+
+	assert.EqualError(t, c.Restart("unknown"), "extension unknown was not started")
+
+	require.NoError(t, c.Shutdown(make(chan struct{}, 1)))
+}
+
+func TestShutdownOrder(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Restart = config.RestartAlways
+	c := newController(cfg)
+
+	for _, name := range []string{"settings", "accounts", "proxy"} {
+		require.NoError(t, c.Start(script(name, "exec sleep 60")))
+	}
+
+	done := make(chan struct{}, 1)
+	require.NoError(t, c.Shutdown(done))
+	<-done
+
+	proxy, accounts, settings := c.History("proxy"), c.History("accounts"), c.History("settings")
+	require.Len(t, proxy, 1)
+	require.Len(t, accounts, 1)
+	require.Len(t, settings, 1)
+	assert.True(t, proxy[0].Exited.Before(accounts[0].Exited))
+	assert.True(t, accounts[0].Exited.Before(settings[0].Exited))
+	assert.Empty(t, c.Store.LoadAll())
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, backoff(time.Second, time.Minute, 0))
+	assert.Equal(t, 8*time.Second, backoff(time.Second, time.Minute, 3))
+	assert.Equal(t, time.Minute, backoff(time.Second, time.Minute, 10))
+}
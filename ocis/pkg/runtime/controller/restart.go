@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/owncloud/ocis/ocis/pkg/runtime/config"
+	"github.com/owncloud/ocis/ocis/pkg/runtime/output"
+	"github.com/owncloud/ocis/ocis/pkg/runtime/process"
+	"github.com/owncloud/ocis/ocis/pkg/runtime/watcher"
+)
+
+// maxHistory is the number of exits that are kept per extension.
+const maxHistory = 10
+
+type status string
+
+const (
+	statusRunning    status = "running"
+	statusRestarting status = "restarting"
+	statusExited     status = "exited"
+	statusStopped    status = "stopped"
+	statusFailed     status = "failed"
+	statusCrashLoop  status = "crash loop"
+)
+
+// Exit describes how a supervised process ended.
+type Exit struct {
+	Pid     int
+	Started time.Time
+	Exited  time.Time
+
+	// Code is the exit code of the process, -1 if it was terminated by a signal or its state is unknown.
+	Code int
+
+	// Reason is the exit status or signal, or why waiting for the process failed.
+	Reason string
+}
+
+// supervision is what the controller knows about an extension it started.
+type supervision struct {
+	entry   process.ProcEntry
+	started time.Time
+	status  status
+
+	// seq orders the extensions by their first start.
+	seq int
+
+	// exited is closed once the current process exited.
+	exited chan struct{}
+
+	// restarts counts all restarts, recent only those within the restart window.
+	restarts int
+	recent   []time.Time
+
+	history []Exit
+
+	// output of the extension, it is kept across restarts.
+	output *output.Output
+
+	// restart is the timer of a pending restart.
+	restart *time.Timer
+}
+
+func (s *supervision) cancelRestart() {
+	if s.restart != nil {
+		s.restart.Stop()
+		s.restart = nil
+	}
+}
+
+// terminated records the termination of a process and schedules its restart, depending on the restart policy of the
+// extension and how often it was restarted recently.
+func (c *Controller) terminated(t watcher.Termination) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	name := t.Entry.Extension
+	s, ok := c.supervised[name]
+	if !ok || s.entry.Pid != t.Entry.Pid {
+		return
+	}
+	close(s.exited)
+
+	exit := Exit{Pid: t.Entry.Pid, Started: s.started, Exited: time.Now(), Code: -1}
+	if t.State != nil {
+		exit.Code = t.State.ExitCode()
+		exit.Reason = t.State.String()
+	} else {
+		exit.Reason = t.Err.Error()
+	}
+	s.history = append(s.history, exit)
+	if len(s.history) > maxHistory {
+		s.history = s.history[len(s.history)-maxHistory:]
+	}
+	if err := c.Store.Delete(t.Entry); err != nil {
+		c.log.Err(err).Str("extension", name).Msg("could not delete the terminated process")
+	}
+
+	if s.status == statusStopped || c.shuttingDown {
+		return
+	}
+
+	policy := c.Config.RestartPolicy(name)
+	if policy == config.RestartNever || policy == config.RestartOnFailure && exit.Code == 0 {
+		s.status = statusExited
+		return
+	}
+
+	now := time.Now()
+	recent := s.recent[:0]
+	for _, r := range s.recent {
+		if now.Sub(r) < c.Config.RestartWindow {
+			recent = append(recent, r)
+		}
+	}
+	s.recent = recent
+	if c.Config.MaxRestarts > 0 && len(s.recent) >= c.Config.MaxRestarts {
+		s.status = statusCrashLoop
+		c.log.Error().Str("extension", name).Str("reason", exit.Reason).
+			Msgf("restarted %d times within %v, not restarting anymore", len(s.recent), c.Config.RestartWindow)
+		return
+	}
+
+	delay := backoff(c.Config.RestartBackoff, c.Config.MaxRestartBackoff, len(s.recent))
+	s.recent = append(s.recent, now)
+	s.restarts++
+	s.status = statusRestarting
+	c.log.Info().Str("extension", name).Str("reason", exit.Reason).Msgf("restarting in %v", delay)
+
+	pe := t.Entry
+	s.restart = time.AfterFunc(delay, func() {
+		c.m.Lock()
+		defer c.m.Unlock()
+
+		// the extension was killed or started by hand in the meantime
+		if s.status != statusRestarting || c.shuttingDown {
+			return
+		}
+		s.restart = nil
+		if err := c.start(s, pe); err != nil {
+			c.log.Err(err).Str("extension", name).Msg("could not restart")
+		}
+	})
+}
+
+// backoff doubles the initial delay for every recent restart, up to max.
+func backoff(initial, max time.Duration, recent int) time.Duration {
+	d := initial
+	for i := 0; i < recent && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return d
+}
@@ -1,10 +1,8 @@
 package controller
 
-// detach will try to restart processes on failures.
+// detach handles the terminations of supervised processes.
 func detach(c *Controller) {
-	for proc := range c.Terminated {
-		if err := c.Start(proc); err != nil {
-			c.log.Err(err)
-		}
+	for t := range c.Terminated {
+		c.terminated(t)
 	}
 }
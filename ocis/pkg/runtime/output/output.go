@@ -0,0 +1,183 @@
+// Package output captures the output of supervised processes, tags it with the name of the extension and keeps it
+// for later retrieval.
+package output
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Line is a line written by an extension.
+type Line struct {
+	// Seq numbers the lines of an extension, starting with 0.
+	Seq    int64
+	Stderr bool
+	Text   string
+}
+
+// Options configure where the output of an extension goes.
+type Options struct {
+	// Stdout and Stderr receive the tagged lines of the extension. They have to be safe for concurrent use.
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// Lines is the number of lines that are at least kept in memory.
+	Lines int
+
+	// Dir is the directory of the log files of the extensions, no log files are written if empty.
+	Dir string
+
+	// MaxSize is the size in bytes after which a log file is rotated.
+	MaxSize int64
+
+	// MaxFiles is the number of rotated log files that are kept.
+	MaxFiles int
+}
+
+// Output collects the lines an extension writes, across restarts of the extension.
+type Output struct {
+	name string
+	opts Options
+	file *rotatingFile
+
+	mu    sync.Mutex
+	lines []Line
+	next  int64
+
+	// changed is closed and replaced whenever a line is added.
+	changed chan struct{}
+}
+
+// New returns the output of an extension. The log file of the extension is opened if a log directory is configured.
+func New(name string, opts Options) (*Output, error) {
+	o := &Output{
+		name:    name,
+		opts:    opts,
+		changed: make(chan struct{}),
+	}
+	if opts.Dir != "" {
+		if err := os.MkdirAll(opts.Dir, 0700); err != nil {
+			return nil, err
+		}
+		f, err := openRotating(filepath.Join(opts.Dir, name+".log"), opts.MaxSize, opts.MaxFiles)
+		if err != nil {
+			return nil, err
+		}
+		o.file = f
+	}
+	return o, nil
+}
+
+// Pipes returns the stdout and stderr files to hand to a new process of the extension. Everything written to them is
+// captured until the process and all its children closed them. The caller has to close both files once the process
+// was started.
+func (o *Output) Pipes() (stdout, stderr *os.File, err error) {
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		return nil, nil, err
+	}
+	errR, errW, err := os.Pipe()
+	if err != nil {
+		outR.Close()
+		outW.Close()
+		return nil, nil, err
+	}
+	go o.capture(outR, false)
+	go o.capture(errR, true)
+	return outW, errW, nil
+}
+
+func (o *Output) capture(r *os.File, stderr bool) {
+	defer r.Close()
+	br := bufio.NewReader(r)
+	for {
+		text, err := br.ReadString('\n')
+		if text != "" {
+			o.add(strings.TrimSuffix(text, "\n"), stderr)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (o *Output) add(text string, stderr bool) {
+	w := o.opts.Stdout
+	if stderr {
+		w = o.opts.Stderr
+	}
+	if w != nil {
+		fmt.Fprintf(w, "[%v] %v\n", o.name, text)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.file != nil {
+		// a failing log file must not block the extension, the line is still kept in memory
+		_, _ = o.file.Write([]byte(text + "\n"))
+	}
+	o.lines = append(o.lines, Line{Seq: o.next, Stderr: stderr, Text: text})
+	o.next++
+	// dropping old lines in batches keeps adding a line cheap
+	if len(o.lines) > 2*o.opts.Lines {
+		o.lines = append(o.lines[:0:0], o.lines[len(o.lines)-o.opts.Lines:]...)
+	}
+	close(o.changed)
+	o.changed = make(chan struct{})
+}
+
+// Since returns the kept lines starting with the sequence number since, or the last tail lines if since is negative.
+// next is the sequence number of the line after the returned ones, changed is closed once a line was added.
+func (o *Output) Since(since int64, tail int) (lines []Line, next int64, changed <-chan struct{}) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	start := 0
+	switch {
+	case since < 0:
+		if tail >= 0 && tail < len(o.lines) {
+			start = len(o.lines) - tail
+		}
+	case len(o.lines) > 0:
+		if i := since - o.lines[0].Seq; i > 0 {
+			start = int(i)
+		}
+		if start > len(o.lines) {
+			start = len(o.lines)
+		}
+	}
+	return append([]Line(nil), o.lines[start:]...), o.next, o.changed
+}
+
+// Close closes the log file.
+func (o *Output) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.file == nil {
+		return nil
+	}
+	return o.file.Close()
+}
+
+// Synchronized wraps w, so that concurrent writes don't interleave.
+func Synchronized(w io.Writer) io.Writer {
+	return &syncWriter{w: w}
+}
+
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
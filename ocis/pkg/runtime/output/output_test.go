@@ -0,0 +1,155 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buffer is a bytes.Buffer that is safe for concurrent use.
+type buffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *buffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *buffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "ocis-runtime-output-")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+// write writes to the pipes of o like a process would and waits until the lines were captured.
+func write(t *testing.T, o *Output, stdout, stderr string) {
+	_, before, _ := o.Since(0, 0)
+	outW, errW, err := o.Pipes()
+	require.NoError(t, err)
+	_, err = outW.WriteString(stdout)
+	require.NoError(t, err)
+	_, err = errW.WriteString(stderr)
+	require.NoError(t, err)
+	outW.Close()
+	errW.Close()
+
+	expected := before + int64(strings.Count(stdout+stderr, "\n"))
+	require.Eventually(t, func() bool {
+		_, next, _ := o.Since(0, 0)
+		return next == expected
+	}, 5*time.Second, 5*time.Millisecond)
+}
+
+func TestCapture(t *testing.T) {
+	stdout, stderr := &buffer{}, &buffer{}
+	dir := tempDir(t)
+	o, err := New("proxy", Options{Stdout: stdout, Stderr: stderr, Lines: 10, Dir: dir})
+	require.NoError(t, err)
+	defer o.Close()
+
+	write(t, o, "first\nsecond\n", "failure\n")
+
+	assert.Equal(t, "[proxy] first\n[proxy] second\n", stdout.String())
+	assert.Equal(t, "[proxy] failure\n", stderr.String())
+
+	lines, next, _ := o.Since(-1, 10)
+	assert.Equal(t, int64(3), next)
+	if assert.Len(t, lines, 3) {
+		// stdout and stderr are captured concurrently, only the order within a stream is defined
+		assert.True(t, findLine(lines, "failure").Stderr)
+		assert.True(t, findLine(lines, "first").Seq < findLine(lines, "second").Seq)
+	}
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "proxy.log"))
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(string(content)), "\n"), 3)
+}
+
+func findLine(lines []Line, text string) Line {
+	for _, l := range lines {
+		if l.Text == text {
+			return l
+		}
+	}
+	return Line{Seq: -1}
+}
+
+func TestSince(t *testing.T) {
+	o, err := New("web", Options{Lines: 5})
+	require.NoError(t, err)
+
+	for i := 0; i < 12; i++ {
+		write(t, o, fmt.Sprintf("line %d\n", i), "")
+	}
+
+	lines, next, _ := o.Since(-1, 2)
+	assert.Equal(t, int64(12), next)
+	assert.Equal(t, []Line{{Seq: 10, Text: "line 10"}, {Seq: 11, Text: "line 11"}}, lines)
+
+	lines, _, _ = o.Since(11, 0)
+	assert.Equal(t, []Line{{Seq: 11, Text: "line 11"}}, lines)
+
+	lines, _, _ = o.Since(12, 0)
+	assert.Empty(t, lines)
+
+	// lines that were dropped already are skipped
+	lines, _, _ = o.Since(0, 0)
+	assert.True(t, len(lines) >= 5)
+	assert.Equal(t, int64(11), lines[len(lines)-1].Seq)
+
+	_, _, changed := o.Since(12, 0)
+	select {
+	case <-changed:
+		t.Fatal("changed without a new line")
+	default:
+	}
+	write(t, o, "line 12\n", "")
+	select {
+	case <-changed:
+	default:
+		t.Fatal("not changed by a new line")
+	}
+}
+
+func TestRotate(t *testing.T) {
+	dir := tempDir(t)
+	o, err := New("settings", Options{Lines: 10, Dir: dir, MaxSize: 10, MaxFiles: 2})
+	require.NoError(t, err)
+	defer o.Close()
+
+	for i := 0; i < 4; i++ {
+		write(t, o, fmt.Sprintf("line %d..\n", i), "")
+	}
+
+	for file, expected := range map[string]string{
+		"settings.log":   "line 3..\n",
+		"settings.log.1": "line 2..\n",
+		"settings.log.2": "line 1..\n",
+	} {
+		content, err := ioutil.ReadFile(filepath.Join(dir, file))
+		if assert.NoError(t, err, file) {
+			assert.Equal(t, expected, string(content), file)
+		}
+	}
+	_, err = os.Stat(filepath.Join(dir, "settings.log.3"))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,83 @@
+package output
+
+import (
+	"fmt"
+	"os"
+)
+
+// rotatingFile is a log file that is renamed to <path>.1 once it reaches its maximum size. Older files are shifted to
+// <path>.2 and so on, up to the maximum number of files.
+type rotatingFile struct {
+	path     string
+	maxSize  int64
+	maxFiles int
+
+	f    *os.File
+	size int64
+}
+
+func openRotating(path string, maxSize int64, maxFiles int) (*rotatingFile, error) {
+	r := &rotatingFile{path: path, maxSize: maxSize, maxFiles: maxFiles}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	r.f = f
+	r.size = info.Size()
+	return nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	if r.maxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.f.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// rotate shifts the files and starts a new one. The current file is reopened if shifting fails.
+func (r *rotatingFile) rotate() error {
+	if err := r.f.Close(); err != nil {
+		return err
+	}
+	err := r.shift()
+	if oerr := r.open(); err == nil {
+		err = oerr
+	}
+	return err
+}
+
+func (r *rotatingFile) shift() error {
+	if r.maxFiles <= 0 {
+		return os.Remove(r.path)
+	}
+	for i := r.maxFiles - 1; i >= 1; i-- {
+		if err := os.Rename(r.name(i), r.name(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(r.path, r.name(1))
+}
+
+func (r *rotatingFile) name(i int) string {
+	return fmt.Sprintf("%v.%d", r.path, i)
+}
+
+func (r *rotatingFile) Close() error {
+	return r.f.Close()
+}
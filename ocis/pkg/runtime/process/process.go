@@ -25,16 +25,16 @@ func NewProcEntry(extension string, env []string, args ...string) ProcEntry {
 	}
 }
 
-// Start a process.
-func (e *ProcEntry) Start(binPath string) error {
+// Start a process that writes to stdout and stderr.
+func (e *ProcEntry) Start(binPath string, stdout, stderr *os.File) error {
 	var argv = []string{binPath}
 	argv = append(argv, e.Args...)
 
 	p, err := os.StartProcess(binPath, argv, &os.ProcAttr{
 		Files: []*os.File{
 			os.Stdin,
-			os.Stdout,
-			os.Stderr,
+			stdout,
+			stderr,
 		},
 		Env: e.Env,
 		Sys: &sys.SysProcAttr{
@@ -49,12 +49,12 @@ func (e *ProcEntry) Start(binPath string) error {
 	return nil
 }
 
-// Kill the wrapped process.
-func (e *ProcEntry) Kill() error {
-	p, err := os.FindProcess(e.Pid)
-	if err != nil {
-		return err
-	}
+// Terminate asks the wrapped process and the processes it started to exit by sending SIGTERM to its process group.
+func (e *ProcEntry) Terminate() error {
+	return sys.Kill(-e.Pid, sys.SIGTERM)
+}
 
-	return p.Kill()
+// Kill the wrapped process and the processes it started.
+func (e *ProcEntry) Kill() error {
+	return sys.Kill(-e.Pid, sys.SIGKILL)
 }
@@ -23,16 +23,16 @@ func NewProcEntry(extension string, env []string, args ...string) ProcEntry {
 	}
 }
 
-// Start a process.
-func (e *ProcEntry) Start(binPath string) error {
+// Start a process that writes to stdout and stderr.
+func (e *ProcEntry) Start(binPath string, stdout, stderr *os.File) error {
 	var argv = []string{binPath}
 	argv = append(argv, e.Args...)
 
 	p, err := os.StartProcess(binPath, argv, &os.ProcAttr{
 		Files: []*os.File{
 			os.Stdin,
-			os.Stdout,
-			os.Stderr,
+			stdout,
+			stderr,
 		},
 		Env: e.Env,
 	})
@@ -45,6 +45,11 @@ func (e *ProcEntry) Start(binPath string) error {
 	return nil
 }
 
+// Terminate the wrapped process. Windows has no SIGTERM, the process is killed right away.
+func (e *ProcEntry) Terminate() error {
+	return e.Kill()
+}
+
 // Kill the wrapped process.
 func (e *ProcEntry) Kill() error {
 	p, err := os.FindProcess(e.Pid)
@@ -1,13 +1,16 @@
 package runtime
 
 import (
+	"context"
 	"fmt"
 	golog "log"
 	"net/rpc"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/micro/go-micro/v2"
+	"github.com/owncloud/ocis/ocis-pkg/log"
 	"github.com/owncloud/ocis/ocis/pkg/config"
 
 	cli "github.com/micro/cli/v2"
@@ -17,6 +20,7 @@ import (
 
 	"github.com/owncloud/ocis/ocis/pkg/runtime/process"
 	"github.com/owncloud/ocis/ocis/pkg/runtime/service"
+	"github.com/owncloud/ocis/ocis/pkg/runtime/startup"
 )
 
 var (
@@ -30,50 +34,76 @@ var (
 		"registry", // :8000
 	}
 
-	// Extensions are oCIS extension services
-	Extensions = []string{
-		"glauth",
-		"idp",
-		"ocs",
-		"onlyoffice",
-		"proxy",
-		"settings",
-		"store",
-		"storage-frontend",
-		"storage-gateway",
-		"storage-userprovider",
-		"storage-auth-basic",
-		"storage-auth-bearer",
-		"storage-home",
-		"storage-users",
-		"storage-metadata",
-		"storage-public-link",
-		"thumbnails",
-		"web",
-		"webdav",
-		//"graph",
-		//"graph-explorer",
-	}
-
-	// There seem to be a race condition when reva-sharing needs to read the sharing.json file and the parent folder is not present.
-	dependants = []string{
-		"accounts",
-		"storage-sharing",
+	// Extensions are oCIS extension services. An extension is started once the extensions it depends on are ready.
+	Extensions = map[string]Extension{
+		"accounts":             {DependsOn: []string{"settings", "storage-metadata"}},
+		"glauth":               {DebugAddr: "0.0.0.0:9129", DebugAddrEnv: "GLAUTH_DEBUG_ADDR"},
+		"idp":                  {DependsOn: []string{"glauth"}, DebugAddr: "0.0.0.0:9134", DebugAddrEnv: "IDP_DEBUG_ADDR"},
+		"ocs":                  {DebugAddr: "0.0.0.0:9114", DebugAddrEnv: "OCS_DEBUG_ADDR"},
+		"onlyoffice":           {DebugAddr: "0.0.0.0:9224", DebugAddrEnv: "ONLYOFFICE_DEBUG_ADDR"},
+		"proxy":                {DebugAddr: "0.0.0.0:9205", DebugAddrEnv: "PROXY_DEBUG_ADDR"},
+		"settings":             {DebugAddr: "0.0.0.0:9194", DebugAddrEnv: "SETTINGS_DEBUG_ADDR"},
+		"store":                {DebugAddr: "0.0.0.0:9460", DebugAddrEnv: "STORE_DEBUG_ADDR"},
+		"storage-frontend":     {DependsOn: []string{"storage-gateway"}, DebugAddr: "0.0.0.0:9141", DebugAddrEnv: "STORAGE_FRONTEND_DEBUG_ADDR"},
+		"storage-gateway":      {DebugAddr: "0.0.0.0:9143", DebugAddrEnv: "STORAGE_GATEWAY_DEBUG_ADDR"},
+		"storage-userprovider": {DebugAddr: "0.0.0.0:9145", DebugAddrEnv: "STORAGE_SHARING_DEBUG_ADDR"},
+		"storage-auth-basic":   {DebugAddr: "0.0.0.0:9147", DebugAddrEnv: "STORAGE_AUTH_BASIC_DEBUG_ADDR"},
+		"storage-auth-bearer":  {DebugAddr: "0.0.0.0:9149", DebugAddrEnv: "STORAGE_AUTH_BEARER_DEBUG_ADDR"},
+		"storage-home":         {DebugAddr: "0.0.0.0:9156", DebugAddrEnv: "STORAGE_HOME_DEBUG_ADDR"},
+		"storage-users":        {DebugAddr: "0.0.0.0:9159", DebugAddrEnv: "STORAGE_USERS_DEBUG_ADDR"},
+		"storage-metadata":     {DebugAddr: "0.0.0.0:9217", DebugAddrEnv: "STORAGE_METADATA_DEBUG_ADDR"},
+		"storage-public-link":  {DebugAddr: "0.0.0.0:9179", DebugAddrEnv: "STORAGE_PUBLIC_LINK_DEBUG_ADDR"},
+		// sharing reads its json files from the storage folder, which is only created by the storages.
+		"storage-sharing": {DependsOn: []string{"storage-home", "storage-users"}, DebugAddr: "0.0.0.0:9151", DebugAddrEnv: "STORAGE_SHARING_DEBUG_ADDR"},
+		"thumbnails":      {DebugAddr: "0.0.0.0:9189", DebugAddrEnv: "THUMBNAILS_DEBUG_ADDR"},
+		"web":             {DebugAddr: "0.0.0.0:9104", DebugAddrEnv: "WEB_DEBUG_ADDR"},
+		"webdav":          {DebugAddr: "0.0.0.0:9119", DebugAddrEnv: "WEBDAV_DEBUG_ADDR"},
+		"graph":           {Optional: true, DebugAddr: "0.0.0.0:9124", DebugAddrEnv: "GRAPH_DEBUG_ADDR"},
+		"graph-explorer":  {Optional: true, DebugAddr: "0.0.0.0:9136", DebugAddrEnv: "GRAPH_EXPLORER_DEBUG_ADDR"},
 	}
 
 	// Maximum number of retries until getting a connection to the rpc runtime service.
 	maxRetries int = 10
 )
 
+// Extension describes how the runtime starts an extension.
+type Extension struct {
+	// DependsOn are the extensions that have to be ready before the extension is started.
+	DependsOn []string
+
+	// Optional extensions are only started if they are included explicitly.
+	Optional bool
+
+	// DebugAddr is the default address of the debug server of the extension, DebugAddrEnv the environment variable
+	// that overrides it. Extensions without a debug server are considered ready once they are started.
+	DebugAddr    string
+	DebugAddrEnv string
+}
+
+// Instance is a supervised process of an extension. Additional instances of an extension are supervised under a name
+// of their own and usually have a different environment, e.g. other addresses.
+type Instance struct {
+	Name      string
+	Extension string
+	Env       []string
+}
+
 // Runtime represents an oCIS runtime environment.
 type Runtime struct {
 	c *config.Config
+	l log.Logger
 }
 
 // New creates a new oCIS + micro runtime
 func New(cfg *config.Config) Runtime {
 	return Runtime{
 		c: cfg,
+		l: log.NewLogger(
+			log.Name("ocis"),
+			log.Level(cfg.Log.Level),
+			log.Pretty(cfg.Log.Pretty),
+			log.Color(cfg.Log.Color),
+		),
 	}
 }
 
@@ -106,32 +136,113 @@ func (r *Runtime) Launch() {
 	}
 
 OUT:
-	for _, v := range MicroServices {
-		RunService(client, v)
+	instances, err := r.instances()
+	if err != nil {
+		golog.Fatal(err)
 	}
 
-	for _, v := range Extensions {
-		RunService(client, v)
+	started := make(map[string]Instance, len(instances))
+	for _, i := range instances {
+		if contains(MicroServices, i.Extension) {
+			RunInstance(client, i)
+			continue
+		}
+		started[i.Name] = i
 	}
 
-	if len(dependants) > 0 {
-		// TODO(refs) this should disappear and tackled at the runtime (pman) level.
-		// see https://github.com/cs3org/reva/issues/795 for race condition.
-		// dependants might not be needed on a ocis_simple build, therefore
-		// it should not be started under these circumstances.
-		time.Sleep(2 * time.Second)
-		for _, v := range dependants {
-			RunService(client, v)
+	// dependencies that are not started by this runtime are not awaited.
+	dependencies := make(map[string][]string, len(started))
+	for name, i := range started {
+		dependencies[name] = nil
+		for _, dep := range Extensions[i.Extension].DependsOn {
+			if _, ok := started[dep]; ok {
+				dependencies[name] = append(dependencies[name], dep)
+			} else {
+				r.l.Debug().Str("extension", name).Str("dependency", dep).Msg("dependency is not started")
+			}
 		}
 	}
+	// extensions are started as soon as the extensions they depend on are ready, readiness is only awaited for
+	// extensions that others depend on.
+	notStarted, err := startup.Launch(dependencies, func(name string) {
+		RunInstance(client, started[name])
+	}, func(name string) error {
+		return r.waitReady(started[name])
+	})
+	if err != nil {
+		golog.Fatal(err)
+	}
+	for name, err := range notStarted {
+		r.l.Error().Err(err).Str("extension", name).Msg("extension not started")
+	}
+}
+
+// instances returns the selected extensions and the additional instances of extensions.
+func (r *Runtime) instances() ([]Instance, error) {
+	available := append([]string(nil), MicroServices...)
+	var optional []string
+	for name, e := range Extensions {
+		available = append(available, name)
+		if e.Optional {
+			optional = append(optional, name)
+		}
+	}
+
+	selected, err := startup.Select(available, optional, r.c.Startup.Extensions, r.c.Startup.ExcludeExtensions)
+	if err != nil {
+		return nil, err
+	}
+	additional, err := startup.ParseInstances(available, r.c.Startup.Instances)
+	if err != nil {
+		return nil, err
+	}
+
+	environ := os.Environ()
+	instances := make([]Instance, 0, len(selected)+len(additional))
+	for _, name := range selected {
+		instances = append(instances, Instance{Name: name, Extension: name, Env: environ})
+	}
+	for name, extension := range additional {
+		instances = append(instances, Instance{Name: name, Extension: extension, Env: startup.InstanceEnv(name, environ)})
+	}
+	return instances, nil
+}
+
+// waitReady waits until the debug server of an instance reports it as ready, at most for the startup timeout.
+func (r *Runtime) waitReady(i Instance) error {
+	e := Extensions[i.Extension]
+	if e.DebugAddr == "" {
+		return nil
+	}
+	addr := e.DebugAddr
+	if v := lookupEnv(i.Env, e.DebugAddrEnv); v != "" {
+		addr = v
+	}
+	url, err := startup.ReadyURL(addr)
+	if err != nil {
+		return err
+	}
+
+	r.l.Debug().Str("extension", i.Name).Str("url", url).Msg("waiting for extension to become ready")
+	ctx, cancel := context.WithTimeout(context.Background(), r.c.Startup.Timeout)
+	defer cancel()
+	if err := startup.WaitReady(ctx, url, 250*time.Millisecond); err != nil {
+		return fmt.Errorf("not ready within %v: %w", r.c.Startup.Timeout, err)
+	}
+	return nil
 }
 
 // RunService sends a Service.Start command with the given service name  to pman
 func RunService(client *rpc.Client, service string) int {
-	args := process.NewProcEntry(service, os.Environ(), []string{service}...)
+	return RunInstance(client, Instance{Name: service, Extension: service, Env: os.Environ()})
+}
+
+// RunInstance sends a Service.Start command for an instance of an extension to pman. The instance is supervised under
+// its own name.
+func RunInstance(client *rpc.Client, i Instance) int {
+	args := process.NewProcEntry(i.Name, i.Env, []string{i.Extension}...)
 
-	all := append(Extensions, append(dependants, MicroServices...)...)
-	if !contains(all, service) {
+	if _, ok := Extensions[i.Extension]; !ok && !contains(MicroServices, i.Extension) {
 		return 1
 	}
 
@@ -166,6 +277,17 @@ func setDefaults() {
 	registry.Name = OwncloudNamespace + "registry"
 }
 
+// lookupEnv returns the value of the variable key in env, the last one wins.
+func lookupEnv(env []string, key string) string {
+	value := ""
+	for _, kv := range env {
+		if strings.HasPrefix(kv, key+"=") {
+			value = strings.TrimPrefix(kv, key+"=")
+		}
+	}
+	return value
+}
+
 func contains(a []string, b string) bool {
 	for i := range a {
 		if a[i] == b {
@@ -10,10 +10,12 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/owncloud/ocis/ocis/pkg/runtime/config"
 	"github.com/owncloud/ocis/ocis/pkg/runtime/controller"
 	"github.com/owncloud/ocis/ocis/pkg/runtime/log"
+	"github.com/owncloud/ocis/ocis/pkg/runtime/output"
 	"github.com/owncloud/ocis/ocis/pkg/runtime/process"
 	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
@@ -33,13 +35,23 @@ type Service struct {
 }
 
 // loadFromEnv would set cmd global variables. This is a workaround spf13/viper since pman used as a library does not
-// parse flags.
-func loadFromEnv() *config.Config {
+// parse flags. Invalid values are logged and replaced by their defaults.
+func loadFromEnv(l zerolog.Logger) *config.Config {
 	cfg := config.NewConfig()
 	viper.AutomaticEnv()
 
 	viper.BindEnv("keep-alive", "RUNTIME_KEEP_ALIVE")
 	viper.BindEnv("port", "RUNTIME_PORT")
+	viper.BindEnv("restart", "RUNTIME_RESTART")
+	viper.BindEnv("restart-policies", "RUNTIME_RESTART_POLICIES")
+	viper.BindEnv("restart-backoff", "RUNTIME_RESTART_BACKOFF")
+	viper.BindEnv("max-restart-backoff", "RUNTIME_MAX_RESTART_BACKOFF")
+	viper.BindEnv("max-restarts", "RUNTIME_MAX_RESTARTS")
+	viper.BindEnv("restart-window", "RUNTIME_RESTART_WINDOW")
+	viper.BindEnv("grace-period", "RUNTIME_GRACE_PERIOD")
+	viper.BindEnv("log-dir", "RUNTIME_LOG_DIR")
+	viper.BindEnv("log-max-size", "RUNTIME_LOG_MAX_SIZE")
+	viper.BindEnv("log-max-files", "RUNTIME_LOG_MAX_FILES")
 
 	cfg.KeepAlive = viper.GetBool("keep-alive")
 
@@ -47,9 +59,57 @@ func loadFromEnv() *config.Config {
 		cfg.Port = viper.GetString("port")
 	}
 
+	if v := viper.GetString("restart"); v != "" {
+		p, err := config.ParseRestartPolicy(v)
+		if err != nil {
+			l.Error().Err(err).Msg("ignoring RUNTIME_RESTART")
+		} else {
+			cfg.Restart = p
+		}
+	}
+
+	if v := viper.GetString("restart-policies"); v != "" {
+		policies, err := config.ParseRestartPolicies(v)
+		if err != nil {
+			l.Error().Err(err).Msg("ignoring RUNTIME_RESTART_POLICIES")
+		} else {
+			cfg.RestartPolicies = policies
+		}
+	}
+
+	loadDuration(l, "restart-backoff", &cfg.RestartBackoff)
+	loadDuration(l, "max-restart-backoff", &cfg.MaxRestartBackoff)
+	loadDuration(l, "restart-window", &cfg.RestartWindow)
+	loadDuration(l, "grace-period", &cfg.GracePeriod)
+
+	if viper.GetString("max-restarts") != "" {
+		cfg.MaxRestarts = viper.GetInt("max-restarts")
+	}
+
+	cfg.LogDir = viper.GetString("log-dir")
+	if viper.GetString("log-max-size") != "" {
+		cfg.LogMaxSize = viper.GetInt("log-max-size")
+	}
+	if viper.GetString("log-max-files") != "" {
+		cfg.LogMaxFiles = viper.GetInt("log-max-files")
+	}
+
 	return cfg
 }
 
+func loadDuration(l zerolog.Logger, key string, d *time.Duration) {
+	v := viper.GetString(key)
+	if v == "" {
+		return
+	}
+	parsed, err := time.ParseDuration(v)
+	if err != nil || parsed <= 0 {
+		l.Error().Str("value", v).Msgf("ignoring invalid duration for %v", key)
+		return
+	}
+	*d = parsed
+}
+
 // NewService returns a configured service with a controller and a default logger.
 // When used as a library, flags are not parsed, and in order to avoid introducing a global state with init functions
 // calls are done explicitly to loadFromEnv().
@@ -62,10 +122,10 @@ func NewService(options ...Option) *Service {
 		f(opts)
 	}
 
-	cfg := loadFromEnv()
 	l := log.NewLogger(
 		log.WithPretty(opts.Log.Pretty),
 	)
+	cfg := loadFromEnv(l)
 
 	return &Service{
 		wg:  &sync.WaitGroup{},
@@ -110,18 +170,25 @@ func Start(o ...Option) error {
 	return http.Serve(l, nil)
 }
 
-// Start indicates the Service Controller to start a new supervised service as an OS thread.
+// Start indicates the Service Controller to start a new supervised service as an OS thread. args.Extension is the name
+// the process is supervised under, args.Args and args.Env are the arguments and environment of the instance, e.g.
+// Extension "storage-users-2" with Args ["storage-users"] starts a second storage-users.
 func (s *Service) Start(args process.ProcEntry, reply *int) error {
+	if len(args.Args) == 0 {
+		*reply = 1
+		return fmt.Errorf("no arguments to start %v with", args.Extension)
+	}
+
 	if !s.done {
 		s.wg.Add(1)
-		s.Log.Info().Str("service", args.Extension).Msgf("%v", "started")
+		defer s.wg.Done()
+		s.Log.Info().Str("service", args.Extension).Strs("args", args.Args).Msgf("%v", "started")
 		if err := s.Controller.Start(args); err != nil {
 			*reply = 1
 			return err
 		}
 
 		*reply = 0
-		s.wg.Done()
 	}
 
 	return nil
@@ -133,6 +200,50 @@ func (s *Service) List(args struct{}, reply *string) error {
 	return nil
 }
 
+// LogsRequest asks for the output of an extension.
+type LogsRequest struct {
+	Extension string
+
+	// Since is the sequence number of the first line to return. If negative, the last Tail lines are returned.
+	Since int64
+	Tail  int
+
+	// Wait blocks for a while if there are no lines yet, so that the output can be followed without polling.
+	Wait bool
+}
+
+// LogsReply contains the output of an extension.
+type LogsReply struct {
+	Lines []output.Line
+
+	// Next is the sequence number to ask for to get the lines following the returned ones.
+	Next int64
+}
+
+// logsWait is the maximum time a logs request waits for new lines.
+const logsWait = 10 * time.Second
+
+// Logs returns the captured output of an extension.
+func (s *Service) Logs(args LogsRequest, reply *LogsReply) error {
+	lines, next, changed, err := s.Controller.Logs(args.Extension, args.Since, args.Tail)
+	if err != nil {
+		return err
+	}
+	if len(lines) == 0 && args.Wait {
+		select {
+		case <-changed:
+		case <-time.After(logsWait):
+		}
+		if lines, next, _, err = s.Controller.Logs(args.Extension, args.Since, args.Tail); err != nil {
+			return err
+		}
+	}
+
+	reply.Lines = lines
+	reply.Next = next
+	return nil
+}
+
 // Kill a supervised process by subcommand name.
 func (s *Service) Kill(args *string, reply *int) error {
 	pe := process.ProcEntry{
@@ -147,17 +258,28 @@ func (s *Service) Kill(args *string, reply *int) error {
 	return nil
 }
 
+// Restart a supervised process by subcommand name, without touching the other processes.
+func (s *Service) Restart(args *string, reply *int) error {
+	if err := s.Controller.Restart(*args); err != nil {
+		*reply = 1
+		return err
+	}
+
+	*reply = 0
+	return nil
+}
+
 // trap blocks on halt channel. When the runtime is interrupted it
-// signals the controller to stop any supervised process.
+// signals the controller to stop any supervised process, in reverse dependency order.
 func trap(s *Service) {
-	<-halt
+	sig := <-halt
 	s.done = true
 	s.wg.Wait()
-	s.Log.Debug().
+	s.Log.Info().
 		Str("service", "runtime service").
-		Msgf("terminating with signal: %v", s)
+		Msgf("terminating with signal: %v", sig)
 	if err := s.Controller.Shutdown(done); err != nil {
-		s.Log.Err(err)
+		s.Log.Err(err).Msg("could not stop all extensions")
 	}
 	close(done)
 	os.Exit(0)
@@ -0,0 +1,116 @@
+// Package startup selects the extensions to start, orders them by their dependencies and waits for extensions to
+// become ready.
+package startup
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Order returns the extensions of the dependency graph in an order in which every extension comes after the
+// extensions it depends on. Extensions without an order between them are sorted by name, so the order is stable.
+func Order(dependencies map[string][]string) ([]string, error) {
+	names := make([]string, 0, len(dependencies))
+	for name, deps := range dependencies {
+		for _, dep := range deps {
+			if _, ok := dependencies[dep]; !ok {
+				return nil, fmt.Errorf("extension %v depends on unknown extension %v", name, dep)
+			}
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	order := make([]string, 0, len(names))
+	placed := make(map[string]bool, len(names))
+	for len(order) < len(names) {
+		next := ""
+		for _, name := range names {
+			if !placed[name] && allPlaced(dependencies[name], placed) {
+				next = name
+				break
+			}
+		}
+		if next == "" {
+			var cycle []string
+			for _, name := range names {
+				if !placed[name] {
+					cycle = append(cycle, name)
+				}
+			}
+			return nil, fmt.Errorf("dependency cycle between the extensions %v", strings.Join(cycle, ", "))
+		}
+		order = append(order, next)
+		placed[next] = true
+	}
+	return order, nil
+}
+
+func allPlaced(deps []string, placed map[string]bool) bool {
+	for _, dep := range deps {
+		if !placed[dep] {
+			return false
+		}
+	}
+	return true
+}
+
+// Launch starts the extensions of the dependency graph. Every extension is started as soon as the extensions it depends
+// on are ready, so a slow extension only delays its dependants. Readiness is only awaited for extensions that others
+// depend on. An extension whose dependency never became ready is not started, neither are the extensions depending on
+// it. Launch returns once every extension is started or given up, with the reasons of those that were not started.
+func Launch(dependencies map[string][]string, start func(name string), ready func(name string) error) (map[string]error, error) {
+	order, err := Order(dependencies)
+	if err != nil {
+		return nil, err
+	}
+	dependants := make(map[string]bool, len(order))
+	for _, deps := range dependencies {
+		for _, dep := range deps {
+			dependants[dep] = true
+		}
+	}
+
+	type launch struct {
+		done    chan struct{}
+		started bool
+		err     error
+	}
+	launches := make(map[string]*launch, len(order))
+	for _, name := range order {
+		launches[name] = &launch{done: make(chan struct{})}
+	}
+
+	var wg sync.WaitGroup
+	for _, name := range order {
+		wg.Add(1)
+		go func(name string, l *launch) {
+			defer wg.Done()
+			defer close(l.done)
+			for _, dep := range dependencies[name] {
+				d := launches[dep]
+				<-d.done
+				if d.err != nil {
+					l.err = fmt.Errorf("dependency %v is not ready: %w", dep, d.err)
+					return
+				}
+			}
+			start(name)
+			l.started = true
+			if dependants[name] {
+				l.err = ready(name)
+			}
+		}(name, launches[name])
+	}
+	wg.Wait()
+
+	notStarted := map[string]error{}
+	for name, l := range launches {
+		if !l.started {
+			notStarted[name] = l.err
+		}
+	}
+	return notStarted, nil
+}
@@ -0,0 +1,62 @@
+package startup
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ReadyURL returns the url of the readiness endpoint of a debug server that listens on addr. Wildcard hosts are
+// replaced by localhost, the runtime and its extensions share the host.
+func ReadyURL(addr string) (string, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", err
+	}
+	if ip := net.ParseIP(host); host == "" || ip != nil && ip.IsUnspecified() {
+		host = "localhost"
+	}
+	return fmt.Sprintf("http://%v/readyz", net.JoinHostPort(host, port)), nil
+}
+
+// WaitReady polls the readiness endpoint at url every interval until it responds with 200 OK. When ctx is done before,
+// the last reason for not being ready is returned.
+func WaitReady(ctx context.Context, url string, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last error
+	for {
+		err := ready(ctx, url)
+		if err == nil {
+			return nil
+		}
+		// a request that is cancelled by ctx tells nothing about the extension
+		if last == nil || ctx.Err() == nil {
+			last = err
+		}
+		select {
+		case <-ctx.Done():
+			return last
+		case <-ticker.C:
+		}
+	}
+}
+
+func ready(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%v responded with %v", url, resp.Status)
+	}
+	return nil
+}
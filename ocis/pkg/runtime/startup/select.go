@@ -0,0 +1,118 @@
+package startup
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Select returns the extensions to start: the included extensions, or all available ones but the optional ones if
+// nothing is included, without the excluded extensions. The result is sorted by name.
+func Select(available, optional, include, exclude []string) ([]string, error) {
+	known := make(map[string]bool, len(available))
+	for _, name := range available {
+		known[name] = true
+	}
+
+	selected := map[string]bool{}
+	if len(include) == 0 {
+		for _, name := range available {
+			selected[name] = true
+		}
+		for _, name := range optional {
+			delete(selected, name)
+		}
+	}
+	for _, name := range include {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown extension %v", name)
+		}
+		selected[name] = true
+	}
+	for _, name := range exclude {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown extension %v", name)
+		}
+		delete(selected, name)
+	}
+
+	names := make([]string, 0, len(selected))
+	for name := range selected {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+var instanceName = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// ParseInstances parses additional instances of extensions given as name=extension, e.g.
+// "storage-users-2=storage-users". It returns the extension of every instance by its name. An instance must not be
+// named like an available extension.
+func ParseInstances(available, instances []string) (map[string]string, error) {
+	known := make(map[string]bool, len(available))
+	for _, name := range available {
+		known[name] = true
+	}
+
+	parsed := make(map[string]string, len(instances))
+	for _, instance := range instances {
+		kv := strings.SplitN(instance, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid instance %q, expected name=extension", instance)
+		}
+		name, extension := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		switch {
+		case !instanceName.MatchString(name):
+			return nil, fmt.Errorf("invalid instance name %q, only lower case letters, digits and dashes are allowed", name)
+		case known[name]:
+			return nil, fmt.Errorf("instance %v is named like an extension", name)
+		case parsed[name] != "":
+			return nil, fmt.Errorf("instance %v is given twice", name)
+		case !known[extension]:
+			return nil, fmt.Errorf("instance %v of unknown extension %v", name, extension)
+		}
+		parsed[name] = extension
+	}
+	return parsed, nil
+}
+
+// InstanceEnvPrefix returns the prefix of the environment variables that only apply to an instance, e.g.
+// OCIS_INSTANCE_STORAGE_USERS_2_ for the instance storage-users-2.
+func InstanceEnvPrefix(name string) string {
+	return "OCIS_INSTANCE_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+}
+
+// InstanceEnv returns the environment of an instance. Variables with the prefix of the instance override the variable
+// without the prefix, e.g. OCIS_INSTANCE_STORAGE_USERS_2_STORAGE_USERS_GRPC_ADDR sets STORAGE_USERS_GRPC_ADDR of the
+// instance storage-users-2.
+func InstanceEnv(name string, environ []string) []string {
+	prefix := InstanceEnvPrefix(name)
+
+	overrides := map[string]string{}
+	for _, kv := range environ {
+		if strings.HasPrefix(kv, prefix) {
+			if k := strings.SplitN(strings.TrimPrefix(kv, prefix), "=", 2); len(k) == 2 && k[0] != "" {
+				overrides[k[0]] = k[1]
+			}
+		}
+	}
+
+	env := make([]string, 0, len(environ))
+	for _, kv := range environ {
+		k := strings.SplitN(kv, "=", 2)[0]
+		if _, ok := overrides[k]; !ok {
+			env = append(env, kv)
+		}
+	}
+	keys := make([]string, 0, len(overrides))
+	for k := range overrides {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		env = append(env, k+"="+overrides[k])
+	}
+	return env
+}
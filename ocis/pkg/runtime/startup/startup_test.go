@@ -0,0 +1,231 @@
+package startup
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrder(t *testing.T) {
+	order, err := Order(map[string][]string{
+		"accounts":         {"settings", "storage-metadata"},
+		"glauth":           {"accounts"},
+		"settings":         nil,
+		"storage-metadata": nil,
+		"web":              nil,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"settings", "storage-metadata", "accounts", "glauth", "web"}, order)
+}
+
+func TestOrderErrors(t *testing.T) {
+	_, err := Order(map[string][]string{
+		"accounts": {"settings"},
+	})
+	assert.EqualError(t, err, "extension accounts depends on unknown extension settings")
+
+	_, err = Order(map[string][]string{
+		"a":   {"b"},
+		"b":   {"c"},
+		"c":   {"a"},
+		"web": nil,
+	})
+	assert.EqualError(t, err, "dependency cycle between the extensions a, b, c")
+}
+
+func TestLaunchDoesNotWaitForUnrelatedExtensions(t *testing.T) {
+	var mu sync.Mutex
+	var started []string
+	independent := make(chan struct{}, 2)
+
+	notStarted, err := Launch(map[string][]string{
+		"accounts":         {"storage-metadata"},
+		"glauth":           nil,
+		"storage-metadata": nil,
+		"web":              nil,
+	}, func(name string) {
+		mu.Lock()
+		started = append(started, name)
+		mu.Unlock()
+		if name == "glauth" || name == "web" {
+			independent <- struct{}{}
+		}
+	}, func(name string) error {
+		// storage-metadata only becomes ready after the extensions that do not depend on it were started.
+		for i := 0; i < 2; i++ {
+			select {
+			case <-independent:
+			case <-time.After(5 * time.Second):
+				return errors.New("independent extensions were not started")
+			}
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Empty(t, notStarted)
+	assert.ElementsMatch(t, []string{"accounts", "glauth", "storage-metadata", "web"}, started)
+	assert.Equal(t, "accounts", started[len(started)-1])
+}
+
+func TestLaunchSkipsDependantsOfUnreadyExtensions(t *testing.T) {
+	var mu sync.Mutex
+	var started, awaited []string
+
+	notStarted, err := Launch(map[string][]string{
+		"accounts":         {"storage-metadata"},
+		"glauth":           {"accounts"},
+		"storage-metadata": nil,
+		"web":              nil,
+	}, func(name string) {
+		mu.Lock()
+		started = append(started, name)
+		mu.Unlock()
+	}, func(name string) error {
+		mu.Lock()
+		awaited = append(awaited, name)
+		mu.Unlock()
+		return errors.New("not ready")
+	})
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"storage-metadata", "web"}, started)
+	assert.Equal(t, []string{"storage-metadata"}, awaited)
+	if assert.Len(t, notStarted, 2) {
+		assert.EqualError(t, notStarted["accounts"], "dependency storage-metadata is not ready: not ready")
+		assert.EqualError(t, notStarted["glauth"], "dependency accounts is not ready: dependency storage-metadata is not ready: not ready")
+	}
+}
+
+func TestLaunchErrors(t *testing.T) {
+	_, err := Launch(map[string][]string{
+		"a": {"b"},
+		"b": {"a"},
+	}, func(string) {}, func(string) error { return nil })
+	assert.EqualError(t, err, "dependency cycle between the extensions a, b")
+}
+
+func TestSelect(t *testing.T) {
+	available := []string{"web", "proxy", "onlyoffice", "settings", "graph", "graph-explorer"}
+	optional := []string{"graph", "graph-explorer"}
+	scenarios := []struct {
+		include, exclude []string
+		expected         []string
+		err              string
+	}{
+		{expected: []string{"onlyoffice", "proxy", "settings", "web"}},
+		{exclude: []string{"onlyoffice"}, expected: []string{"proxy", "settings", "web"}},
+		{exclude: []string{"graph"}, expected: []string{"onlyoffice", "proxy", "settings", "web"}},
+		{include: []string{"web", "proxy"}, expected: []string{"proxy", "web"}},
+		{include: []string{"web", "proxy"}, exclude: []string{"web"}, expected: []string{"proxy"}},
+		{include: []string{"web", "graph", "graph-explorer"}, expected: []string{"graph", "graph-explorer", "web"}},
+		{include: []string{"hello"}, err: "unknown extension hello"},
+		{exclude: []string{"hello"}, err: "unknown extension hello"},
+	}
+	for _, s := range scenarios {
+		selected, err := Select(available, optional, s.include, s.exclude)
+		if s.err != "" {
+			assert.EqualError(t, err, s.err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, s.expected, selected)
+	}
+}
+
+func TestParseInstances(t *testing.T) {
+	available := []string{"storage-users", "web"}
+
+	instances, err := ParseInstances(available, []string{"storage-users-2=storage-users", " web-2 = web "})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"storage-users-2": "storage-users", "web-2": "web"}, instances)
+
+	for instance, expected := range map[string]string{
+		"storage-users-2":                      `invalid instance "storage-users-2", expected name=extension`,
+		"Storage_2=storage-users":              `invalid instance name "Storage_2", only lower case letters, digits and dashes are allowed`,
+		"web=storage-users":                    "instance web is named like an extension",
+		"storage-users-2=graph":                "instance storage-users-2 of unknown extension graph",
+		"storage-users-2=storage-users,-2=web": `invalid instance name "-2", only lower case letters, digits and dashes are allowed`,
+	} {
+		_, err := ParseInstances(available, strings.Split(instance, ","))
+		assert.EqualError(t, err, expected, instance)
+	}
+
+	_, err = ParseInstances(available, []string{"web-2=web", "web-2=web"})
+	assert.EqualError(t, err, "instance web-2 is given twice")
+}
+
+func TestInstanceEnv(t *testing.T) {
+	assert.Equal(t, "OCIS_INSTANCE_STORAGE_USERS_2_", InstanceEnvPrefix("storage-users-2"))
+
+	env := InstanceEnv("storage-users-2", []string{
+		"STORAGE_USERS_GRPC_ADDR=0.0.0.0:9157",
+		"OCIS_INSTANCE_STORAGE_USERS_2_STORAGE_USERS_GRPC_ADDR=0.0.0.0:9257",
+		"OCIS_INSTANCE_STORAGE_USERS_2_STORAGE_USERS_DRIVER=owncloud",
+		"OCIS_INSTANCE_WEB_2_WEB_UI_CONFIG=web.json",
+		"OCIS_LOG_LEVEL=debug",
+	})
+	assert.Equal(t, []string{
+		"OCIS_INSTANCE_STORAGE_USERS_2_STORAGE_USERS_GRPC_ADDR=0.0.0.0:9257",
+		"OCIS_INSTANCE_STORAGE_USERS_2_STORAGE_USERS_DRIVER=owncloud",
+		"OCIS_INSTANCE_WEB_2_WEB_UI_CONFIG=web.json",
+		"OCIS_LOG_LEVEL=debug",
+		"STORAGE_USERS_DRIVER=owncloud",
+		"STORAGE_USERS_GRPC_ADDR=0.0.0.0:9257",
+	}, env)
+}
+
+func TestReadyURL(t *testing.T) {
+	scenarios := map[string]string{
+		"0.0.0.0:9194":   "http://localhost:9194/readyz",
+		":9194":          "http://localhost:9194/readyz",
+		"[::]:9194":      "http://localhost:9194/readyz",
+		"127.0.0.1:9194": "http://127.0.0.1:9194/readyz",
+		"ocis:9194":      "http://ocis:9194/readyz",
+	}
+	for addr, expected := range scenarios {
+		url, err := ReadyURL(addr)
+		assert.NoError(t, err, addr)
+		assert.Equal(t, expected, url, addr)
+	}
+
+	_, err := ReadyURL("9194")
+	assert.Error(t, err)
+}
+
+func TestWaitReady(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, WaitReady(ctx, srv.URL, 10*time.Millisecond))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+func TestWaitReadyTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := WaitReady(ctx, srv.URL, 10*time.Millisecond)
+	assert.EqualError(t, err, srv.URL+" responded with 503 Service Unavailable")
+}
@@ -1,7 +1,6 @@
 package watcher
 
 import (
-	golog "log"
 	"os"
 
 	"github.com/owncloud/ocis/ocis/pkg/runtime/log"
@@ -14,6 +13,17 @@ type Watcher struct {
 	log zerolog.Logger
 }
 
+// Termination is sent by a watcher once the process it follows terminated.
+type Termination struct {
+	Entry process.ProcEntry
+
+	// State of the terminated process, nil if waiting for the process failed.
+	State *os.ProcessState
+
+	// Err is the reason why waiting for the process failed.
+	Err error
+}
+
 // NewWatcher initializes a watcher.
 func NewWatcher() Watcher {
 	return Watcher{
@@ -21,26 +31,19 @@ func NewWatcher() Watcher {
 	}
 }
 
-// Follow a process until it dies. If restart is enabled, a new fork of the original process will be automatically spawned.
-func (w *Watcher) Follow(pe process.ProcEntry, followerChan chan process.ProcEntry, restart bool) {
-	state := make(chan *os.ProcessState, 1)
-
+// Follow a process until it dies and send its termination to terminated. Deciding whether the process is restarted
+// is up to the receiver.
+func (w *Watcher) Follow(pe process.ProcEntry, terminated chan<- Termination) {
 	w.log.Debug().Str("package", "watcher").Msgf("watching %v", pe.Extension)
 	go func() {
 		ps, err := watch(pe.Pid)
 		if err != nil {
-			golog.Fatal(err)
+			w.log.Error().Err(err).Str("package", "watcher").Msgf("could not wait for %v", pe.Extension)
+		} else {
+			w.log.Info().Str("package", "watcher").Msgf("%v exited with: %v", pe.Extension, ps)
 		}
 
-		state <- ps
-	}()
-
-	go func() {
-		status := <-state
-		w.log.Info().Str("package", "watcher").Msgf("%v exited with: %v", pe.Extension, status)
-		if restart {
-			followerChan <- pe
-		}
+		terminated <- Termination{Entry: pe, State: ps, Err: err}
 	}()
 }
 
@@ -33,6 +33,7 @@ func Server(cfg *config.Config) *cli.Command {
 			if cfg.HTTP.Root != "/" {
 				cfg.HTTP.Root = strings.TrimSuffix(cfg.HTTP.Root, "/")
 			}
+			cfg.PreSignedURL.AllowedHTTPMethods = c.StringSlice("presignedurl-allow-method")
 
 			return ParseConfig(c, cfg)
 		},
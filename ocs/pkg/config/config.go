@@ -42,6 +42,11 @@ type TokenManager struct {
 	JWTSecret string
 }
 
+// PreSignedURL is the config for signing urls
+type PreSignedURL struct {
+	AllowedHTTPMethods []string
+}
+
 // Config combines all available configuration parts.
 type Config struct {
 	File         string
@@ -50,6 +55,7 @@ type Config struct {
 	HTTP         HTTP
 	Tracing      Tracing
 	TokenManager TokenManager
+	PreSignedURL PreSignedURL
 	Service      Service
 }
 
@@ -152,6 +152,12 @@ func ServerWithConfig(cfg *config.Config) []cli.Flag {
 			EnvVars:     []string{"OCS_JWT_SECRET", "OCIS_JWT_SECRET"},
 			Destination: &cfg.TokenManager.JWTSecret,
 		},
+		&cli.StringSliceFlag{
+			Name:    "presignedurl-allow-method",
+			Value:   cli.NewStringSlice("GET"),
+			Usage:   "--presignedurl-allow-method GET [--presignedurl-allow-method POST]",
+			EnvVars: []string{"PRESIGNEDURL_ALLOWED_METHODS"},
+		},
 	}
 }
 
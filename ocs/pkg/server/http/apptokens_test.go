@@ -0,0 +1,194 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	"github.com/owncloud/ocis/ocis-pkg/apptoken"
+	storepb "github.com/owncloud/ocis/store/pkg/proto/v0"
+	"github.com/stretchr/testify/assert"
+)
+
+const appTokensEndPoint = "/v2.php/cloud/user/app-tokens"
+
+var (
+	appTokenEinstein = &user.User{Id: &user.UserId{OpaqueId: userIDEinstein}, Username: userEinstein}
+	appTokenMarie    = &user.User{Id: &user.UserId{OpaqueId: userIDMarie}, Username: userMarie}
+)
+
+type AppToken struct {
+	ID         string `json:"id"`
+	Label      string `json:"label"`
+	Token      string `json:"token"`
+	CreatedAt  string `json:"created_at"`
+	ExpiresAt  string `json:"expires_at"`
+	ReadOnly   bool   `json:"read_only"`
+	PathPrefix string `json:"path_prefix"`
+}
+
+type AppTokenResponse struct {
+	Ocs struct {
+		Meta Meta     `json:"meta"`
+		Data AppToken `json:"data"`
+	} `json:"ocs"`
+}
+
+type AppTokensResponse struct {
+	Ocs struct {
+		Meta Meta `json:"meta"`
+		Data struct {
+			Tokens []AppToken `json:"tokens"`
+		} `json:"data"`
+	} `json:"ocs"`
+}
+
+func decodeResponse(t *testing.T, rr *httptest.ResponseRecorder, response interface{}) {
+	if err := json.Unmarshal(rr.Body.Bytes(), response); err != nil {
+		t.Log(rr.Body.String())
+		t.Fatal(err)
+	}
+}
+
+func createAppToken(t *testing.T, store storepb.StoreService, u *user.User, body string) AppTokenResponse {
+	req := httptest.NewRequest("POST", appTokensEndPoint+"?format=json", strings.NewReader(body))
+	var response AppTokenResponse
+	decodeResponse(t, sendStoreRequest(t, store, req, u), &response)
+	return response
+}
+
+func listAppTokens(t *testing.T, store storepb.StoreService, u *user.User) []AppToken {
+	req := httptest.NewRequest("GET", appTokensEndPoint+"?format=json", nil)
+	var response AppTokensResponse
+	decodeResponse(t, sendStoreRequest(t, store, req, u), &response)
+	assert.True(t, response.Ocs.Meta.Success(ocsV2), unsuccessfulResponseText)
+	return response.Ocs.Data.Tokens
+}
+
+func deleteAppToken(t *testing.T, store storepb.StoreService, u *user.User, id string) Meta {
+	req := httptest.NewRequest("DELETE", appTokensEndPoint+"/"+id+"?format=json", nil)
+	var response EmptyResponse
+	decodeResponse(t, sendStoreRequest(t, store, req, u), &response)
+	return response.Ocs.Meta
+}
+
+func tokenIDs(tokens []AppToken) []string {
+	ids := []string{}
+	for _, token := range tokens {
+		ids = append(ids, token.ID)
+	}
+	return ids
+}
+
+func TestCreateAppToken(t *testing.T) {
+	store := newMemoryStore()
+
+	created := createAppToken(t, store, appTokenEinstein, "label=calendar&read_only=true&path_prefix=/remote.php/dav/files/einstein/Calendars")
+	assert.True(t, created.Ocs.Meta.Success(ocsV2), unsuccessfulResponseText)
+	token := created.Ocs.Data
+	assert.Equal(t, "calendar", token.Label)
+	assert.True(t, token.ReadOnly)
+	assert.Equal(t, "/remote.php/dav/files/einstein/Calendars", token.PathPrefix)
+	assert.Empty(t, token.ExpiresAt)
+	assert.NotEmpty(t, token.Token)
+
+	// only the hash of the secret is persisted and the secret is not listed
+	rec := store.records[apptoken.Database+"/"+apptoken.Table+"/"+token.ID]
+	if assert.NotNil(t, rec) {
+		assert.Equal(t, apptoken.Hash(token.Token), rec.Metadata[apptoken.HashField].Value)
+		assert.Equal(t, userIDEinstein, rec.Metadata[apptoken.AccountIDField].Value)
+		assert.NotContains(t, string(rec.Value), token.Token)
+	}
+	listed := listAppTokens(t, store, appTokenEinstein)
+	if assert.Len(t, listed, 1) {
+		assert.Equal(t, token.ID, listed[0].ID)
+		assert.Empty(t, listed[0].Token)
+	}
+}
+
+func TestCreateAppTokenRejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		message string
+	}{
+		{"missing label", "read_only=true", "missing label"},
+		{"invalid read only", "label=sync&read_only=maybe", "invalid read_only value"},
+		{"relative path prefix", "label=sync&path_prefix=remote.php", "path_prefix must be absolute"},
+		{"expires not a number", "label=sync&expires=soon", "expires must be a positive number of seconds"},
+		{"expires in the past", "label=sync&expires=-60", "expires must be a positive number of seconds"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemoryStore()
+			response := createAppToken(t, store, appTokenEinstein, tt.body)
+			assertResponseMeta(t, Meta{
+				Status:     "error",
+				StatusCode: 400,
+				Message:    tt.message,
+			}, response.Ocs.Meta)
+			assert.Empty(t, store.records)
+		})
+	}
+}
+
+func TestListAppTokensOfOwnerOnly(t *testing.T) {
+	store := newMemoryStore()
+	einstein1 := createAppToken(t, store, appTokenEinstein, "label=calendar").Ocs.Data
+	einstein2 := createAppToken(t, store, appTokenEinstein, "label=backup").Ocs.Data
+	marie := createAppToken(t, store, appTokenMarie, "label=calendar").Ocs.Data
+
+	assert.ElementsMatch(t, []string{einstein1.ID, einstein2.ID}, tokenIDs(listAppTokens(t, store, appTokenEinstein)))
+	assert.ElementsMatch(t, []string{marie.ID}, tokenIDs(listAppTokens(t, store, appTokenMarie)))
+}
+
+func TestAppTokenExpiry(t *testing.T) {
+	store := newMemoryStore()
+	before := time.Now().Add(time.Hour).Add(-time.Second)
+	expiring := createAppToken(t, store, appTokenEinstein, "label=sync&expires=3600").Ocs.Data
+	forever := createAppToken(t, store, appTokenEinstein, "label=backup").Ocs.Data
+
+	expiresAt, err := time.Parse(time.RFC3339, expiring.ExpiresAt)
+	if assert.NoError(t, err) {
+		assert.True(t, expiresAt.After(before), "the token should expire in an hour, not at %s", expiring.ExpiresAt)
+		// the store removes the record once the token expired
+		assert.Equal(t, expiresAt.Unix(), store.expiries[apptoken.Database+"/"+apptoken.Table+"/"+expiring.ID])
+	}
+	assert.Zero(t, store.expiries[apptoken.Database+"/"+apptoken.Table+"/"+forever.ID])
+
+	listed := listAppTokens(t, store, appTokenEinstein)
+	assert.ElementsMatch(t, []string{expiring.ID, forever.ID}, tokenIDs(listed))
+	for _, token := range listed {
+		if token.ID == expiring.ID {
+			assert.Equal(t, expiring.ExpiresAt, token.ExpiresAt)
+		}
+	}
+
+	// once the record expired the token is not listed anymore
+	store.expiries[apptoken.Database+"/"+apptoken.Table+"/"+expiring.ID] = time.Now().Add(-time.Second).Unix()
+	assert.Equal(t, []string{forever.ID}, tokenIDs(listAppTokens(t, store, appTokenEinstein)))
+}
+
+func TestDeleteAppToken(t *testing.T) {
+	store := newMemoryStore()
+	einstein := createAppToken(t, store, appTokenEinstein, "label=calendar").Ocs.Data
+	marie := createAppToken(t, store, appTokenMarie, "label=calendar").Ocs.Data
+
+	notFound := Meta{Status: "error", StatusCode: 998, Message: "The requested app token could not be found"}
+
+	// the token of another user looks like one that does not exist
+	assertResponseMeta(t, notFound, deleteAppToken(t, store, appTokenMarie, einstein.ID))
+	assertResponseMeta(t, notFound, deleteAppToken(t, store, appTokenMarie, "unknown"))
+	assert.Equal(t, []string{einstein.ID}, tokenIDs(listAppTokens(t, store, appTokenEinstein)))
+
+	meta := deleteAppToken(t, store, appTokenEinstein, einstein.ID)
+	assert.True(t, meta.Success(ocsV2), unsuccessfulResponseText)
+	assert.Empty(t, listAppTokens(t, store, appTokenEinstein))
+	assert.Equal(t, []string{marie.ID}, tokenIDs(listAppTokens(t, store, appTokenMarie)))
+
+	assertResponseMeta(t, notFound, deleteAppToken(t, store, appTokenEinstein, einstein.ID))
+}
@@ -0,0 +1,136 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	"github.com/owncloud/ocis/ocis-pkg/signedurl"
+	storepb "github.com/owncloud/ocis/store/pkg/proto/v0"
+	"github.com/stretchr/testify/assert"
+)
+
+type SignedURLResponse struct {
+	Ocs struct {
+		Meta Meta `json:"meta"`
+		Data struct {
+			URL       string `json:"url"`
+			Verb      string `json:"verb"`
+			ExpiresAt string `json:"expires_at"`
+		} `json:"data"`
+	} `json:"ocs"`
+}
+
+func sendSignedURLRequest(t *testing.T, store storepb.StoreService, body string, header http.Header) SignedURLResponse {
+	req := httptest.NewRequest("POST", "/v2.php/cloud/user/signed-url?format=json", strings.NewReader(body))
+	req.Host = "cloud.example.com"
+	for k := range header {
+		req.Header.Set(k, header.Get(k))
+	}
+	rr := sendStoreRequest(t, store, req, &user.User{Id: &user.UserId{OpaqueId: userIDEinstein}, Username: userEinstein})
+
+	var response SignedURLResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Log(rr.Body.String())
+		t.Fatal(err)
+	}
+	return response
+}
+
+func TestCreateSignedURL(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		forwardedProto string
+		scheme         string
+		verb           string
+	}{
+		{"default verb", "path=/remote.php/dav/files/einstein/a.txt", "", "https", "GET"},
+		{"allowed verb", "path=/remote.php/dav/files/einstein/a.txt&verb=propfind", "https", "https", "PROPFIND"},
+		{"forwarded scheme", "path=/remote.php/dav/files/einstein/a.txt&expires=60", "http", "http", "GET"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemoryStore()
+			header := http.Header{}
+			if tt.forwardedProto != "" {
+				header.Set("X-Forwarded-Proto", tt.forwardedProto)
+			}
+
+			response := sendSignedURLRequest(t, store, tt.body, header)
+			assert.True(t, response.Ocs.Meta.Success(ocsV2), unsuccessfulResponseText)
+			assert.Equal(t, tt.verb, response.Ocs.Data.Verb)
+
+			u, err := url.Parse(response.Ocs.Data.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tt.scheme, u.Scheme)
+			assert.Equal(t, "cloud.example.com", u.Host)
+			assert.Equal(t, "/remote.php/dav/files/einstein/a.txt", u.Path)
+
+			q := u.Query()
+			assert.Equal(t, userEinstein, q.Get("OC-Credential"))
+			assert.Equal(t, tt.verb, q.Get("OC-Verb"))
+
+			// the url is signed with the key the service created for the user
+			res, err := store.Read(context.Background(), &storepb.ReadRequest{
+				Options: &storepb.ReadOptions{Database: signedurl.Database, Table: signedurl.Table},
+				Key:     userIDEinstein,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			signature := q.Get("OC-Signature")
+			q.Del("OC-Signature")
+			u.RawQuery = q.Encode()
+			assert.Equal(t, signedurl.Signature(u.String(), res.Records[0].Value), signature)
+		})
+	}
+}
+
+func TestCreateSignedURLKeepsSigningKey(t *testing.T) {
+	store := newMemoryStore()
+	first := sendSignedURLRequest(t, store, "path=/a.txt", nil)
+	second := sendSignedURLRequest(t, store, "path=/a.txt", nil)
+	assert.True(t, first.Ocs.Meta.Success(ocsV2), unsuccessfulResponseText)
+	assert.True(t, second.Ocs.Meta.Success(ocsV2), unsuccessfulResponseText)
+	assert.Len(t, store.records, 1)
+}
+
+func TestCreateSignedURLRejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		message string
+	}{
+		{"missing path", "", "path must be absolute"},
+		{"relative path", "path=remote.php/dav/files/einstein/a.txt", "path must be absolute"},
+		{"absolute url", "path=//evil.example.com/a.txt", "invalid path"},
+		{"invalid verb", "path=/a.txt&verb=GE%0AT", "invalid verb"},
+		{"disallowed verb", "path=/a.txt&verb=DELETE", "verb DELETE is not allowed for signed urls"},
+		{"expires not a number", "path=/a.txt&expires=soon", "expires must be between 1 and 604800 seconds"},
+		{"expires too short", "path=/a.txt&expires=0", "expires must be between 1 and 604800 seconds"},
+		{"expires too long", "path=/a.txt&expires=604801", "expires must be between 1 and 604800 seconds"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemoryStore()
+			response := sendSignedURLRequest(t, store, tt.body, nil)
+			assertResponseMeta(t, Meta{
+				Status:     "error",
+				StatusCode: 400,
+				Message:    tt.message,
+			}, response.Ocs.Meta)
+			assert.Empty(t, response.Ocs.Data.URL)
+			assert.Empty(t, store.records, "no signing key must be created for rejected requests")
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	"github.com/micro/go-micro/v2/client"
+	merrors "github.com/micro/go-micro/v2/errors"
+	ocisLog "github.com/owncloud/ocis/ocis-pkg/log"
+	"github.com/owncloud/ocis/ocs/pkg/config"
+	svc "github.com/owncloud/ocis/ocs/pkg/service/v0"
+	storepb "github.com/owncloud/ocis/store/pkg/proto/v0"
+)
+
+// memoryStore implements the store calls the ocs service uses in memory, all other calls panic. Like the store
+// service it does not return expired records.
+type memoryStore struct {
+	storepb.StoreService
+	mu       sync.Mutex
+	records  map[string]*storepb.Record
+	expiries map[string]int64
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{records: map[string]*storepb.Record{}, expiries: map[string]int64{}}
+}
+
+// sendStoreRequest sends the request as the given user to an ocs service that uses the store.
+func sendStoreRequest(t *testing.T, store storepb.StoreService, req *http.Request, u *user.User) *httptest.ResponseRecorder {
+	token, err := tokenManager.MintToken(context.Background(), u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("x-access-token", token)
+
+	var logger ocisLog.Logger
+	s := svc.NewService(
+		svc.Logger(logger),
+		svc.Config(&config.Config{
+			HTTP:         config.HTTP{Root: "/"},
+			TokenManager: config.TokenManager{JWTSecret: jwtSecret},
+			PreSignedURL: config.PreSignedURL{AllowedHTTPMethods: []string{"GET", "PROPFIND"}},
+		}),
+		svc.RoleService(buildRoleServiceMock()),
+		svc.StoreService(store),
+	)
+	rr := httptest.NewRecorder()
+	s.ServeHTTP(rr, req)
+	return rr
+}
+
+func (s *memoryStore) id(database, table, key string) string {
+	return database + "/" + table + "/" + key
+}
+
+func (s *memoryStore) expired(id string) bool {
+	expiry := s.expiries[id]
+	return expiry != 0 && expiry <= time.Now().Unix()
+}
+
+func (s *memoryStore) Read(ctx context.Context, in *storepb.ReadRequest, opts ...client.CallOption) (*storepb.ReadResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if in.Key != "" {
+		id := s.id(in.Options.Database, in.Options.Table, in.Key)
+		r, ok := s.records[id]
+		if !ok || s.expired(id) {
+			return nil, merrors.NotFound("com.owncloud.api.store", "record %s not found", in.Key)
+		}
+		return &storepb.ReadResponse{Records: []*storepb.Record{r}}, nil
+	}
+
+	res := &storepb.ReadResponse{}
+	prefix := s.id(in.Options.Database, in.Options.Table, "")
+	for id, r := range s.records {
+		if !strings.HasPrefix(id, prefix) || s.expired(id) {
+			continue
+		}
+		match := true
+		for k, v := range in.Options.Where {
+			if r.Metadata[k].GetValue() != v.Value {
+				match = false
+			}
+		}
+		if match {
+			res.Records = append(res.Records, r)
+		}
+	}
+	return res, nil
+}
+
+func (s *memoryStore) Write(ctx context.Context, in *storepb.WriteRequest, opts ...client.CallOption) (*storepb.WriteResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.id(in.Options.Database, in.Options.Table, in.Record.Key)
+	if _, ok := s.records[id]; ok && in.Options.IfNotExists {
+		return nil, merrors.Conflict("com.owncloud.api.store", "record %s already exists", in.Record.Key)
+	}
+	s.records[id] = in.Record
+	s.expiries[id] = in.Options.Expiry
+	return &storepb.WriteResponse{}, nil
+}
+
+func (s *memoryStore) Delete(ctx context.Context, in *storepb.DeleteRequest, opts ...client.CallOption) (*storepb.DeleteResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.id(in.Options.Database, in.Options.Table, in.Key)
+	if _, ok := s.records[id]; !ok {
+		return nil, merrors.NotFound("com.owncloud.api.store", "record %s not found", in.Key)
+	}
+	delete(s.records, id)
+	delete(s.expiries, id)
+	return &storepb.DeleteResponse{}, nil
+}
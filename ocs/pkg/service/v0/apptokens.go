@@ -0,0 +1,192 @@
+package svc
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cs3org/reva/pkg/user"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	merrors "github.com/micro/go-micro/v2/errors"
+	"github.com/owncloud/ocis/ocis-pkg/apptoken"
+	"github.com/owncloud/ocis/ocs/pkg/service/v0/data"
+	"github.com/owncloud/ocis/ocs/pkg/service/v0/response"
+	storepb "github.com/owncloud/ocis/store/pkg/proto/v0"
+)
+
+// ListAppTokens lists the app tokens of the current user
+func (o Ocs) ListAppTokens(w http.ResponseWriter, r *http.Request) {
+	u, _ := user.ContextGetUser(r.Context())
+
+	res, err := o.getStoreService().Read(r.Context(), &storepb.ReadRequest{
+		Options: &storepb.ReadOptions{
+			Database: apptoken.Database,
+			Table:    apptoken.Table,
+			Where: map[string]*storepb.Field{
+				apptoken.AccountIDField: {Type: "string", Value: u.Id.OpaqueId},
+			},
+		},
+	})
+	if err != nil {
+		o.logger.Error().Err(err).Str("userid", u.Id.OpaqueId).Msg("could not list app tokens")
+		render.Render(w, r, response.ErrRender(data.MetaServerError.StatusCode, "could not list app tokens"))
+		return
+	}
+
+	tokens := make([]*data.AppToken, 0, len(res.Records))
+	for _, rec := range res.Records {
+		t, err := apptoken.Unmarshal(rec.Value)
+		if err != nil {
+			o.logger.Error().Err(err).Str("token", rec.Key).Msg("could not unmarshal app token")
+			continue
+		}
+		tokens = append(tokens, appTokenData(t))
+	}
+
+	render.Render(w, r, response.DataRender(&data.AppTokens{Tokens: tokens}))
+}
+
+// CreateAppToken creates an app token for the current user. The secret is only contained in this response.
+func (o Ocs) CreateAppToken(w http.ResponseWriter, r *http.Request) {
+	u, _ := user.ContextGetUser(r.Context())
+
+	label := strings.TrimSpace(r.PostFormValue("label"))
+	if label == "" {
+		render.Render(w, r, response.ErrRender(data.MetaBadRequest.StatusCode, "missing label"))
+		return
+	}
+
+	scope := apptoken.Scope{
+		PathPrefix: r.PostFormValue("path_prefix"),
+	}
+	if ro := r.PostFormValue("read_only"); ro != "" {
+		var err error
+		if scope.ReadOnly, err = strconv.ParseBool(ro); err != nil {
+			render.Render(w, r, response.ErrRender(data.MetaBadRequest.StatusCode, "invalid read_only value"))
+			return
+		}
+	}
+	if scope.PathPrefix != "" && !strings.HasPrefix(scope.PathPrefix, "/") {
+		render.Render(w, r, response.ErrRender(data.MetaBadRequest.StatusCode, "path_prefix must be absolute"))
+		return
+	}
+
+	var expires time.Duration
+	if e := r.PostFormValue("expires"); e != "" {
+		seconds, err := strconv.ParseInt(e, 10, 64)
+		if err != nil || seconds < 1 {
+			render.Render(w, r, response.ErrRender(data.MetaBadRequest.StatusCode, "expires must be a positive number of seconds"))
+			return
+		}
+		expires = time.Duration(seconds) * time.Second
+	}
+
+	t, secret, err := apptoken.New(u.Id.OpaqueId, label, scope)
+	if err != nil {
+		o.logger.Error().Err(err).Msg("could not generate app token")
+		render.Render(w, r, response.ErrRender(data.MetaServerError.StatusCode, "could not generate app token"))
+		return
+	}
+	if expires > 0 {
+		t.ExpiresAt = t.CreatedAt.Add(expires)
+	}
+
+	value, err := t.Marshal()
+	if err != nil {
+		o.logger.Error().Err(err).Msg("could not marshal app token")
+		render.Render(w, r, response.ErrRender(data.MetaServerError.StatusCode, "could not generate app token"))
+		return
+	}
+
+	_, err = o.getStoreService().Write(r.Context(), &storepb.WriteRequest{
+		Options: &storepb.WriteOptions{
+			Database: apptoken.Database,
+			Table:    apptoken.Table,
+			Expiry:   t.StoreExpiry(),
+		},
+		Record: &storepb.Record{
+			Key:   t.ID,
+			Value: value,
+			Metadata: map[string]*storepb.Field{
+				apptoken.AccountIDField: {Type: "string", Value: t.AccountID},
+				apptoken.HashField:      {Type: "string", Value: t.Hash},
+			},
+		},
+	})
+	if err != nil {
+		o.logger.Error().Err(err).Str("userid", u.Id.OpaqueId).Msg("could not persist app token")
+		render.Render(w, r, response.ErrRender(data.MetaServerError.StatusCode, "could not persist app token"))
+		return
+	}
+
+	o.logger.Debug().Str("userid", u.Id.OpaqueId).Str("token", t.ID).Msg("created app token")
+	d := appTokenData(t)
+	d.Token = secret
+	render.Render(w, r, response.DataRender(d))
+}
+
+// DeleteAppToken revokes an app token of the current user
+func (o Ocs) DeleteAppToken(w http.ResponseWriter, r *http.Request) {
+	u, _ := user.ContextGetUser(r.Context())
+	id := chi.URLParam(r, "tokenid")
+
+	c := o.getStoreService()
+	res, err := c.Read(r.Context(), &storepb.ReadRequest{
+		Options: &storepb.ReadOptions{
+			Database: apptoken.Database,
+			Table:    apptoken.Table,
+		},
+		Key: id,
+	})
+	if err != nil || len(res.Records) < 1 {
+		if err == nil || merrors.Parse(err.Error()).Code == http.StatusNotFound {
+			render.Render(w, r, response.ErrRender(data.MetaNotFound.StatusCode, "The requested app token could not be found"))
+			return
+		}
+		o.logger.Error().Err(err).Str("token", id).Msg("could not read app token")
+		render.Render(w, r, response.ErrRender(data.MetaServerError.StatusCode, "could not read app token"))
+		return
+	}
+
+	t, err := apptoken.Unmarshal(res.Records[0].Value)
+	if err != nil || t.AccountID != u.Id.OpaqueId {
+		// do not tell other users which token ids exist
+		render.Render(w, r, response.ErrRender(data.MetaNotFound.StatusCode, "The requested app token could not be found"))
+		return
+	}
+
+	_, err = c.Delete(r.Context(), &storepb.DeleteRequest{
+		Options: &storepb.DeleteOptions{
+			Database: apptoken.Database,
+			Table:    apptoken.Table,
+		},
+		Key: id,
+	})
+	if err != nil {
+		o.logger.Error().Err(err).Str("token", id).Msg("could not delete app token")
+		render.Render(w, r, response.ErrRender(data.MetaServerError.StatusCode, "could not delete app token"))
+		return
+	}
+
+	o.logger.Debug().Str("userid", u.Id.OpaqueId).Str("token", id).Msg("revoked app token")
+	render.Render(w, r, response.DataRender(struct{}{}))
+}
+
+func appTokenData(t *apptoken.Token) *data.AppToken {
+	d := &data.AppToken{
+		ID:         t.ID,
+		Label:      t.Label,
+		CreatedAt:  t.CreatedAt.Format(time.RFC3339),
+		ReadOnly:   t.Scope.ReadOnly,
+		PathPrefix: t.Scope.PathPrefix,
+	}
+	if !t.LastUsedAt.IsZero() {
+		d.LastUsedAt = t.LastUsedAt.Format(time.RFC3339)
+	}
+	if !t.ExpiresAt.IsZero() {
+		d.ExpiresAt = t.ExpiresAt.Format(time.RFC3339)
+	}
+	return d
+}
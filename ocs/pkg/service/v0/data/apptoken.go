@@ -0,0 +1,18 @@
+package data
+
+// AppToken holds the payload for app token responses. The secret is only returned when the token is created.
+type AppToken struct {
+	ID         string `json:"id" xml:"id"`
+	Label      string `json:"label" xml:"label"`
+	Token      string `json:"token,omitempty" xml:"token,omitempty"`
+	CreatedAt  string `json:"created_at" xml:"created_at"`
+	LastUsedAt string `json:"last_used_at,omitempty" xml:"last_used_at,omitempty"`
+	ExpiresAt  string `json:"expires_at,omitempty" xml:"expires_at,omitempty"`
+	ReadOnly   bool   `json:"read_only" xml:"read_only"`
+	PathPrefix string `json:"path_prefix,omitempty" xml:"path_prefix,omitempty"`
+}
+
+// AppTokens holds the payload for a ListAppTokens response
+type AppTokens struct {
+	Tokens []*AppToken `json:"tokens" xml:"tokens>element"`
+}
@@ -31,3 +31,10 @@ type SigningKey struct {
 	User       string `json:"user" xml:"user"`
 	SigningKey string `json:"signing-key" xml:"signing-key"`
 }
+
+// SignedURL holds the Payload for a CreateSignedURL response
+type SignedURL struct {
+	URL       string `json:"url" xml:"url"`
+	Verb      string `json:"verb" xml:"verb"`
+	ExpiresAt string `json:"expires_at" xml:"expires_at"`
+}
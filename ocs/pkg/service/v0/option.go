@@ -7,6 +7,7 @@ import (
 	"github.com/owncloud/ocis/ocis-pkg/roles"
 	"github.com/owncloud/ocis/ocs/pkg/config"
 	settings "github.com/owncloud/ocis/settings/pkg/proto/v0"
+	storepb "github.com/owncloud/ocis/store/pkg/proto/v0"
 )
 
 // Option defines a single option function.
@@ -14,11 +15,12 @@ type Option func(o *Options)
 
 // Options defines the available options for this package.
 type Options struct {
-	Logger      log.Logger
-	Config      *config.Config
-	Middleware  []func(http.Handler) http.Handler
-	RoleService settings.RoleService
-	RoleManager *roles.Manager
+	Logger       log.Logger
+	Config       *config.Config
+	Middleware   []func(http.Handler) http.Handler
+	RoleService  settings.RoleService
+	RoleManager  *roles.Manager
+	StoreService storepb.StoreService
 }
 
 // newOptions initializes the available default options.
@@ -66,3 +68,10 @@ func RoleManager(val *roles.Manager) Option {
 		o.RoleManager = val
 	}
 }
+
+// StoreService provides a function to set the StoreService option.
+func StoreService(val storepb.StoreService) Option {
+	return func(o *Options) {
+		o.StoreService = val
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/owncloud/ocis/ocs/pkg/service/v0/data"
 	"github.com/owncloud/ocis/ocs/pkg/service/v0/response"
 	settings "github.com/owncloud/ocis/settings/pkg/proto/v0"
+	storepb "github.com/owncloud/ocis/store/pkg/proto/v0"
 )
 
 var defaultClient = grpc.NewClient()
@@ -53,10 +54,11 @@ func NewService(opts ...Option) Service {
 	}
 
 	svc := Ocs{
-		config:      options.Config,
-		mux:         m,
-		RoleManager: roleManager,
-		logger:      options.Logger,
+		config:       options.Config,
+		mux:          m,
+		RoleManager:  roleManager,
+		logger:       options.Logger,
+		storeService: options.StoreService,
 	}
 
 	requireUser := ocsm.RequireUser()
@@ -87,6 +89,13 @@ func NewService(opts ...Option) Service {
 				r.Route("/user", func(r chi.Router) {
 					r.With(requireSelfOrAdmin).Get("/", svc.GetSelf)
 					r.Get("/signing-key", svc.GetSigningKey)
+					r.With(requireUser).Put("/signing-key", svc.RotateSigningKey)
+					r.With(requireUser).Post("/signed-url", svc.CreateSignedURL)
+					r.Route("/app-tokens", func(r chi.Router) {
+						r.With(requireUser).Get("/", svc.ListAppTokens)
+						r.With(requireUser).Post("/", svc.CreateAppToken)
+						r.With(requireUser).Delete("/{tokenid}", svc.DeleteAppToken)
+					})
 				})
 
 				// for /users endpoints see https://github.com/owncloud/core/blob/master/apps/provisioning_api/appinfo/routes.php#L44-L56
@@ -134,11 +143,12 @@ func NewService(opts ...Option) Service {
 
 // Ocs defines implements the business logic for Service.
 type Ocs struct {
-	config      *config.Config
-	logger      log.Logger
-	RoleService settings.RoleService
-	RoleManager *roles.Manager
-	mux         *chi.Mux
+	config       *config.Config
+	logger       log.Logger
+	RoleService  settings.RoleService
+	RoleManager  *roles.Manager
+	mux          *chi.Mux
+	storeService storepb.StoreService
 }
 
 // ServeHTTP implements the Service interface.
@@ -159,6 +169,13 @@ func (o Ocs) getGroupsService() accounts.GroupsService {
 	return accounts.NewGroupsService("com.owncloud.api.accounts", defaultClient)
 }
 
+func (o Ocs) getStoreService() storepb.StoreService {
+	if o.storeService != nil {
+		return o.storeService
+	}
+	return storepb.NewStoreService("com.owncloud.api.store", defaultClient)
+}
+
 // NotImplementedStub returns a not implemented error
 func (o Ocs) NotImplementedStub(w http.ResponseWriter, r *http.Request) {
 	render.Render(w, r, response.ErrRender(data.MetaUnknownError.StatusCode, "Not implemented"))
@@ -0,0 +1,186 @@
+package svc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cs3org/reva/pkg/user"
+	"github.com/go-chi/render"
+	merrors "github.com/micro/go-micro/v2/errors"
+	"github.com/owncloud/ocis/ocis-pkg/signedurl"
+	"github.com/owncloud/ocis/ocs/pkg/service/v0/data"
+	"github.com/owncloud/ocis/ocs/pkg/service/v0/response"
+	storepb "github.com/owncloud/ocis/store/pkg/proto/v0"
+)
+
+// RotateSigningKey replaces the signing key of the current user with a new one. All urls signed with the old key
+// become invalid.
+func (o Ocs) RotateSigningKey(w http.ResponseWriter, r *http.Request) {
+	u, _ := user.ContextGetUser(r.Context())
+	userID := u.Id.OpaqueId
+
+	signingKey, err := o.createSigningKey(r.Context(), userID)
+	if err != nil {
+		o.logger.Error().Err(err).Str("userid", userID).Msg("could not rotate signing key")
+		render.Render(w, r, response.ErrRender(data.MetaServerError.StatusCode, err.Error()))
+		return
+	}
+
+	o.logger.Debug().Str("userid", userID).Msg("rotated signing key")
+	render.Render(w, r, response.DataRender(&data.SigningKey{
+		User:       userID,
+		SigningKey: string(signingKey),
+	}))
+}
+
+// CreateSignedURL returns a url for the given path and verb signed with the signing key of the current user
+func (o Ocs) CreateSignedURL(w http.ResponseWriter, r *http.Request) {
+	u, _ := user.ContextGetUser(r.Context())
+
+	path := r.PostFormValue("path")
+	if !strings.HasPrefix(path, "/") {
+		render.Render(w, r, response.ErrRender(data.MetaBadRequest.StatusCode, "path must be absolute"))
+		return
+	}
+	target, err := url.Parse(path)
+	if err != nil || target.IsAbs() || target.Host != "" {
+		render.Render(w, r, response.ErrRender(data.MetaBadRequest.StatusCode, "invalid path"))
+		return
+	}
+
+	verb := strings.ToUpper(r.PostFormValue("verb"))
+	if verb == "" {
+		verb = http.MethodGet
+	}
+	if strings.IndexFunc(verb, func(c rune) bool { return c < 'A' || c > 'Z' }) != -1 {
+		render.Render(w, r, response.ErrRender(data.MetaBadRequest.StatusCode, "invalid verb"))
+		return
+	}
+	if !o.verbAllowed(verb) {
+		render.Render(w, r, response.ErrRender(data.MetaBadRequest.StatusCode, "verb "+verb+" is not allowed for signed urls"))
+		return
+	}
+
+	expires := time.Hour
+	if e := r.PostFormValue("expires"); e != "" {
+		seconds, err := strconv.ParseInt(e, 10, 64)
+		expires = time.Duration(seconds) * time.Second
+		if err != nil || expires < time.Second || expires > signedurl.MaxExpiry {
+			render.Render(w, r, response.ErrRender(data.MetaBadRequest.StatusCode, "expires must be between 1 and 604800 seconds"))
+			return
+		}
+	}
+
+	signingKey, err := o.getOrCreateSigningKey(r.Context(), u.Id.OpaqueId)
+	if err != nil {
+		o.logger.Error().Err(err).Str("userid", u.Id.OpaqueId).Msg("could not get signing key")
+		render.Render(w, r, response.ErrRender(data.MetaServerError.StatusCode, err.Error()))
+		return
+	}
+
+	// the proxy verifies the signature against the url the client requested, it replaces the forwarded scheme
+	target.Scheme = signedurl.ForwardedScheme(r)
+	target.Host = r.Host
+	now := time.Now()
+	signed := signedurl.Sign(target, u.Username, verb, now, expires, signingKey)
+
+	render.Render(w, r, response.DataRender(&data.SignedURL{
+		URL:       signed.String(),
+		Verb:      verb,
+		ExpiresAt: now.Add(expires).UTC().Format(time.RFC3339),
+	}))
+}
+
+// verbAllowed checks if the proxy accepts urls signed for the verb.
+func (o Ocs) verbAllowed(verb string) bool {
+	for _, m := range o.config.PreSignedURL.AllowedHTTPMethods {
+		if strings.EqualFold(m, verb) {
+			return true
+		}
+	}
+	return false
+}
+
+// getOrCreateSigningKey reads the signing key of the user and creates one if there is none yet.
+func (o Ocs) getOrCreateSigningKey(ctx context.Context, userID string) ([]byte, error) {
+	signingKey, err := o.readSigningKey(ctx, userID)
+	if err != nil || signingKey != nil {
+		return signingKey, err
+	}
+
+	signingKey, err = o.writeSigningKey(ctx, userID, true)
+	if err == errSigningKeyExists {
+		// another request created the first key in the meantime, only the stored one is valid
+		signingKey, err = o.readSigningKey(ctx, userID)
+		if err == nil && signingKey == nil {
+			return nil, errors.New("error reading from store")
+		}
+	}
+	return signingKey, err
+}
+
+// readSigningKey returns the signing key of the user or nil if there is none yet.
+func (o Ocs) readSigningKey(ctx context.Context, userID string) ([]byte, error) {
+	res, err := o.getStoreService().Read(ctx, &storepb.ReadRequest{
+		Options: &storepb.ReadOptions{
+			Database: signedurl.Database,
+			Table:    signedurl.Table,
+		},
+		Key: userID,
+	})
+	if err != nil {
+		if merrors.Parse(err.Error()).Code == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, errors.New("error reading from store")
+	}
+	if len(res.Records) == 0 {
+		return nil, nil
+	}
+	return res.Records[0].Value, nil
+}
+
+// createSigningKey generates a new signing key for the user and replaces the existing one.
+func (o Ocs) createSigningKey(ctx context.Context, userID string) ([]byte, error) {
+	return o.writeSigningKey(ctx, userID, false)
+}
+
+// errSigningKeyExists is returned by writeSigningKey if only a missing key may be created and the user already has one.
+var errSigningKeyExists = errors.New("signing key already exists")
+
+// writeSigningKey generates and persists a new signing key for the user. If ifNotExists is set an existing key is kept
+// and errSigningKeyExists is returned.
+func (o Ocs) writeSigningKey(ctx context.Context, userID string, ifNotExists bool) ([]byte, error) {
+	key := make([]byte, 64)
+	if _, err := rand.Read(key[:]); err != nil {
+		return nil, errors.New("could not generate signing key")
+	}
+	signingKey := []byte(hex.EncodeToString(key))
+
+	_, err := o.getStoreService().Write(ctx, &storepb.WriteRequest{
+		Options: &storepb.WriteOptions{
+			Database:    signedurl.Database,
+			Table:       signedurl.Table,
+			IfNotExists: ifNotExists,
+		},
+		Record: &storepb.Record{
+			Key:   userID,
+			Value: signingKey,
+		},
+	})
+	if err != nil {
+		if ifNotExists && merrors.FromError(err).Code == http.StatusConflict {
+			return nil, errSigningKeyExists
+		}
+		return nil, errors.New("could not persist signing key")
+	}
+
+	return signingKey, nil
+}
@@ -10,6 +10,7 @@ require (
 	github.com/cs3org/go-cs3apis v0.0.0-20210104105209-0d3ecb3453dc
 	github.com/cs3org/reva v1.5.2-0.20210212085611-d8aa2eb3ec9c
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible
+	github.com/go-chi/chi v4.1.2+incompatible
 	github.com/justinas/alice v1.2.0
 	github.com/micro/cli/v2 v2.1.2
 	github.com/micro/go-micro/v2 v2.9.1
@@ -31,6 +32,7 @@ require (
 	golang.org/x/crypto v0.0.0-20201203163018-be400aefbc4c
 	golang.org/x/oauth2 v0.0.0-20200107190931-bf48bf16ab8d
 	google.golang.org/grpc v1.35.0
+	gopkg.in/natefinch/lumberjack.v2 v2.0.0
 )
 
 replace (
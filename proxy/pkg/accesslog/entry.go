@@ -0,0 +1,75 @@
+package accesslog
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Entry collects everything that is known about a single proxied request. It is created by the access log
+// middleware and filled by the middlewares and the reverse proxy while the request passes through them.
+type Entry struct {
+	mu sync.Mutex
+
+	RequestID  string
+	RemoteAddr string
+	Method     string
+	URI        string
+	Proto      string
+	Referer    string
+	UserAgent  string
+	Start      time.Time
+
+	UserID   string
+	Username string
+
+	Policy          string
+	RouteType       string
+	Route           string
+	Upstream        string
+	UpstreamLatency time.Duration
+
+	Status   int
+	BytesIn  int64
+	BytesOut int64
+	Duration time.Duration
+}
+
+// SetUser records the resolved user.
+func (e *Entry) SetUser(id, username string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.UserID = id
+	e.Username = username
+}
+
+// SetRoute records the selected policy and the matched route.
+func (e *Entry) SetRoute(policy, routeType, route string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.Policy = policy
+	e.RouteType = routeType
+	e.Route = route
+}
+
+// SetUpstream records the upstream backend and how long it took to answer.
+func (e *Entry) SetUpstream(host string, latency time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.Upstream = host
+	e.UpstreamLatency = latency
+}
+
+// contextKey is the key for the access log entry in a context
+type contextKey struct{}
+
+// NewContext makes a new context that contains the access log entry.
+func NewContext(parent context.Context, e *Entry) context.Context {
+	return context.WithValue(parent, contextKey{}, e)
+}
+
+// FromContext returns the Entry stored in a context, or nil if there isn't one.
+func FromContext(ctx context.Context) *Entry {
+	e, _ := ctx.Value(contextKey{}).(*Entry)
+	return e
+}
@@ -0,0 +1,121 @@
+package accesslog
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// FormatCombined is the Apache combined log format followed by the oCIS specific fields as key="value" pairs.
+	FormatCombined = "combined"
+	// FormatJSON writes one JSON object per request.
+	FormatJSON = "json"
+)
+
+// Formatter writes a single access log line for an Entry.
+type Formatter func(w io.Writer, e *Entry) error
+
+// NewFormatter returns the formatter for the given format name.
+func NewFormatter(format string) (Formatter, error) {
+	switch format {
+	case FormatCombined, "":
+		return combined, nil
+	case FormatJSON:
+		return jsonLine, nil
+	default:
+		return nil, fmt.Errorf("unknown access log format '%s'", format)
+	}
+}
+
+// combined renders e.g.
+// 10.0.0.1 - einstein [10/Oct/2020:13:55:36 +0000] "GET /ocs/v1.php HTTP/1.1" 200 2326 "-" "curl/7.64.0" request_id="..." ...
+func combined(w io.Writer, e *Entry) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s - %s [%s] \"%s %s %s\" %d %s %s %s",
+		orDash(remoteHost(e.RemoteAddr)),
+		orDash(e.Username),
+		e.Start.Format("02/Jan/2006:15:04:05 -0700"),
+		e.Method, e.URI, e.Proto,
+		e.Status,
+		bytesOrDash(e.BytesOut),
+		strconv.Quote(orDash(e.Referer)),
+		strconv.Quote(orDash(e.UserAgent)),
+	)
+	fmt.Fprintf(&b, " request_id=%s user_id=%s policy=%s route_type=%s route=%s upstream=%s upstream_latency=%.6f bytes_in=%d duration=%.6f\n",
+		strconv.Quote(e.RequestID),
+		strconv.Quote(e.UserID),
+		strconv.Quote(e.Policy),
+		strconv.Quote(e.RouteType),
+		strconv.Quote(e.Route),
+		strconv.Quote(e.Upstream),
+		e.UpstreamLatency.Seconds(),
+		e.BytesIn,
+		e.Duration.Seconds(),
+	)
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+func jsonLine(w io.Writer, e *Entry) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var b bytes.Buffer
+	l := zerolog.New(&b)
+	l.Log().
+		Time("time", e.Start).
+		Str("request_id", e.RequestID).
+		Str("remote_addr", e.RemoteAddr).
+		Str("user_id", e.UserID).
+		Str("username", e.Username).
+		Str("method", e.Method).
+		Str("uri", e.URI).
+		Str("proto", e.Proto).
+		Int("status", e.Status).
+		Int64("bytes_in", e.BytesIn).
+		Int64("bytes_out", e.BytesOut).
+		Str("referer", e.Referer).
+		Str("user_agent", e.UserAgent).
+		Str("policy", e.Policy).
+		Str("route_type", e.RouteType).
+		Str("route", e.Route).
+		Str("upstream", e.Upstream).
+		Float64("upstream_latency", e.UpstreamLatency.Seconds()).
+		Float64("duration", e.Duration.Seconds()).
+		Send()
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// remoteHost strips the port from the remote address, log parsers expect only the host in the combined format.
+func remoteHost(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func bytesOrDash(n int64) string {
+	if n == 0 {
+		return "-"
+	}
+	return strconv.FormatInt(n, 10)
+}
@@ -0,0 +1,105 @@
+package accesslog
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testEntry() *Entry {
+	return &Entry{
+		RequestID:       "rid-1",
+		RemoteAddr:      "10.0.0.1:53211",
+		Method:          "GET",
+		URI:             "/ocs/v1.php/cloud/user",
+		Proto:           "HTTP/1.1",
+		UserAgent:       "curl/7.64.0",
+		Start:           time.Date(2020, 10, 10, 13, 55, 36, 0, time.UTC),
+		UserID:          "4c510ada-c86b-4815-8820-42cdf82c3d51",
+		Username:        "einstein",
+		Policy:          "ocis",
+		RouteType:       "regex",
+		Route:           "/ocs/v[12].php/cloud/(users?|groups)",
+		Upstream:        "localhost:9110",
+		UpstreamLatency: 12 * time.Millisecond,
+		Status:          200,
+		BytesIn:         0,
+		BytesOut:        2326,
+		Duration:        15 * time.Millisecond,
+	}
+}
+
+func TestCombinedFormat(t *testing.T) {
+	var b bytes.Buffer
+	f, err := NewFormatter(FormatCombined)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f(&b, testEntry()); err != nil {
+		t.Fatal(err)
+	}
+
+	line := b.String()
+	prefix := `10.0.0.1 - einstein [10/Oct/2020:13:55:36 +0000] "GET /ocs/v1.php/cloud/user HTTP/1.1" 200 2326 "-" "curl/7.64.0" `
+	if !strings.HasPrefix(line, prefix) {
+		t.Errorf("expected line to start with %q, got %q", prefix, line)
+	}
+
+	for _, field := range []string{
+		`request_id="rid-1"`,
+		`user_id="4c510ada-c86b-4815-8820-42cdf82c3d51"`,
+		`policy="ocis"`,
+		`route="/ocs/v[12].php/cloud/(users?|groups)"`,
+		`upstream="localhost:9110"`,
+		`upstream_latency=0.012000`,
+		`bytes_in=0`,
+	} {
+		if !strings.Contains(line, field) {
+			t.Errorf("expected %q in %q", field, line)
+		}
+	}
+
+	if !strings.HasSuffix(line, "\n") {
+		t.Errorf("expected line to be terminated by a newline")
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	var b bytes.Buffer
+	f, err := NewFormatter(FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f(&b, testEntry()); err != nil {
+		t.Fatal(err)
+	}
+
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(b.Bytes(), &m); err != nil {
+		t.Fatalf("expected valid json, got %q: %v", b.String(), err)
+	}
+
+	expected := map[string]interface{}{
+		"request_id":       "rid-1",
+		"user_id":          "4c510ada-c86b-4815-8820-42cdf82c3d51",
+		"policy":           "ocis",
+		"route_type":       "regex",
+		"upstream":         "localhost:9110",
+		"status":           float64(200),
+		"bytes_out":        float64(2326),
+		"upstream_latency": 0.012,
+	}
+	for k, v := range expected {
+		if m[k] != v {
+			t.Errorf("expected %s to be %v, got %v", k, v, m[k])
+		}
+	}
+}
+
+func TestUnknownFormat(t *testing.T) {
+	if _, err := NewFormatter("clf"); err == nil {
+		t.Errorf("expected an error for an unknown format")
+	}
+}
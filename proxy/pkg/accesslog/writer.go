@@ -0,0 +1,31 @@
+package accesslog
+
+import (
+	"io"
+	"os"
+
+	"github.com/owncloud/ocis/proxy/pkg/config"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// NewWriter returns the destination for access log lines. Without a configured file the lines go to stdout,
+// otherwise they are written to the file which is rotated according to the configured limits.
+func NewWriter(cfg config.AccessLog) io.WriteCloser {
+	if cfg.File == "" {
+		return nopCloser{os.Stdout}
+	}
+
+	return &lumberjack.Logger{
+		Filename:   cfg.File,
+		MaxSize:    cfg.MaxSize,
+		MaxBackups: cfg.MaxBackups,
+		MaxAge:     cfg.MaxAge,
+		Compress:   cfg.Compress,
+	}
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"os/signal"
@@ -24,7 +25,9 @@ import (
 	acc "github.com/owncloud/ocis/accounts/pkg/proto/v0"
 	"github.com/owncloud/ocis/ocis-pkg/conversions"
 	"github.com/owncloud/ocis/ocis-pkg/log"
+	pkgmiddleware "github.com/owncloud/ocis/ocis-pkg/middleware"
 	"github.com/owncloud/ocis/ocis-pkg/service/grpc"
+	"github.com/owncloud/ocis/proxy/pkg/accesslog"
 	"github.com/owncloud/ocis/proxy/pkg/config"
 	"github.com/owncloud/ocis/proxy/pkg/cs3"
 	"github.com/owncloud/ocis/proxy/pkg/flagset"
@@ -51,6 +54,7 @@ func Server(cfg *config.Config) *cli.Command {
 				cfg.HTTP.Root = strings.TrimSuffix(cfg.HTTP.Root, "/")
 			}
 			cfg.PreSignedURL.AllowedHTTPMethods = ctx.StringSlice("presignedurl-allow-method")
+			cfg.HTTP.TrustedProxies = ctx.StringSlice("trusted-proxy")
 
 			if err := loadUserAgent(ctx, cfg); err != nil {
 				return err
@@ -164,6 +168,9 @@ func Server(cfg *config.Config) *cli.Command {
 
 			metrics.BuildInfo.WithLabelValues(cfg.Service.Version).Set(1)
 
+			accessLog := accesslog.NewWriter(cfg.AccessLog)
+			defer accessLog.Close()
+
 			rp := proxy.NewMultiHostReverseProxy(
 				proxy.Logger(logger),
 				proxy.Config(cfg),
@@ -178,7 +185,7 @@ func Server(cfg *config.Config) *cli.Command {
 					proxyHTTP.Metrics(metrics),
 					proxyHTTP.Flags(flagset.RootWithConfig(config.New())),
 					proxyHTTP.Flags(flagset.ServerWithConfig(config.New())),
-					proxyHTTP.Middlewares(loadMiddlewares(ctx, logger, cfg)),
+					proxyHTTP.Middlewares(loadMiddlewares(ctx, logger, cfg, accessLog)),
 				)
 
 				if err != nil {
@@ -258,7 +265,7 @@ func Server(cfg *config.Config) *cli.Command {
 	}
 }
 
-func loadMiddlewares(ctx context.Context, l log.Logger, cfg *config.Config) alice.Chain {
+func loadMiddlewares(ctx context.Context, l log.Logger, cfg *config.Config, accessLog io.Writer) alice.Chain {
 	rolesClient := settings.NewRoleService("com.owncloud.api.settings", grpc.DefaultClient)
 	revaClient, err := cs3.GetGatewayServiceClient(cfg.Reva.Address)
 	var userProvider backend.UserBackend
@@ -267,11 +274,12 @@ func loadMiddlewares(ctx context.Context, l log.Logger, cfg *config.Config) alic
 		userProvider = backend.NewAccountsServiceUserBackend(
 			acc.NewAccountsService("com.owncloud.api.accounts", grpc.DefaultClient),
 			rolesClient,
+			cfg.TokenManager.JWTSecret,
 			cfg.OIDC.Issuer,
 			l,
 		)
 	case "cs3":
-		userProvider = backend.NewCS3UserBackend(revaClient, rolesClient, revaClient, l)
+		userProvider = backend.NewCS3UserBackend(revaClient, rolesClient, cfg.TokenManager.JWTSecret, revaClient, l)
 	default:
 		l.Fatal().Msgf("Invalid accounts backend type '%s'", cfg.AccountBackend)
 	}
@@ -294,6 +302,12 @@ func loadMiddlewares(ctx context.Context, l log.Logger, cfg *config.Config) alic
 	}
 
 	return alice.New(
+		pkgmiddleware.RequestID,
+		middleware.AccessLog(
+			middleware.Logger(l),
+			middleware.AccessLogConfig(cfg.AccessLog),
+			middleware.AccessLogWriter(accessLog),
+		),
 		middleware.HTTPSRedirect,
 		middleware.Authentication(
 			// OIDC Options
@@ -307,19 +321,31 @@ func loadMiddlewares(ctx context.Context, l log.Logger, cfg *config.Config) alic
 				)
 			}),
 			middleware.HTTPClient(oidcHTTPClient),
+			middleware.AccessTokenVerifyMethod(cfg.OIDC.AccessTokenVerifyMethod),
+			middleware.OIDCAudience(cfg.OIDC.Audience),
+			middleware.OIDCIntrospection(cfg.OIDC.Introspection),
 			middleware.TokenCacheSize(cfg.OIDC.UserinfoCache.Size),
 			middleware.TokenCacheTTL(time.Second*time.Duration(cfg.OIDC.UserinfoCache.TTL)),
 
 			// basic Options
 			middleware.Logger(l),
 			middleware.EnableBasicAuth(cfg.EnableBasicAuth),
+			middleware.EnableAppTokens(cfg.EnableAppTokens),
+			middleware.Store(storeClient),
 			middleware.UserProvider(userProvider),
+
+			// client certificate Options
+			middleware.EnableClientCertAuth(cfg.HTTP.ClientAuth == config.ClientAuthOptional || cfg.HTTP.ClientAuth == config.ClientAuthRequired),
+			middleware.ClientCRL(cfg.HTTP.ClientCRL),
+			middleware.ClientCertClaim(cfg.HTTP.ClientCertClaim),
+
 			middleware.OIDCIss(cfg.OIDC.Issuer),
 			middleware.CredentialsByUserAgent(cfg.Reva.Middleware.Auth.CredentialsByUserAgent),
 		),
 		middleware.SignedURLAuth(
 			middleware.Logger(l),
 			middleware.PreSignedURLConfig(cfg.PreSignedURL),
+			middleware.TrustedProxies(cfg.HTTP.TrustedProxies),
 			middleware.UserProvider(userProvider),
 			middleware.Store(storeClient),
 		),
@@ -17,11 +17,41 @@ type Debug struct {
 
 // HTTP defines the available http configuration.
 type HTTP struct {
-	Addr    string
-	Root    string
-	TLSCert string
-	TLSKey  string
-	TLS     bool
+	Addr            string
+	Root            string
+	TLSCert         string
+	TLSKey          string
+	TLS             bool
+	ClientAuth      string   `mapstructure:"client_auth"`
+	ClientCA        string   `mapstructure:"client_ca"`
+	ClientCRL       string   `mapstructure:"client_crl"`
+	ClientCertClaim string   `mapstructure:"client_cert_claim"`
+	TrustedProxies  []string `mapstructure:"trusted_proxies"`
+}
+
+const (
+	// ClientAuthNone does not request client certificates.
+	ClientAuthNone = "none"
+	// ClientAuthOptional verifies client certificates if the client sends one.
+	ClientAuthOptional = "optional"
+	// ClientAuthRequired rejects connections without a valid client certificate.
+	ClientAuthRequired = "required"
+
+	// ClientCertClaimCN maps the subject common name of a client certificate to the username of an account.
+	ClientCertClaimCN = "cn"
+	// ClientCertClaimEmail maps the first email SAN of a client certificate to the mail of an account.
+	ClientCertClaimEmail = "email"
+)
+
+// AccessLog defines the available access log configuration.
+type AccessLog struct {
+	Enabled    bool
+	Format     string
+	File       string
+	MaxSize    int
+	MaxBackups int
+	MaxAge     int
+	Compress   bool
 }
 
 // Service defines the available service configuration.
@@ -104,6 +134,7 @@ type Cache struct {
 type Config struct {
 	File                  string
 	Log                   Log
+	AccessLog             AccessLog `mapstructure:"access_log"`
 	Debug                 Debug
 	HTTP                  HTTP
 	Service               Service
@@ -118,15 +149,37 @@ type Config struct {
 	AccountBackend        string
 	AutoprovisionAccounts bool
 	EnableBasicAuth       bool
+	EnableAppTokens       bool
 	InsecureBackends      bool
 }
 
 // OIDC is the config for the OpenID-Connect middleware. If set the proxy will try to authenticate every request
 // with the configured oidc-provider
 type OIDC struct {
-	Issuer        string
-	Insecure      bool
-	UserinfoCache Cache
+	Issuer                  string
+	Insecure                bool
+	UserinfoCache           Cache
+	AccessTokenVerifyMethod string `mapstructure:"access_token_verify_method"`
+	Audience                string
+	Introspection           OIDCIntrospection
+}
+
+const (
+	// AccessTokenVerificationUserinfo resolves every access token at the userinfo endpoint of the issuer.
+	AccessTokenVerificationUserinfo = "userinfo"
+	// AccessTokenVerificationJWT verifies JWT access tokens locally against the keys of the issuer and falls back to
+	// token introspection for opaque tokens.
+	AccessTokenVerificationJWT = "jwt"
+	// AccessTokenVerificationIntrospection checks every access token at the introspection endpoint of the issuer.
+	AccessTokenVerificationIntrospection = "introspection"
+)
+
+// OIDCIntrospection is the config for the RFC 7662 token introspection. The endpoint is taken from the
+// issuer discovery document when not set.
+type OIDCIntrospection struct {
+	Endpoint     string
+	ClientID     string `mapstructure:"client_id"`
+	ClientSecret string `mapstructure:"client_secret"`
 }
 
 // PolicySelector is the toplevel-configuration for different selectors
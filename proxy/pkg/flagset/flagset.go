@@ -55,6 +55,55 @@ func ServerWithConfig(cfg *config.Config) []cli.Flag {
 			EnvVars:     []string{"PROXY_CONFIG_FILE"},
 			Destination: &cfg.File,
 		},
+		&cli.BoolFlag{
+			Name:        "access-log-enabled",
+			Value:       true,
+			Usage:       "Enable the access log",
+			EnvVars:     []string{"PROXY_ACCESS_LOG_ENABLED"},
+			Destination: &cfg.AccessLog.Enabled,
+		},
+		&cli.StringFlag{
+			Name:        "access-log-format",
+			Value:       "combined",
+			Usage:       "Access log format, either 'combined' or 'json'",
+			EnvVars:     []string{"PROXY_ACCESS_LOG_FORMAT"},
+			Destination: &cfg.AccessLog.Format,
+		},
+		&cli.StringFlag{
+			Name:        "access-log-file",
+			Value:       "",
+			Usage:       "Write the access log to this file instead of stdout",
+			EnvVars:     []string{"PROXY_ACCESS_LOG_FILE"},
+			Destination: &cfg.AccessLog.File,
+		},
+		&cli.IntFlag{
+			Name:        "access-log-max-size",
+			Value:       100,
+			Usage:       "Max size in megabytes of the access log file before it gets rotated",
+			EnvVars:     []string{"PROXY_ACCESS_LOG_MAX_SIZE"},
+			Destination: &cfg.AccessLog.MaxSize,
+		},
+		&cli.IntFlag{
+			Name:        "access-log-max-backups",
+			Value:       0,
+			Usage:       "Max number of rotated access log files to keep, 0 keeps all",
+			EnvVars:     []string{"PROXY_ACCESS_LOG_MAX_BACKUPS"},
+			Destination: &cfg.AccessLog.MaxBackups,
+		},
+		&cli.IntFlag{
+			Name:        "access-log-max-age",
+			Value:       0,
+			Usage:       "Max days to keep rotated access log files, 0 keeps them forever",
+			EnvVars:     []string{"PROXY_ACCESS_LOG_MAX_AGE"},
+			Destination: &cfg.AccessLog.MaxAge,
+		},
+		&cli.BoolFlag{
+			Name:        "access-log-compress",
+			Value:       false,
+			Usage:       "Compress rotated access log files",
+			EnvVars:     []string{"PROXY_ACCESS_LOG_COMPRESS"},
+			Destination: &cfg.AccessLog.Compress,
+		},
 		&cli.BoolFlag{
 			Name:        "tracing-enabled",
 			Usage:       "Enable sending traces",
@@ -171,6 +220,39 @@ func ServerWithConfig(cfg *config.Config) []cli.Flag {
 			Value:       true,
 			Destination: &cfg.HTTP.TLS,
 		},
+		&cli.StringFlag{
+			Name:        "tls-client-auth",
+			Value:       "none",
+			Usage:       "Authenticate clients with TLS client certificates: 'none', 'optional' or 'required'",
+			EnvVars:     []string{"PROXY_TLS_CLIENT_AUTH"},
+			Destination: &cfg.HTTP.ClientAuth,
+		},
+		&cli.StringFlag{
+			Name:        "tls-client-ca",
+			Value:       "",
+			Usage:       "CA bundle to verify client certificates against",
+			EnvVars:     []string{"PROXY_TLS_CLIENT_CA"},
+			Destination: &cfg.HTTP.ClientCA,
+		},
+		&cli.StringFlag{
+			Name:        "tls-client-crl",
+			Value:       "",
+			Usage:       "PEM file with the certificate revocation lists of the client CAs, one per CA, reloaded when it changes",
+			EnvVars:     []string{"PROXY_TLS_CLIENT_CRL"},
+			Destination: &cfg.HTTP.ClientCRL,
+		},
+		&cli.StringFlag{
+			Name:        "tls-client-cert-claim",
+			Value:       "cn",
+			Usage:       "Maps client certificates to accounts, either by subject common name 'cn' or email SAN 'email'",
+			EnvVars:     []string{"PROXY_TLS_CLIENT_CERT_CLAIM"},
+			Destination: &cfg.HTTP.ClientCertClaim,
+		},
+		&cli.StringSliceFlag{
+			Name:    "trusted-proxy",
+			Usage:   "IP address or CIDR range of a reverse proxy in front of the proxy whose X-Forwarded-Proto header is trusted",
+			EnvVars: []string{"PROXY_TRUSTED_PROXIES"},
+		},
 		&cli.StringFlag{
 			Name:        "jwt-secret",
 			Value:       "Pive-Fumkiu4",
@@ -223,6 +305,41 @@ func ServerWithConfig(cfg *config.Config) []cli.Flag {
 			EnvVars:     []string{"PROXY_OIDC_USERINFO_CACHE_SIZE"},
 			Destination: &cfg.OIDC.UserinfoCache.Size,
 		},
+		&cli.StringFlag{
+			Name:        "oidc-access-token-verify-method",
+			Value:       "userinfo",
+			Usage:       "How to verify access tokens: 'userinfo', 'jwt' (local JWKS validation, introspection for opaque tokens) or 'introspection'",
+			EnvVars:     []string{"PROXY_OIDC_ACCESS_TOKEN_VERIFY_METHOD"},
+			Destination: &cfg.OIDC.AccessTokenVerifyMethod,
+		},
+		&cli.StringFlag{
+			Name:        "oidc-audience",
+			Value:       "",
+			Usage:       "Audience that access tokens must be issued for, not checked when empty",
+			EnvVars:     []string{"PROXY_OIDC_AUDIENCE"},
+			Destination: &cfg.OIDC.Audience,
+		},
+		&cli.StringFlag{
+			Name:        "oidc-introspection-endpoint",
+			Value:       "",
+			Usage:       "Token introspection endpoint, discovered from the issuer when empty",
+			EnvVars:     []string{"PROXY_OIDC_INTROSPECTION_ENDPOINT"},
+			Destination: &cfg.OIDC.Introspection.Endpoint,
+		},
+		&cli.StringFlag{
+			Name:        "oidc-introspection-client-id",
+			Value:       "",
+			Usage:       "Client id used to authenticate at the token introspection endpoint",
+			EnvVars:     []string{"PROXY_OIDC_INTROSPECTION_CLIENT_ID"},
+			Destination: &cfg.OIDC.Introspection.ClientID,
+		},
+		&cli.StringFlag{
+			Name:        "oidc-introspection-client-secret",
+			Value:       "",
+			Usage:       "Client secret used to authenticate at the token introspection endpoint",
+			EnvVars:     []string{"PROXY_OIDC_INTROSPECTION_CLIENT_SECRET"},
+			Destination: &cfg.OIDC.Introspection.ClientSecret,
+		},
 
 		&cli.BoolFlag{
 			Name:        "autoprovision-accounts",
@@ -255,6 +372,13 @@ func ServerWithConfig(cfg *config.Config) []cli.Flag {
 			EnvVars:     []string{"PROXY_ENABLE_BASIC_AUTH"},
 			Destination: &cfg.EnableBasicAuth,
 		},
+		&cli.BoolFlag{
+			Name:        "enable-app-tokens",
+			Value:       false,
+			Usage:       "accept app tokens in place of passwords for basic authentication",
+			EnvVars:     []string{"PROXY_ENABLE_APP_TOKENS"},
+			Destination: &cfg.EnableAppTokens,
+		},
 
 		&cli.StringFlag{
 			Name:        "account-backend-type",
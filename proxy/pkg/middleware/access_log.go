@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/owncloud/ocis/proxy/pkg/accesslog"
+)
+
+// AccessLog provides a middleware which writes one access log line per request. It has to be the outermost middleware
+// after the request id middleware, so that the later middlewares and the proxy can add the user, policy, route and
+// upstream to the entry it puts into the request context.
+func AccessLog(optionSetters ...Option) func(next http.Handler) http.Handler {
+	options := newOptions(optionSetters...)
+	logger := options.Logger
+
+	format, err := accesslog.NewFormatter(options.AccessLogConfig.Format)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Could not initialize access log")
+	}
+
+	return func(next http.Handler) http.Handler {
+		if !options.AccessLogConfig.Enabled {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			e := &accesslog.Entry{
+				RequestID:  req.Header.Get("X-Request-ID"),
+				RemoteAddr: req.RemoteAddr,
+				Method:     req.Method,
+				URI:        req.RequestURI,
+				Proto:      req.Proto,
+				Referer:    req.Referer(),
+				UserAgent:  req.UserAgent(),
+				Start:      time.Now(),
+			}
+
+			body := &countingReader{ReadCloser: req.Body}
+			if req.Body != nil {
+				req.Body = body
+			}
+			wrap := middleware.NewWrapResponseWriter(w, req.ProtoMajor)
+
+			next.ServeHTTP(wrap, req.WithContext(accesslog.NewContext(req.Context(), e)))
+
+			e.Status = wrap.Status()
+			if e.Status == 0 {
+				e.Status = http.StatusOK
+			}
+			e.BytesIn = atomic.LoadInt64(&body.n)
+			e.BytesOut = int64(wrap.BytesWritten())
+			e.Duration = time.Since(e.Start)
+
+			if err := format(options.AccessLogWriter, e); err != nil {
+				logger.Error().Err(err).Msg("could not write access log")
+			}
+		})
+	}
+}
+
+// countingReader counts the bytes read from the request body.
+type countingReader struct {
+	io.ReadCloser
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	atomic.AddInt64(&r.n, int64(n))
+	return n, err
+}
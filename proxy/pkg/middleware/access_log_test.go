@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	userv1beta1 "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	"github.com/owncloud/ocis/ocis-pkg/log"
+	"github.com/owncloud/ocis/ocis-pkg/oidc"
+	"github.com/owncloud/ocis/proxy/pkg/config"
+	"github.com/owncloud/ocis/proxy/pkg/proxy"
+	"github.com/owncloud/ocis/proxy/pkg/user/backend/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessLogRecordsUserRouteAndUpstream(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		_, _ = w.Write([]byte("OK"))
+	}))
+	defer backend.Close()
+	backendURL, _ := url.Parse(backend.URL)
+
+	rp := proxy.NewMultiHostReverseProxy(proxy.Logger(log.NewLogger()), proxy.Config(&config.Config{
+		Policies: []config.Policy{{
+			Name:   "ocis",
+			Routes: []config.Route{{Endpoint: "/ocs/", Backend: backend.URL}},
+		}},
+	}))
+
+	userProvider := &test.UserBackendMock{
+		GetUserByClaimsFunc: func(ctx context.Context, claim string, value string, withRoles bool) (*userv1beta1.User, error) {
+			return &userv1beta1.User{Id: &userv1beta1.UserId{OpaqueId: "einstein-id"}, Username: "einstein"}, nil
+		},
+	}
+
+	var b bytes.Buffer
+	handler := AccessLog(
+		Logger(log.NewLogger()),
+		AccessLogConfig(config.AccessLog{Enabled: true, Format: "json"}),
+		AccessLogWriter(&b),
+	)(AccountResolver(
+		Logger(log.NewLogger()),
+		UserProvider(userProvider),
+		TokenManagerConfig(config.TokenManager{JWTSecret: "secret"}),
+	)(rp))
+
+	req := httptest.NewRequest("GET", "http://example.com/ocs/v1.php/cloud/user", nil)
+	req = req.WithContext(oidc.NewContext(req.Context(), &oidc.StandardClaims{PreferredUsername: "einstein"}))
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+	assert.Equal(t, http.StatusOK, rw.Code)
+
+	var line struct {
+		UserID          string  `json:"user_id"`
+		Username        string  `json:"username"`
+		Policy          string  `json:"policy"`
+		RouteType       string  `json:"route_type"`
+		Route           string  `json:"route"`
+		Upstream        string  `json:"upstream"`
+		UpstreamLatency float64 `json:"upstream_latency"`
+		Status          int     `json:"status"`
+		BytesOut        int64   `json:"bytes_out"`
+	}
+	if err := json.Unmarshal(b.Bytes(), &line); err != nil {
+		t.Fatalf("could not parse access log line %q: %v", b.String(), err)
+	}
+
+	assert.Equal(t, "einstein-id", line.UserID)
+	assert.Equal(t, "einstein", line.Username)
+	assert.Equal(t, "ocis", line.Policy)
+	assert.Equal(t, string(config.PrefixRoute), line.RouteType)
+	assert.Equal(t, "/ocs/", line.Route)
+	assert.Equal(t, backendURL.Host, line.Upstream)
+	assert.GreaterOrEqual(t, line.UpstreamLatency, (10 * time.Millisecond).Seconds())
+	assert.Equal(t, http.StatusOK, line.Status)
+	assert.Equal(t, int64(2), line.BytesOut)
+}
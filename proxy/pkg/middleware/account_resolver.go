@@ -1,7 +1,6 @@
 package middleware
 
 import (
-	"github.com/owncloud/ocis/proxy/pkg/user/backend"
 	"net/http"
 
 	tokenPkg "github.com/cs3org/reva/pkg/token"
@@ -9,6 +8,8 @@ import (
 	revauser "github.com/cs3org/reva/pkg/user"
 	"github.com/owncloud/ocis/ocis-pkg/log"
 	"github.com/owncloud/ocis/ocis-pkg/oidc"
+	"github.com/owncloud/ocis/proxy/pkg/accesslog"
+	"github.com/owncloud/ocis/proxy/pkg/user/backend"
 )
 
 // AccountResolver provides a middleware which mints a jwt and adds it to the proxied request based
@@ -91,6 +92,10 @@ func (m accountResolver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		m.logger.Debug().Interface("claims", claims).Interface("user", u).Msgf("associated claims with uuid")
 	}
 
+	if e := accesslog.FromContext(req.Context()); e != nil {
+		e.SetUser(u.GetId().GetOpaqueId(), u.GetUsername())
+	}
+
 	token, err := m.tokenManager.MintToken(req.Context(), u)
 
 	if err != nil {
@@ -35,33 +35,39 @@ func Authentication(opts ...Option) func(next http.Handler) http.Handler {
 	options := newOptions(opts...)
 
 	configureSupportedChallenges(options)
-	oidc := newOIDCAuth(options)
-	basic := newBasicAuth(options)
 
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if options.OIDCIss != "" && options.EnableBasicAuth {
-				oidc(basic(next)).ServeHTTP(w, r)
-			}
-
-			if options.OIDCIss != "" && !options.EnableBasicAuth {
-				oidc(next).ServeHTTP(w, r)
-			}
+	// the first configured strategy wraps the others
+	var strategies []func(http.Handler) http.Handler
+	if options.EnableClientCertAuth {
+		strategies = append(strategies, newClientCertAuth(options))
+	}
+	if options.OIDCIss != "" {
+		strategies = append(strategies, newOIDCAuth(options))
+	}
+	// app tokens are accepted by the basic auth middleware
+	if options.EnableBasicAuth || options.EnableAppTokens {
+		strategies = append(strategies, newBasicAuth(options))
+	}
 
-			if options.OIDCIss == "" && options.EnableBasicAuth {
-				basic(next).ServeHTTP(w, r)
-			}
-		})
+	return func(next http.Handler) http.Handler {
+		for i := len(strategies) - 1; i >= 0; i-- {
+			next = strategies[i](next)
+		}
+		return next
 	}
 }
 
 // configureSupportedChallenges adds known authentication challenges to the current session.
 func configureSupportedChallenges(options Options) {
+	if options.EnableClientCertAuth {
+		SupportedAuthStrategies = append(SupportedAuthStrategies, "certificate")
+	}
+
 	if options.OIDCIss != "" {
 		SupportedAuthStrategies = append(SupportedAuthStrategies, "bearer")
 	}
 
-	if options.EnableBasicAuth {
+	if options.EnableBasicAuth || options.EnableAppTokens {
 		SupportedAuthStrategies = append(SupportedAuthStrategies, "basic")
 	}
 }
@@ -113,6 +119,9 @@ func newOIDCAuth(options Options) func(http.Handler) http.Handler {
 		OIDCProviderFunc(options.OIDCProviderFunc),
 		HTTPClient(options.HTTPClient),
 		OIDCIss(options.OIDCIss),
+		AccessTokenVerifyMethod(options.AccessTokenVerifyMethod),
+		OIDCAudience(options.OIDCAudience),
+		OIDCIntrospection(options.OIDCIntrospection),
 		TokenCacheSize(options.UserinfoCacheSize),
 		TokenCacheTTL(time.Second*time.Duration(options.UserinfoCacheTTL)),
 		CredentialsByUserAgent(options.CredentialsByUserAgent),
@@ -125,8 +134,20 @@ func newBasicAuth(options Options) func(http.Handler) http.Handler {
 		UserProvider(options.UserProvider),
 		Logger(options.Logger),
 		EnableBasicAuth(options.EnableBasicAuth),
+		EnableAppTokens(options.EnableAppTokens),
+		Store(options.Store),
 		AccountsClient(options.AccountsClient),
 		OIDCIss(options.OIDCIss),
 		CredentialsByUserAgent(options.CredentialsByUserAgent),
 	)
 }
+
+// newClientCertAuth returns a configured client certificate middleware
+func newClientCertAuth(options Options) func(http.Handler) http.Handler {
+	return ClientCertAuth(
+		Logger(options.Logger),
+		UserProvider(options.UserProvider),
+		ClientCRL(options.ClientCRL),
+		ClientCertClaim(options.ClientCertClaim),
+	)
+}
@@ -1,12 +1,19 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	userv1beta1 "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	merrors "github.com/micro/go-micro/v2/errors"
+	"github.com/owncloud/ocis/ocis-pkg/apptoken"
 	"github.com/owncloud/ocis/ocis-pkg/log"
 	"github.com/owncloud/ocis/ocis-pkg/oidc"
 	"github.com/owncloud/ocis/proxy/pkg/user/backend"
-	"net/http"
-	"strings"
+	store "github.com/owncloud/ocis/store/pkg/proto/v0"
 )
 
 const publicFilesEndpoint = "/remote.php/dav/public-files/"
@@ -23,7 +30,9 @@ func BasicAuth(optionSetters ...Option) func(next http.Handler) http.Handler {
 	h := basicAuth{
 		logger:       logger,
 		enabled:      options.EnableBasicAuth,
+		appTokens:    options.EnableAppTokens,
 		userProvider: options.UserProvider,
+		store:        options.Store,
 	}
 
 	return func(next http.Handler) http.Handler {
@@ -39,7 +48,7 @@ func BasicAuth(optionSetters ...Option) func(next http.Handler) http.Handler {
 
 				removeSuperfluousAuthenticate(w)
 				login, password, _ := req.BasicAuth()
-				user, err := h.userProvider.Authenticate(req.Context(), login, password)
+				user, token, err := h.authenticate(req.Context(), login, password)
 
 				// touch is a user agent locking guard, when touched changes to true it indicates the User-Agent on the
 				// request is configured to support only one challenge, it it remains untouched, there are no considera-
@@ -65,6 +74,12 @@ func BasicAuth(optionSetters ...Option) func(next http.Handler) http.Handler {
 					return
 				}
 
+				if token != nil && !token.Scope.AllowsRequest(req) {
+					logger.Debug().Str("token", token.ID).Str("method", req.Method).Str("path", req.URL.Path).Str("destination", req.Header.Get("Destination")).Msg("request not covered by app token scope")
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+
 				claims := &oidc.StandardClaims{
 					OcisID:            user.Id.OpaqueId,
 					Iss:               user.Id.Idp,
@@ -81,7 +96,113 @@ func BasicAuth(optionSetters ...Option) func(next http.Handler) http.Handler {
 type basicAuth struct {
 	logger       log.Logger
 	enabled      bool
+	appTokens    bool
 	userProvider backend.UserBackend
+	store        store.StoreService
+}
+
+// authenticate accepts an app token of the user in place of the password. The real password is only accepted when
+// basic auth is enabled, it is still accepted if the app tokens can't be looked up.
+func (m basicAuth) authenticate(ctx context.Context, login, password string) (*userv1beta1.User, *apptoken.Token, error) {
+	if m.appTokens {
+		token, revision, err := m.lookupAppToken(ctx, password)
+		switch {
+		case err != nil && !m.enabled:
+			return nil, nil, err
+		case err != nil:
+			m.logger.Warn().Err(err).Msg("could not look up app token, trying password authentication")
+		case token != nil && token.Expired(time.Now()):
+			// the store does not return expired records, this also covers records written without their expiry
+			return nil, nil, fmt.Errorf("app token %s has expired", token.ID)
+		case token != nil:
+			user, err := m.userProvider.GetUserByClaims(ctx, "username", login, true)
+			if err != nil {
+				return nil, nil, err
+			}
+			if user.GetId().GetOpaqueId() != token.AccountID {
+				return nil, nil, fmt.Errorf("app token does not belong to %s", login)
+			}
+			m.touchAppToken(ctx, token, revision)
+			return user, token, nil
+		}
+	}
+
+	if !m.enabled {
+		return nil, nil, backend.ErrAccountNotFound
+	}
+
+	user, err := m.userProvider.Authenticate(ctx, login, password)
+	return user, nil, err
+}
+
+// lookupAppToken finds the app token by the hash of the secret together with the revision of its record. It returns
+// nil if there is none.
+func (m basicAuth) lookupAppToken(ctx context.Context, secret string) (*apptoken.Token, uint64, error) {
+	res, err := m.store.Read(ctx, &store.ReadRequest{
+		Options: &store.ReadOptions{
+			Database: apptoken.Database,
+			Table:    apptoken.Table,
+			Where: map[string]*store.Field{
+				apptoken.HashField: {Type: "string", Value: apptoken.Hash(secret)},
+			},
+		},
+	})
+	if err != nil {
+		m.logger.Error().Err(err).Msg("could not look up app token")
+		return nil, 0, err
+	}
+	if len(res.Records) < 1 {
+		return nil, 0, nil
+	}
+
+	token, err := apptoken.Unmarshal(res.Records[0].Value)
+	if err != nil {
+		return nil, 0, err
+	}
+	return token, res.Records[0].Revision, nil
+}
+
+// touchAppToken updates the last used time of the token. To avoid a write on every request it is only updated once
+// per minute. The write only succeeds if the record still has the revision it was read with, so that a token revoked
+// in the meantime is not written again.
+func (m basicAuth) touchAppToken(ctx context.Context, token *apptoken.Token, revision uint64) {
+	// records without a revision predate revisions in the store, they can't be written conditionally
+	if revision == 0 || time.Since(token.LastUsedAt) < time.Minute {
+		return
+	}
+
+	token.LastUsedAt = time.Now().UTC()
+	value, err := token.Marshal()
+	if err != nil {
+		m.logger.Error().Err(err).Str("token", token.ID).Msg("could not marshal app token")
+		return
+	}
+
+	_, err = m.store.Write(ctx, &store.WriteRequest{
+		Options: &store.WriteOptions{
+			Database:         apptoken.Database,
+			Table:            apptoken.Table,
+			ExpectedRevision: revision,
+			// the record would not expire anymore otherwise
+			Expiry: token.StoreExpiry(),
+		},
+		Record: &store.Record{
+			Key:   token.ID,
+			Value: value,
+			Metadata: map[string]*store.Field{
+				apptoken.AccountIDField: {Type: "string", Value: token.AccountID},
+				apptoken.HashField:      {Type: "string", Value: token.Hash},
+			},
+		},
+	})
+	switch {
+	case err == nil:
+	case merrors.FromError(err).Code == http.StatusConflict:
+		// the token was revoked or used by a concurrent request
+		m.logger.Debug().Str("token", token.ID).Msg("app token changed since it was read, not updating its last used time")
+	default:
+		m.logger.Error().Err(err).Str("token", token.ID).Msg("could not update last used time of app token")
+	}
 }
 
 func (m basicAuth) isPublicLink(req *http.Request) bool {
@@ -91,5 +212,5 @@ func (m basicAuth) isPublicLink(req *http.Request) bool {
 
 func (m basicAuth) isBasicAuth(req *http.Request) bool {
 	login, password, ok := req.BasicAuth()
-	return m.enabled && ok && login != "" && password != ""
+	return (m.enabled || m.appTokens) && ok && login != "" && password != ""
 }
@@ -1,3 +1,243 @@
 package middleware
 
-/**/
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	userv1beta1 "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	"github.com/micro/go-micro/v2/client"
+	merrors "github.com/micro/go-micro/v2/errors"
+	"github.com/owncloud/ocis/ocis-pkg/apptoken"
+	"github.com/owncloud/ocis/ocis-pkg/log"
+	"github.com/owncloud/ocis/proxy/pkg/user/backend"
+	"github.com/owncloud/ocis/proxy/pkg/user/backend/test"
+	store "github.com/owncloud/ocis/store/pkg/proto/v0"
+)
+
+func TestBasicAuthAppTokens(t *testing.T) {
+	einstein := &userv1beta1.User{Id: &userv1beta1.UserId{OpaqueId: "einstein-id"}, Username: "einstein"}
+	marie := &userv1beta1.User{Id: &userv1beta1.UserId{OpaqueId: "marie-id"}, Username: "marie"}
+
+	readOnly, readOnlySecret, _ := apptoken.New("einstein-id", "calendar", apptoken.Scope{ReadOnly: true})
+	full, fullSecret, _ := apptoken.New("einstein-id", "backup", apptoken.Scope{})
+	scoped, scopedSecret, _ := apptoken.New("einstein-id", "documents", apptoken.Scope{PathPrefix: "/remote.php/dav/files/einstein/Documents"})
+	expiring, expiringSecret, _ := apptoken.New("einstein-id", "sync", apptoken.Scope{})
+	expiring.ExpiresAt = time.Now().Add(time.Hour)
+	expired, expiredSecret, _ := apptoken.New("einstein-id", "old sync", apptoken.Scope{})
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	s := newMockStore(readOnly, full, scoped, expiring, expired)
+
+	up := &test.UserBackendMock{
+		GetUserByClaimsFunc: func(ctx context.Context, claim, value string, withRoles bool) (*userv1beta1.User, error) {
+			switch value {
+			case "einstein":
+				return einstein, nil
+			case "marie":
+				return marie, nil
+			}
+			return nil, backend.ErrAccountNotFound
+		},
+		AuthenticateFunc: func(ctx context.Context, username, password string) (*userv1beta1.User, error) {
+			if username == "einstein" && password == "relativity" {
+				return einstein, nil
+			}
+			return nil, backend.ErrAccountNotFound
+		},
+	}
+
+	tests := []struct {
+		name            string
+		enableBasicAuth bool
+		method          string
+		path            string
+		destination     string
+		login, password string
+		status          int
+	}{
+		{"full token", false, "PUT", "/remote.php/dav/files/einstein/", "", "einstein", fullSecret, http.StatusOK},
+		{"read only token reading", false, "PROPFIND", "/remote.php/dav/files/einstein/", "", "einstein", readOnlySecret, http.StatusOK},
+		{"read only token writing", false, "PUT", "/remote.php/dav/files/einstein/", "", "einstein", readOnlySecret, http.StatusForbidden},
+		{"token of another user", false, "GET", "/remote.php/dav/files/einstein/", "", "marie", fullSecret, http.StatusUnauthorized},
+		{"unknown token", false, "GET", "/remote.php/dav/files/einstein/", "", "einstein", "guessed", http.StatusUnauthorized},
+		{"password without basic auth", false, "GET", "/remote.php/dav/files/einstein/", "", "einstein", "relativity", http.StatusUnauthorized},
+		{"password with basic auth", true, "GET", "/remote.php/dav/files/einstein/", "", "einstein", "relativity", http.StatusOK},
+		{"scoped token within its prefix", false, "GET", "/remote.php/dav/files/einstein/Documents/a.txt", "", "einstein", scopedSecret, http.StatusOK},
+		{"scoped token leaving its prefix with dot segments", false, "GET", "/remote.php/dav/files/einstein/Documents/../Photos/a.jpg", "", "einstein", scopedSecret, http.StatusForbidden},
+		{"scoped token moving within its prefix", false, "MOVE", "/remote.php/dav/files/einstein/Documents/a.txt", "https://localhost:9200/remote.php/dav/files/einstein/Documents/b.txt", "einstein", scopedSecret, http.StatusOK},
+		{"scoped token moving out of its prefix", false, "MOVE", "/remote.php/dav/files/einstein/Documents/a.txt", "https://localhost:9200/remote.php/dav/files/einstein/Photos/a.txt", "einstein", scopedSecret, http.StatusForbidden},
+		{"scoped token copying out of its prefix", false, "COPY", "/remote.php/dav/files/einstein/Documents/a.txt", "https://localhost:9200/remote.php/dav/files/einstein/Documents/../a.txt", "einstein", scopedSecret, http.StatusForbidden},
+		{"token before its expiry", false, "GET", "/remote.php/dav/files/einstein/", "", "einstein", expiringSecret, http.StatusOK},
+		{"expired token", true, "GET", "/remote.php/dav/files/einstein/", "", "einstein", expiredSecret, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := BasicAuth(
+				Logger(log.NewLogger()),
+				UserProvider(up),
+				Store(s),
+				EnableBasicAuth(tt.enableBasicAuth),
+				EnableAppTokens(true),
+			)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			r := httptest.NewRequest(tt.method, "https://localhost:9200/", nil)
+			// the path is set as is, a client is free to send dot segments
+			r.URL.Path = tt.path
+			if tt.destination != "" {
+				r.Header.Set("Destination", tt.destination)
+			}
+			r.SetBasicAuth(tt.login, tt.password)
+			w := httptest.NewRecorder()
+			m.ServeHTTP(w, r)
+
+			if w.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, w.Code)
+			}
+		})
+	}
+
+	if rec, ok := s.records[full.ID]; !ok || rec.Metadata[apptoken.HashField].Value != full.Hash {
+		t.Fatalf("expected the token record to keep its metadata")
+	} else if used, _ := apptoken.Unmarshal(rec.Value); used.LastUsedAt.IsZero() {
+		t.Errorf("expected the last used time to be updated")
+	}
+	if got := s.expiries[expiring.ID]; got != expiring.ExpiresAt.Unix() {
+		t.Errorf("expected the token record to keep its expiry %d, got %d", expiring.ExpiresAt.Unix(), got)
+	}
+	if used, _ := apptoken.Unmarshal(s.records[expired.ID].Value); !used.LastUsedAt.IsZero() {
+		t.Errorf("expected the expired token not to be used")
+	}
+}
+
+func TestBasicAuthStoreUnavailable(t *testing.T) {
+	einstein := &userv1beta1.User{Id: &userv1beta1.UserId{OpaqueId: "einstein-id"}, Username: "einstein"}
+	s := newMockStore()
+	s.readErr = merrors.InternalServerError("com.owncloud.api.store", "unavailable")
+
+	up := &test.UserBackendMock{
+		AuthenticateFunc: func(ctx context.Context, username, password string) (*userv1beta1.User, error) {
+			if username == "einstein" && password == "relativity" {
+				return einstein, nil
+			}
+			return nil, backend.ErrAccountNotFound
+		},
+	}
+
+	for enabled, expected := range map[bool]int{true: http.StatusOK, false: http.StatusUnauthorized} {
+		m := BasicAuth(
+			Logger(log.NewLogger()),
+			UserProvider(up),
+			Store(s),
+			EnableBasicAuth(enabled),
+			EnableAppTokens(true),
+		)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+		r := httptest.NewRequest(http.MethodGet, "https://localhost:9200/remote.php/dav/files/einstein/", nil)
+		r.SetBasicAuth("einstein", "relativity")
+		w := httptest.NewRecorder()
+		m.ServeHTTP(w, r)
+
+		if w.Code != expected {
+			t.Errorf("basic auth enabled %t: expected status %d, got %d", enabled, expected, w.Code)
+		}
+	}
+}
+
+func TestBasicAuthRevokedTokenIsNotWrittenAgain(t *testing.T) {
+	einstein := &userv1beta1.User{Id: &userv1beta1.UserId{OpaqueId: "einstein-id"}, Username: "einstein"}
+	token, secret, _ := apptoken.New("einstein-id", "backup", apptoken.Scope{})
+	s := newMockStore(token)
+	// the user revokes the token while the request is authenticated
+	s.afterRead = func() {
+		delete(s.records, token.ID)
+	}
+
+	up := &test.UserBackendMock{
+		GetUserByClaimsFunc: func(ctx context.Context, claim, value string, withRoles bool) (*userv1beta1.User, error) {
+			return einstein, nil
+		},
+	}
+	m := BasicAuth(
+		Logger(log.NewLogger()),
+		UserProvider(up),
+		Store(s),
+		EnableAppTokens(true),
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	r := httptest.NewRequest(http.MethodGet, "https://localhost:9200/remote.php/dav/files/einstein/", nil)
+	r.SetBasicAuth("einstein", secret)
+	m.ServeHTTP(httptest.NewRecorder(), r)
+
+	if _, ok := s.records[token.ID]; ok {
+		t.Errorf("expected the revoked token to stay deleted")
+	}
+}
+
+// mockStore implements the parts of the store service needed for app tokens. Writes are checked against the expected
+// revision like the store does.
+type mockStore struct {
+	store.StoreService
+	records  map[string]*store.Record
+	expiries map[string]int64
+	revision uint64
+
+	readErr   error
+	afterRead func()
+}
+
+func newMockStore(tokens ...*apptoken.Token) *mockStore {
+	s := &mockStore{records: map[string]*store.Record{}, expiries: map[string]int64{}}
+	for _, t := range tokens {
+		v, _ := t.Marshal()
+		s.revision++
+		s.records[t.ID] = &store.Record{
+			Key:   t.ID,
+			Value: v,
+			Metadata: map[string]*store.Field{
+				apptoken.AccountIDField: {Type: "string", Value: t.AccountID},
+				apptoken.HashField:      {Type: "string", Value: t.Hash},
+			},
+			Revision: s.revision,
+		}
+	}
+	return s
+}
+
+func (s *mockStore) Read(ctx context.Context, in *store.ReadRequest, opts ...client.CallOption) (*store.ReadResponse, error) {
+	if s.readErr != nil {
+		return nil, s.readErr
+	}
+	res := &store.ReadResponse{}
+	for _, rec := range s.records {
+		match := true
+		for k, v := range in.Options.Where {
+			if rec.Metadata[k].GetValue() != v.Value {
+				match = false
+			}
+		}
+		if match {
+			res.Records = append(res.Records, rec)
+		}
+	}
+	if s.afterRead != nil {
+		s.afterRead()
+	}
+	return res, nil
+}
+
+func (s *mockStore) Write(ctx context.Context, in *store.WriteRequest, opts ...client.CallOption) (*store.WriteResponse, error) {
+	if expected := in.Options.GetExpectedRevision(); expected > 0 {
+		existing, ok := s.records[in.Record.Key]
+		if !ok || existing.Revision != expected {
+			return nil, merrors.Conflict("com.owncloud.api.store", "record has another revision")
+		}
+	}
+	s.revision++
+	in.Record.Revision = s.revision
+	s.records[in.Record.Key] = in.Record
+	s.expiries[in.Record.Key] = in.Options.GetExpiry()
+	return &store.WriteResponse{Revision: s.revision}, nil
+}
@@ -0,0 +1,219 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	userv1beta1 "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	"github.com/owncloud/ocis/ocis-pkg/log"
+	"github.com/owncloud/ocis/ocis-pkg/oidc"
+	"github.com/owncloud/ocis/proxy/pkg/config"
+	"github.com/owncloud/ocis/proxy/pkg/user/backend"
+)
+
+// ClientCertAuth provides a middleware to authenticate requests with the verified TLS client certificate of the
+// connection. Requests without a client certificate are passed on to the next authentication middleware.
+func ClientCertAuth(optionSetters ...Option) func(next http.Handler) http.Handler {
+	options := newOptions(optionSetters...)
+
+	h := clientCertAuth{
+		logger:       options.Logger,
+		claim:        options.ClientCertClaim,
+		userProvider: options.UserProvider,
+	}
+	if options.ClientCRL != "" {
+		h.crl = &revocationList{path: options.ClientCRL}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			cert := clientCertificate(req)
+			if cert == nil {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			user, err := h.authenticate(req, cert)
+			if err != nil {
+				h.logger.Debug().Err(err).Str("subject", cert.Subject.String()).Msg("client certificate rejected")
+				writeSupportedAuthenticateHeader(w, req)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			claims := &oidc.StandardClaims{
+				OcisID:            user.Id.OpaqueId,
+				Iss:               user.Id.Idp,
+				PreferredUsername: user.Username,
+				Email:             user.Mail,
+			}
+
+			next.ServeHTTP(w, req.WithContext(oidc.NewContext(req.Context(), claims)))
+		})
+	}
+}
+
+type clientCertAuth struct {
+	logger       log.Logger
+	claim        string
+	userProvider backend.UserBackend
+	crl          *revocationList
+}
+
+// clientCertificate returns the leaf certificate of the verified chain or nil if the client did not present one.
+func clientCertificate(req *http.Request) *x509.Certificate {
+	if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 || len(req.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return req.TLS.VerifiedChains[0][0]
+}
+
+// authenticate maps the certificate to an account, either by the subject common name or by the email SAN.
+func (m clientCertAuth) authenticate(req *http.Request, cert *x509.Certificate) (*userv1beta1.User, error) {
+	if m.crl != nil {
+		revoked, err := m.crl.isRevoked(cert, req.TLS.VerifiedChains[0])
+		if err != nil {
+			m.logger.Error().Err(err).Str("crl", m.crl.path).Msg("could not check certificate revocation list")
+			return nil, err
+		}
+		if revoked {
+			return nil, fmt.Errorf("certificate %s is revoked", cert.SerialNumber)
+		}
+	}
+
+	switch m.claim {
+	case config.ClientCertClaimEmail:
+		if len(cert.EmailAddresses) == 0 {
+			return nil, fmt.Errorf("certificate has no email SAN")
+		}
+		return m.userProvider.GetUserByClaims(req.Context(), "mail", cert.EmailAddresses[0], true)
+	default:
+		if cert.Subject.CommonName == "" {
+			return nil, fmt.Errorf("certificate has no common name")
+		}
+		return m.userProvider.GetUserByClaims(req.Context(), "username", cert.Subject.CommonName, true)
+	}
+}
+
+// revocationList holds the certificate revocation lists of the client CAs, read from one file on disk. It is reloaded
+// when the file changes.
+type revocationList struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	// lists maps the issuer names to their revocation lists
+	lists map[string]*issuerList
+}
+
+// issuerList is the revocation list of one CA
+type issuerList struct {
+	list    *pkix.CertificateList
+	revoked map[string]struct{}
+}
+
+// isRevoked checks if the certificate is listed on the revocation list of its issuer, which is the next certificate
+// in the verified chain. The check fails if there is no list for the issuer, or if the list has expired or is not
+// signed by the issuer.
+func (l *revocationList) isRevoked(cert *x509.Certificate, chain []*x509.Certificate) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.load(); err != nil {
+		return false, err
+	}
+
+	if len(chain) < 2 {
+		return false, fmt.Errorf("certificate %s has no issuer in the verified chain", cert.SerialNumber)
+	}
+	issuer := chain[1]
+	// compare the names like they are parsed from the lists
+	var name pkix.RDNSequence
+	if _, err := asn1.Unmarshal(issuer.RawSubject, &name); err != nil {
+		return false, err
+	}
+	il, ok := l.lists[name.String()]
+	if !ok {
+		return false, fmt.Errorf("no revocation list for issuer %s", issuer.Subject)
+	}
+	if err := issuer.CheckCRLSignature(il.list); err != nil {
+		return false, fmt.Errorf("revocation list of issuer %s is not signed by it: %w", issuer.Subject, err)
+	}
+	if il.list.HasExpired(time.Now()) {
+		return false, fmt.Errorf("revocation list of issuer %s expired at %s", issuer.Subject, il.list.TBSCertList.NextUpdate)
+	}
+
+	_, revoked := il.revoked[cert.SerialNumber.String()]
+	return revoked, nil
+}
+
+func (l *revocationList) load() error {
+	info, err := os.Stat(l.path)
+	if err != nil {
+		return err
+	}
+	if l.lists != nil && info.ModTime().Equal(l.modTime) {
+		return nil
+	}
+
+	b, err := ioutil.ReadFile(l.path)
+	if err != nil {
+		return err
+	}
+	ders, err := crlBlocks(b)
+	if err != nil {
+		return err
+	}
+
+	lists := make(map[string]*issuerList, len(ders))
+	for _, der := range ders {
+		list, err := x509.ParseDERCRL(der)
+		if err != nil {
+			return err
+		}
+		issuer := list.TBSCertList.Issuer.String()
+		if il, ok := lists[issuer]; ok && !list.TBSCertList.ThisUpdate.After(il.list.TBSCertList.ThisUpdate) {
+			// keep the most recent list of an issuer
+			continue
+		}
+		revoked := make(map[string]struct{}, len(list.TBSCertList.RevokedCertificates))
+		for _, c := range list.TBSCertList.RevokedCertificates {
+			revoked[c.SerialNumber.String()] = struct{}{}
+		}
+		lists[issuer] = &issuerList{list: list, revoked: revoked}
+	}
+	l.lists = lists
+	l.modTime = info.ModTime()
+	return nil
+}
+
+// crlBlocks returns the DER encoded lists of a file with one DER encoded list or several PEM encoded lists.
+func crlBlocks(b []byte) ([][]byte, error) {
+	if !bytes.HasPrefix(bytes.TrimSpace(b), []byte("-----BEGIN")) {
+		return [][]byte{b}, nil
+	}
+	ders := [][]byte{}
+	for {
+		var block *pem.Block
+		block, b = pem.Decode(b)
+		if block == nil {
+			break
+		}
+		if block.Type == "X509 CRL" {
+			ders = append(ders, block.Bytes)
+		}
+	}
+	if len(ders) == 0 {
+		return nil, fmt.Errorf("no X509 CRL blocks found")
+	}
+	return ders, nil
+}
@@ -0,0 +1,237 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	userv1beta1 "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	"github.com/owncloud/ocis/ocis-pkg/log"
+	"github.com/owncloud/ocis/ocis-pkg/oidc"
+	"github.com/owncloud/ocis/proxy/pkg/config"
+	"github.com/owncloud/ocis/proxy/pkg/user/backend"
+	"github.com/owncloud/ocis/proxy/pkg/user/backend/test"
+)
+
+// testCA is a certificate authority that issues client certificates and revocation lists
+type testCA struct {
+	cert *x509.Certificate
+	key  *rsa.PrivateKey
+}
+
+func newTestCA(t *testing.T, name string) testCA {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return testCA{cert: cert, key: key}
+}
+
+func (ca testCA) issue(serial int64, cn, email string) *x509.Certificate {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if email != "" {
+		tmpl.EmailAddresses = []string{email}
+	}
+	der, _ := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	cert, _ := x509.ParseCertificate(der)
+	return cert
+}
+
+// crl returns a PEM encoded revocation list of the CA that is valid until nextUpdate
+func (ca testCA) crl(t *testing.T, nextUpdate time.Time, revoked ...*x509.Certificate) []byte {
+	list := []pkix.RevokedCertificate{}
+	for _, c := range revoked {
+		list = append(list, pkix.RevokedCertificate{SerialNumber: c.SerialNumber, RevocationTime: time.Now()})
+	}
+	der, err := ca.cert.CreateCRL(rand.Reader, ca.key, list, time.Now().Add(-time.Minute), nextUpdate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})
+}
+
+// clientCertRequest returns a request made with the certificate issued by the CA
+func clientCertRequest(cert *x509.Certificate, ca *x509.Certificate) *http.Request {
+	r := httptest.NewRequest("GET", "https://localhost:9200/remote.php/dav/files/einstein/", nil)
+	if cert != nil {
+		r.TLS = &tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{cert},
+			VerifiedChains:   [][]*x509.Certificate{{cert, ca}},
+		}
+	}
+	return r
+}
+
+func TestClientCertAuth(t *testing.T) {
+	ca := newTestCA(t, "ocis test ca")
+	issue := ca.issue
+
+	einsteinCert := issue(2, "einstein", "einstein@example.org")
+	revokedCert := issue(3, "einstein", "einstein@example.org")
+	unknownCert := issue(4, "feynman", "feynman@example.org")
+
+	dir, _ := ioutil.TempDir("", "client-cert-auth")
+	defer os.RemoveAll(dir)
+	crlPath := filepath.Join(dir, "crl.pem")
+	_ = ioutil.WriteFile(crlPath, ca.crl(t, time.Now().Add(time.Hour), revokedCert), 0600)
+
+	einstein := &userv1beta1.User{Id: &userv1beta1.UserId{OpaqueId: "einstein-id"}, Username: "einstein", Mail: "einstein@example.org"}
+	up := &test.UserBackendMock{
+		GetUserByClaimsFunc: func(ctx context.Context, claim, value string, withRoles bool) (*userv1beta1.User, error) {
+			if (claim == "username" && value == "einstein") || (claim == "mail" && value == "einstein@example.org") {
+				return einstein, nil
+			}
+			return nil, backend.ErrAccountNotFound
+		},
+	}
+
+	tests := []struct {
+		name   string
+		claim  string
+		cert   *x509.Certificate
+		status int
+		user   string
+	}{
+		{"no certificate", config.ClientCertClaimCN, nil, http.StatusTeapot, ""},
+		{"common name", config.ClientCertClaimCN, einsteinCert, http.StatusOK, "einstein-id"},
+		{"email", config.ClientCertClaimEmail, einsteinCert, http.StatusOK, "einstein-id"},
+		{"unknown account", config.ClientCertClaimCN, unknownCert, http.StatusUnauthorized, ""},
+		{"revoked", config.ClientCertClaimCN, revokedCert, http.StatusUnauthorized, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := ClientCertAuth(
+				Logger(log.NewLogger()),
+				UserProvider(up),
+				ClientCRL(crlPath),
+				ClientCertClaim(tt.claim),
+			)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				claims := oidc.FromContext(r.Context())
+				if claims == nil {
+					// requests without certificate are left to the other strategies
+					w.WriteHeader(http.StatusTeapot)
+					return
+				}
+				if claims.OcisID != tt.user {
+					t.Errorf("expected user %s, got %s", tt.user, claims.OcisID)
+				}
+			}))
+
+			w := httptest.NewRecorder()
+			m.ServeHTTP(w, clientCertRequest(tt.cert, ca.cert))
+
+			if w.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, w.Code)
+			}
+		})
+	}
+}
+
+func TestClientCertRevocation(t *testing.T) {
+	ca, otherCA, unlistedCA := newTestCA(t, "ocis test ca"), newTestCA(t, "other test ca"), newTestCA(t, "unlisted test ca")
+	// a list with the name of the CA, but signed by another key
+	forgedCA := newTestCA(t, "ocis test ca")
+
+	valid, revoked := ca.issue(2, "einstein", ""), ca.issue(3, "einstein", "")
+	otherValid, otherRevoked := otherCA.issue(2, "einstein", ""), otherCA.issue(4, "einstein", "")
+	unlisted := unlistedCA.issue(2, "einstein", "")
+
+	einstein := &userv1beta1.User{Id: &userv1beta1.UserId{OpaqueId: "einstein-id"}, Username: "einstein"}
+	up := &test.UserBackendMock{
+		GetUserByClaimsFunc: func(ctx context.Context, claim, value string, withRoles bool) (*userv1beta1.User, error) {
+			return einstein, nil
+		},
+	}
+
+	dir, _ := ioutil.TempDir("", "client-cert-revocation")
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name   string
+		crls   [][]byte
+		cert   *x509.Certificate
+		ca     *x509.Certificate
+		status int
+	}{
+		{"valid", [][]byte{ca.crl(t, time.Now().Add(time.Hour), revoked)}, valid, ca.cert, http.StatusOK},
+		{"revoked", [][]byte{ca.crl(t, time.Now().Add(time.Hour), revoked)}, revoked, ca.cert, http.StatusUnauthorized},
+		{
+			"list per issuer",
+			[][]byte{ca.crl(t, time.Now().Add(time.Hour), revoked), otherCA.crl(t, time.Now().Add(time.Hour), otherRevoked)},
+			otherValid, otherCA.cert, http.StatusOK,
+		},
+		{
+			"revoked by the other issuer",
+			[][]byte{ca.crl(t, time.Now().Add(time.Hour), revoked), otherCA.crl(t, time.Now().Add(time.Hour), otherRevoked)},
+			otherRevoked, otherCA.cert, http.StatusUnauthorized,
+		},
+		{
+			"serial revoked by another issuer",
+			[][]byte{ca.crl(t, time.Now().Add(time.Hour)), otherCA.crl(t, time.Now().Add(time.Hour), otherValid)},
+			valid, ca.cert, http.StatusOK,
+		},
+		{"missing list", [][]byte{ca.crl(t, time.Now().Add(time.Hour))}, unlisted, unlistedCA.cert, http.StatusUnauthorized},
+		{"expired list", [][]byte{ca.crl(t, time.Now().Add(-time.Second))}, valid, ca.cert, http.StatusUnauthorized},
+		{"badly signed list", [][]byte{forgedCA.crl(t, time.Now().Add(time.Hour))}, revoked, ca.cert, http.StatusUnauthorized},
+		{"badly signed list of a valid certificate", [][]byte{forgedCA.crl(t, time.Now().Add(time.Hour))}, valid, ca.cert, http.StatusUnauthorized},
+		{"no issuer in the chain", [][]byte{ca.crl(t, time.Now().Add(time.Hour))}, valid, nil, http.StatusUnauthorized},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crlPath := filepath.Join(dir, fmt.Sprintf("crl-%d.pem", i))
+			if err := ioutil.WriteFile(crlPath, bytes.Join(tt.crls, nil), 0600); err != nil {
+				t.Fatal(err)
+			}
+			m := ClientCertAuth(
+				Logger(log.NewLogger()),
+				UserProvider(up),
+				ClientCRL(crlPath),
+			)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			r := clientCertRequest(tt.cert, tt.ca)
+			if tt.ca == nil {
+				r.TLS.VerifiedChains = [][]*x509.Certificate{{tt.cert}}
+			}
+			w := httptest.NewRecorder()
+			m.ServeHTTP(w, r)
+
+			if w.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, w.Code)
+			}
+		})
+	}
+}
@@ -3,8 +3,12 @@ package middleware
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
+	gosync "sync"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
@@ -13,12 +17,15 @@ import (
 	"github.com/owncloud/ocis/ocis-pkg/log"
 	"github.com/owncloud/ocis/ocis-pkg/oidc"
 	"github.com/owncloud/ocis/ocis-pkg/sync"
+	"github.com/owncloud/ocis/proxy/pkg/config"
 	"golang.org/x/oauth2"
 )
 
 // OIDCProvider used to mock the oidc provider during tests
 type OIDCProvider interface {
 	UserInfo(ctx context.Context, ts oauth2.TokenSource) (*gOidc.UserInfo, error)
+	Verifier(config *gOidc.Config) *gOidc.IDTokenVerifier
+	Claims(v interface{}) error
 }
 
 // OIDCAuth provides a middleware to check access secured by a static token.
@@ -26,13 +33,21 @@ func OIDCAuth(optionSetters ...Option) func(next http.Handler) http.Handler {
 	options := newOptions(optionSetters...)
 	tokenCache := sync.NewCache(options.UserinfoCacheSize)
 
-	h := oidcAuth{
+	verifyMethod, err := accessTokenVerifyMethod(options.AccessTokenVerifyMethod)
+	if err != nil {
+		options.Logger.Fatal().Err(err).Msg("Could not initialize oidc auth")
+	}
+
+	h := &oidcAuth{
 		logger:        options.Logger,
 		providerFunc:  options.OIDCProviderFunc,
 		httpClient:    options.HTTPClient,
 		oidcIss:       options.OIDCIss,
 		tokenCache:    &tokenCache,
 		tokenCacheTTL: options.UserinfoCacheTTL,
+		verifyMethod:  verifyMethod,
+		audience:      options.OIDCAudience,
+		introspection: options.OIDCIntrospection,
 	}
 
 	return func(next http.Handler) http.Handler {
@@ -68,47 +83,59 @@ func OIDCAuth(optionSetters ...Option) func(next http.Handler) http.Handler {
 	}
 }
 
+// accessTokenVerifyMethod checks the configured access token verification method, an empty one means userinfo.
+func accessTokenVerifyMethod(method string) (string, error) {
+	switch method {
+	case "":
+		return config.AccessTokenVerificationUserinfo, nil
+	case config.AccessTokenVerificationUserinfo, config.AccessTokenVerificationJWT, config.AccessTokenVerificationIntrospection:
+		return method, nil
+	default:
+		return "", fmt.Errorf("unknown access token verification method %q, expected %v, %v or %v", method,
+			config.AccessTokenVerificationUserinfo, config.AccessTokenVerificationJWT, config.AccessTokenVerificationIntrospection)
+	}
+}
+
 type oidcAuth struct {
-	logger        log.Logger
-	provider      OIDCProvider
-	providerFunc  func() (OIDCProvider, error)
+	logger log.Logger
+
+	// providerMu guards provider, which is initialized by the first request.
+	providerMu   gosync.Mutex
+	provider     OIDCProvider
+	providerFunc func() (OIDCProvider, error)
+
 	httpClient    *http.Client
 	oidcIss       string
 	tokenCache    *sync.Cache
 	tokenCacheTTL time.Duration
+	verifyMethod  string
+	audience      string
+	introspection config.OIDCIntrospection
+
+	// verifier is built once, by the first request verifying a jwt.
+	verifierOnce gosync.Once
+	verifier     *gOidc.IDTokenVerifier
 }
 
-func (m oidcAuth) getClaims(token string, req *http.Request) (claims oidc.StandardClaims, status int) {
+func (m *oidcAuth) getClaims(token string, req *http.Request) (claims oidc.StandardClaims, status int) {
 	hit := m.tokenCache.Load(token)
 	if hit == nil {
-		// TODO cache userinfo for access token if we can determine the expiry (which works in case it is a jwt based access token)
-		oauth2Token := &oauth2.Token{
-			AccessToken: token,
+		var expiration time.Time
+		switch {
+		case m.verifyMethod == config.AccessTokenVerificationJWT && isJWT(token):
+			claims, expiration, status = m.claimsFromJWT(token, req)
+		case m.verifyMethod == config.AccessTokenVerificationJWT, m.verifyMethod == config.AccessTokenVerificationIntrospection:
+			claims, expiration, status = m.claimsFromIntrospection(token, req)
+		default:
+			claims, expiration, status = m.claimsFromUserinfo(token, req)
 		}
-
-		userInfo, err := m.getProvider().UserInfo(
-			context.WithValue(req.Context(), oauth2.HTTPClient, m.httpClient),
-			oauth2.StaticTokenSource(oauth2Token),
-		)
-		if err != nil {
-			m.logger.Error().Err(err).Str("token", token).Msg("Failed to get userinfo")
-			status = http.StatusUnauthorized
+		if status != 0 {
 			return
 		}
 
-		if err := userInfo.Claims(&claims); err != nil {
-			m.logger.Error().Err(err).Interface("userinfo", userInfo).Msg("failed to unmarshal userinfo claims")
-			status = http.StatusInternalServerError
-			return
-		}
-
-		//TODO: This should be read from the token instead of config
-		claims.Iss = m.oidcIss
-
-		expiration := m.extractExpiration(token)
 		m.tokenCache.Store(token, claims, expiration)
 
-		m.logger.Debug().Interface("claims", claims).Interface("userInfo", userInfo).Time("expiration", expiration.UTC()).Msg("unmarshalled and cached userinfo")
+		m.logger.Debug().Interface("claims", claims).Str("method", m.verifyMethod).Time("expiration", expiration.UTC()).Msg("verified and cached access token claims")
 		return
 	}
 
@@ -121,10 +148,177 @@ func (m oidcAuth) getClaims(token string, req *http.Request) (claims oidc.Standa
 	return
 }
 
+// claimsFromUserinfo resolves the token at the userinfo endpoint of the issuer.
+func (m *oidcAuth) claimsFromUserinfo(token string, req *http.Request) (claims oidc.StandardClaims, expiration time.Time, status int) {
+	oauth2Token := &oauth2.Token{
+		AccessToken: token,
+	}
+
+	userInfo, err := m.getProvider().UserInfo(
+		context.WithValue(req.Context(), oauth2.HTTPClient, m.httpClient),
+		oauth2.StaticTokenSource(oauth2Token),
+	)
+	if err != nil {
+		m.logger.Error().Err(err).Str("token", token).Msg("Failed to get userinfo")
+		status = http.StatusUnauthorized
+		return
+	}
+
+	if err := userInfo.Claims(&claims); err != nil {
+		m.logger.Error().Err(err).Interface("userinfo", userInfo).Msg("failed to unmarshal userinfo claims")
+		status = http.StatusInternalServerError
+		return
+	}
+
+	//TODO: This should be read from the token instead of config
+	claims.Iss = m.oidcIss
+
+	expiration = m.extractExpiration(token)
+	return
+}
+
+// claimsFromJWT verifies the signature of the token against the keys published by the issuer as well as issuer,
+// audience and expiry. Keys are fetched again when the token was signed with an unknown key, which handles key rotation.
+func (m *oidcAuth) claimsFromJWT(token string, req *http.Request) (claims oidc.StandardClaims, expiration time.Time, status int) {
+	// the provider was initialized before the claims are resolved
+	m.verifierOnce.Do(func() {
+		m.verifier = m.getProvider().Verifier(&gOidc.Config{
+			ClientID:          m.audience,
+			SkipClientIDCheck: m.audience == "",
+		})
+	})
+
+	at, err := m.verifier.Verify(context.WithValue(req.Context(), oauth2.HTTPClient, m.httpClient), token)
+	if err != nil {
+		m.logger.Error().Err(err).Msg("Failed to verify access token")
+		status = http.StatusUnauthorized
+		return
+	}
+
+	if err := at.Claims(&claims); err != nil {
+		m.logger.Error().Err(err).Msg("failed to unmarshal access token claims")
+		status = http.StatusInternalServerError
+		return
+	}
+
+	expiration = at.Expiry
+	return
+}
+
+// introspectionResponse is the part of a RFC 7662 token introspection response not covered by the standard claims.
+type introspectionResponse struct {
+	Active   bool        `json:"active"`
+	Exp      int64       `json:"exp"`
+	Aud      interface{} `json:"aud"`
+	Username string      `json:"username"`
+}
+
+// claimsFromIntrospection checks the token at the RFC 7662 introspection endpoint of the issuer.
+func (m *oidcAuth) claimsFromIntrospection(token string, req *http.Request) (claims oidc.StandardClaims, expiration time.Time, status int) {
+	endpoint := m.introspection.Endpoint
+	if endpoint == "" {
+		md := oidc.ProviderMetadata{}
+		if err := m.getProvider().Claims(&md); err != nil || md.IntrospectionEndpoint == "" {
+			m.logger.Error().Err(err).Msg("no introspection endpoint configured or discovered")
+			status = http.StatusUnauthorized
+			return
+		}
+		endpoint = md.IntrospectionEndpoint
+	}
+
+	form := url.Values{}
+	form.Set("token", token)
+	form.Set("token_type_hint", "access_token")
+	ireq, err := http.NewRequestWithContext(req.Context(), http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		m.logger.Error().Err(err).Str("endpoint", endpoint).Msg("could not create introspection request")
+		status = http.StatusInternalServerError
+		return
+	}
+	ireq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	ireq.Header.Set("Accept", "application/json")
+	if m.introspection.ClientID != "" {
+		ireq.SetBasicAuth(m.introspection.ClientID, m.introspection.ClientSecret)
+	}
+
+	client := m.httpClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(ireq)
+	if err != nil {
+		m.logger.Error().Err(err).Str("endpoint", endpoint).Msg("Failed to introspect token")
+		status = http.StatusUnauthorized
+		return
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil || res.StatusCode != http.StatusOK {
+		m.logger.Error().Err(err).Int("status", res.StatusCode).Str("endpoint", endpoint).Msg("Failed to introspect token")
+		status = http.StatusUnauthorized
+		return
+	}
+
+	ir := introspectionResponse{}
+	if err := json.Unmarshal(body, &ir); err != nil {
+		m.logger.Error().Err(err).Msg("failed to unmarshal introspection response")
+		status = http.StatusInternalServerError
+		return
+	}
+	if !ir.Active {
+		m.logger.Debug().Msg("introspected token is not active")
+		status = http.StatusUnauthorized
+		return
+	}
+	if m.audience != "" && !containsAudience(ir.Aud, m.audience) {
+		m.logger.Error().Interface("aud", ir.Aud).Str("expected", m.audience).Msg("introspected token was issued for another audience")
+		status = http.StatusUnauthorized
+		return
+	}
+
+	if err := json.Unmarshal(body, &claims); err != nil {
+		m.logger.Error().Err(err).Msg("failed to unmarshal introspection claims")
+		status = http.StatusInternalServerError
+		return
+	}
+	if claims.PreferredUsername == "" {
+		claims.PreferredUsername = ir.Username
+	}
+	if claims.Iss == "" {
+		claims.Iss = m.oidcIss
+	}
+
+	expiration = time.Now().Add(m.tokenCacheTTL)
+	if ir.Exp != 0 && time.Unix(ir.Exp, 0).Before(expiration) {
+		expiration = time.Unix(ir.Exp, 0)
+	}
+	return
+}
+
+// containsAudience checks the aud claim, which is either a single string or an array of strings.
+func containsAudience(aud interface{}, expected string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == expected
+	case []interface{}:
+		for _, a := range v {
+			if s, ok := a.(string); ok && s == expected {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isJWT checks if the token looks like a signed jwt.
+func isJWT(token string) bool {
+	return len(strings.SplitN(token, ".", 4)) == 3
+}
+
 // extractExpiration tries to parse and extract the expiration from the provided token. It might not even be a jwt.
 // defaults to the configured fallback TTL.
-// TODO: use introspection endpoint if available in the oidc configuration. Still needs a fallback to configured TTL.
-func (m oidcAuth) extractExpiration(token string) time.Time {
+func (m *oidcAuth) extractExpiration(token string) time.Time {
 	defaultExpiration := time.Now().Add(m.tokenCacheTTL)
 
 	s := strings.SplitN(token, ".", 4)
@@ -146,7 +340,7 @@ func (m oidcAuth) extractExpiration(token string) time.Time {
 	return time.Unix(at.ExpiresAt, 0)
 }
 
-func (m oidcAuth) shouldServe(req *http.Request) bool {
+func (m *oidcAuth) shouldServe(req *http.Request) bool {
 	header := req.Header.Get("Authorization")
 
 	if m.oidcIss == "" {
@@ -165,6 +359,9 @@ func (m oidcAuth) shouldServe(req *http.Request) bool {
 }
 
 func (m *oidcAuth) getProvider() OIDCProvider {
+	m.providerMu.Lock()
+	defer m.providerMu.Unlock()
+
 	if m.provider == nil {
 		// Lazily initialize a provider
 
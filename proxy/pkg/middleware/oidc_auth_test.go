@@ -2,13 +2,23 @@ package middleware
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/coreos/go-oidc"
+	"github.com/dgrijalva/jwt-go"
 	"github.com/owncloud/ocis/ocis-pkg/log"
+	ocisoidc "github.com/owncloud/ocis/ocis-pkg/oidc"
+	"github.com/owncloud/ocis/proxy/pkg/config"
 	"golang.org/x/oauth2"
 )
 
@@ -46,6 +56,16 @@ func (m mockOIDCProvider) UserInfo(ctx context.Context, ts oauth2.TokenSource) (
 	panic("UserInfo was called in test but not mocked")
 }
 
+// Verifier will panic if the function has been called
+func (m mockOIDCProvider) Verifier(config *oidc.Config) *oidc.IDTokenVerifier {
+	panic("Verifier was called in test but not mocked")
+}
+
+// Claims will panic if the function has been called
+func (m mockOIDCProvider) Claims(v interface{}) error {
+	panic("Claims was called in test but not mocked")
+}
+
 func mockOP(retErr bool) OIDCProvider {
 	if retErr {
 		return &mockOIDCProvider{
@@ -65,3 +85,296 @@ func mockOP(retErr bool) OIDCProvider {
 	}
 
 }
+
+func TestOIDCAuthJWTVerification(t *testing.T) {
+	iss := newMockIssuer(t)
+	defer iss.Close()
+
+	rotated := iss.newKey(t)
+	unknown := iss.newKey(t)
+	iss.publish(iss.keys[0])
+
+	now := time.Now()
+	tests := []struct {
+		name   string
+		token  func() string
+		status int
+	}{
+		{
+			name: "valid token",
+			token: func() string {
+				return iss.sign(t, iss.keys[0], jwt.MapClaims{"iss": iss.URL, "aud": "ocis", "exp": now.Add(time.Hour).Unix(), "email": "einstein@example.org"})
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "expired token",
+			token: func() string {
+				return iss.sign(t, iss.keys[0], jwt.MapClaims{"iss": iss.URL, "aud": "ocis", "exp": now.Add(-time.Hour).Unix(), "email": "einstein@example.org"})
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "wrong audience",
+			token: func() string {
+				return iss.sign(t, iss.keys[0], jwt.MapClaims{"iss": iss.URL, "aud": "other", "exp": now.Add(time.Hour).Unix(), "email": "einstein@example.org"})
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "wrong issuer",
+			token: func() string {
+				return iss.sign(t, iss.keys[0], jwt.MapClaims{"iss": "https://evil.example.org", "aud": "ocis", "exp": now.Add(time.Hour).Unix(), "email": "einstein@example.org"})
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "unknown signing key",
+			token: func() string {
+				return iss.sign(t, unknown, jwt.MapClaims{"iss": iss.URL, "aud": "ocis", "exp": now.Add(time.Hour).Unix(), "email": "einstein@example.org"})
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "rotated signing key",
+			token: func() string {
+				iss.publish(iss.keys[0], rotated)
+				return iss.sign(t, rotated, jwt.MapClaims{"iss": iss.URL, "aud": "ocis", "exp": now.Add(time.Hour).Unix(), "email": "einstein@example.org"})
+			},
+			status: http.StatusOK,
+		},
+	}
+
+	m := iss.middleware(t, config.AccessTokenVerificationJWT)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, claims := serveBearer(m, tt.token())
+			if status != tt.status {
+				t.Fatalf("expected status %d, got %d", tt.status, status)
+			}
+			if status == http.StatusOK && claims.Email != "einstein@example.org" {
+				t.Errorf("expected the email claim to be taken from the token, got %v", claims)
+			}
+		})
+	}
+}
+
+func TestOIDCAuthJWTConcurrentVerification(t *testing.T) {
+	iss := newMockIssuer(t)
+	defer iss.Close()
+	iss.publish(iss.keys[0])
+
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, iss.Client())
+	provider, err := oidc.NewProvider(ctx, iss.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the verifier is built by the first of the concurrent requests
+	m := &oidcAuth{
+		logger:     log.NewLogger(),
+		provider:   provider,
+		httpClient: iss.Client(),
+		audience:   "ocis",
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		token := iss.sign(t, iss.keys[0], jwt.MapClaims{"iss": iss.URL, "aud": "ocis", "exp": time.Now().Add(time.Hour).Unix(), "jti": fmt.Sprint(i)})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := httptest.NewRequest(http.MethodGet, "https://localhost:9200/ocs/v1.php/cloud/user", nil)
+			if _, _, status := m.claimsFromJWT(token, r); status != 0 {
+				t.Errorf("expected the token to be verified, got status %d", status)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestAccessTokenVerifyMethod(t *testing.T) {
+	for method, expected := range map[string]string{
+		"":                                     config.AccessTokenVerificationUserinfo,
+		config.AccessTokenVerificationUserinfo: config.AccessTokenVerificationUserinfo,
+		config.AccessTokenVerificationJWT:      config.AccessTokenVerificationJWT,
+		config.AccessTokenVerificationIntrospection: config.AccessTokenVerificationIntrospection,
+	} {
+		if m, err := accessTokenVerifyMethod(method); err != nil || m != expected {
+			t.Errorf("expected %q for %q, got %q, %v", expected, method, m, err)
+		}
+	}
+
+	if _, err := accessTokenVerifyMethod("jwks"); err == nil {
+		t.Error("expected an error for an unknown verification method")
+	}
+}
+
+func TestOIDCAuthIntrospection(t *testing.T) {
+	iss := newMockIssuer(t)
+	defer iss.Close()
+
+	iss.introspected["active-token"] = map[string]interface{}{
+		"active":   true,
+		"aud":      []string{"ocis", "web"},
+		"exp":      time.Now().Add(time.Hour).Unix(),
+		"username": "einstein",
+		"email":    "einstein@example.org",
+	}
+	iss.introspected["foreign-token"] = map[string]interface{}{
+		"active": true,
+		"aud":    "other",
+	}
+	iss.introspected["inactive-token"] = map[string]interface{}{
+		"active": false,
+	}
+
+	for _, method := range []string{config.AccessTokenVerificationJWT, config.AccessTokenVerificationIntrospection} {
+		m := iss.middleware(t, method)
+		for token, expected := range map[string]int{
+			"active-token":   http.StatusOK,
+			"foreign-token":  http.StatusUnauthorized,
+			"inactive-token": http.StatusUnauthorized,
+			"unknown-token":  http.StatusUnauthorized,
+		} {
+			status, claims := serveBearer(m, token)
+			if status != expected {
+				t.Errorf("%s: expected status %d for %s, got %d", method, expected, token, status)
+			}
+			if status == http.StatusOK && (claims.PreferredUsername != "einstein" || claims.Email != "einstein@example.org") {
+				t.Errorf("%s: expected claims to be taken from the introspection response, got %v", method, claims)
+			}
+		}
+	}
+
+	if iss.introspectionAuth != "proxy:secret" {
+		t.Errorf("expected the introspection request to be authenticated with the client credentials, got %q", iss.introspectionAuth)
+	}
+}
+
+func serveBearer(m func(http.Handler) http.Handler, token string) (int, *ocisoidc.StandardClaims) {
+	var claims *ocisoidc.StandardClaims
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims = ocisoidc.FromContext(r.Context())
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "https://localhost:9200/ocs/v1.php/cloud/user", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	m(next).ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		return w.Code, nil
+	}
+	return w.Code, claims
+}
+
+// mockIssuer is a minimal openid connect issuer with discovery, jwks and token introspection.
+type mockIssuer struct {
+	*httptest.Server
+
+	mu                sync.Mutex
+	keys              []*mockKey
+	published         []*mockKey
+	introspected      map[string]map[string]interface{}
+	introspectionAuth string
+}
+
+type mockKey struct {
+	id  string
+	key *rsa.PrivateKey
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	iss := &mockIssuer{
+		introspected: map[string]map[string]interface{}{},
+	}
+	iss.newKey(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                 iss.URL,
+			"jwks_uri":               iss.URL + "/jwks",
+			"userinfo_endpoint":      iss.URL + "/userinfo",
+			"introspection_endpoint": iss.URL + "/introspect",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		iss.mu.Lock()
+		defer iss.mu.Unlock()
+		keys := []map[string]string{}
+		for _, k := range iss.published {
+			keys = append(keys, map[string]string{
+				"kty": "RSA",
+				"alg": "RS256",
+				"use": "sig",
+				"kid": k.id,
+				"n":   base64.RawURLEncoding.EncodeToString(k.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.key.E)).Bytes()),
+			})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys})
+	})
+	mux.HandleFunc("/introspect", func(w http.ResponseWriter, r *http.Request) {
+		iss.mu.Lock()
+		defer iss.mu.Unlock()
+		if u, p, ok := r.BasicAuth(); ok {
+			iss.introspectionAuth = u + ":" + p
+		}
+		res, ok := iss.introspected[r.PostFormValue("token")]
+		if !ok {
+			res = map[string]interface{}{"active": false}
+		}
+		_ = json.NewEncoder(w).Encode(res)
+	})
+	iss.Server = httptest.NewServer(mux)
+
+	return iss
+}
+
+func (iss *mockIssuer) newKey(t *testing.T) *mockKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := &mockKey{id: fmt.Sprintf("key-%d", len(iss.keys)), key: key}
+	iss.keys = append(iss.keys, k)
+	return k
+}
+
+func (iss *mockIssuer) publish(keys ...*mockKey) {
+	iss.mu.Lock()
+	defer iss.mu.Unlock()
+	iss.published = keys
+}
+
+func (iss *mockIssuer) sign(t *testing.T, k *mockKey, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = k.id
+	s, err := token.SignedString(k.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func (iss *mockIssuer) middleware(t *testing.T, method string) func(http.Handler) http.Handler {
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, iss.Client())
+	provider, err := oidc.NewProvider(ctx, iss.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return OIDCAuth(
+		Logger(log.NewLogger()),
+		OIDCProviderFunc(func() (OIDCProvider, error) {
+			return provider, nil
+		}),
+		HTTPClient(iss.Client()),
+		OIDCIss(iss.URL),
+		AccessTokenVerifyMethod(method),
+		OIDCAudience("ocis"),
+		OIDCIntrospection(config.OIDCIntrospection{ClientID: "proxy", ClientSecret: "secret"}),
+		TokenCacheTTL(time.Minute),
+	)
+}
@@ -1,7 +1,7 @@
 package middleware
 
 import (
-	"github.com/owncloud/ocis/proxy/pkg/user/backend"
+	"io"
 	"net/http"
 	"time"
 
@@ -11,6 +11,7 @@ import (
 	acc "github.com/owncloud/ocis/accounts/pkg/proto/v0"
 	"github.com/owncloud/ocis/ocis-pkg/log"
 	"github.com/owncloud/ocis/proxy/pkg/config"
+	"github.com/owncloud/ocis/proxy/pkg/user/backend"
 	storepb "github.com/owncloud/ocis/store/pkg/proto/v0"
 )
 
@@ -35,22 +36,42 @@ type Options struct {
 	OIDCProviderFunc func() (OIDCProvider, error)
 	// OIDCIss is the oidcAuth-issuer
 	OIDCIss string
+	// AccessTokenVerifyMethod selects how the oidc_auth middleware verifies access tokens
+	AccessTokenVerifyMethod string
+	// OIDCAudience is the audience access tokens must be issued for
+	OIDCAudience string
+	// OIDCIntrospection configures the token introspection
+	OIDCIntrospection config.OIDCIntrospection
 	// RevaGatewayClient to send requests to the reva gateway
 	RevaGatewayClient gateway.GatewayAPIClient
 	// Store for persisting data
 	Store storepb.StoreService
 	// PreSignedURLConfig to configure the middleware
 	PreSignedURLConfig config.PreSignedURL
+	// TrustedProxies are the reverse proxies whose X-Forwarded-Proto header is trusted
+	TrustedProxies []string
 	// AutoprovisionAccounts when an accountResolver does not exist.
 	AutoprovisionAccounts bool
 	// EnableBasicAuth to allow basic auth
 	EnableBasicAuth bool
+	// EnableAppTokens to accept app tokens in place of passwords for basic auth
+	EnableAppTokens bool
+	// EnableClientCertAuth to authenticate requests with TLS client certificates
+	EnableClientCertAuth bool
+	// ClientCRL is the path of the revocation list client certificates are checked against
+	ClientCRL string
+	// ClientCertClaim selects how client certificates are mapped to accounts
+	ClientCertClaim string
 	// UserinfoCacheSize defines the max number of entries in the userinfo cache, intended for the oidc_auth middleware
 	UserinfoCacheSize int
 	// UserinfoCacheTTL sets the max cache duration for the userinfo cache, intended for the oidc_auth middleware
 	UserinfoCacheTTL time.Duration
 	// CredentialsByUserAgent sets the auth challenges on a per user-agent basis
 	CredentialsByUserAgent map[string]string
+	// AccessLogConfig to configure the access log middleware
+	AccessLogConfig config.AccessLog
+	// AccessLogWriter is the destination of the access log lines
+	AccessLogWriter io.Writer
 }
 
 // newOptions initializes the available default options.
@@ -113,6 +134,27 @@ func OIDCIss(iss string) Option {
 	}
 }
 
+// AccessTokenVerifyMethod sets the access token verification method
+func AccessTokenVerifyMethod(method string) Option {
+	return func(o *Options) {
+		o.AccessTokenVerifyMethod = method
+	}
+}
+
+// OIDCAudience sets the audience access tokens must be issued for
+func OIDCAudience(aud string) Option {
+	return func(o *Options) {
+		o.OIDCAudience = aud
+	}
+}
+
+// OIDCIntrospection sets the token introspection config
+func OIDCIntrospection(cfg config.OIDCIntrospection) Option {
+	return func(o *Options) {
+		o.OIDCIntrospection = cfg
+	}
+}
+
 // CredentialsByUserAgent sets UserAgentChallenges.
 func CredentialsByUserAgent(v map[string]string) Option {
 	return func(o *Options) {
@@ -134,6 +176,13 @@ func Store(sc storepb.StoreService) Option {
 	}
 }
 
+// TrustedProxies provides a function to set the TrustedProxies option
+func TrustedProxies(val []string) Option {
+	return func(o *Options) {
+		o.TrustedProxies = val
+	}
+}
+
 // PreSignedURLConfig provides a function to set the PreSignedURL config
 func PreSignedURLConfig(cfg config.PreSignedURL) Option {
 	return func(o *Options) {
@@ -155,6 +204,34 @@ func EnableBasicAuth(enableBasicAuth bool) Option {
 	}
 }
 
+// EnableAppTokens provides a function to set the EnableAppTokens config
+func EnableAppTokens(enableAppTokens bool) Option {
+	return func(o *Options) {
+		o.EnableAppTokens = enableAppTokens
+	}
+}
+
+// EnableClientCertAuth provides a function to set the EnableClientCertAuth config
+func EnableClientCertAuth(enableClientCertAuth bool) Option {
+	return func(o *Options) {
+		o.EnableClientCertAuth = enableClientCertAuth
+	}
+}
+
+// ClientCRL provides a function to set the path of the client certificate revocation list
+func ClientCRL(path string) Option {
+	return func(o *Options) {
+		o.ClientCRL = path
+	}
+}
+
+// ClientCertClaim provides a function to set how client certificates are mapped to accounts
+func ClientCertClaim(claim string) Option {
+	return func(o *Options) {
+		o.ClientCertClaim = claim
+	}
+}
+
 // TokenCacheSize provides a function to set the TokenCacheSize
 func TokenCacheSize(size int) Option {
 	return func(o *Options) {
@@ -175,3 +252,17 @@ func UserProvider(up backend.UserBackend) Option {
 		o.UserProvider = up
 	}
 }
+
+// AccessLogConfig provides a function to set the AccessLog config
+func AccessLogConfig(cfg config.AccessLog) Option {
+	return func(o *Options) {
+		o.AccessLogConfig = cfg
+	}
+}
+
+// AccessLogWriter provides a function to set the access log destination
+func AccessLogWriter(w io.Writer) Option {
+	return func(o *Options) {
+		o.AccessLogWriter = w
+	}
+}
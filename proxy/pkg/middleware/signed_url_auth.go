@@ -2,21 +2,19 @@ package middleware
 
 import (
 	"context"
-	"crypto/sha512"
-	"encoding/hex"
 	"errors"
 	"fmt"
-	revauser "github.com/cs3org/reva/pkg/user"
-	"github.com/owncloud/ocis/proxy/pkg/user/backend"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
 
+	revauser "github.com/cs3org/reva/pkg/user"
 	"github.com/owncloud/ocis/ocis-pkg/log"
+	"github.com/owncloud/ocis/ocis-pkg/signedurl"
 	"github.com/owncloud/ocis/proxy/pkg/config"
+	"github.com/owncloud/ocis/proxy/pkg/user/backend"
 	store "github.com/owncloud/ocis/store/pkg/proto/v0"
-	"golang.org/x/crypto/pbkdf2"
 )
 
 // SignedURLAuth provides a middleware to check access secured by a signed URL.
@@ -28,6 +26,7 @@ func SignedURLAuth(optionSetters ...Option) func(next http.Handler) http.Handler
 			next:               next,
 			logger:             options.Logger,
 			preSignedURLConfig: options.PreSignedURLConfig,
+			trustedProxies:     options.TrustedProxies,
 			store:              options.Store,
 			userProvider:       options.UserProvider,
 		}
@@ -38,6 +37,7 @@ type signedURLAuth struct {
 	next               http.Handler
 	logger             log.Logger
 	preSignedURLConfig config.PreSignedURL
+	trustedProxies     []string
 	userProvider       backend.UserBackend
 	store              store.StoreService
 }
@@ -180,27 +180,21 @@ func (m signedURLAuth) signatureIsValid(req *http.Request) (ok bool, err error)
 	req.URL.RawQuery = q.Encode()
 	url := req.URL.String()
 	if !req.URL.IsAbs() {
-		url = "https://" + req.Host + url // TODO where do we get the scheme from
+		url = signedurl.Scheme(req, m.trustedProxies) + "://" + req.Host + url
 	}
 
 	return m.createSignature(url, signingKey) == signature, nil
 }
 
 func (m signedURLAuth) createSignature(url string, signingKey []byte) string {
-	// the oc10 signature check: $hash = \hash_pbkdf2("sha512", $url, $signingKey, 10000, 64, false);
-	// - sets the length of the output string to 64
-	// - sets raw output to false ->  if raw_output is FALSE length corresponds to twice the byte-length of the derived key (as every byte of the key is returned as two hexits).
-	// TODO change to length 128 in oc10?
-	// fo golangs pbkdf2.Key we need to use 32 because it will be encoded into 64 hexits later
-	hash := pbkdf2.Key([]byte(url), signingKey, 10000, 32, sha512.New)
-	return hex.EncodeToString(hash)
+	return signedurl.Signature(url, signingKey)
 }
 
 func (m signedURLAuth) getSigningKey(ctx context.Context, ocisID string) ([]byte, error) {
 	res, err := m.store.Read(ctx, &store.ReadRequest{
 		Options: &store.ReadOptions{
-			Database: "proxy",
-			Table:    "signing-keys",
+			Database: signedurl.Database,
+			Table:    signedurl.Table,
 		},
 		Key: ocisID,
 	})
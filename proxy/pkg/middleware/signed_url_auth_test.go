@@ -1,9 +1,17 @@
 package middleware
 
 import (
+	"crypto/tls"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
+
+	userv1beta1 "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	revauser "github.com/cs3org/reva/pkg/user"
+	"github.com/owncloud/ocis/ocis-pkg/log"
+	"github.com/owncloud/ocis/ocis-pkg/signedurl"
+	store "github.com/owncloud/ocis/store/pkg/proto/v0"
 )
 
 func TestSignedURLAuth_shouldServe(t *testing.T) {
@@ -134,3 +142,50 @@ func TestSignedURLAuth_createSignature(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestSignedURLAuth_signatureIsValid(t *testing.T) {
+	s := &mockStore{records: map[string]*store.Record{
+		"einstein-id": {Key: "einstein-id", Value: []byte("somerandomkey")},
+	}}
+	pua := signedURLAuth{logger: log.NewLogger(), store: s, trustedProxies: []string{"10.0.0.1"}}
+	einstein := &userv1beta1.User{Id: &userv1beta1.UserId{OpaqueId: "einstein-id"}, Username: "einstein"}
+
+	tests := []struct {
+		signedScheme   string
+		remoteAddr     string
+		tls            bool
+		forwardedProto string
+		key            string
+		expected       bool
+	}{
+		{"https", "10.0.0.1:1234", false, "https", "somerandomkey", true},
+		{"https", "192.0.2.1:1234", true, "", "somerandomkey", true},
+		{"http", "192.0.2.1:1234", false, "", "somerandomkey", true},
+		{"http", "10.0.0.1:1234", false, "http", "somerandomkey", true},
+		{"https", "10.0.0.1:1234", false, "http", "somerandomkey", false},
+		{"https", "192.0.2.1:1234", false, "https", "somerandomkey", false},
+		{"http", "192.0.2.1:1234", true, "http", "somerandomkey", false},
+		{"https", "10.0.0.1:1234", false, "https", "rotatedkey", false},
+	}
+
+	for _, tt := range tests {
+		u, _ := url.Parse(tt.signedScheme + "://cloud.example.com/remote.php/dav/files/einstein/a.txt")
+		signed := signedurl.Sign(u, "einstein", "GET", time.Now(), time.Hour, []byte(tt.key))
+
+		r := httptest.NewRequest("GET", signed.RequestURI(), nil)
+		r.Host = "cloud.example.com"
+		r.RemoteAddr = tt.remoteAddr
+		if tt.tls {
+			r.TLS = &tls.ConnectionState{}
+		}
+		if tt.forwardedProto != "" {
+			r.Header.Set("X-Forwarded-Proto", tt.forwardedProto)
+		}
+		r = r.WithContext(revauser.ContextSetUser(r.Context(), einstein))
+
+		ok, _ := pua.signatureIsValid(r)
+		if ok != tt.expected {
+			t.Errorf("with url signed for %s, remote %s, tls %t, X-Forwarded-Proto %q and key %s expected %t got %t", tt.signedScheme, tt.remoteAddr, tt.tls, tt.forwardedProto, tt.key, tt.expected, ok)
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package proxy
 
 import (
-	"context"
 	"crypto/tls"
 	"net"
 	"net/http"
@@ -11,11 +10,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/owncloud/ocis/proxy/pkg/accesslog"
 	"github.com/owncloud/ocis/proxy/pkg/proxy/policy"
 	"go.opencensus.io/plugin/ochttp/propagation/tracecontext"
 	"go.opencensus.io/trace"
 
 	"github.com/owncloud/ocis/ocis-pkg/log"
+	"github.com/owncloud/ocis/ocis-pkg/signedurl"
 	"github.com/owncloud/ocis/proxy/pkg/config"
 )
 
@@ -41,7 +42,7 @@ func NewMultiHostReverseProxy(opts ...Option) *MultiHostReverseProxy {
 	rp.Director = rp.directorSelectionDirector
 
 	// equals http.DefaultTransport except TLSClientConfig
-	rp.Transport = &http.Transport{
+	transport := &http.Transport{
 		Proxy: http.ProxyFromEnvironment,
 		DialContext: (&net.Dialer{
 			Timeout:   30 * time.Second,
@@ -57,6 +58,7 @@ func NewMultiHostReverseProxy(opts ...Option) *MultiHostReverseProxy {
 			InsecureSkipVerify: options.Config.InsecureBackends,
 		},
 	}
+	rp.Transport = upstreamTimer{transport}
 
 	if options.Config.Policies == nil {
 		rp.logger.Info().Str("source", "runtime").Msg("Policies")
@@ -146,12 +148,9 @@ func (p *MultiHostReverseProxy) directorSelectionDirector(r *http.Request) {
 					Str("routeType", string(rt)).
 					Msg("director found")
 
-				p.logger.
-					Info().Fields(map[string]interface{}{
-					"method": r.Method,
-					"path":   r.URL.Path,
-					"from":   r.RemoteAddr,
-				}).Msg("access-log")
+				if e := accesslog.FromContext(r.Context()); e != nil {
+					e.SetRoute(pol, string(rt), endpoint)
+				}
 				p.Directors[pol][rt][endpoint](r)
 				return
 			}
@@ -160,6 +159,9 @@ func (p *MultiHostReverseProxy) directorSelectionDirector(r *http.Request) {
 
 	// override default director with root. If any
 	if p.Directors[pol][config.PrefixRoute]["/"] != nil {
+		if e := accesslog.FromContext(r.Context()); e != nil {
+			e.SetRoute(pol, string(config.PrefixRoute), "/")
+		}
 		p.Directors[pol][config.PrefixRoute]["/"](r)
 		return
 	}
@@ -219,20 +221,37 @@ func (p *MultiHostReverseProxy) AddHost(policy string, target *url.URL, rt confi
 }
 
 func (p *MultiHostReverseProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	ctx := context.Background()
+	ctx := r.Context()
 	var span *trace.Span
 
 	// Start root span.
 	if p.config.Tracing.Enabled {
-		ctx, span = trace.StartSpan(context.Background(), r.URL.String())
+		ctx, span = trace.StartSpan(ctx, r.URL.String())
 		defer span.End()
 		p.propagator.SpanContextToRequest(span.SpanContext(), r)
 	}
 
+	// services behind the proxy use the header to build urls for the client, see signedurl.ForwardedScheme
+	r.Header.Set("X-Forwarded-Proto", signedurl.Scheme(r, p.config.HTTP.TrustedProxies))
+
 	// Call upstream ServeHTTP
 	p.ReverseProxy.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// upstreamTimer records the upstream backend and its latency in the access log entry of the request.
+type upstreamTimer struct {
+	http.RoundTripper
+}
+
+func (t upstreamTimer) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.RoundTripper.RoundTrip(req)
+	if e := accesslog.FromContext(req.Context()); e != nil {
+		e.SetUpstream(req.URL.Host, time.Since(start))
+	}
+	return resp, err
+}
+
 func (p MultiHostReverseProxy) queryRouteMatcher(endpoint string, target url.URL) bool {
 	u, _ := url.Parse(endpoint)
 	if !strings.HasPrefix(target.Path, u.Path) || endpoint == "/" {
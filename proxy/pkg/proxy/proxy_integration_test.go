@@ -158,6 +158,45 @@ func TestProxyIntegration(t *testing.T) {
 	}
 }
 
+func TestProxyForwardedProto(t *testing.T) {
+	policies := []config.Policy{withPolicy("ocis", withRoutes{{Endpoint: "/", Backend: "http://ocis.example.com"}})}
+
+	tests := []struct {
+		remoteAddr     string
+		forwardedProto string
+		expected       string
+	}{
+		{"10.0.0.1:1234", "https", "https"},
+		{"10.0.0.1:1234", "", "http"},
+		{"192.0.2.1:1234", "https", "http"},
+	}
+
+	for _, tt := range tests {
+		cfg := testConfig(policies)
+		cfg.HTTP.TrustedProxies = []string{"10.0.0.0/8"}
+		var got string
+		rp := newTestProxy(cfg, func(req *http.Request) *http.Response {
+			got = req.Header.Get("X-Forwarded-Proto")
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`OK`)),
+				Header:     make(http.Header),
+			}
+		})
+
+		req := httptest.NewRequest("GET", "http://example.com/", nil)
+		req.RemoteAddr = tt.remoteAddr
+		if tt.forwardedProto != "" {
+			req.Header.Set("X-Forwarded-Proto", tt.forwardedProto)
+		}
+		rp.ServeHTTP(httptest.NewRecorder(), req)
+
+		if got != tt.expected {
+			t.Errorf("request from %s with X-Forwarded-Proto %q should be forwarded with %q got %q", tt.remoteAddr, tt.forwardedProto, tt.expected, got)
+		}
+	}
+}
+
 func newTestProxy(cfg *config.Config, fn RoundTripFunc) *MultiHostReverseProxy {
 	rp := NewMultiHostReverseProxy(Config(cfg))
 	rp.Transport = fn
@@ -2,9 +2,13 @@ package http
 
 import (
 	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
 	"os"
 
 	svc "github.com/owncloud/ocis/ocis-pkg/service/http"
+	"github.com/owncloud/ocis/proxy/pkg/config"
 	"github.com/owncloud/ocis/proxy/pkg/crypto"
 )
 
@@ -43,6 +47,13 @@ func Server(opts ...Option) (svc.Service, error) {
 		}
 
 		tlsConfig = &tls.Config{Certificates: []tls.Certificate{cer}}
+
+		if err := configureClientAuth(tlsConfig, httpCfg); err != nil {
+			l.Fatal().Err(err).Msg("Could not setup TLS client authentication")
+			os.Exit(1)
+		}
+	} else if httpCfg.ClientAuth != "" && httpCfg.ClientAuth != config.ClientAuthNone {
+		l.Warn().Msg("TLS client authentication requires TLS to be enabled, client certificates are ignored")
 	}
 	chain := options.Middlewares.Then(options.Handler)
 
@@ -64,3 +75,36 @@ func Server(opts ...Option) (svc.Service, error) {
 
 	return service, nil
 }
+
+// configureClientAuth makes the server verify client certificates against the configured CA bundle.
+func configureClientAuth(tlsConfig *tls.Config, cfg config.HTTP) error {
+	switch cfg.ClientAuth {
+	case "", config.ClientAuthNone:
+		return nil
+	case config.ClientAuthOptional:
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	case config.ClientAuthRequired:
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	default:
+		return fmt.Errorf("unknown client auth mode %q", cfg.ClientAuth)
+	}
+
+	if cfg.ClientCA == "" {
+		return fmt.Errorf("client auth mode %q requires a client CA bundle", cfg.ClientAuth)
+	}
+	bundle, err := ioutil.ReadFile(cfg.ClientCA)
+	if err != nil {
+		return err
+	}
+	tlsConfig.ClientCAs = x509.NewCertPool()
+	if !tlsConfig.ClientCAs.AppendCertsFromPEM(bundle) {
+		return fmt.Errorf("no certificates found in %s", cfg.ClientCA)
+	}
+
+	switch cfg.ClientCertClaim {
+	case config.ClientCertClaimCN, config.ClientCertClaimEmail:
+		return nil
+	default:
+		return fmt.Errorf("unknown client certificate claim %q", cfg.ClientCertClaim)
+	}
+}
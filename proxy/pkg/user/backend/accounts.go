@@ -2,6 +2,7 @@ package backend
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	cs3 "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
 	types "github.com/cs3org/go-cs3apis/cs3/types/v1beta1"
@@ -15,10 +16,11 @@ import (
 )
 
 // NewAccountsServiceUserBackend creates a user-provider which fetches users from the ocis accounts-service
-func NewAccountsServiceUserBackend(ac accounts.AccountsService, rs settings.RoleService, oidcISS string, logger log.Logger) UserBackend {
+func NewAccountsServiceUserBackend(ac accounts.AccountsService, rs settings.RoleService, jwtSecret, oidcISS string, logger log.Logger) UserBackend {
 	return &accountsServiceBackend{
 		accountsClient:      ac,
 		settingsRoleService: rs,
+		jwtSecret:           jwtSecret,
 		OIDCIss:             oidcISS,
 		logger:              logger,
 	}
@@ -27,6 +29,7 @@ func NewAccountsServiceUserBackend(ac accounts.AccountsService, rs settings.Role
 type accountsServiceBackend struct {
 	accountsClient      accounts.AccountsService
 	settingsRoleService settings.RoleService
+	jwtSecret           string
 	OIDCIss             string
 	logger              log.Logger
 }
@@ -66,7 +69,7 @@ func (a accountsServiceBackend) GetUserByClaims(ctx context.Context, claim, valu
 		return user, nil
 	}
 
-	if err := injectRoles(ctx, user, a.settingsRoleService); err != nil {
+	if err := injectRoles(ctx, user, a.settingsRoleService, a.jwtSecret); err != nil {
 		a.logger.Warn().Err(err).Msgf("Could not load roles... continuing without")
 	}
 
@@ -89,7 +92,7 @@ func (a *accountsServiceBackend) Authenticate(ctx context.Context, username stri
 
 	user := a.accountToUser(account)
 
-	if err := injectRoles(ctx, user, a.settingsRoleService); err != nil {
+	if err := injectRoles(ctx, user, a.settingsRoleService, a.jwtSecret); err != nil {
 		a.logger.Warn().Err(err).Msgf("Could not load roles... continuing without")
 	}
 
@@ -115,7 +118,7 @@ func (a accountsServiceBackend) CreateUserFromClaims(ctx context.Context, claims
 
 	user := a.accountToUser(created)
 
-	if err := injectRoles(ctx, user, a.settingsRoleService); err != nil {
+	if err := injectRoles(ctx, user, a.settingsRoleService, a.jwtSecret); err != nil {
 		a.logger.Warn().Err(err).Msgf("Could not load roles... continuing without")
 	}
 
@@ -152,6 +155,13 @@ func (a *accountsServiceBackend) accountToUser(account *accounts.Account) *cs3.U
 			},
 		},
 	}
+	// the settings service resolves group values by the ids of the groups, reva only knows their names
+	if groupIDs, err := json.Marshal(expandGroupIDs(account)); err == nil {
+		user.Opaque.Map["group-ids"] = &types.OpaqueEntry{
+			Decoder: "json",
+			Value:   groupIDs,
+		}
+	}
 	return user
 }
 
@@ -193,8 +203,8 @@ func expandGroups(account *accounts.Account) []string {
 }
 
 // injectRoles adds roles from the roles-service to the user-struct by mutating an existing struct
-func injectRoles(ctx context.Context, u *cs3.User, ss settings.RoleService) error {
-	roleIDs, err := loadRolesIDs(ctx, u.Id.OpaqueId, ss)
+func injectRoles(ctx context.Context, u *cs3.User, ss settings.RoleService, jwtSecret string) error {
+	roleIDs, err := loadRolesIDs(ctx, u.Id.OpaqueId, ss, jwtSecret)
 	if err != nil {
 		return err
 	}
@@ -212,3 +222,11 @@ func injectRoles(ctx context.Context, u *cs3.User, ss settings.RoleService) erro
 
 	return nil
 }
+
+func expandGroupIDs(account *accounts.Account) []string {
+	groupIDs := make([]string, len(account.MemberOf))
+	for i := range account.MemberOf {
+		groupIDs[i] = account.MemberOf[i].Id
+	}
+	return groupIDs
+}
@@ -2,10 +2,12 @@ package backend
 
 import (
 	"context"
+	"errors"
 	userv1beta1 "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
 	"github.com/micro/go-micro/v2/client"
 	accounts "github.com/owncloud/ocis/accounts/pkg/proto/v0"
 	"github.com/owncloud/ocis/ocis-pkg/log"
+	"github.com/owncloud/ocis/ocis-pkg/middleware"
 	"github.com/owncloud/ocis/ocis-pkg/oidc"
 	settings "github.com/owncloud/ocis/settings/pkg/proto/v0"
 	"github.com/rs/zerolog"
@@ -24,8 +26,8 @@ var mockAccResp = []*accounts.Account{
 		Mail:                     "foo@example.org",
 		OnPremisesSamAccountName: "samaccount",
 		MemberOf: []*accounts.Group{
-			{OnPremisesSamAccountName: "g1"},
-			{OnPremisesSamAccountName: "g2"},
+			{Id: "g1-id", OnPremisesSamAccountName: "g1"},
+			{Id: "g2-id", OnPremisesSamAccountName: "g2"},
 		},
 	},
 }
@@ -138,6 +140,8 @@ func assertUserMatchesAccount(t *testing.T, exp *accounts.Account, act *userv1be
 
 	// Groups
 	assert.ElementsMatch(t, []string{"g1", "g2"}, act.Groups)
+	assert.NotNil(t, act.Opaque.Map["group-ids"])
+	assert.Equal(t, `["g1-id","g2-id"]`, string(act.Opaque.Map["group-ids"].GetValue()))
 
 	// Roles
 	assert.NotNil(t, act.Opaque.Map["roles"])
@@ -153,7 +157,7 @@ func assertUserMatchesAccount(t *testing.T, exp *accounts.Account, act *userv1be
 
 func newAccountsBackend(mockAccounts []*accounts.Account, mockRoles []*settings.UserRoleAssignment) UserBackend {
 	accSvc, roleSvc := getAccountService(mockAccounts, nil), getRoleService(mockRoles, nil)
-	accBackend := NewAccountsServiceUserBackend(accSvc, roleSvc, "https://idp.example.org", log.NewLogger())
+	accBackend := NewAccountsServiceUserBackend(accSvc, roleSvc, "secret", "https://idp.example.org", log.NewLogger())
 	zerolog.SetGlobalLevel(zerolog.Disabled)
 	return accBackend
 }
@@ -174,6 +178,10 @@ func getAccountService(expectedResponse []*accounts.Account, err error) *account
 func getRoleService(expectedRespone []*settings.UserRoleAssignment, err error) *settings.MockRoleService {
 	return &settings.MockRoleService{
 		ListRoleAssignmentsFunc: func(ctx context.Context, req *settings.ListRoleAssignmentsRequest, opts ...client.CallOption) (*settings.ListRoleAssignmentsResponse, error) {
+			// the settings service only lists the assignments of other accounts to services
+			if _, ok := middleware.ServiceFromContext(ctx, "secret"); !ok {
+				return nil, errors.New("no service token")
+			}
 			return &settings.ListRoleAssignmentsResponse{Assignments: expectedRespone}, err
 		},
 	}
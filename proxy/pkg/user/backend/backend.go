@@ -7,6 +7,7 @@ import (
 	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
 	cs3 "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
 	types "github.com/cs3org/go-cs3apis/cs3/types/v1beta1"
+	"github.com/owncloud/ocis/ocis-pkg/middleware"
 	"github.com/owncloud/ocis/ocis-pkg/oidc"
 	settings "github.com/owncloud/ocis/settings/pkg/proto/v0"
 	"google.golang.org/grpc"
@@ -34,8 +35,13 @@ type RevaAuthenticator interface {
 	Authenticate(ctx context.Context, in *gateway.AuthenticateRequest, opts ...grpc.CallOption) (*gateway.AuthenticateResponse, error)
 }
 
-// loadRolesIDs returns the role-ids assigned to an user
-func loadRolesIDs(ctx context.Context, opaqueUserID string, rs settings.RoleService) ([]string, error) {
+// loadRolesIDs returns the role-ids assigned to an user. The user is not authenticated yet, so the proxy asks the
+// settings service with its service token.
+func loadRolesIDs(ctx context.Context, opaqueUserID string, rs settings.RoleService, jwtSecret string) ([]string, error) {
+	ctx, err := middleware.ContextWithServiceToken(ctx, "proxy", jwtSecret)
+	if err != nil {
+		return nil, err
+	}
 	req := &settings.ListRoleAssignmentsRequest{AccountUuid: opaqueUserID}
 	assignmentResponse, err := rs.ListRoleAssignments(ctx, req)
 
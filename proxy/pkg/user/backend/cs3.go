@@ -15,15 +15,17 @@ import (
 type cs3backend struct {
 	userProvider        cs3.UserAPIClient
 	settingsRoleService settings.RoleService
+	jwtSecret           string
 	authProvider        RevaAuthenticator
 	logger              log.Logger
 }
 
 // NewCS3UserBackend creates a user-provider which fetches users from a CS3 UserBackend
-func NewCS3UserBackend(up cs3.UserAPIClient, rs settings.RoleService, ap RevaAuthenticator, logger log.Logger) UserBackend {
+func NewCS3UserBackend(up cs3.UserAPIClient, rs settings.RoleService, jwtSecret string, ap RevaAuthenticator, logger log.Logger) UserBackend {
 	return &cs3backend{
 		userProvider:        up,
 		settingsRoleService: rs,
+		jwtSecret:           jwtSecret,
 		authProvider:        ap,
 		logger:              logger,
 	}
@@ -51,7 +53,7 @@ func (c *cs3backend) GetUserByClaims(ctx context.Context, claim, value string, w
 		return user, nil
 	}
 
-	roleIDs, err := loadRolesIDs(ctx, user.Id.OpaqueId, c.settingsRoleService)
+	roleIDs, err := loadRolesIDs(ctx, user.Id.OpaqueId, c.settingsRoleService, c.jwtSecret)
 	if err != nil {
 		c.logger.Error().Err(err).Msg("Could not load roles")
 	}
@@ -31,9 +31,10 @@ type GRPC struct {
 
 // Service provides configuration options for the service
 type Service struct {
-	Name     string
-	Version  string
-	DataPath string
+	Name      string
+	Version   string
+	DataPath  string
+	StoreType string
 }
 
 // Tracing defines the available tracing configuration.
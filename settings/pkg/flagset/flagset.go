@@ -178,6 +178,13 @@ func ServerWithConfig(cfg *config.Config) []cli.Flag {
 			EnvVars:     []string{"SETTINGS_DATA_PATH"},
 			Destination: &cfg.Service.DataPath,
 		},
+		&cli.StringFlag{
+			Name:        "store-type",
+			Value:       "filesystem",
+			Usage:       "Store implementation for the settings, filesystem or bolt",
+			EnvVars:     []string{"SETTINGS_STORE_TYPE"},
+			Destination: &cfg.Service.StoreType,
+		},
 		&cli.StringFlag{
 			Name:        "jwt-secret",
 			Value:       "Pive-Fumkiu4",
@@ -89,6 +89,7 @@ type MockValueService struct {
 	GetValueFunc                    func(ctx context.Context, req *GetValueRequest, opts ...client.CallOption) (*GetValueResponse, error)
 	GetValueByUniqueIdentifiersFunc func(ctx context.Context, req *GetValueByUniqueIdentifiersRequest, opts ...client.CallOption) (*GetValueResponse, error)
 	SaveValueFunc                   func(ctx context.Context, req *SaveValueRequest, opts ...client.CallOption) (*SaveValueResponse, error)
+	ListResolvedValuesFunc          func(ctx context.Context, req *ListResolvedValuesRequest, opts ...client.CallOption) (*ListResolvedValuesResponse, error)
 }
 
 // ListValues will panic if the function has been called, but not mocked
@@ -123,11 +124,24 @@ func (m MockValueService) SaveValue(ctx context.Context, req *SaveValueRequest,
 	panic("SaveValueFunc was called in test but not mocked")
 }
 
+// ListResolvedValues will panic if the function has been called, but not mocked
+func (m MockValueService) ListResolvedValues(ctx context.Context, req *ListResolvedValuesRequest, opts ...client.CallOption) (*ListResolvedValuesResponse, error) {
+	if m.ListResolvedValuesFunc != nil {
+		return m.ListResolvedValuesFunc(ctx, req, opts...)
+	}
+	panic("ListResolvedValuesFunc was called in test but not mocked")
+}
+
 // MockRoleService will panic if the function has been called, but not mocked
 type MockRoleService struct {
 	ListRolesFunc           func(ctx context.Context, req *ListBundlesRequest, opts ...client.CallOption) (*ListBundlesResponse, error)
+	GetRoleFunc             func(ctx context.Context, req *GetRoleRequest, opts ...client.CallOption) (*GetRoleResponse, error)
+	SaveRoleFunc            func(ctx context.Context, req *SaveRoleRequest, opts ...client.CallOption) (*SaveRoleResponse, error)
+	CloneRoleFunc           func(ctx context.Context, req *CloneRoleRequest, opts ...client.CallOption) (*SaveRoleResponse, error)
+	DeleteRoleFunc          func(ctx context.Context, req *DeleteRoleRequest, opts ...client.CallOption) (*empty.Empty, error)
 	ListRoleAssignmentsFunc func(ctx context.Context, req *ListRoleAssignmentsRequest, opts ...client.CallOption) (*ListRoleAssignmentsResponse, error)
 	AssignRoleToUserFunc    func(ctx context.Context, req *AssignRoleToUserRequest, opts ...client.CallOption) (*AssignRoleToUserResponse, error)
+	AddRoleToUserFunc       func(ctx context.Context, req *AddRoleToUserRequest, opts ...client.CallOption) (*AddRoleToUserResponse, error)
 	RemoveRoleFromUserFunc  func(ctx context.Context, req *RemoveRoleFromUserRequest, opts ...client.CallOption) (*empty.Empty, error)
 }
 
@@ -139,6 +153,38 @@ func (m MockRoleService) ListRoles(ctx context.Context, req *ListBundlesRequest,
 	panic("ListRolesFunc was called in test but not mocked")
 }
 
+// GetRole will panic if the function has been called, but not mocked
+func (m MockRoleService) GetRole(ctx context.Context, req *GetRoleRequest, opts ...client.CallOption) (*GetRoleResponse, error) {
+	if m.GetRoleFunc != nil {
+		return m.GetRoleFunc(ctx, req, opts...)
+	}
+	panic("GetRoleFunc was called in test but not mocked")
+}
+
+// SaveRole will panic if the function has been called, but not mocked
+func (m MockRoleService) SaveRole(ctx context.Context, req *SaveRoleRequest, opts ...client.CallOption) (*SaveRoleResponse, error) {
+	if m.SaveRoleFunc != nil {
+		return m.SaveRoleFunc(ctx, req, opts...)
+	}
+	panic("SaveRoleFunc was called in test but not mocked")
+}
+
+// CloneRole will panic if the function has been called, but not mocked
+func (m MockRoleService) CloneRole(ctx context.Context, req *CloneRoleRequest, opts ...client.CallOption) (*SaveRoleResponse, error) {
+	if m.CloneRoleFunc != nil {
+		return m.CloneRoleFunc(ctx, req, opts...)
+	}
+	panic("CloneRoleFunc was called in test but not mocked")
+}
+
+// DeleteRole will panic if the function has been called, but not mocked
+func (m MockRoleService) DeleteRole(ctx context.Context, req *DeleteRoleRequest, opts ...client.CallOption) (*empty.Empty, error) {
+	if m.DeleteRoleFunc != nil {
+		return m.DeleteRoleFunc(ctx, req, opts...)
+	}
+	panic("DeleteRoleFunc was called in test but not mocked")
+}
+
 // ListRoleAssignments will panic if the function has been called, but not mocked
 func (m MockRoleService) ListRoleAssignments(ctx context.Context, req *ListRoleAssignmentsRequest, opts ...client.CallOption) (*ListRoleAssignmentsResponse, error) {
 	if m.ListRoleAssignmentsFunc != nil {
@@ -155,6 +201,14 @@ func (m MockRoleService) AssignRoleToUser(ctx context.Context, req *AssignRoleTo
 	panic("AssignRoleToUserFunc was called in test but not mocked")
 }
 
+// AddRoleToUser will panic if the function has been called, but not mocked
+func (m MockRoleService) AddRoleToUser(ctx context.Context, req *AddRoleToUserRequest, opts ...client.CallOption) (*AddRoleToUserResponse, error) {
+	if m.AddRoleToUserFunc != nil {
+		return m.AddRoleToUserFunc(ctx, req, opts...)
+	}
+	panic("AddRoleToUserFunc was called in test but not mocked")
+}
+
 // RemoveRoleFromUser will panic if the function has been called, but not mocked
 func (m MockRoleService) RemoveRoleFromUser(ctx context.Context, req *RemoveRoleFromUserRequest, opts ...client.CallOption) (*empty.Empty, error) {
 	if m.RemoveRoleFromUserFunc != nil {
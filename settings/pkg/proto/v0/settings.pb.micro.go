@@ -266,6 +266,13 @@ func NewValueServiceEndpoints() []*api.Endpoint {
 			Body:    "*",
 			Handler: "rpc",
 		},
+		&api.Endpoint{
+			Name:    "ValueService.ListResolvedValues",
+			Path:    []string{"/api/v0/settings/values-list-resolved"},
+			Method:  []string{"POST"},
+			Body:    "*",
+			Handler: "rpc",
+		},
 	}
 }
 
@@ -276,6 +283,7 @@ type ValueService interface {
 	GetValue(ctx context.Context, in *GetValueRequest, opts ...client.CallOption) (*GetValueResponse, error)
 	ListValues(ctx context.Context, in *ListValuesRequest, opts ...client.CallOption) (*ListValuesResponse, error)
 	GetValueByUniqueIdentifiers(ctx context.Context, in *GetValueByUniqueIdentifiersRequest, opts ...client.CallOption) (*GetValueResponse, error)
+	ListResolvedValues(ctx context.Context, in *ListResolvedValuesRequest, opts ...client.CallOption) (*ListResolvedValuesResponse, error)
 }
 
 type valueService struct {
@@ -330,6 +338,16 @@ func (c *valueService) GetValueByUniqueIdentifiers(ctx context.Context, in *GetV
 	return out, nil
 }
 
+func (c *valueService) ListResolvedValues(ctx context.Context, in *ListResolvedValuesRequest, opts ...client.CallOption) (*ListResolvedValuesResponse, error) {
+	req := c.c.NewRequest(c.name, "ValueService.ListResolvedValues", in)
+	out := new(ListResolvedValuesResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for ValueService service
 
 type ValueServiceHandler interface {
@@ -337,6 +355,7 @@ type ValueServiceHandler interface {
 	GetValue(context.Context, *GetValueRequest, *GetValueResponse) error
 	ListValues(context.Context, *ListValuesRequest, *ListValuesResponse) error
 	GetValueByUniqueIdentifiers(context.Context, *GetValueByUniqueIdentifiersRequest, *GetValueResponse) error
+	ListResolvedValues(context.Context, *ListResolvedValuesRequest, *ListResolvedValuesResponse) error
 }
 
 func RegisterValueServiceHandler(s server.Server, hdlr ValueServiceHandler, opts ...server.HandlerOption) error {
@@ -345,6 +364,7 @@ func RegisterValueServiceHandler(s server.Server, hdlr ValueServiceHandler, opts
 		GetValue(ctx context.Context, in *GetValueRequest, out *GetValueResponse) error
 		ListValues(ctx context.Context, in *ListValuesRequest, out *ListValuesResponse) error
 		GetValueByUniqueIdentifiers(ctx context.Context, in *GetValueByUniqueIdentifiersRequest, out *GetValueResponse) error
+		ListResolvedValues(ctx context.Context, in *ListResolvedValuesRequest, out *ListResolvedValuesResponse) error
 	}
 	type ValueService struct {
 		valueService
@@ -378,6 +398,13 @@ func RegisterValueServiceHandler(s server.Server, hdlr ValueServiceHandler, opts
 		Body:    "*",
 		Handler: "rpc",
 	}))
+	opts = append(opts, api.WithEndpoint(&api.Endpoint{
+		Name:    "ValueService.ListResolvedValues",
+		Path:    []string{"/api/v0/settings/values-list-resolved"},
+		Method:  []string{"POST"},
+		Body:    "*",
+		Handler: "rpc",
+	}))
 	return s.Handle(s.NewHandler(&ValueService{h}, opts...))
 }
 
@@ -401,6 +428,10 @@ func (h *valueServiceHandler) GetValueByUniqueIdentifiers(ctx context.Context, i
 	return h.ValueServiceHandler.GetValueByUniqueIdentifiers(ctx, in, out)
 }
 
+func (h *valueServiceHandler) ListResolvedValues(ctx context.Context, in *ListResolvedValuesRequest, out *ListResolvedValuesResponse) error {
+	return h.ValueServiceHandler.ListResolvedValues(ctx, in, out)
+}
+
 // Api Endpoints for RoleService service
 
 func NewRoleServiceEndpoints() []*api.Endpoint {
@@ -412,6 +443,34 @@ func NewRoleServiceEndpoints() []*api.Endpoint {
 			Body:    "*",
 			Handler: "rpc",
 		},
+		&api.Endpoint{
+			Name:    "RoleService.GetRole",
+			Path:    []string{"/api/v0/settings/roles-get"},
+			Method:  []string{"POST"},
+			Body:    "*",
+			Handler: "rpc",
+		},
+		&api.Endpoint{
+			Name:    "RoleService.SaveRole",
+			Path:    []string{"/api/v0/settings/roles-save"},
+			Method:  []string{"POST"},
+			Body:    "*",
+			Handler: "rpc",
+		},
+		&api.Endpoint{
+			Name:    "RoleService.CloneRole",
+			Path:    []string{"/api/v0/settings/roles-clone"},
+			Method:  []string{"POST"},
+			Body:    "*",
+			Handler: "rpc",
+		},
+		&api.Endpoint{
+			Name:    "RoleService.DeleteRole",
+			Path:    []string{"/api/v0/settings/roles-delete"},
+			Method:  []string{"POST"},
+			Body:    "*",
+			Handler: "rpc",
+		},
 		&api.Endpoint{
 			Name:    "RoleService.ListRoleAssignments",
 			Path:    []string{"/api/v0/settings/assignments-list"},
@@ -426,6 +485,13 @@ func NewRoleServiceEndpoints() []*api.Endpoint {
 			Body:    "*",
 			Handler: "rpc",
 		},
+		&api.Endpoint{
+			Name:    "RoleService.AddRoleToUser",
+			Path:    []string{"/api/v0/settings/assignments-append"},
+			Method:  []string{"POST"},
+			Body:    "*",
+			Handler: "rpc",
+		},
 		&api.Endpoint{
 			Name:    "RoleService.RemoveRoleFromUser",
 			Path:    []string{"/api/v0/settings/assignments-remove"},
@@ -440,8 +506,13 @@ func NewRoleServiceEndpoints() []*api.Endpoint {
 
 type RoleService interface {
 	ListRoles(ctx context.Context, in *ListBundlesRequest, opts ...client.CallOption) (*ListBundlesResponse, error)
+	GetRole(ctx context.Context, in *GetRoleRequest, opts ...client.CallOption) (*GetRoleResponse, error)
+	SaveRole(ctx context.Context, in *SaveRoleRequest, opts ...client.CallOption) (*SaveRoleResponse, error)
+	CloneRole(ctx context.Context, in *CloneRoleRequest, opts ...client.CallOption) (*SaveRoleResponse, error)
+	DeleteRole(ctx context.Context, in *DeleteRoleRequest, opts ...client.CallOption) (*empty.Empty, error)
 	ListRoleAssignments(ctx context.Context, in *ListRoleAssignmentsRequest, opts ...client.CallOption) (*ListRoleAssignmentsResponse, error)
 	AssignRoleToUser(ctx context.Context, in *AssignRoleToUserRequest, opts ...client.CallOption) (*AssignRoleToUserResponse, error)
+	AddRoleToUser(ctx context.Context, in *AddRoleToUserRequest, opts ...client.CallOption) (*AddRoleToUserResponse, error)
 	RemoveRoleFromUser(ctx context.Context, in *RemoveRoleFromUserRequest, opts ...client.CallOption) (*empty.Empty, error)
 }
 
@@ -467,6 +538,46 @@ func (c *roleService) ListRoles(ctx context.Context, in *ListBundlesRequest, opt
 	return out, nil
 }
 
+func (c *roleService) GetRole(ctx context.Context, in *GetRoleRequest, opts ...client.CallOption) (*GetRoleResponse, error) {
+	req := c.c.NewRequest(c.name, "RoleService.GetRole", in)
+	out := new(GetRoleResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleService) SaveRole(ctx context.Context, in *SaveRoleRequest, opts ...client.CallOption) (*SaveRoleResponse, error) {
+	req := c.c.NewRequest(c.name, "RoleService.SaveRole", in)
+	out := new(SaveRoleResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleService) CloneRole(ctx context.Context, in *CloneRoleRequest, opts ...client.CallOption) (*SaveRoleResponse, error) {
+	req := c.c.NewRequest(c.name, "RoleService.CloneRole", in)
+	out := new(SaveRoleResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleService) DeleteRole(ctx context.Context, in *DeleteRoleRequest, opts ...client.CallOption) (*empty.Empty, error) {
+	req := c.c.NewRequest(c.name, "RoleService.DeleteRole", in)
+	out := new(empty.Empty)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *roleService) ListRoleAssignments(ctx context.Context, in *ListRoleAssignmentsRequest, opts ...client.CallOption) (*ListRoleAssignmentsResponse, error) {
 	req := c.c.NewRequest(c.name, "RoleService.ListRoleAssignments", in)
 	out := new(ListRoleAssignmentsResponse)
@@ -487,6 +598,16 @@ func (c *roleService) AssignRoleToUser(ctx context.Context, in *AssignRoleToUser
 	return out, nil
 }
 
+func (c *roleService) AddRoleToUser(ctx context.Context, in *AddRoleToUserRequest, opts ...client.CallOption) (*AddRoleToUserResponse, error) {
+	req := c.c.NewRequest(c.name, "RoleService.AddRoleToUser", in)
+	out := new(AddRoleToUserResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *roleService) RemoveRoleFromUser(ctx context.Context, in *RemoveRoleFromUserRequest, opts ...client.CallOption) (*empty.Empty, error) {
 	req := c.c.NewRequest(c.name, "RoleService.RemoveRoleFromUser", in)
 	out := new(empty.Empty)
@@ -501,16 +622,26 @@ func (c *roleService) RemoveRoleFromUser(ctx context.Context, in *RemoveRoleFrom
 
 type RoleServiceHandler interface {
 	ListRoles(context.Context, *ListBundlesRequest, *ListBundlesResponse) error
+	GetRole(context.Context, *GetRoleRequest, *GetRoleResponse) error
+	SaveRole(context.Context, *SaveRoleRequest, *SaveRoleResponse) error
+	CloneRole(context.Context, *CloneRoleRequest, *SaveRoleResponse) error
+	DeleteRole(context.Context, *DeleteRoleRequest, *empty.Empty) error
 	ListRoleAssignments(context.Context, *ListRoleAssignmentsRequest, *ListRoleAssignmentsResponse) error
 	AssignRoleToUser(context.Context, *AssignRoleToUserRequest, *AssignRoleToUserResponse) error
+	AddRoleToUser(context.Context, *AddRoleToUserRequest, *AddRoleToUserResponse) error
 	RemoveRoleFromUser(context.Context, *RemoveRoleFromUserRequest, *empty.Empty) error
 }
 
 func RegisterRoleServiceHandler(s server.Server, hdlr RoleServiceHandler, opts ...server.HandlerOption) error {
 	type roleService interface {
 		ListRoles(ctx context.Context, in *ListBundlesRequest, out *ListBundlesResponse) error
+		GetRole(ctx context.Context, in *GetRoleRequest, out *GetRoleResponse) error
+		SaveRole(ctx context.Context, in *SaveRoleRequest, out *SaveRoleResponse) error
+		CloneRole(ctx context.Context, in *CloneRoleRequest, out *SaveRoleResponse) error
+		DeleteRole(ctx context.Context, in *DeleteRoleRequest, out *empty.Empty) error
 		ListRoleAssignments(ctx context.Context, in *ListRoleAssignmentsRequest, out *ListRoleAssignmentsResponse) error
 		AssignRoleToUser(ctx context.Context, in *AssignRoleToUserRequest, out *AssignRoleToUserResponse) error
+		AddRoleToUser(ctx context.Context, in *AddRoleToUserRequest, out *AddRoleToUserResponse) error
 		RemoveRoleFromUser(ctx context.Context, in *RemoveRoleFromUserRequest, out *empty.Empty) error
 	}
 	type RoleService struct {
@@ -524,6 +655,34 @@ func RegisterRoleServiceHandler(s server.Server, hdlr RoleServiceHandler, opts .
 		Body:    "*",
 		Handler: "rpc",
 	}))
+	opts = append(opts, api.WithEndpoint(&api.Endpoint{
+		Name:    "RoleService.GetRole",
+		Path:    []string{"/api/v0/settings/roles-get"},
+		Method:  []string{"POST"},
+		Body:    "*",
+		Handler: "rpc",
+	}))
+	opts = append(opts, api.WithEndpoint(&api.Endpoint{
+		Name:    "RoleService.SaveRole",
+		Path:    []string{"/api/v0/settings/roles-save"},
+		Method:  []string{"POST"},
+		Body:    "*",
+		Handler: "rpc",
+	}))
+	opts = append(opts, api.WithEndpoint(&api.Endpoint{
+		Name:    "RoleService.CloneRole",
+		Path:    []string{"/api/v0/settings/roles-clone"},
+		Method:  []string{"POST"},
+		Body:    "*",
+		Handler: "rpc",
+	}))
+	opts = append(opts, api.WithEndpoint(&api.Endpoint{
+		Name:    "RoleService.DeleteRole",
+		Path:    []string{"/api/v0/settings/roles-delete"},
+		Method:  []string{"POST"},
+		Body:    "*",
+		Handler: "rpc",
+	}))
 	opts = append(opts, api.WithEndpoint(&api.Endpoint{
 		Name:    "RoleService.ListRoleAssignments",
 		Path:    []string{"/api/v0/settings/assignments-list"},
@@ -538,6 +697,13 @@ func RegisterRoleServiceHandler(s server.Server, hdlr RoleServiceHandler, opts .
 		Body:    "*",
 		Handler: "rpc",
 	}))
+	opts = append(opts, api.WithEndpoint(&api.Endpoint{
+		Name:    "RoleService.AddRoleToUser",
+		Path:    []string{"/api/v0/settings/assignments-append"},
+		Method:  []string{"POST"},
+		Body:    "*",
+		Handler: "rpc",
+	}))
 	opts = append(opts, api.WithEndpoint(&api.Endpoint{
 		Name:    "RoleService.RemoveRoleFromUser",
 		Path:    []string{"/api/v0/settings/assignments-remove"},
@@ -556,6 +722,22 @@ func (h *roleServiceHandler) ListRoles(ctx context.Context, in *ListBundlesReque
 	return h.RoleServiceHandler.ListRoles(ctx, in, out)
 }
 
+func (h *roleServiceHandler) GetRole(ctx context.Context, in *GetRoleRequest, out *GetRoleResponse) error {
+	return h.RoleServiceHandler.GetRole(ctx, in, out)
+}
+
+func (h *roleServiceHandler) SaveRole(ctx context.Context, in *SaveRoleRequest, out *SaveRoleResponse) error {
+	return h.RoleServiceHandler.SaveRole(ctx, in, out)
+}
+
+func (h *roleServiceHandler) CloneRole(ctx context.Context, in *CloneRoleRequest, out *SaveRoleResponse) error {
+	return h.RoleServiceHandler.CloneRole(ctx, in, out)
+}
+
+func (h *roleServiceHandler) DeleteRole(ctx context.Context, in *DeleteRoleRequest, out *empty.Empty) error {
+	return h.RoleServiceHandler.DeleteRole(ctx, in, out)
+}
+
 func (h *roleServiceHandler) ListRoleAssignments(ctx context.Context, in *ListRoleAssignmentsRequest, out *ListRoleAssignmentsResponse) error {
 	return h.RoleServiceHandler.ListRoleAssignments(ctx, in, out)
 }
@@ -564,6 +746,10 @@ func (h *roleServiceHandler) AssignRoleToUser(ctx context.Context, in *AssignRol
 	return h.RoleServiceHandler.AssignRoleToUser(ctx, in, out)
 }
 
+func (h *roleServiceHandler) AddRoleToUser(ctx context.Context, in *AddRoleToUserRequest, out *AddRoleToUserResponse) error {
+	return h.RoleServiceHandler.AddRoleToUser(ctx, in, out)
+}
+
 func (h *roleServiceHandler) RemoveRoleFromUser(ctx context.Context, in *RemoveRoleFromUserRequest, out *empty.Empty) error {
 	return h.RoleServiceHandler.RemoveRoleFromUser(ctx, in, out)
 }
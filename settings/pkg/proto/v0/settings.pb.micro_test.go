@@ -440,27 +440,28 @@ testing that saving a value works and can be retrieved again
 */
 func TestSaveGetIntValue(t *testing.T) {
 	tests := []struct {
-		name  string
-		value proto.Value_IntValue
+		name      string
+		value     proto.Value_IntValue
+		violation proto.ValueViolation_Rule
 	}{
 		{
 			name:  "simple int",
 			value: proto.Value_IntValue{IntValue: 43},
 		},
 		{
-			name:  "negative",
-			value: proto.Value_IntValue{IntValue: -42},
-			// https://github.com/owncloud/ocis/settings/issues/57
+			name:      "negative",
+			value:     proto.Value_IntValue{IntValue: -42},
+			violation: proto.ValueViolation_RULE_MIN,
 		},
 		{
-			name:  "less than Min",
-			value: proto.Value_IntValue{IntValue: 0},
-			// https://github.com/owncloud/ocis/settings/issues/57
+			name:      "less than Min",
+			value:     proto.Value_IntValue{IntValue: 0},
+			violation: proto.ValueViolation_RULE_MIN,
 		},
 		{
-			name:  "more than Max",
-			value: proto.Value_IntValue{IntValue: 128},
-			// https://github.com/owncloud/ocis/settings/issues/57
+			name:      "more than Max",
+			value:     proto.Value_IntValue{IntValue: 128},
+			violation: proto.ValueViolation_RULE_MAX,
 		},
 	}
 	for _, tt := range tests {
@@ -488,6 +489,14 @@ func TestSaveGetIntValue(t *testing.T) {
 					},
 				},
 			})
+			if tt.violation != proto.ValueViolation_RULE_UNKNOWN {
+				// https://github.com/owncloud/ocis/settings/issues/57
+				valueError := svc.ParseValueError(err)
+				if assert.NotNil(t, valueError) && assert.Len(t, valueError.Violations, 1) {
+					assert.Equal(t, tt.violation, valueError.Violations[0].Rule)
+				}
+				return
+			}
 			assert.NoError(t, err)
 			assert.Equal(t, tt.value.IntValue, saveValueResponse.Value.Value.GetIntValue())
 
@@ -261,6 +261,30 @@ func (h *webValueServiceHandler) GetValueByUniqueIdentifiers(w http.ResponseWrit
 	render.JSON(w, r, resp)
 }
 
+func (h *webValueServiceHandler) ListResolvedValues(w http.ResponseWriter, r *http.Request) {
+
+	req := &ListResolvedValuesRequest{}
+
+	resp := &ListResolvedValuesResponse{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	if err := h.h.ListResolvedValues(
+		r.Context(),
+		req,
+		resp,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, resp)
+}
+
 func RegisterValueServiceWeb(r chi.Router, i ValueServiceHandler, middlewares ...func(http.Handler) http.Handler) {
 	handler := &webValueServiceHandler{
 		r: r,
@@ -271,6 +295,7 @@ func RegisterValueServiceWeb(r chi.Router, i ValueServiceHandler, middlewares ..
 	r.MethodFunc("POST", "/api/v0/settings/values-get", handler.GetValue)
 	r.MethodFunc("POST", "/api/v0/settings/values-list", handler.ListValues)
 	r.MethodFunc("POST", "/api/v0/settings/values-get-by-unique-identifiers", handler.GetValueByUniqueIdentifiers)
+	r.MethodFunc("POST", "/api/v0/settings/values-list-resolved", handler.ListResolvedValues)
 }
 
 type webRoleServiceHandler struct {
@@ -306,6 +331,101 @@ func (h *webRoleServiceHandler) ListRoles(w http.ResponseWriter, r *http.Request
 	render.JSON(w, r, resp)
 }
 
+func (h *webRoleServiceHandler) GetRole(w http.ResponseWriter, r *http.Request) {
+
+	req := &GetRoleRequest{}
+
+	resp := &GetRoleResponse{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	if err := h.h.GetRole(
+		r.Context(),
+		req,
+		resp,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, resp)
+}
+
+func (h *webRoleServiceHandler) SaveRole(w http.ResponseWriter, r *http.Request) {
+
+	req := &SaveRoleRequest{}
+
+	resp := &SaveRoleResponse{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	if err := h.h.SaveRole(
+		r.Context(),
+		req,
+		resp,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, resp)
+}
+
+func (h *webRoleServiceHandler) CloneRole(w http.ResponseWriter, r *http.Request) {
+
+	req := &CloneRoleRequest{}
+
+	resp := &SaveRoleResponse{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	if err := h.h.CloneRole(
+		r.Context(),
+		req,
+		resp,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, resp)
+}
+
+func (h *webRoleServiceHandler) DeleteRole(w http.ResponseWriter, r *http.Request) {
+
+	req := &DeleteRoleRequest{}
+	resp := &empty.Empty{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	if err := h.h.DeleteRole(
+		r.Context(),
+		req,
+		resp,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	render.Status(r, http.StatusNoContent)
+	render.NoContent(w, r)
+}
+
 func (h *webRoleServiceHandler) ListRoleAssignments(w http.ResponseWriter, r *http.Request) {
 
 	req := &ListRoleAssignmentsRequest{}
@@ -354,6 +474,30 @@ func (h *webRoleServiceHandler) AssignRoleToUser(w http.ResponseWriter, r *http.
 	render.JSON(w, r, resp)
 }
 
+func (h *webRoleServiceHandler) AddRoleToUser(w http.ResponseWriter, r *http.Request) {
+
+	req := &AddRoleToUserRequest{}
+
+	resp := &AddRoleToUserResponse{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	if err := h.h.AddRoleToUser(
+		r.Context(),
+		req,
+		resp,
+	); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, resp)
+}
+
 func (h *webRoleServiceHandler) RemoveRoleFromUser(w http.ResponseWriter, r *http.Request) {
 
 	req := &RemoveRoleFromUserRequest{}
@@ -384,8 +528,13 @@ func RegisterRoleServiceWeb(r chi.Router, i RoleServiceHandler, middlewares ...f
 	}
 
 	r.MethodFunc("POST", "/api/v0/settings/roles-list", handler.ListRoles)
+	r.MethodFunc("POST", "/api/v0/settings/roles-get", handler.GetRole)
+	r.MethodFunc("POST", "/api/v0/settings/roles-save", handler.SaveRole)
+	r.MethodFunc("POST", "/api/v0/settings/roles-clone", handler.CloneRole)
+	r.MethodFunc("POST", "/api/v0/settings/roles-delete", handler.DeleteRole)
 	r.MethodFunc("POST", "/api/v0/settings/assignments-list", handler.ListRoleAssignments)
 	r.MethodFunc("POST", "/api/v0/settings/assignments-add", handler.AssignRoleToUser)
+	r.MethodFunc("POST", "/api/v0/settings/assignments-append", handler.AddRoleToUser)
 	r.MethodFunc("POST", "/api/v0/settings/assignments-remove", handler.RemoveRoleFromUser)
 }
 
@@ -996,6 +1145,78 @@ func (m *ListValuesResponse) UnmarshalJSON(b []byte) error {
 
 var _ json.Unmarshaler = (*ListValuesResponse)(nil)
 
+// ListResolvedValuesRequestJSONMarshaler describes the default jsonpb.Marshaler used by all
+// instances of ListResolvedValuesRequest. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var ListResolvedValuesRequestJSONMarshaler = new(jsonpb.Marshaler)
+
+// MarshalJSON satisfies the encoding/json Marshaler interface. This method
+// uses the more correct jsonpb package to correctly marshal the message.
+func (m *ListResolvedValuesRequest) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return json.Marshal(nil)
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := ListResolvedValuesRequestJSONMarshaler.Marshal(buf, m); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+var _ json.Marshaler = (*ListResolvedValuesRequest)(nil)
+
+// ListResolvedValuesRequestJSONUnmarshaler describes the default jsonpb.Unmarshaler used by all
+// instances of ListResolvedValuesRequest. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var ListResolvedValuesRequestJSONUnmarshaler = new(jsonpb.Unmarshaler)
+
+// UnmarshalJSON satisfies the encoding/json Unmarshaler interface. This method
+// uses the more correct jsonpb package to correctly unmarshal the message.
+func (m *ListResolvedValuesRequest) UnmarshalJSON(b []byte) error {
+	return ListResolvedValuesRequestJSONUnmarshaler.Unmarshal(bytes.NewReader(b), m)
+}
+
+var _ json.Unmarshaler = (*ListResolvedValuesRequest)(nil)
+
+// ListResolvedValuesResponseJSONMarshaler describes the default jsonpb.Marshaler used by all
+// instances of ListResolvedValuesResponse. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var ListResolvedValuesResponseJSONMarshaler = new(jsonpb.Marshaler)
+
+// MarshalJSON satisfies the encoding/json Marshaler interface. This method
+// uses the more correct jsonpb package to correctly marshal the message.
+func (m *ListResolvedValuesResponse) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return json.Marshal(nil)
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := ListResolvedValuesResponseJSONMarshaler.Marshal(buf, m); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+var _ json.Marshaler = (*ListResolvedValuesResponse)(nil)
+
+// ListResolvedValuesResponseJSONUnmarshaler describes the default jsonpb.Unmarshaler used by all
+// instances of ListResolvedValuesResponse. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var ListResolvedValuesResponseJSONUnmarshaler = new(jsonpb.Unmarshaler)
+
+// UnmarshalJSON satisfies the encoding/json Unmarshaler interface. This method
+// uses the more correct jsonpb package to correctly unmarshal the message.
+func (m *ListResolvedValuesResponse) UnmarshalJSON(b []byte) error {
+	return ListResolvedValuesResponseJSONUnmarshaler.Unmarshal(bytes.NewReader(b), m)
+}
+
+var _ json.Unmarshaler = (*ListResolvedValuesResponse)(nil)
+
 // GetValueByUniqueIdentifiersRequestJSONMarshaler describes the default jsonpb.Marshaler used by all
 // instances of GetValueByUniqueIdentifiersRequest. This struct is safe to replace or modify but
 // should not be done so concurrently.
@@ -1104,6 +1325,222 @@ func (m *Identifier) UnmarshalJSON(b []byte) error {
 
 var _ json.Unmarshaler = (*Identifier)(nil)
 
+// GetRoleRequestJSONMarshaler describes the default jsonpb.Marshaler used by all
+// instances of GetRoleRequest. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var GetRoleRequestJSONMarshaler = new(jsonpb.Marshaler)
+
+// MarshalJSON satisfies the encoding/json Marshaler interface. This method
+// uses the more correct jsonpb package to correctly marshal the message.
+func (m *GetRoleRequest) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return json.Marshal(nil)
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := GetRoleRequestJSONMarshaler.Marshal(buf, m); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+var _ json.Marshaler = (*GetRoleRequest)(nil)
+
+// GetRoleRequestJSONUnmarshaler describes the default jsonpb.Unmarshaler used by all
+// instances of GetRoleRequest. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var GetRoleRequestJSONUnmarshaler = new(jsonpb.Unmarshaler)
+
+// UnmarshalJSON satisfies the encoding/json Unmarshaler interface. This method
+// uses the more correct jsonpb package to correctly unmarshal the message.
+func (m *GetRoleRequest) UnmarshalJSON(b []byte) error {
+	return GetRoleRequestJSONUnmarshaler.Unmarshal(bytes.NewReader(b), m)
+}
+
+var _ json.Unmarshaler = (*GetRoleRequest)(nil)
+
+// GetRoleResponseJSONMarshaler describes the default jsonpb.Marshaler used by all
+// instances of GetRoleResponse. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var GetRoleResponseJSONMarshaler = new(jsonpb.Marshaler)
+
+// MarshalJSON satisfies the encoding/json Marshaler interface. This method
+// uses the more correct jsonpb package to correctly marshal the message.
+func (m *GetRoleResponse) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return json.Marshal(nil)
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := GetRoleResponseJSONMarshaler.Marshal(buf, m); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+var _ json.Marshaler = (*GetRoleResponse)(nil)
+
+// GetRoleResponseJSONUnmarshaler describes the default jsonpb.Unmarshaler used by all
+// instances of GetRoleResponse. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var GetRoleResponseJSONUnmarshaler = new(jsonpb.Unmarshaler)
+
+// UnmarshalJSON satisfies the encoding/json Unmarshaler interface. This method
+// uses the more correct jsonpb package to correctly unmarshal the message.
+func (m *GetRoleResponse) UnmarshalJSON(b []byte) error {
+	return GetRoleResponseJSONUnmarshaler.Unmarshal(bytes.NewReader(b), m)
+}
+
+var _ json.Unmarshaler = (*GetRoleResponse)(nil)
+
+// SaveRoleRequestJSONMarshaler describes the default jsonpb.Marshaler used by all
+// instances of SaveRoleRequest. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var SaveRoleRequestJSONMarshaler = new(jsonpb.Marshaler)
+
+// MarshalJSON satisfies the encoding/json Marshaler interface. This method
+// uses the more correct jsonpb package to correctly marshal the message.
+func (m *SaveRoleRequest) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return json.Marshal(nil)
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := SaveRoleRequestJSONMarshaler.Marshal(buf, m); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+var _ json.Marshaler = (*SaveRoleRequest)(nil)
+
+// SaveRoleRequestJSONUnmarshaler describes the default jsonpb.Unmarshaler used by all
+// instances of SaveRoleRequest. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var SaveRoleRequestJSONUnmarshaler = new(jsonpb.Unmarshaler)
+
+// UnmarshalJSON satisfies the encoding/json Unmarshaler interface. This method
+// uses the more correct jsonpb package to correctly unmarshal the message.
+func (m *SaveRoleRequest) UnmarshalJSON(b []byte) error {
+	return SaveRoleRequestJSONUnmarshaler.Unmarshal(bytes.NewReader(b), m)
+}
+
+var _ json.Unmarshaler = (*SaveRoleRequest)(nil)
+
+// SaveRoleResponseJSONMarshaler describes the default jsonpb.Marshaler used by all
+// instances of SaveRoleResponse. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var SaveRoleResponseJSONMarshaler = new(jsonpb.Marshaler)
+
+// MarshalJSON satisfies the encoding/json Marshaler interface. This method
+// uses the more correct jsonpb package to correctly marshal the message.
+func (m *SaveRoleResponse) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return json.Marshal(nil)
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := SaveRoleResponseJSONMarshaler.Marshal(buf, m); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+var _ json.Marshaler = (*SaveRoleResponse)(nil)
+
+// SaveRoleResponseJSONUnmarshaler describes the default jsonpb.Unmarshaler used by all
+// instances of SaveRoleResponse. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var SaveRoleResponseJSONUnmarshaler = new(jsonpb.Unmarshaler)
+
+// UnmarshalJSON satisfies the encoding/json Unmarshaler interface. This method
+// uses the more correct jsonpb package to correctly unmarshal the message.
+func (m *SaveRoleResponse) UnmarshalJSON(b []byte) error {
+	return SaveRoleResponseJSONUnmarshaler.Unmarshal(bytes.NewReader(b), m)
+}
+
+var _ json.Unmarshaler = (*SaveRoleResponse)(nil)
+
+// CloneRoleRequestJSONMarshaler describes the default jsonpb.Marshaler used by all
+// instances of CloneRoleRequest. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var CloneRoleRequestJSONMarshaler = new(jsonpb.Marshaler)
+
+// MarshalJSON satisfies the encoding/json Marshaler interface. This method
+// uses the more correct jsonpb package to correctly marshal the message.
+func (m *CloneRoleRequest) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return json.Marshal(nil)
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := CloneRoleRequestJSONMarshaler.Marshal(buf, m); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+var _ json.Marshaler = (*CloneRoleRequest)(nil)
+
+// CloneRoleRequestJSONUnmarshaler describes the default jsonpb.Unmarshaler used by all
+// instances of CloneRoleRequest. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var CloneRoleRequestJSONUnmarshaler = new(jsonpb.Unmarshaler)
+
+// UnmarshalJSON satisfies the encoding/json Unmarshaler interface. This method
+// uses the more correct jsonpb package to correctly unmarshal the message.
+func (m *CloneRoleRequest) UnmarshalJSON(b []byte) error {
+	return CloneRoleRequestJSONUnmarshaler.Unmarshal(bytes.NewReader(b), m)
+}
+
+var _ json.Unmarshaler = (*CloneRoleRequest)(nil)
+
+// DeleteRoleRequestJSONMarshaler describes the default jsonpb.Marshaler used by all
+// instances of DeleteRoleRequest. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var DeleteRoleRequestJSONMarshaler = new(jsonpb.Marshaler)
+
+// MarshalJSON satisfies the encoding/json Marshaler interface. This method
+// uses the more correct jsonpb package to correctly marshal the message.
+func (m *DeleteRoleRequest) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return json.Marshal(nil)
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := DeleteRoleRequestJSONMarshaler.Marshal(buf, m); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+var _ json.Marshaler = (*DeleteRoleRequest)(nil)
+
+// DeleteRoleRequestJSONUnmarshaler describes the default jsonpb.Unmarshaler used by all
+// instances of DeleteRoleRequest. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var DeleteRoleRequestJSONUnmarshaler = new(jsonpb.Unmarshaler)
+
+// UnmarshalJSON satisfies the encoding/json Unmarshaler interface. This method
+// uses the more correct jsonpb package to correctly unmarshal the message.
+func (m *DeleteRoleRequest) UnmarshalJSON(b []byte) error {
+	return DeleteRoleRequestJSONUnmarshaler.Unmarshal(bytes.NewReader(b), m)
+}
+
+var _ json.Unmarshaler = (*DeleteRoleRequest)(nil)
+
 // ListRoleAssignmentsRequestJSONMarshaler describes the default jsonpb.Marshaler used by all
 // instances of ListRoleAssignmentsRequest. This struct is safe to replace or modify but
 // should not be done so concurrently.
@@ -1248,6 +1685,78 @@ func (m *AssignRoleToUserResponse) UnmarshalJSON(b []byte) error {
 
 var _ json.Unmarshaler = (*AssignRoleToUserResponse)(nil)
 
+// AddRoleToUserRequestJSONMarshaler describes the default jsonpb.Marshaler used by all
+// instances of AddRoleToUserRequest. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var AddRoleToUserRequestJSONMarshaler = new(jsonpb.Marshaler)
+
+// MarshalJSON satisfies the encoding/json Marshaler interface. This method
+// uses the more correct jsonpb package to correctly marshal the message.
+func (m *AddRoleToUserRequest) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return json.Marshal(nil)
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := AddRoleToUserRequestJSONMarshaler.Marshal(buf, m); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+var _ json.Marshaler = (*AddRoleToUserRequest)(nil)
+
+// AddRoleToUserRequestJSONUnmarshaler describes the default jsonpb.Unmarshaler used by all
+// instances of AddRoleToUserRequest. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var AddRoleToUserRequestJSONUnmarshaler = new(jsonpb.Unmarshaler)
+
+// UnmarshalJSON satisfies the encoding/json Unmarshaler interface. This method
+// uses the more correct jsonpb package to correctly unmarshal the message.
+func (m *AddRoleToUserRequest) UnmarshalJSON(b []byte) error {
+	return AddRoleToUserRequestJSONUnmarshaler.Unmarshal(bytes.NewReader(b), m)
+}
+
+var _ json.Unmarshaler = (*AddRoleToUserRequest)(nil)
+
+// AddRoleToUserResponseJSONMarshaler describes the default jsonpb.Marshaler used by all
+// instances of AddRoleToUserResponse. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var AddRoleToUserResponseJSONMarshaler = new(jsonpb.Marshaler)
+
+// MarshalJSON satisfies the encoding/json Marshaler interface. This method
+// uses the more correct jsonpb package to correctly marshal the message.
+func (m *AddRoleToUserResponse) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return json.Marshal(nil)
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := AddRoleToUserResponseJSONMarshaler.Marshal(buf, m); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+var _ json.Marshaler = (*AddRoleToUserResponse)(nil)
+
+// AddRoleToUserResponseJSONUnmarshaler describes the default jsonpb.Unmarshaler used by all
+// instances of AddRoleToUserResponse. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var AddRoleToUserResponseJSONUnmarshaler = new(jsonpb.Unmarshaler)
+
+// UnmarshalJSON satisfies the encoding/json Unmarshaler interface. This method
+// uses the more correct jsonpb package to correctly unmarshal the message.
+func (m *AddRoleToUserResponse) UnmarshalJSON(b []byte) error {
+	return AddRoleToUserResponseJSONUnmarshaler.Unmarshal(bytes.NewReader(b), m)
+}
+
+var _ json.Unmarshaler = (*AddRoleToUserResponse)(nil)
+
 // RemoveRoleFromUserRequestJSONMarshaler describes the default jsonpb.Marshaler used by all
 // instances of RemoveRoleFromUserRequest. This struct is safe to replace or modify but
 // should not be done so concurrently.
@@ -1896,6 +2405,114 @@ func (m *ListValue) UnmarshalJSON(b []byte) error {
 
 var _ json.Unmarshaler = (*ListValue)(nil)
 
+// ResolvedValueJSONMarshaler describes the default jsonpb.Marshaler used by all
+// instances of ResolvedValue. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var ResolvedValueJSONMarshaler = new(jsonpb.Marshaler)
+
+// MarshalJSON satisfies the encoding/json Marshaler interface. This method
+// uses the more correct jsonpb package to correctly marshal the message.
+func (m *ResolvedValue) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return json.Marshal(nil)
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := ResolvedValueJSONMarshaler.Marshal(buf, m); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+var _ json.Marshaler = (*ResolvedValue)(nil)
+
+// ResolvedValueJSONUnmarshaler describes the default jsonpb.Unmarshaler used by all
+// instances of ResolvedValue. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var ResolvedValueJSONUnmarshaler = new(jsonpb.Unmarshaler)
+
+// UnmarshalJSON satisfies the encoding/json Unmarshaler interface. This method
+// uses the more correct jsonpb package to correctly unmarshal the message.
+func (m *ResolvedValue) UnmarshalJSON(b []byte) error {
+	return ResolvedValueJSONUnmarshaler.Unmarshal(bytes.NewReader(b), m)
+}
+
+var _ json.Unmarshaler = (*ResolvedValue)(nil)
+
+// ValueErrorJSONMarshaler describes the default jsonpb.Marshaler used by all
+// instances of ValueError. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var ValueErrorJSONMarshaler = new(jsonpb.Marshaler)
+
+// MarshalJSON satisfies the encoding/json Marshaler interface. This method
+// uses the more correct jsonpb package to correctly marshal the message.
+func (m *ValueError) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return json.Marshal(nil)
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := ValueErrorJSONMarshaler.Marshal(buf, m); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+var _ json.Marshaler = (*ValueError)(nil)
+
+// ValueErrorJSONUnmarshaler describes the default jsonpb.Unmarshaler used by all
+// instances of ValueError. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var ValueErrorJSONUnmarshaler = new(jsonpb.Unmarshaler)
+
+// UnmarshalJSON satisfies the encoding/json Unmarshaler interface. This method
+// uses the more correct jsonpb package to correctly unmarshal the message.
+func (m *ValueError) UnmarshalJSON(b []byte) error {
+	return ValueErrorJSONUnmarshaler.Unmarshal(bytes.NewReader(b), m)
+}
+
+var _ json.Unmarshaler = (*ValueError)(nil)
+
+// ValueViolationJSONMarshaler describes the default jsonpb.Marshaler used by all
+// instances of ValueViolation. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var ValueViolationJSONMarshaler = new(jsonpb.Marshaler)
+
+// MarshalJSON satisfies the encoding/json Marshaler interface. This method
+// uses the more correct jsonpb package to correctly marshal the message.
+func (m *ValueViolation) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return json.Marshal(nil)
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := ValueViolationJSONMarshaler.Marshal(buf, m); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+var _ json.Marshaler = (*ValueViolation)(nil)
+
+// ValueViolationJSONUnmarshaler describes the default jsonpb.Unmarshaler used by all
+// instances of ValueViolation. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var ValueViolationJSONUnmarshaler = new(jsonpb.Unmarshaler)
+
+// UnmarshalJSON satisfies the encoding/json Unmarshaler interface. This method
+// uses the more correct jsonpb package to correctly unmarshal the message.
+func (m *ValueViolation) UnmarshalJSON(b []byte) error {
+	return ValueViolationJSONUnmarshaler.Unmarshal(bytes.NewReader(b), m)
+}
+
+var _ json.Unmarshaler = (*ValueViolation)(nil)
+
 // ListOptionValueJSONMarshaler describes the default jsonpb.Marshaler used by all
 // instances of ListOptionValue. This struct is safe to replace or modify but
 // should not be done so concurrently.
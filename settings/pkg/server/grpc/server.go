@@ -33,6 +33,9 @@ func Server(opts ...Option) grpc.Service {
 	if err := proto.RegisterPermissionServiceHandler(service.Server(), handle); err != nil {
 		options.Logger.Fatal().Err(err).Msg("could not register Permission service handler")
 	}
+	if err := proto.RegisterBackupServiceHandler(service.Server(), handle); err != nil {
+		options.Logger.Fatal().Err(err).Msg("could not register Backup service handler")
+	}
 
 	service.Init()
 	return service
@@ -0,0 +1,82 @@
+package svc
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	merrors "github.com/micro/go-micro/v2/errors"
+	"github.com/owncloud/ocis/ocis-pkg/backup"
+	"github.com/owncloud/ocis/settings/pkg/proto/v0"
+)
+
+// storeTypeFile records the type of the store a snapshot was taken from, a store can only restore its own snapshots.
+const storeTypeFile = "store.type"
+
+// Snapshot implements the BackupServiceHandler interface.
+func (g Service) Snapshot(c context.Context, req *proto.SnapshotRequest, stream proto.BackupService_SnapshotStream) error {
+	if !g.hasStaticPermission(c, SettingsManagementPermissionID) {
+		return merrors.Forbidden(g.id, "user has no settings management permission")
+	}
+
+	dir, err := ioutil.TempDir("", "ocis-settings-snapshot-")
+	if err != nil {
+		return merrors.InternalServerError(g.id, "could not create snapshot directory")
+	}
+	defer os.RemoveAll(dir)
+
+	if err := g.manager.Snapshot(dir); err != nil {
+		g.logger.Error().Err(err).Msg("could not copy settings")
+		return merrors.InternalServerError(g.id, "could not copy settings")
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, storeTypeFile), []byte(g.storeType()), 0600); err != nil {
+		return merrors.InternalServerError(g.id, "could not write store type")
+	}
+	return backup.Send(dir, func(path string, data []byte) error {
+		return stream.Send(&proto.SnapshotResponse{Path: path, Data: data})
+	})
+}
+
+// Restore implements the BackupServiceHandler interface. The files of a snapshot are staged until the restore is
+// committed, then they replace all settings.
+func (g Service) Restore(c context.Context, req *proto.RestoreRequest, res *proto.RestoreResponse) error {
+	if !g.hasStaticPermission(c, SettingsManagementPermissionID) {
+		return merrors.Forbidden(g.id, "user has no settings management permission")
+	}
+
+	if !req.Commit {
+		if err := g.uploads.Stage(req.Upload, req.Path, req.Data); err != nil {
+			return merrors.BadRequest(g.id, "could not stage %v: %v", req.Path, err)
+		}
+		return nil
+	}
+
+	dir, err := g.uploads.Take(req.Upload)
+	if err != nil {
+		return merrors.BadRequest(g.id, "%v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	storeType, err := ioutil.ReadFile(filepath.Join(dir, storeTypeFile))
+	if err != nil {
+		return merrors.BadRequest(g.id, "the snapshot does not contain a store type")
+	}
+	if t := strings.TrimSpace(string(storeType)); t != g.storeType() {
+		return merrors.BadRequest(g.id, "the snapshot was taken from a %v store, this is a %v store", t, g.storeType())
+	}
+	if err := g.manager.Restore(dir); err != nil {
+		g.logger.Error().Err(err).Msg("could not restore settings")
+		return merrors.InternalServerError(g.id, "could not restore settings: %v", err)
+	}
+	return nil
+}
+
+// storeType returns the name of the configured store type.
+func (g Service) storeType() string {
+	if g.config.Service.StoreType == "" {
+		return "filesystem"
+	}
+	return g.config.Service.StoreType
+}
@@ -1,6 +1,17 @@
 package svc
 
-import "github.com/owncloud/ocis/settings/pkg/proto/v0"
+import (
+	"context"
+
+	merrors "github.com/micro/go-micro/v2/errors"
+	"github.com/owncloud/ocis/ocis-pkg/roles"
+	"github.com/owncloud/ocis/settings/pkg/proto/v0"
+)
+
+var (
+	readOperations  = []proto.Permission_Operation{proto.Permission_OPERATION_READ, proto.Permission_OPERATION_READWRITE}
+	writeOperations = []proto.Permission_Operation{proto.Permission_OPERATION_WRITE, proto.Permission_OPERATION_READWRITE}
+)
 
 func (g Service) hasPermission(
 	roleIDs []string,
@@ -55,3 +66,49 @@ func isConstraintFulfilled(permissions []*proto.Permission, constraint proto.Per
 	}
 	return false
 }
+
+// checkValuePermission checks if the authenticated user may access the value with one of the given operations.
+// Values of the user itself require a permission with CONSTRAINT_OWN, values of other accounts CONSTRAINT_ALL.
+// System values can be read with CONSTRAINT_OWN but written only with CONSTRAINT_ALL.
+// The permission can be granted for the setting or for the whole bundle.
+func (g Service) checkValuePermission(ctx context.Context, value *proto.Value, operations []proto.Permission_Operation) error {
+	if g.hasStaticPermission(ctx, SettingsManagementPermissionID) {
+		return nil
+	}
+	roleIDs, ok := roles.ReadRoleIDsFromContext(ctx)
+	if !ok {
+		return merrors.Forbidden(g.id, "no roles to access the value of setting %s", value.SettingId)
+	}
+
+	constraint := proto.Permission_CONSTRAINT_ALL
+	switch {
+	case value.AccountUuid != "" && g.isOwnAccount(ctx, value.AccountUuid):
+		constraint = proto.Permission_CONSTRAINT_OWN
+	case value.AccountUuid == "" && !isAnyOperationIn(proto.Permission_OPERATION_WRITE, operations):
+		constraint = proto.Permission_CONSTRAINT_OWN
+	}
+
+	settingResource := &proto.Resource{Type: proto.Resource_TYPE_SETTING, Id: value.SettingId}
+	bundleResource := &proto.Resource{Type: proto.Resource_TYPE_BUNDLE, Id: value.BundleId}
+	if g.hasPermission(roleIDs, settingResource, operations, constraint) ||
+		g.hasPermission(roleIDs, bundleResource, operations, constraint) {
+		return nil
+	}
+	return merrors.Forbidden(g.id, "user has no permission to access the value of setting %s", value.SettingId)
+}
+
+// isOwnAccount checks if the account uuid belongs to the authenticated user
+func (g Service) isOwnAccount(ctx context.Context, accountUUID string) bool {
+	ownAccountUUID := getValidatedAccountUUID(ctx, "me")
+	return ownAccountUUID != "" && ownAccountUUID == accountUUID
+}
+
+// isAnyOperationIn checks if the operation is one of the given operations
+func isAnyOperationIn(operation proto.Permission_Operation, operations []proto.Permission_Operation) bool {
+	for _, o := range operations {
+		if o == operation {
+			return true
+		}
+	}
+	return false
+}
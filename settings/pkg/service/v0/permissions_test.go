@@ -0,0 +1,665 @@
+package svc
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/micro/go-micro/v2/client"
+	merrors "github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/metadata"
+	accounts "github.com/owncloud/ocis/accounts/pkg/proto/v0"
+	"github.com/owncloud/ocis/ocis-pkg/log"
+	"github.com/owncloud/ocis/ocis-pkg/middleware"
+	"github.com/owncloud/ocis/settings/pkg/config"
+	"github.com/owncloud/ocis/settings/pkg/proto/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	adminAccountUUID = "27ee8e5a-4c1b-4e0e-9c5c-0aa6c3b2c67b"
+	userAccountUUID  = "4c510ada-c86b-4815-8820-42cdf82c3d51"
+	guestAccountUUID = "f7fbf8c8-139b-4376-b307-cf0a8c2d0d9c"
+
+	profileBundleID    = "2a506de7-99bd-4f0d-994e-c38e72c28fd9"
+	languageSettingID  = "aa8cfbe5-95d4-4f7e-a032-c3c01f5f062f"
+	timezoneSettingID  = "b6e8f1a6-3c1a-4c53-a4ae-3b5d8c0d5a2d"
+	userLanguageValue  = "96d5a1a7-2f3a-4c56-a8e1-9b0a0d8c3f11"
+	adminLanguageValue = "0a1c7ed5-1f0e-4d38-8cf0-63d0e6ab2c10"
+)
+
+type role struct {
+	name        string
+	roleID      string
+	accountUUID string
+}
+
+var (
+	admin = role{"admin", BundleUUIDRoleAdmin, adminAccountUUID}
+	user  = role{"user", BundleUUIDRoleUser, userAccountUUID}
+	guest = role{"guest", BundleUUIDRoleGuest, guestAccountUUID}
+)
+
+func (r role) ctx() context.Context {
+	roleIDs, _ := json.Marshal([]string{r.roleID})
+	ctx := metadata.Set(context.Background(), middleware.RoleIDs, string(roleIDs))
+	return metadata.Set(ctx, middleware.AccountID, r.accountUUID)
+}
+
+const testJWTSecret = "settings-test-secret"
+
+// serviceCtx returns the context of an internal request of another service
+func serviceCtx(t *testing.T) context.Context {
+	ctx, err := middleware.ContextWithServiceToken(context.Background(), "test", testJWTSecret)
+	require.NoError(t, err)
+	return ctx
+}
+
+// groupsService is an accounts service that knows the groups with the given ids
+type groupsService struct {
+	accounts.GroupsService
+	ids []string
+}
+
+func (s groupsService) GetGroup(ctx context.Context, in *accounts.GetGroupRequest, opts ...client.CallOption) (*accounts.Group, error) {
+	if _, ok := middleware.ServiceFromContext(ctx, testJWTSecret); !ok {
+		return nil, merrors.Forbidden("com.owncloud.api.accounts", "no service token")
+	}
+	for _, id := range s.ids {
+		if id == in.Id {
+			return &accounts.Group{Id: id}, nil
+		}
+	}
+	return nil, merrors.NotFound("com.owncloud.api.accounts", "group not found: %s", in.Id)
+}
+
+// newTestService sets up the default roles and a profile bundle with a language and a timezone setting.
+// Admins may read and write the language of everyone, users their own language and timezone, guests only read their
+// own language. Bundles are filtered by these permissions, so admins only see the language setting.
+func newTestService(t *testing.T) Service {
+	dir, err := ioutil.TempDir("", "settings")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	cfg := config.New()
+	cfg.Service.DataPath = dir
+	cfg.TokenManager.JWTSecret = testJWTSecret
+	g := NewService(cfg, log.NewLogger())
+	g.groups = groupsService{ids: []string{"sales", "support", "marketing"}}
+
+	_, err = g.manager.WriteBundle(&proto.Bundle{
+		Id:          profileBundleID,
+		Name:        "profile",
+		Extension:   "test",
+		DisplayName: "Profile",
+		Type:        proto.Bundle_TYPE_DEFAULT,
+		Resource:    &proto.Resource{Type: proto.Resource_TYPE_SYSTEM},
+		Settings: []*proto.Setting{
+			{
+				Id:       languageSettingID,
+				Name:     "language",
+				Resource: &proto.Resource{Type: proto.Resource_TYPE_USER},
+				Value:    &proto.Setting_StringValue{StringValue: &proto.String{}},
+			},
+			{
+				Id:       timezoneSettingID,
+				Name:     "timezone",
+				Resource: &proto.Resource{Type: proto.Resource_TYPE_USER},
+				Value:    &proto.Setting_StringValue{StringValue: &proto.String{}},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	permission := func(roleID, settingID string, operation proto.Permission_Operation, constraint proto.Permission_Constraint) {
+		_, err := g.manager.AddSettingToBundle(roleID, &proto.Setting{
+			Name:     "permission-" + settingID,
+			Resource: &proto.Resource{Type: proto.Resource_TYPE_SETTING, Id: settingID},
+			Value: &proto.Setting_PermissionValue{
+				PermissionValue: &proto.Permission{Operation: operation, Constraint: constraint},
+			},
+		})
+		require.NoError(t, err)
+	}
+	permission(BundleUUIDRoleAdmin, languageSettingID, proto.Permission_OPERATION_READWRITE, proto.Permission_CONSTRAINT_ALL)
+	permission(BundleUUIDRoleUser, languageSettingID, proto.Permission_OPERATION_READWRITE, proto.Permission_CONSTRAINT_OWN)
+	permission(BundleUUIDRoleUser, timezoneSettingID, proto.Permission_OPERATION_READWRITE, proto.Permission_CONSTRAINT_OWN)
+	permission(BundleUUIDRoleGuest, languageSettingID, proto.Permission_OPERATION_READ, proto.Permission_CONSTRAINT_OWN)
+
+	for id, accountUUID := range map[string]string{userLanguageValue: userAccountUUID, adminLanguageValue: adminAccountUUID} {
+		_, err = g.manager.WriteValue(&proto.Value{
+			Id:          id,
+			BundleId:    profileBundleID,
+			SettingId:   languageSettingID,
+			AccountUuid: accountUUID,
+			Resource:    &proto.Resource{Type: proto.Resource_TYPE_USER},
+			Value:       &proto.Value_StringValue{StringValue: "de"},
+		})
+		require.NoError(t, err)
+	}
+	return g
+}
+
+// code returns the http status code of a go-micro error, 0 for no error
+func code(err error) int32 {
+	if err == nil {
+		return 0
+	}
+	return merrors.Parse(err.Error()).Code
+}
+
+func TestBundlePermissions(t *testing.T) {
+	t.Run("SaveBundle", func(t *testing.T) {
+		for r, expected := range map[role]int32{admin: 0, user: 403, guest: 403} {
+			g := newTestService(t)
+			err := g.SaveBundle(r.ctx(), &proto.SaveBundleRequest{Bundle: &proto.Bundle{
+				Name:        "appearance",
+				Extension:   "test",
+				DisplayName: "Appearance",
+				Type:        proto.Bundle_TYPE_DEFAULT,
+				Resource:    &proto.Resource{Type: proto.Resource_TYPE_SYSTEM},
+				Settings: []*proto.Setting{{
+					Name:     "theme",
+					Resource: &proto.Resource{Type: proto.Resource_TYPE_USER},
+					Value:    &proto.Setting_StringValue{StringValue: &proto.String{}},
+				}},
+			}}, &proto.SaveBundleResponse{})
+			assert.Equal(t, expected, code(err), r.name)
+		}
+	})
+
+	t.Run("GetBundle", func(t *testing.T) {
+		g := newTestService(t)
+		for r, settings := range map[role]int{admin: 1, user: 2, guest: 1} {
+			res := &proto.GetBundleResponse{}
+			err := g.GetBundle(r.ctx(), &proto.GetBundleRequest{BundleId: profileBundleID}, res)
+			require.NoError(t, err, r.name)
+			assert.Len(t, res.Bundle.Settings, settings, r.name)
+		}
+	})
+
+	t.Run("ListBundles", func(t *testing.T) {
+		g := newTestService(t)
+		for r, settings := range map[role]int{admin: 1, user: 2, guest: 1} {
+			res := &proto.ListBundlesResponse{}
+			err := g.ListBundles(r.ctx(), &proto.ListBundlesRequest{}, res)
+			require.NoError(t, err, r.name)
+			require.Len(t, res.Bundles, 1, r.name)
+			assert.Len(t, res.Bundles[0].Settings, settings, r.name)
+		}
+	})
+
+	t.Run("AddSettingToBundle", func(t *testing.T) {
+		for r, expected := range map[role]int32{admin: 0, user: 403, guest: 403} {
+			g := newTestService(t)
+			err := g.AddSettingToBundle(r.ctx(), &proto.AddSettingToBundleRequest{
+				BundleId: profileBundleID,
+				Setting: &proto.Setting{
+					Name:     "theme",
+					Resource: &proto.Resource{Type: proto.Resource_TYPE_USER},
+					Value:    &proto.Setting_StringValue{StringValue: &proto.String{}},
+				},
+			}, &proto.AddSettingToBundleResponse{})
+			assert.Equal(t, expected, code(err), r.name)
+		}
+	})
+
+	t.Run("RemoveSettingFromBundle", func(t *testing.T) {
+		for r, expected := range map[role]int32{admin: 0, user: 403, guest: 403} {
+			g := newTestService(t)
+			err := g.RemoveSettingFromBundle(r.ctx(), &proto.RemoveSettingFromBundleRequest{
+				BundleId:  profileBundleID,
+				SettingId: timezoneSettingID,
+			}, &empty.Empty{})
+			assert.Equal(t, expected, code(err), r.name)
+		}
+	})
+}
+
+func TestValuePermissions(t *testing.T) {
+	saveValue := func(g Service, r role, accountUUID, settingID string) error {
+		return g.SaveValue(r.ctx(), &proto.SaveValueRequest{Value: &proto.Value{
+			BundleId:    profileBundleID,
+			SettingId:   settingID,
+			AccountUuid: accountUUID,
+			Resource:    &proto.Resource{Type: proto.Resource_TYPE_USER},
+			Value:       &proto.Value_StringValue{StringValue: "en"},
+		}}, &proto.SaveValueResponse{})
+	}
+
+	t.Run("SaveValue own", func(t *testing.T) {
+		g := newTestService(t)
+		for r, expected := range map[role]int32{admin: 0, user: 0, guest: 403} {
+			assert.Equal(t, expected, code(saveValue(g, r, "me", languageSettingID)), r.name)
+		}
+	})
+
+	t.Run("SaveValue of another account", func(t *testing.T) {
+		g := newTestService(t)
+		for r, expected := range map[role]int32{admin: 0, user: 403, guest: 403} {
+			other := userAccountUUID
+			if r == user {
+				other = guestAccountUUID
+			}
+			assert.Equal(t, expected, code(saveValue(g, r, other, languageSettingID)), r.name)
+		}
+	})
+
+	t.Run("SaveValue overwriting the value of another account", func(t *testing.T) {
+		g := newTestService(t)
+		err := g.SaveValue(user.ctx(), &proto.SaveValueRequest{Value: &proto.Value{
+			Id:          adminLanguageValue,
+			BundleId:    profileBundleID,
+			SettingId:   languageSettingID,
+			AccountUuid: "me",
+			Resource:    &proto.Resource{Type: proto.Resource_TYPE_USER},
+			Value:       &proto.Value_StringValue{StringValue: "en"},
+		}}, &proto.SaveValueResponse{})
+		assert.Equal(t, int32(403), code(err))
+	})
+
+	t.Run("SaveValue system", func(t *testing.T) {
+		g := newTestService(t)
+		for r, expected := range map[role]int32{admin: 0, user: 403, guest: 403} {
+			err := g.SaveValue(r.ctx(), &proto.SaveValueRequest{Value: &proto.Value{
+				BundleId:    profileBundleID,
+				SettingId:   languageSettingID,
+				AccountUuid: "me",
+				Resource:    &proto.Resource{Type: proto.Resource_TYPE_SYSTEM},
+				Value:       &proto.Value_StringValue{StringValue: "en"},
+			}}, &proto.SaveValueResponse{})
+			assert.Equal(t, expected, code(err), r.name)
+		}
+	})
+
+	t.Run("GetValue", func(t *testing.T) {
+		g := newTestService(t)
+		for r, expected := range map[role]int32{admin: 0, user: 0, guest: 403} {
+			err := g.GetValue(r.ctx(), &proto.GetValueRequest{Id: userLanguageValue}, &proto.GetValueResponse{})
+			assert.Equal(t, expected, code(err), r.name)
+		}
+	})
+
+	t.Run("GetValueByUniqueIdentifiers", func(t *testing.T) {
+		g := newTestService(t)
+		for r, expected := range map[role]int32{admin: 0, user: 0, guest: 403} {
+			err := g.GetValueByUniqueIdentifiers(r.ctx(), &proto.GetValueByUniqueIdentifiersRequest{
+				AccountUuid: userAccountUUID,
+				SettingId:   languageSettingID,
+			}, &proto.GetValueResponse{})
+			assert.Equal(t, expected, code(err), r.name)
+		}
+	})
+
+	t.Run("ListValues", func(t *testing.T) {
+		g := newTestService(t)
+		for r, values := range map[role]int{admin: 1, user: 1, guest: 0} {
+			res := &proto.ListValuesResponse{}
+			err := g.ListValues(r.ctx(), &proto.ListValuesRequest{BundleId: profileBundleID, AccountUuid: userAccountUUID}, res)
+			require.NoError(t, err, r.name)
+			assert.Len(t, res.Values, values, r.name)
+		}
+	})
+}
+
+func TestRolePermissions(t *testing.T) {
+	t.Run("ListRoles", func(t *testing.T) {
+		g := newTestService(t)
+		for r, roles := range map[role]int{admin: 3, user: 1, guest: 1} {
+			res := &proto.ListBundlesResponse{}
+			err := g.ListRoles(r.ctx(), &proto.ListBundlesRequest{}, res)
+			require.NoError(t, err, r.name)
+			assert.Len(t, res.Bundles, roles, r.name)
+		}
+	})
+
+	t.Run("ListRoleAssignments", func(t *testing.T) {
+		g := newTestService(t)
+		for r, expected := range map[role]int32{admin: 0, user: 0, guest: 403} {
+			err := g.ListRoleAssignments(r.ctx(), &proto.ListRoleAssignmentsRequest{AccountUuid: userAccountUUID}, &proto.ListRoleAssignmentsResponse{})
+			assert.Equal(t, expected, code(err), r.name)
+		}
+	})
+
+	t.Run("AssignRoleToUser", func(t *testing.T) {
+		g := newTestService(t)
+		for r, expected := range map[role]int32{admin: 0, user: 403, guest: 403} {
+			err := g.AssignRoleToUser(r.ctx(), &proto.AssignRoleToUserRequest{
+				AccountUuid: guestAccountUUID,
+				RoleId:      BundleUUIDRoleAdmin,
+			}, &proto.AssignRoleToUserResponse{})
+			assert.Equal(t, expected, code(err), r.name)
+		}
+	})
+
+	t.Run("AddRoleToUser", func(t *testing.T) {
+		g := newTestService(t)
+		for r, expected := range map[role]int32{admin: 0, user: 403, guest: 403} {
+			err := g.AddRoleToUser(r.ctx(), &proto.AddRoleToUserRequest{
+				AccountUuid: guestAccountUUID,
+				RoleId:      BundleUUIDRoleAdmin,
+			}, &proto.AddRoleToUserResponse{})
+			assert.Equal(t, expected, code(err), r.name)
+		}
+	})
+
+	t.Run("RemoveRoleFromUser", func(t *testing.T) {
+		for r, expected := range map[role]int32{admin: 0, user: 403, guest: 403} {
+			g := newTestService(t)
+			assignment, err := g.manager.WriteRoleAssignment(userAccountUUID, BundleUUIDRoleUser)
+			require.NoError(t, err)
+			err = g.RemoveRoleFromUser(r.ctx(), &proto.RemoveRoleFromUserRequest{Id: assignment.Id}, &empty.Empty{})
+			assert.Equal(t, expected, code(err), r.name)
+		}
+	})
+}
+
+func TestRoleManagement(t *testing.T) {
+	helpdesk := func() *proto.Bundle {
+		return &proto.Bundle{
+			Name:        "helpdesk",
+			DisplayName: "Helpdesk",
+			Settings: []*proto.Setting{{
+				Name:     "language-read",
+				Resource: &proto.Resource{Type: proto.Resource_TYPE_SETTING, Id: languageSettingID},
+				Value: &proto.Setting_PermissionValue{
+					PermissionValue: &proto.Permission{
+						Operation:  proto.Permission_OPERATION_READ,
+						Constraint: proto.Permission_CONSTRAINT_ALL,
+					},
+				},
+			}},
+		}
+	}
+
+	t.Run("SaveRole", func(t *testing.T) {
+		for r, expected := range map[role]int32{admin: 0, user: 403, guest: 403} {
+			g := newTestService(t)
+			res := &proto.SaveRoleResponse{}
+			err := g.SaveRole(r.ctx(), &proto.SaveRoleRequest{Role: helpdesk()}, res)
+			require.Equal(t, expected, code(err), r.name)
+			if err == nil {
+				assert.NotEmpty(t, res.Role.Id)
+				assert.Equal(t, proto.Bundle_TYPE_ROLE, res.Role.Type)
+				assert.Equal(t, defaultRoleExtension, res.Role.Extension)
+				assert.NotEmpty(t, res.Role.Settings[0].Id)
+			}
+		}
+	})
+
+	t.Run("SaveRole validates permissions", func(t *testing.T) {
+		g := newTestService(t)
+		noPermission := helpdesk()
+		noPermission.Settings[0].Value = &proto.Setting_StringValue{StringValue: &proto.String{}}
+		noOperation := helpdesk()
+		noOperation.Settings[0].GetPermissionValue().Operation = proto.Permission_OPERATION_UNKNOWN
+		noConstraint := helpdesk()
+		noConstraint.Settings[0].GetPermissionValue().Constraint = proto.Permission_CONSTRAINT_UNKNOWN
+		duplicate := helpdesk()
+		duplicate.Name = "admin"
+		defaultBundle := helpdesk()
+		defaultBundle.Id = profileBundleID
+
+		for name, r := range map[string]*proto.Bundle{
+			"no permission":      noPermission,
+			"unknown operation":  noOperation,
+			"unknown constraint": noConstraint,
+			"duplicate name":     duplicate,
+			"no role":            defaultBundle,
+		} {
+			err := g.SaveRole(admin.ctx(), &proto.SaveRoleRequest{Role: r}, &proto.SaveRoleResponse{})
+			assert.Equal(t, int32(400), code(err), name)
+		}
+	})
+
+	t.Run("CloneRole", func(t *testing.T) {
+		g := newTestService(t)
+		res := &proto.SaveRoleResponse{}
+		err := g.CloneRole(admin.ctx(), &proto.CloneRoleRequest{
+			RoleId:      BundleUUIDRoleUser,
+			Name:        "power-user",
+			DisplayName: "Power User",
+		}, res)
+		require.NoError(t, err)
+		source, err := g.manager.ReadBundle(BundleUUIDRoleUser)
+		require.NoError(t, err)
+		assert.NotEqual(t, source.Id, res.Role.Id)
+		assert.Equal(t, "power-user", res.Role.Name)
+		require.Len(t, res.Role.Settings, len(source.Settings))
+		for i := range source.Settings {
+			assert.Equal(t, source.Settings[i].Id, res.Role.Settings[i].Id)
+		}
+
+		err = g.CloneRole(user.ctx(), &proto.CloneRoleRequest{
+			RoleId:      BundleUUIDRoleAdmin,
+			Name:        "my-admin",
+			DisplayName: "My Admin",
+		}, &proto.SaveRoleResponse{})
+		assert.Equal(t, int32(403), code(err))
+	})
+
+	t.Run("GetRole", func(t *testing.T) {
+		g := newTestService(t)
+		for r, expected := range map[role]int32{admin: 0, user: 0, guest: 403} {
+			err := g.GetRole(r.ctx(), &proto.GetRoleRequest{RoleId: BundleUUIDRoleUser}, &proto.GetRoleResponse{})
+			assert.Equal(t, expected, code(err), r.name)
+		}
+		err := g.GetRole(admin.ctx(), &proto.GetRoleRequest{RoleId: profileBundleID}, &proto.GetRoleResponse{})
+		assert.Equal(t, int32(404), code(err))
+	})
+
+	t.Run("DeleteRole", func(t *testing.T) {
+		g := newTestService(t)
+		for _, roleID := range []string{BundleUUIDRoleAdmin, BundleUUIDRoleUser, BundleUUIDRoleGuest} {
+			err := g.DeleteRole(admin.ctx(), &proto.DeleteRoleRequest{RoleId: roleID}, &empty.Empty{})
+			assert.Equal(t, int32(403), code(err), roleID)
+		}
+
+		res := &proto.SaveRoleResponse{}
+		require.NoError(t, g.SaveRole(admin.ctx(), &proto.SaveRoleRequest{Role: helpdesk()}, res))
+		_, err := g.manager.WriteRoleAssignment(guestAccountUUID, res.Role.Id)
+		require.NoError(t, err)
+
+		err = g.DeleteRole(user.ctx(), &proto.DeleteRoleRequest{RoleId: res.Role.Id}, &empty.Empty{})
+		assert.Equal(t, int32(403), code(err))
+		require.NoError(t, g.DeleteRole(admin.ctx(), &proto.DeleteRoleRequest{RoleId: res.Role.Id}, &empty.Empty{}))
+
+		err = g.GetRole(admin.ctx(), &proto.GetRoleRequest{RoleId: res.Role.Id}, &proto.GetRoleResponse{})
+		assert.Equal(t, int32(404), code(err))
+		assignments, err := g.manager.ListRoleAssignments(guestAccountUUID)
+		require.NoError(t, err)
+		assert.Empty(t, assignments)
+	})
+
+	t.Run("AddRoleToUser with multiple roles", func(t *testing.T) {
+		g := newTestService(t)
+		res := &proto.SaveRoleResponse{}
+		require.NoError(t, g.SaveRole(admin.ctx(), &proto.SaveRoleRequest{Role: helpdesk()}, res))
+		for _, roleID := range []string{BundleUUIDRoleUser, res.Role.Id} {
+			err := g.AddRoleToUser(admin.ctx(), &proto.AddRoleToUserRequest{
+				AccountUuid: userAccountUUID,
+				RoleId:      roleID,
+			}, &proto.AddRoleToUserResponse{})
+			require.NoError(t, err)
+		}
+		assignments := &proto.ListRoleAssignmentsResponse{}
+		require.NoError(t, g.ListRoleAssignments(admin.ctx(), &proto.ListRoleAssignmentsRequest{AccountUuid: userAccountUUID}, assignments))
+		assert.Len(t, assignments.Assignments, 2)
+
+		err := g.AddRoleToUser(admin.ctx(), &proto.AddRoleToUserRequest{
+			AccountUuid: userAccountUUID,
+			RoleId:      profileBundleID,
+		}, &proto.AddRoleToUserResponse{})
+		assert.Equal(t, int32(404), code(err))
+
+		// the permissions of both roles apply: reading the value of another account is granted by the helpdesk role
+		roleIDs, _ := json.Marshal([]string{BundleUUIDRoleUser, res.Role.Id})
+		ctx := metadata.Set(context.Background(), middleware.RoleIDs, string(roleIDs))
+		ctx = metadata.Set(ctx, middleware.AccountID, userAccountUUID)
+		assert.NoError(t, g.GetValue(ctx, &proto.GetValueRequest{Id: adminLanguageValue}, &proto.GetValueResponse{}))
+		assert.NoError(t, g.GetValue(ctx, &proto.GetValueRequest{Id: userLanguageValue}, &proto.GetValueResponse{}))
+		err = g.GetValue(user.ctx(), &proto.GetValueRequest{Id: adminLanguageValue}, &proto.GetValueResponse{})
+		assert.Equal(t, int32(403), code(err))
+	})
+
+	t.Run("AssignRoleToUser replaces the roles", func(t *testing.T) {
+		g := newTestService(t)
+		assign := func(roleID string) {
+			err := g.AssignRoleToUser(admin.ctx(), &proto.AssignRoleToUserRequest{
+				AccountUuid: guestAccountUUID,
+				RoleId:      roleID,
+			}, &proto.AssignRoleToUserResponse{})
+			require.NoError(t, err)
+		}
+		// ctx builds the context like the proxy does, from the assigned roles
+		ctx := func() context.Context {
+			assignments := &proto.ListRoleAssignmentsResponse{}
+			require.NoError(t, g.ListRoleAssignments(admin.ctx(), &proto.ListRoleAssignmentsRequest{AccountUuid: guestAccountUUID}, assignments))
+			roleIDs := []string{}
+			for _, a := range assignments.Assignments {
+				roleIDs = append(roleIDs, a.RoleId)
+			}
+			encoded, _ := json.Marshal(roleIDs)
+			return metadata.Set(metadata.Set(context.Background(), middleware.RoleIDs, string(encoded)), middleware.AccountID, guestAccountUUID)
+		}
+
+		assign(BundleUUIDRoleAdmin)
+		assert.NoError(t, g.GetValue(ctx(), &proto.GetValueRequest{Id: userLanguageValue}, &proto.GetValueResponse{}))
+
+		// demoting the admin to a user removes the admin permissions
+		assign(BundleUUIDRoleUser)
+		assignments := &proto.ListRoleAssignmentsResponse{}
+		require.NoError(t, g.ListRoleAssignments(admin.ctx(), &proto.ListRoleAssignmentsRequest{AccountUuid: guestAccountUUID}, assignments))
+		if assert.Len(t, assignments.Assignments, 1) {
+			assert.Equal(t, BundleUUIDRoleUser, assignments.Assignments[0].RoleId)
+		}
+		err := g.GetValue(ctx(), &proto.GetValueRequest{Id: userLanguageValue}, &proto.GetValueResponse{})
+		assert.Equal(t, int32(403), code(err))
+		err = g.AssignRoleToUser(ctx(), &proto.AssignRoleToUserRequest{
+			AccountUuid: guestAccountUUID,
+			RoleId:      BundleUUIDRoleAdmin,
+		}, &proto.AssignRoleToUserResponse{})
+		assert.Equal(t, int32(403), code(err))
+	})
+}
+
+func TestPermissionServicePermissions(t *testing.T) {
+	t.Run("ListPermissionsByResource", func(t *testing.T) {
+		g := newTestService(t)
+		for r, constraint := range map[role]proto.Permission_Constraint{
+			admin: proto.Permission_CONSTRAINT_ALL,
+			user:  proto.Permission_CONSTRAINT_OWN,
+			guest: proto.Permission_CONSTRAINT_OWN,
+		} {
+			res := &proto.ListPermissionsByResourceResponse{}
+			err := g.ListPermissionsByResource(r.ctx(), &proto.ListPermissionsByResourceRequest{
+				Resource: &proto.Resource{Type: proto.Resource_TYPE_SETTING, Id: languageSettingID},
+			}, res)
+			require.NoError(t, err, r.name)
+			require.Len(t, res.Permissions, 1, r.name)
+			assert.Equal(t, constraint, res.Permissions[0].Constraint, r.name)
+		}
+	})
+
+	t.Run("GetPermissionByID", func(t *testing.T) {
+		g := newTestService(t)
+		for r, expected := range map[role]int32{admin: 0, user: 404, guest: 404} {
+			err := g.GetPermissionByID(r.ctx(), &proto.GetPermissionByIDRequest{PermissionId: RoleManagementPermissionID}, &proto.GetPermissionByIDResponse{})
+			assert.Equal(t, expected, code(err), r.name)
+		}
+	})
+}
+
+// snapshotStream collects the chunks of a snapshot.
+type snapshotStream struct {
+	proto.BackupService_SnapshotStream
+	chunks []*proto.SnapshotResponse
+}
+
+func (s *snapshotStream) Send(res *proto.SnapshotResponse) error {
+	s.chunks = append(s.chunks, res)
+	return nil
+}
+
+func TestBackupPermissions(t *testing.T) {
+	t.Run("Snapshot", func(t *testing.T) {
+		g := newTestService(t)
+		for r, expected := range map[role]int32{admin: 0, user: 403, guest: 403} {
+			stream := &snapshotStream{}
+			err := g.Snapshot(r.ctx(), &proto.SnapshotRequest{}, stream)
+			assert.Equal(t, expected, code(err), r.name)
+			if expected != 0 {
+				assert.Empty(t, stream.chunks, r.name)
+			}
+		}
+	})
+
+	t.Run("Restore", func(t *testing.T) {
+		g := newTestService(t)
+		for r, expected := range map[role]int32{admin: 0, user: 403, guest: 403} {
+			err := g.Restore(r.ctx(), &proto.RestoreRequest{Upload: r.name, Path: storeTypeFile, Data: []byte("filesystem")}, &proto.RestoreResponse{})
+			assert.Equal(t, expected, code(err), r.name)
+			if expected == 0 {
+				dir, err := g.uploads.Take(r.name)
+				require.NoError(t, err)
+				os.RemoveAll(dir)
+			}
+		}
+	})
+}
+
+func TestRequestsWithoutRoles(t *testing.T) {
+	g := newTestService(t)
+	forged, err := middleware.ContextWithServiceToken(context.Background(), "test", "another secret")
+	require.NoError(t, err)
+
+	requests := map[string]func(ctx context.Context) error{
+		"SaveBundle": func(ctx context.Context) error {
+			return g.SaveBundle(ctx, &proto.SaveBundleRequest{Bundle: &proto.Bundle{
+				Id:          "9f3a4c8e-2b1d-4e6f-8a7c-5d0e1f2a3b4c",
+				Name:        "test",
+				Extension:   "test",
+				DisplayName: "Test",
+				Type:        proto.Bundle_TYPE_DEFAULT,
+				Resource:    &proto.Resource{Type: proto.Resource_TYPE_SYSTEM},
+				Settings: []*proto.Setting{{
+					Name:     "test",
+					Resource: &proto.Resource{Type: proto.Resource_TYPE_USER},
+					Value:    &proto.Setting_StringValue{StringValue: &proto.String{}},
+				}},
+			}}, &proto.SaveBundleResponse{})
+		},
+		"AssignRoleToUser": func(ctx context.Context) error {
+			return g.AssignRoleToUser(ctx, &proto.AssignRoleToUserRequest{
+				AccountUuid: guestAccountUUID,
+				RoleId:      BundleUUIDRoleAdmin,
+			}, &proto.AssignRoleToUserResponse{})
+		},
+		"SaveValue": func(ctx context.Context) error {
+			return g.SaveValue(ctx, &proto.SaveValueRequest{Value: &proto.Value{
+				Id:          userLanguageValue,
+				BundleId:    profileBundleID,
+				SettingId:   languageSettingID,
+				AccountUuid: userAccountUUID,
+				Resource:    &proto.Resource{Type: proto.Resource_TYPE_USER},
+				Value:       &proto.Value_StringValue{StringValue: "en"},
+			}}, &proto.SaveValueResponse{})
+		},
+		"ListRoleAssignments": func(ctx context.Context) error {
+			return g.ListRoleAssignments(ctx, &proto.ListRoleAssignmentsRequest{AccountUuid: userAccountUUID}, &proto.ListRoleAssignmentsResponse{})
+		},
+	}
+
+	for name, request := range requests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, int32(403), code(request(context.Background())), "no metadata")
+			assert.Equal(t, int32(403), code(request(metadata.Set(context.Background(), middleware.AccountID, adminAccountUUID))), "no roles")
+			assert.Equal(t, int32(403), code(request(forged)), "service token signed with another secret")
+			assert.Equal(t, int32(0), code(request(serviceCtx(t))), "service token")
+		})
+	}
+}
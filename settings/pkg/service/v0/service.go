@@ -4,15 +4,21 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/gofrs/uuid"
 	"github.com/golang/protobuf/ptypes/empty"
 	merrors "github.com/micro/go-micro/v2/errors"
 	"github.com/micro/go-micro/v2/metadata"
+	accounts "github.com/owncloud/ocis/accounts/pkg/proto/v0"
+	"github.com/owncloud/ocis/ocis-pkg/backup"
 	"github.com/owncloud/ocis/ocis-pkg/log"
 	"github.com/owncloud/ocis/ocis-pkg/middleware"
 	"github.com/owncloud/ocis/ocis-pkg/roles"
+	"github.com/owncloud/ocis/ocis-pkg/service/grpc"
 	"github.com/owncloud/ocis/settings/pkg/config"
 	"github.com/owncloud/ocis/settings/pkg/proto/v0"
 	"github.com/owncloud/ocis/settings/pkg/settings"
+	// register the stores
+	_ "github.com/owncloud/ocis/settings/pkg/store"
 	store "github.com/owncloud/ocis/settings/pkg/store/filesystem"
 )
 
@@ -22,6 +28,8 @@ type Service struct {
 	config  *config.Config
 	logger  log.Logger
 	manager settings.Manager
+	uploads *backup.Uploads
+	groups  accounts.GroupsService
 }
 
 // NewService returns a service implementation for Service.
@@ -30,12 +38,25 @@ func NewService(cfg *config.Config, logger log.Logger) Service {
 		id:      "ocis-settings",
 		config:  cfg,
 		logger:  logger,
-		manager: store.New(cfg),
+		manager: newManager(cfg, logger),
+		uploads: backup.NewUploads("settings"),
+		groups:  accounts.NewGroupsService("com.owncloud.api.accounts", grpc.DefaultClient),
 	}
 	service.RegisterDefaultRoles()
 	return service
 }
 
+// newManager creates the store of the configured type, the filesystem store if no type is configured.
+func newManager(cfg *config.Config, logger log.Logger) settings.Manager {
+	if newStore, ok := settings.Registry[cfg.Service.StoreType]; ok {
+		return newStore(cfg)
+	}
+	if cfg.Service.StoreType != "" {
+		logger.Fatal().Str("type", cfg.Service.StoreType).Msg("unknown settings store type")
+	}
+	return store.New(cfg)
+}
+
 // RegisterDefaultRoles composes default roles and saves them. Skipped if the roles already exist.
 func (g Service) RegisterDefaultRoles() {
 	// FIXME: we're writing default roles per service start (i.e. twice at the moment, for http and grpc server). has to happen only once.
@@ -67,8 +88,6 @@ func (g Service) RegisterDefaultRoles() {
 	}
 }
 
-// TODO: check permissions on every request
-
 // SaveBundle implements the BundleServiceHandler interface
 func (g Service) SaveBundle(ctx context.Context, req *proto.SaveBundleRequest, res *proto.SaveBundleResponse) error {
 	cleanUpResource(ctx, req.Bundle.Resource)
@@ -203,10 +222,44 @@ func (g Service) RemoveSettingFromBundle(ctx context.Context, req *proto.RemoveS
 func (g Service) SaveValue(ctx context.Context, req *proto.SaveValueRequest, res *proto.SaveValueResponse) error {
 	req.Value.AccountUuid = getValidatedAccountUUID(ctx, req.Value.AccountUuid)
 	cleanUpResource(ctx, req.Value.Resource)
-	// TODO: we need to check, if the authenticated user has permission to write the value for the specified resource (e.g. global, file with id xy, ...)
 	if validationError := validateSaveValue(req); validationError != nil {
 		return merrors.BadRequest(g.id, "%s", validationError)
 	}
+	if req.Value.Resource.Type == proto.Resource_TYPE_SYSTEM || req.Value.Resource.Type == proto.Resource_TYPE_GROUP {
+		// system and group values are stored without account, check the permission the same way
+		req.Value.AccountUuid = ""
+		if req.Value.Id == "" {
+			req.Value.Id = g.findSharedValueID(req.Value)
+		}
+	}
+	if req.Value.Id != "" {
+		// the stored value decides whose value gets overwritten
+		if existing, err := g.manager.ReadValue(req.Value.Id); err == nil {
+			if err := g.checkValuePermission(ctx, existing, writeOperations); err != nil {
+				return err
+			}
+		}
+	}
+	if err := g.checkValuePermission(ctx, req.Value, writeOperations); err != nil {
+		return err
+	}
+	if req.Value.Resource.Type == proto.Resource_TYPE_GROUP {
+		if err := g.checkGroup(req.Value.Resource.Id); err != nil {
+			return err
+		}
+	}
+	setting, err := g.readSettingOfBundle(req.Value.BundleId, req.Value.SettingId)
+	if err != nil {
+		return err
+	}
+	if violations := validateValue(setting, req.Value); len(violations) > 0 {
+		return g.newValueError(setting.Id, violations)
+	}
+	if req.Value.AccountUuid != "" {
+		if err := g.checkValueLock(ctx, req.Value); err != nil {
+			return err
+		}
+	}
 	r, err := g.manager.WriteValue(req.Value)
 	if err != nil {
 		return merrors.BadRequest(g.id, "%s", err)
@@ -228,6 +281,9 @@ func (g Service) GetValue(ctx context.Context, req *proto.GetValueRequest, res *
 	if err != nil {
 		return merrors.NotFound(g.id, "%s", err)
 	}
+	if err := g.checkValuePermission(ctx, r, readOperations); err != nil {
+		return err
+	}
 	valueWithIdentifier, err := g.getValueWithIdentifier(r)
 	if err != nil {
 		return merrors.NotFound(g.id, "%s", err)
@@ -238,22 +294,27 @@ func (g Service) GetValue(ctx context.Context, req *proto.GetValueRequest, res *
 
 // GetValueByUniqueIdentifiers implements the ValueService interface
 func (g Service) GetValueByUniqueIdentifiers(ctx context.Context, req *proto.GetValueByUniqueIdentifiersRequest, res *proto.GetValueResponse) error {
+	req.AccountUuid = getValidatedAccountUUID(ctx, req.AccountUuid)
 	if validationError := validateGetValueByUniqueIdentifiers(req); validationError != nil {
 		return merrors.BadRequest(g.id, "%s", validationError)
 	}
-	v, err := g.manager.ReadValueByUniqueIdentifiers(req.AccountUuid, req.SettingId)
+	values, err := g.readScopedValues("", req.AccountUuid, g.getAccountGroupIDs(ctx, req.AccountUuid))
 	if err != nil {
 		return merrors.NotFound(g.id, "%s", err)
 	}
-
-	if v.BundleId != "" {
-		valueWithIdentifier, err := g.getValueWithIdentifier(v)
-		if err != nil {
-			return merrors.NotFound(g.id, "%s", err)
-		}
-
-		res.Value = valueWithIdentifier
+	// locked values of the system and of groups win over the value of the account, like in ListResolvedValues
+	v, _ := values.resolve(req.SettingId)
+	if v == nil {
+		return merrors.NotFound(g.id, "could not read value by settingID=%v and accountID=%v", req.SettingId, req.AccountUuid)
+	}
+	if err := g.checkValuePermission(ctx, v, readOperations); err != nil {
+		return err
 	}
+	valueWithIdentifier, err := g.getValueWithIdentifier(v)
+	if err != nil {
+		return merrors.NotFound(g.id, "%s", err)
+	}
+	res.Value = valueWithIdentifier
 	return nil
 }
 
@@ -269,6 +330,10 @@ func (g Service) ListValues(ctx context.Context, req *proto.ListValuesRequest, r
 	}
 	var result []*proto.ValueWithIdentifier
 	for _, value := range r {
+		// values the user is not allowed to read are left out
+		if g.checkValuePermission(ctx, value, readOperations) != nil {
+			continue
+		}
 		valueWithIdentifier, err := g.getValueWithIdentifier(value)
 		if err == nil {
 			result = append(result, valueWithIdentifier)
@@ -278,9 +343,69 @@ func (g Service) ListValues(ctx context.Context, req *proto.ListValuesRequest, r
 	return nil
 }
 
+// ListResolvedValues implements the ValueServiceHandler interface.
+// For every setting the value of the account is returned, or the value of a group of the account, or the system value,
+// or the default of the setting. Locked values of the system and of groups take precedence, see scopedValues.resolve.
+func (g Service) ListResolvedValues(ctx context.Context, req *proto.ListResolvedValuesRequest, res *proto.ListResolvedValuesResponse) error {
+	req.AccountUuid = getValidatedAccountUUID(ctx, req.AccountUuid)
+	if validationError := validateListResolvedValues(req); validationError != nil {
+		return merrors.BadRequest(g.id, "%s", validationError)
+	}
+	var bundleIDs []string
+	if req.BundleId != "" {
+		bundleIDs = append(bundleIDs, req.BundleId)
+	}
+	bundles, err := g.manager.ListBundles(proto.Bundle_TYPE_DEFAULT, bundleIDs)
+	if err != nil {
+		return merrors.NotFound(g.id, "%s", err)
+	}
+	values, err := g.readScopedValues(req.BundleId, req.AccountUuid, g.getAccountGroupIDs(ctx, req.AccountUuid))
+	if err != nil {
+		return merrors.NotFound(g.id, "%s", err)
+	}
+
+	for _, bundle := range bundles {
+		for _, setting := range bundle.Settings {
+			value, source := values.resolve(setting.Id)
+			if value == nil {
+				value, source = defaultValue(bundle, setting, req.AccountUuid), proto.ResolvedValue_SOURCE_DEFAULT
+			}
+			// values the user is not allowed to read are left out
+			if value == nil || g.checkValuePermission(ctx, value, readOperations) != nil {
+				continue
+			}
+			res.Values = append(res.Values, &proto.ResolvedValue{
+				Value: &proto.ValueWithIdentifier{
+					Identifier: &proto.Identifier{
+						Extension: bundle.Extension,
+						Bundle:    bundle.Name,
+						Setting:   setting.Name,
+					},
+					Value: value,
+				},
+				Source: source,
+			})
+		}
+	}
+	return nil
+}
+
+// readSettingOfBundle finds the setting with the given id in the bundle with the given id.
+func (g Service) readSettingOfBundle(bundleID, settingID string) (*proto.Setting, error) {
+	bundle, err := g.manager.ReadBundle(bundleID)
+	if err != nil {
+		return nil, merrors.NotFound(g.id, "%s", err)
+	}
+	for _, setting := range bundle.Settings {
+		if setting.Id == settingID {
+			return setting, nil
+		}
+	}
+	return nil, merrors.BadRequest(g.id, "setting %s is not part of bundle %s", settingID, bundleID)
+}
+
 // ListRoles implements the RoleServiceHandler interface
-func (g Service) ListRoles(c context.Context, req *proto.ListBundlesRequest, res *proto.ListBundlesResponse) error {
-	//accountUUID := getValidatedAccountUUID(c, "me")
+func (g Service) ListRoles(ctx context.Context, req *proto.ListBundlesRequest, res *proto.ListBundlesResponse) error {
 	if validationError := validateListRoles(req); validationError != nil {
 		return merrors.BadRequest(g.id, "%s", validationError)
 	}
@@ -288,17 +413,188 @@ func (g Service) ListRoles(c context.Context, req *proto.ListBundlesRequest, res
 	if err != nil {
 		return merrors.NotFound(g.id, "%s", err)
 	}
-	// TODO: only allow to list roles when user has account/role/... management permissions
-	res.Bundles = r
+	if g.hasStaticPermission(ctx, RoleManagementPermissionID) {
+		res.Bundles = r
+		return nil
+	}
+	// without role management permission users only see their own roles
+	ownRoleIDs := g.getRoleIDs(ctx)
+	for _, role := range r {
+		if containsRoleID(ownRoleIDs, role.Id) {
+			res.Bundles = append(res.Bundles, role)
+		}
+	}
+	return nil
+}
+
+// GetRole implements the RoleServiceHandler interface
+func (g Service) GetRole(ctx context.Context, req *proto.GetRoleRequest, res *proto.GetRoleResponse) error {
+	if validationError := validateGetRole(req); validationError != nil {
+		return merrors.BadRequest(g.id, "%s", validationError)
+	}
+	role, err := g.readRole(req.RoleId)
+	if err != nil {
+		return err
+	}
+	if !g.hasStaticPermission(ctx, RoleManagementPermissionID) && !containsRoleID(g.getRoleIDs(ctx), role.Id) {
+		return merrors.Forbidden(g.id, "user has no permission to read role %s", role.Id)
+	}
+	res.Role = role
 	return nil
 }
 
+// SaveRole implements the RoleServiceHandler interface
+func (g Service) SaveRole(ctx context.Context, req *proto.SaveRoleRequest, res *proto.SaveRoleResponse) error {
+	if err := g.checkStaticPermissionsByBundleType(ctx, proto.Bundle_TYPE_ROLE); err != nil {
+		return err
+	}
+	if req.Role != nil {
+		prepareRole(req.Role)
+	}
+	if validationError := validateSaveRole(req); validationError != nil {
+		return merrors.BadRequest(g.id, "%s", validationError)
+	}
+	if req.Role.Id != "" {
+		if existing, err := g.manager.ReadBundle(req.Role.Id); err == nil && existing.Type != proto.Bundle_TYPE_ROLE {
+			return merrors.BadRequest(g.id, "bundle %s is not a role", req.Role.Id)
+		}
+	}
+
+	r, err := g.saveRole(req.Role)
+	if err != nil {
+		return err
+	}
+	res.Role = r
+	return nil
+}
+
+// CloneRole implements the RoleServiceHandler interface
+func (g Service) CloneRole(ctx context.Context, req *proto.CloneRoleRequest, res *proto.SaveRoleResponse) error {
+	if err := g.checkStaticPermissionsByBundleType(ctx, proto.Bundle_TYPE_ROLE); err != nil {
+		return err
+	}
+	if validationError := validateCloneRole(req); validationError != nil {
+		return merrors.BadRequest(g.id, "%s", validationError)
+	}
+	source, err := g.readRole(req.RoleId)
+	if err != nil {
+		return err
+	}
+
+	// the permissions keep their ids, they identify the permission across roles
+	r, err := g.saveRole(&proto.Bundle{
+		Name:        req.Name,
+		Type:        proto.Bundle_TYPE_ROLE,
+		Extension:   source.Extension,
+		DisplayName: req.DisplayName,
+		Settings:    source.Settings,
+		Resource:    source.Resource,
+	})
+	if err != nil {
+		return err
+	}
+	res.Role = r
+	return nil
+}
+
+// DeleteRole implements the RoleServiceHandler interface
+func (g Service) DeleteRole(ctx context.Context, req *proto.DeleteRoleRequest, _ *empty.Empty) error {
+	if err := g.checkStaticPermissionsByBundleType(ctx, proto.Bundle_TYPE_ROLE); err != nil {
+		return err
+	}
+	if validationError := validateDeleteRole(req); validationError != nil {
+		return merrors.BadRequest(g.id, "%s", validationError)
+	}
+	if isBuiltInRole(req.RoleId) {
+		return merrors.Forbidden(g.id, "built-in role %s cannot be deleted", req.RoleId)
+	}
+	if _, err := g.readRole(req.RoleId); err != nil {
+		return err
+	}
+
+	// users must not keep an assignment to a role that doesn't exist anymore
+	assignments, err := g.manager.ListRoleAssignmentsByRole(req.RoleId)
+	if err != nil {
+		return merrors.BadRequest(g.id, "%s", err)
+	}
+	for _, assignment := range assignments {
+		if err := g.manager.RemoveRoleAssignment(assignment.Id); err != nil {
+			return merrors.BadRequest(g.id, "%s", err)
+		}
+	}
+	if err := g.manager.DeleteBundle(req.RoleId); err != nil {
+		return merrors.BadRequest(g.id, "%s", err)
+	}
+	return nil
+}
+
+// readRole reads the bundle with the given id and makes sure that it is a role.
+func (g Service) readRole(roleID string) (*proto.Bundle, error) {
+	role, err := g.manager.ReadBundle(roleID)
+	if err != nil {
+		return nil, merrors.NotFound(g.id, "%s", err)
+	}
+	if role.Type != proto.Bundle_TYPE_ROLE {
+		return nil, merrors.NotFound(g.id, "could not read role: %s", roleID)
+	}
+	return role, nil
+}
+
+// saveRole writes the role after making sure that its name is unique among the roles.
+func (g Service) saveRole(role *proto.Bundle) (*proto.Bundle, error) {
+	roles, err := g.manager.ListBundles(proto.Bundle_TYPE_ROLE, []string{})
+	if err != nil {
+		return nil, merrors.BadRequest(g.id, "%s", err)
+	}
+	for _, r := range roles {
+		if r.Id != role.Id && r.Extension == role.Extension && r.Name == role.Name {
+			return nil, merrors.BadRequest(g.id, "a role with the name %s already exists", role.Name)
+		}
+	}
+	for _, setting := range role.Settings {
+		if setting.Id == "" {
+			setting.Id = uuid.Must(uuid.NewV4()).String()
+		}
+	}
+	r, err := g.manager.WriteBundle(role)
+	if err != nil {
+		return nil, merrors.BadRequest(g.id, "%s", err)
+	}
+	return r, nil
+}
+
+// prepareRole fills in the fields of a role that are the same for all roles.
+func prepareRole(role *proto.Bundle) {
+	role.Type = proto.Bundle_TYPE_ROLE
+	if role.Extension == "" {
+		role.Extension = defaultRoleExtension
+	}
+	if role.Resource == nil {
+		role.Resource = &proto.Resource{
+			Type: proto.Resource_TYPE_SYSTEM,
+		}
+	}
+}
+
+// containsRoleID checks if the roleIDs contain the given roleID.
+func containsRoleID(roleIDs []string, roleID string) bool {
+	for _, id := range roleIDs {
+		if id == roleID {
+			return true
+		}
+	}
+	return false
+}
+
 // ListRoleAssignments implements the RoleServiceHandler interface
 func (g Service) ListRoleAssignments(ctx context.Context, req *proto.ListRoleAssignmentsRequest, res *proto.ListRoleAssignmentsResponse) error {
 	req.AccountUuid = getValidatedAccountUUID(ctx, req.AccountUuid)
 	if validationError := validateListRoleAssignments(req); validationError != nil {
 		return merrors.BadRequest(g.id, "%s", validationError)
 	}
+	if !g.isOwnAccount(ctx, req.AccountUuid) && !g.hasStaticPermission(ctx, RoleManagementPermissionID) {
+		return merrors.Forbidden(g.id, "user has no permission to list role assignments of other accounts")
+	}
 	r, err := g.manager.ListRoleAssignments(req.AccountUuid)
 	if err != nil {
 		return merrors.NotFound(g.id, "%s", err)
@@ -317,6 +613,9 @@ func (g Service) AssignRoleToUser(ctx context.Context, req *proto.AssignRoleToUs
 	if validationError := validateAssignRoleToUser(req); validationError != nil {
 		return merrors.BadRequest(g.id, "%s", validationError)
 	}
+	if _, err := g.readRole(req.RoleId); err != nil {
+		return err
+	}
 	r, err := g.manager.WriteRoleAssignment(req.AccountUuid, req.RoleId)
 	if err != nil {
 		return merrors.BadRequest(g.id, "%s", err)
@@ -325,6 +624,27 @@ func (g Service) AssignRoleToUser(ctx context.Context, req *proto.AssignRoleToUs
 	return nil
 }
 
+// AddRoleToUser implements the RoleServiceHandler interface
+func (g Service) AddRoleToUser(ctx context.Context, req *proto.AddRoleToUserRequest, res *proto.AddRoleToUserResponse) error {
+	if err := g.checkStaticPermissionsByBundleType(ctx, proto.Bundle_TYPE_ROLE); err != nil {
+		return err
+	}
+
+	req.AccountUuid = getValidatedAccountUUID(ctx, req.AccountUuid)
+	if validationError := validateAddRoleToUser(req); validationError != nil {
+		return merrors.BadRequest(g.id, "%s", validationError)
+	}
+	if _, err := g.readRole(req.RoleId); err != nil {
+		return err
+	}
+	r, err := g.manager.AddRoleAssignment(req.AccountUuid, req.RoleId)
+	if err != nil {
+		return merrors.BadRequest(g.id, "%s", err)
+	}
+	res.Assignment = r
+	return nil
+}
+
 // RemoveRoleFromUser implements the RoleServiceHandler interface
 func (g Service) RemoveRoleFromUser(ctx context.Context, req *proto.RemoveRoleFromUserRequest, _ *empty.Empty) error {
 	if err := g.checkStaticPermissionsByBundleType(ctx, proto.Bundle_TYPE_ROLE); err != nil {
@@ -340,7 +660,8 @@ func (g Service) RemoveRoleFromUser(ctx context.Context, req *proto.RemoveRoleFr
 	return nil
 }
 
-// ListPermissionsByResource implements the PermissionServiceHandler interface
+// ListPermissionsByResource implements the PermissionServiceHandler interface.
+// Only the permissions granted by the roles of the authenticated user are looked up.
 func (g Service) ListPermissionsByResource(ctx context.Context, req *proto.ListPermissionsByResourceRequest, res *proto.ListPermissionsByResourceResponse) error {
 	if validationError := validateListPermissionsByResource(req); validationError != nil {
 		return merrors.BadRequest(g.id, "%s", validationError)
@@ -353,7 +674,8 @@ func (g Service) ListPermissionsByResource(ctx context.Context, req *proto.ListP
 	return nil
 }
 
-// GetPermissionByID implements the PermissionServiceHandler interface
+// GetPermissionByID implements the PermissionServiceHandler interface.
+// Only the permissions granted by the roles of the authenticated user are looked up.
 func (g Service) GetPermissionByID(ctx context.Context, req *proto.GetPermissionByIDRequest, res *proto.GetPermissionByIDResponse) error {
 	if validationError := validateGetPermissionByID(req); validationError != nil {
 		return merrors.BadRequest(g.id, "%s", validationError)
@@ -418,24 +740,26 @@ func (g Service) getValueWithIdentifier(value *proto.Value) (*proto.ValueWithIde
 	}, nil
 }
 
+// hasStaticPermission checks if the request may use the permission. Internal requests of other services have every
+// permission, requests without roles none.
 func (g Service) hasStaticPermission(ctx context.Context, permissionID string) bool {
+	if g.isServiceRequest(ctx) {
+		return true
+	}
 	roleIDs, ok := roles.ReadRoleIDsFromContext(ctx)
 	if !ok {
-		/**
-		* FIXME: with this we are skipping permission checks on all requests that are coming in without roleIDs in the
-		* metadata context. This is a huge security impairment, as that's the case not only for grpc requests but also
-		* for unauthenticated http requests and http requests coming in without hitting the ocis-proxy first.
-		 */
-		// TODO add system role for internal requests.
-		// - at least the proxy needs to look up account info
-		// - glauth needs to make bind requests
-		// tracked as OCIS-454
-		return true
+		return false
 	}
 	p, err := g.manager.ReadPermissionByID(permissionID, roleIDs)
 	return err == nil && p != nil
 }
 
+// isServiceRequest checks if the request is an internal request of another service, made with a service token.
+func (g Service) isServiceRequest(ctx context.Context) bool {
+	_, ok := middleware.ServiceFromContext(ctx, g.config.TokenManager.JWTSecret)
+	return ok
+}
+
 func (g Service) checkStaticPermissionsByBundleID(ctx context.Context, bundleID string) error {
 	bundle, err := g.manager.ReadBundle(bundleID)
 	if err != nil {
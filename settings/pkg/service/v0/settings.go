@@ -21,6 +21,9 @@ const (
 	SettingsManagementPermissionID string = "79e13b30-3e22-11eb-bc51-0b9f0bad9a58"
 	// SettingsManagementPermissionName is the hardcoded setting name for the settings management permission
 	SettingsManagementPermissionName string = "settings-management"
+
+	// defaultRoleExtension is the extension of the default roles and of roles saved without an extension
+	defaultRoleExtension = "ocis-roles"
 )
 
 // generateBundlesDefaultRoles bootstraps the default roles.
@@ -32,12 +35,22 @@ func generateBundlesDefaultRoles() []*settings.Bundle {
 	}
 }
 
+// isBuiltInRole checks if the roleID belongs to one of the default roles, they must not be deleted.
+func isBuiltInRole(roleID string) bool {
+	for _, role := range generateBundlesDefaultRoles() {
+		if role.Id == roleID {
+			return true
+		}
+	}
+	return false
+}
+
 func generateBundleAdminRole() *settings.Bundle {
 	return &settings.Bundle{
 		Id:          BundleUUIDRoleAdmin,
 		Name:        "admin",
 		Type:        settings.Bundle_TYPE_ROLE,
-		Extension:   "ocis-roles",
+		Extension:   defaultRoleExtension,
 		DisplayName: "Admin",
 		Resource: &settings.Resource{
 			Type: settings.Resource_TYPE_SYSTEM,
@@ -51,7 +64,7 @@ func generateBundleUserRole() *settings.Bundle {
 		Id:          BundleUUIDRoleUser,
 		Name:        "user",
 		Type:        settings.Bundle_TYPE_ROLE,
-		Extension:   "ocis-roles",
+		Extension:   defaultRoleExtension,
 		DisplayName: "User",
 		Resource: &settings.Resource{
 			Type: settings.Resource_TYPE_SYSTEM,
@@ -65,7 +78,7 @@ func generateBundleGuestRole() *settings.Bundle {
 		Id:          BundleUUIDRoleGuest,
 		Name:        "guest",
 		Type:        settings.Bundle_TYPE_ROLE,
-		Extension:   "ocis-roles",
+		Extension:   defaultRoleExtension,
 		DisplayName: "Guest",
 		Resource: &settings.Resource{
 			Type: settings.Resource_TYPE_SYSTEM,
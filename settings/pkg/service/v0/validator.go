@@ -1,6 +1,7 @@
 package svc
 
 import (
+	"fmt"
 	"regexp"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -38,7 +39,11 @@ func validateSaveBundle(req *proto.SaveBundleRequest) error {
 		return err
 	}
 	for i := range req.Bundle.Settings {
-		if err := validateSetting(req.Bundle.Settings[i]); err != nil {
+		validate := validateSetting
+		if req.Bundle.Type == proto.Bundle_TYPE_ROLE {
+			validate = validatePermissionSetting
+		}
+		if err := validate(req.Bundle.Settings[i]); err != nil {
 			return err
 		}
 	}
@@ -83,7 +88,22 @@ func validateSaveValue(req *proto.SaveValueRequest) error {
 		return err
 	}
 
-	// TODO: validate values against the respective setting. need to check if constraints of the setting are fulfilled.
+	switch req.Value.Resource.Type {
+	case proto.Resource_TYPE_GROUP:
+		if err := validation.ValidateStruct(
+			req.Value.Resource,
+			validation.Field(&req.Value.Resource.Id, validation.Required),
+		); err != nil {
+			return err
+		}
+	case proto.Resource_TYPE_SYSTEM:
+	default:
+		if req.Value.Locked {
+			return fmt.Errorf("only values of the system or of a group can be locked")
+		}
+	}
+
+	// the value is validated against the constraints of its setting when saving it
 	return nil
 }
 
@@ -107,10 +127,59 @@ func validateListValues(req *proto.ListValuesRequest) error {
 	)
 }
 
+func validateListResolvedValues(req *proto.ListResolvedValuesRequest) error {
+	return validation.ValidateStruct(
+		req,
+		validation.Field(&req.BundleId, validation.When(req.BundleId != "", is.UUID)),
+		validation.Field(&req.AccountUuid, requireAccountID...),
+	)
+}
+
 func validateListRoles(req *proto.ListBundlesRequest) error {
 	return nil
 }
 
+func validateGetRole(req *proto.GetRoleRequest) error {
+	return validation.Validate(req.RoleId, validation.Required, is.UUID)
+}
+
+func validateSaveRole(req *proto.SaveRoleRequest) error {
+	if err := validation.Validate(req.Role, validation.Required); err != nil {
+		return err
+	}
+	if err := validation.ValidateStruct(
+		req.Role,
+		validation.Field(&req.Role.Id, validation.When(req.Role.Id != "", is.UUID)),
+		validation.Field(&req.Role.Name, requireAlphanumeric...),
+		validation.Field(&req.Role.Extension, requireAlphanumeric...),
+		validation.Field(&req.Role.DisplayName, validation.Required),
+	); err != nil {
+		return err
+	}
+	if err := validateResource(req.Role.Resource); err != nil {
+		return err
+	}
+	for i := range req.Role.Settings {
+		if err := validatePermissionSetting(req.Role.Settings[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateCloneRole(req *proto.CloneRoleRequest) error {
+	return validation.ValidateStruct(
+		req,
+		validation.Field(&req.RoleId, validation.Required, is.UUID),
+		validation.Field(&req.Name, requireAlphanumeric...),
+		validation.Field(&req.DisplayName, validation.Required),
+	)
+}
+
+func validateDeleteRole(req *proto.DeleteRoleRequest) error {
+	return validation.Validate(req.RoleId, validation.Required, is.UUID)
+}
+
 func validateListRoleAssignments(req *proto.ListRoleAssignmentsRequest) error {
 	return validation.Validate(req.AccountUuid, requireAccountID...)
 }
@@ -123,6 +192,14 @@ func validateAssignRoleToUser(req *proto.AssignRoleToUserRequest) error {
 	)
 }
 
+func validateAddRoleToUser(req *proto.AddRoleToUserRequest) error {
+	return validation.ValidateStruct(
+		req,
+		validation.Field(&req.AccountUuid, requireAccountID...),
+		validation.Field(&req.RoleId, is.UUID),
+	)
+}
+
 func validateRemoveRoleFromUser(req *proto.RemoveRoleFromUserRequest) error {
 	return validation.ValidateStruct(
 		req,
@@ -161,3 +238,20 @@ func validateSetting(setting *proto.Setting) error {
 	}
 	return validateResource(setting.Resource)
 }
+
+// validatePermissionSetting is an internal helper for validating the settings of a role, which need to be permissions.
+func validatePermissionSetting(setting *proto.Setting) error {
+	if err := validateSetting(setting); err != nil {
+		return err
+	}
+	value, ok := setting.Value.(*proto.Setting_PermissionValue)
+	if !ok || value.PermissionValue == nil {
+		return fmt.Errorf("setting %s of a role must be a permission", setting.Name)
+	}
+	permission := value.PermissionValue
+	return validation.ValidateStruct(
+		permission,
+		validation.Field(&permission.Operation, validation.Required),
+		validation.Field(&permission.Constraint, validation.Required),
+	)
+}
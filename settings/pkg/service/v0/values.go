@@ -0,0 +1,379 @@
+package svc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/jsonpb"
+	merrors "github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/metadata"
+	accounts "github.com/owncloud/ocis/accounts/pkg/proto/v0"
+	"github.com/owncloud/ocis/ocis-pkg/middleware"
+	"github.com/owncloud/ocis/settings/pkg/proto/v0"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// validateValue checks the value against the constraints of its setting and returns all violations.
+func validateValue(setting *proto.Setting, value *proto.Value) []*proto.ValueViolation {
+	switch s := setting.Value.(type) {
+	case *proto.Setting_IntValue:
+		v, ok := value.Value.(*proto.Value_IntValue)
+		if !ok {
+			return []*proto.ValueViolation{typeViolation("an int value")}
+		}
+		return validateInt(s.IntValue, v.IntValue)
+	case *proto.Setting_StringValue:
+		v, ok := value.Value.(*proto.Value_StringValue)
+		if !ok {
+			return []*proto.ValueViolation{typeViolation("a string value")}
+		}
+		return validateString(s.StringValue, v.StringValue)
+	case *proto.Setting_BoolValue:
+		if _, ok := value.Value.(*proto.Value_BoolValue); !ok {
+			return []*proto.ValueViolation{typeViolation("a bool value")}
+		}
+		return nil
+	case *proto.Setting_SingleChoiceValue:
+		v, ok := value.Value.(*proto.Value_ListValue)
+		if !ok {
+			return []*proto.ValueViolation{typeViolation("a list value")}
+		}
+		violations := validateOptions(s.SingleChoiceValue.Options, v.ListValue.GetValues())
+		if len(v.ListValue.GetValues()) > 1 {
+			violations = append(violations, &proto.ValueViolation{
+				Rule:    proto.ValueViolation_RULE_OPTION,
+				Message: "only one option can be selected",
+			})
+		}
+		return violations
+	case *proto.Setting_MultiChoiceValue:
+		v, ok := value.Value.(*proto.Value_ListValue)
+		if !ok {
+			return []*proto.ValueViolation{typeViolation("a list value")}
+		}
+		return validateOptions(s.MultiChoiceValue.Options, v.ListValue.GetValues())
+	default:
+		return []*proto.ValueViolation{{
+			Rule:    proto.ValueViolation_RULE_TYPE,
+			Message: fmt.Sprintf("setting %s doesn't accept values", setting.Name),
+		}}
+	}
+}
+
+func typeViolation(expected string) *proto.ValueViolation {
+	return &proto.ValueViolation{
+		Rule:    proto.ValueViolation_RULE_TYPE,
+		Message: "the setting requires " + expected,
+	}
+}
+
+// validateInt checks the bounds and the step of an int value. Bounds of 0 are treated as not set, unless the other
+// bound is set. The step counts from the min bound.
+func validateInt(setting *proto.Int, value int64) []*proto.ValueViolation {
+	var violations []*proto.ValueViolation
+	if (setting.Min != 0 || setting.Max != 0) && value < setting.Min {
+		violations = append(violations, &proto.ValueViolation{
+			Rule:    proto.ValueViolation_RULE_MIN,
+			Message: fmt.Sprintf("the value must be at least %d", setting.Min),
+		})
+	}
+	if setting.Max != 0 && value > setting.Max {
+		violations = append(violations, &proto.ValueViolation{
+			Rule:    proto.ValueViolation_RULE_MAX,
+			Message: fmt.Sprintf("the value must be at most %d", setting.Max),
+		})
+	}
+	if setting.Step > 0 && (value-setting.Min)%setting.Step != 0 {
+		violations = append(violations, &proto.ValueViolation{
+			Rule:    proto.ValueViolation_RULE_STEP,
+			Message: fmt.Sprintf("the value must be %d plus a multiple of %d", setting.Min, setting.Step),
+		})
+	}
+	return violations
+}
+
+// validateString checks a string value. The length constraints don't apply to an empty value of an optional setting.
+func validateString(setting *proto.String, value string) []*proto.ValueViolation {
+	if strings.TrimSpace(value) == "" {
+		if setting.Required {
+			return []*proto.ValueViolation{{
+				Rule:    proto.ValueViolation_RULE_REQUIRED,
+				Message: "the value must not be empty",
+			}}
+		}
+		if value == "" {
+			return nil
+		}
+	}
+	var violations []*proto.ValueViolation
+	length := int32(utf8.RuneCountInString(value))
+	if setting.MinLength > 0 && length < setting.MinLength {
+		violations = append(violations, &proto.ValueViolation{
+			Rule:    proto.ValueViolation_RULE_MIN_LENGTH,
+			Message: fmt.Sprintf("the value must have at least %d characters", setting.MinLength),
+		})
+	}
+	if setting.MaxLength > 0 && length > setting.MaxLength {
+		violations = append(violations, &proto.ValueViolation{
+			Rule:    proto.ValueViolation_RULE_MAX_LENGTH,
+			Message: fmt.Sprintf("the value must have at most %d characters", setting.MaxLength),
+		})
+	}
+	return violations
+}
+
+// validateOptions checks that every selected value is one of the options and selected only once.
+func validateOptions(options []*proto.ListOption, values []*proto.ListOptionValue) []*proto.ValueViolation {
+	var violations []*proto.ValueViolation
+	for i, value := range values {
+		if !isOption(options, value) {
+			violations = append(violations, &proto.ValueViolation{
+				Rule:    proto.ValueViolation_RULE_OPTION,
+				Message: fmt.Sprintf("%s is not one of the options", optionString(value)),
+			})
+		}
+		for _, other := range values[:i] {
+			if protobuf.Equal(value, other) {
+				violations = append(violations, &proto.ValueViolation{
+					Rule:    proto.ValueViolation_RULE_OPTION,
+					Message: fmt.Sprintf("%s is selected more than once", optionString(value)),
+				})
+				break
+			}
+		}
+	}
+	return violations
+}
+
+func isOption(options []*proto.ListOption, value *proto.ListOptionValue) bool {
+	for _, option := range options {
+		if protobuf.Equal(option.Value, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func optionString(value *proto.ListOptionValue) string {
+	switch v := value.GetOption().(type) {
+	case *proto.ListOptionValue_IntValue:
+		return fmt.Sprintf("%d", v.IntValue)
+	case *proto.ListOptionValue_StringValue:
+		return fmt.Sprintf("%q", v.StringValue)
+	}
+	return "an empty option"
+}
+
+// defaultValue builds the value of the account from the default of the setting.
+// It returns nil for settings that have no values, like permissions.
+func defaultValue(bundle *proto.Bundle, setting *proto.Setting, accountUUID string) *proto.Value {
+	value := &proto.Value{
+		BundleId:    bundle.Id,
+		SettingId:   setting.Id,
+		AccountUuid: accountUUID,
+		Resource:    setting.Resource,
+	}
+	switch s := setting.Value.(type) {
+	case *proto.Setting_IntValue:
+		value.Value = &proto.Value_IntValue{IntValue: s.IntValue.Default}
+	case *proto.Setting_StringValue:
+		value.Value = &proto.Value_StringValue{StringValue: s.StringValue.Default}
+	case *proto.Setting_BoolValue:
+		value.Value = &proto.Value_BoolValue{BoolValue: s.BoolValue.Default}
+	case *proto.Setting_SingleChoiceValue:
+		values := defaultOptions(s.SingleChoiceValue.Options)
+		if len(values) > 1 {
+			values = values[:1]
+		}
+		value.Value = &proto.Value_ListValue{ListValue: &proto.ListValue{Values: values}}
+	case *proto.Setting_MultiChoiceValue:
+		value.Value = &proto.Value_ListValue{ListValue: &proto.ListValue{Values: defaultOptions(s.MultiChoiceValue.Options)}}
+	default:
+		return nil
+	}
+	return value
+}
+
+func defaultOptions(options []*proto.ListOption) []*proto.ListOptionValue {
+	var values []*proto.ListOptionValue
+	for _, option := range options {
+		if option.Default {
+			values = append(values, option.Value)
+		}
+	}
+	return values
+}
+
+// scopedValues holds the saved values of an account, of its groups and of the system by setting id.
+type scopedValues struct {
+	account map[string]*proto.Value
+	group   map[string]*proto.Value
+	system  map[string]*proto.Value
+}
+
+// readScopedValues reads the values of the account, its groups and the system. If several groups have a value for
+// the same setting, a locked one wins, otherwise the one of the group that comes first.
+func (g Service) readScopedValues(bundleID, accountUUID string, groupIDs []string) (*scopedValues, error) {
+	values := &scopedValues{
+		account: map[string]*proto.Value{},
+		group:   map[string]*proto.Value{},
+		system:  map[string]*proto.Value{},
+	}
+	accountValues, err := g.manager.ListValues(bundleID, accountUUID)
+	if err != nil {
+		return nil, err
+	}
+	for _, value := range accountValues {
+		if value.AccountUuid == "" {
+			values.system[value.SettingId] = value
+		} else {
+			values.account[value.SettingId] = value
+		}
+	}
+	groupValues, err := g.manager.ListGroupValues(bundleID, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	groupOrder := make(map[string]int, len(groupIDs))
+	for i := len(groupIDs) - 1; i >= 0; i-- {
+		groupOrder[groupIDs[i]] = i
+	}
+	for _, value := range groupValues {
+		existing := values.group[value.SettingId]
+		switch {
+		case existing == nil,
+			value.Locked && !existing.Locked,
+			value.Locked == existing.Locked && groupOrder[value.Resource.Id] < groupOrder[existing.Resource.Id]:
+			values.group[value.SettingId] = value
+		}
+	}
+	return values, nil
+}
+
+// resolve returns the value that is in effect for the setting and where it comes from, nil if no value was saved.
+// The value of the account wins over the one of its groups, which wins over the one of the system. A locked value
+// of the system wins over all others, a locked value of a group over the one of the account.
+func (v *scopedValues) resolve(settingID string) (*proto.Value, proto.ResolvedValue_Source) {
+	account, group, system := v.account[settingID], v.group[settingID], v.system[settingID]
+	switch {
+	case system.GetLocked():
+		return system, proto.ResolvedValue_SOURCE_SYSTEM
+	case group.GetLocked():
+		return group, proto.ResolvedValue_SOURCE_GROUP
+	case account != nil:
+		return account, proto.ResolvedValue_SOURCE_ACCOUNT
+	case group != nil:
+		return group, proto.ResolvedValue_SOURCE_GROUP
+	case system != nil:
+		return system, proto.ResolvedValue_SOURCE_SYSTEM
+	}
+	return nil, proto.ResolvedValue_SOURCE_UNKNOWN
+}
+
+// findSharedValueID returns the id of the saved value of the system or of the group for the same setting, so that
+// there is only one of them. It returns an empty id if there is none.
+func (g Service) findSharedValueID(value *proto.Value) string {
+	var values []*proto.Value
+	if value.Resource.Type == proto.Resource_TYPE_GROUP {
+		values, _ = g.manager.ListGroupValues(value.BundleId, []string{value.Resource.Id})
+	} else {
+		values, _ = g.manager.ListValues(value.BundleId, "")
+	}
+	for _, v := range values {
+		if v.SettingId == value.SettingId {
+			return v.Id
+		}
+	}
+	return ""
+}
+
+// checkValueLock returns a forbidden error if a locked value of the system or of a group overrides the value of the
+// account.
+func (g Service) checkValueLock(ctx context.Context, value *proto.Value) error {
+	values, err := g.readScopedValues(value.BundleId, value.AccountUuid, g.getAccountGroupIDs(ctx, value.AccountUuid))
+	if err != nil {
+		return merrors.NotFound(g.id, "%s", err)
+	}
+	if locked, source := values.resolve(value.SettingId); locked.GetLocked() {
+		return merrors.Forbidden(g.id, "setting %s is locked by the value of the %s", value.SettingId, lockOwner(source))
+	}
+	return nil
+}
+
+func lockOwner(source proto.ResolvedValue_Source) string {
+	if source == proto.ResolvedValue_SOURCE_GROUP {
+		return "group"
+	}
+	return "system"
+}
+
+// checkGroup checks that the group of a group value exists in the accounts service. Values apply to the groups of
+// an account by their ids, a value saved for the name of a group would never apply.
+func (g Service) checkGroup(groupID string) error {
+	ctx, err := middleware.ContextWithServiceToken(context.Background(), "settings", g.config.TokenManager.JWTSecret)
+	if err != nil {
+		return merrors.InternalServerError(g.id, "%s", err)
+	}
+	if _, err := g.groups.GetGroup(ctx, &accounts.GetGroupRequest{Id: groupID}); err != nil {
+		if merrors.Parse(err.Error()).Code == http.StatusNotFound {
+			return merrors.BadRequest(g.id, "group %s does not exist", groupID)
+		}
+		return merrors.InternalServerError(g.id, "could not look up group %s: %s", groupID, err)
+	}
+	return nil
+}
+
+// getAccountGroupIDs returns the groups of the account. The settings service only knows the groups of the
+// authenticated user, so only the values of the system apply to other accounts.
+func (g Service) getAccountGroupIDs(ctx context.Context, accountUUID string) []string {
+	if !g.isOwnAccount(ctx, accountUUID) {
+		return []string{}
+	}
+	groupIDsJSON, ok := metadata.Get(ctx, middleware.GroupIDs)
+	if !ok {
+		return []string{}
+	}
+	var groupIDs []string
+	if err := json.Unmarshal([]byte(groupIDsJSON), &groupIDs); err != nil {
+		return []string{}
+	}
+	return groupIDs
+}
+
+// newValueError creates a bad request error with the json encoded proto.ValueError as detail.
+func (g Service) newValueError(settingID string, violations []*proto.ValueViolation) error {
+	detail, err := (&jsonpb.Marshaler{}).MarshalToString(&proto.ValueError{
+		SettingId:  settingID,
+		Violations: violations,
+	})
+	if err != nil {
+		return merrors.BadRequest(g.id, "%s", err)
+	}
+	return &merrors.Error{
+		Id:     g.id,
+		Code:   http.StatusBadRequest,
+		Detail: detail,
+		Status: http.StatusText(http.StatusBadRequest),
+	}
+}
+
+// ParseValueError extracts the violations from an error returned for an invalid value.
+// It returns nil if the error is about something else.
+func ParseValueError(err error) *proto.ValueError {
+	if err == nil {
+		return nil
+	}
+	merr := merrors.Parse(err.Error())
+	if merr.Code != http.StatusBadRequest {
+		return nil
+	}
+	valueError := &proto.ValueError{}
+	if err := jsonpb.UnmarshalString(merr.Detail, valueError); err != nil || len(valueError.Violations) == 0 {
+		return nil
+	}
+	return valueError
+}
@@ -0,0 +1,365 @@
+package svc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/owncloud/ocis/ocis-pkg/middleware"
+	"github.com/owncloud/ocis/settings/pkg/proto/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	intSetting = &proto.Setting{
+		Name:  "int",
+		Value: &proto.Setting_IntValue{IntValue: &proto.Int{Default: 10, Min: 10, Max: 100, Step: 5}},
+	}
+	stringSetting = &proto.Setting{
+		Name:  "string",
+		Value: &proto.Setting_StringValue{StringValue: &proto.String{Default: "abc", MinLength: 2, MaxLength: 4}},
+	}
+	requiredStringSetting = &proto.Setting{
+		Name:  "required",
+		Value: &proto.Setting_StringValue{StringValue: &proto.String{Required: true}},
+	}
+	singleChoiceSetting = &proto.Setting{
+		Name: "single",
+		Value: &proto.Setting_SingleChoiceValue{SingleChoiceValue: &proto.SingleChoiceList{Options: []*proto.ListOption{
+			{Value: stringOption("de")},
+			{Value: stringOption("en"), Default: true},
+		}}},
+	}
+	multiChoiceSetting = &proto.Setting{
+		Name: "multi",
+		Value: &proto.Setting_MultiChoiceValue{MultiChoiceValue: &proto.MultiChoiceList{Options: []*proto.ListOption{
+			{Value: intOption(1), Default: true},
+			{Value: intOption(2)},
+			{Value: intOption(3), Default: true},
+		}}},
+	}
+	permissionSetting = &proto.Setting{
+		Name:  "permission",
+		Value: &proto.Setting_PermissionValue{PermissionValue: &proto.Permission{}},
+	}
+)
+
+func stringOption(v string) *proto.ListOptionValue {
+	return &proto.ListOptionValue{Option: &proto.ListOptionValue_StringValue{StringValue: v}}
+}
+
+func intOption(v int64) *proto.ListOptionValue {
+	return &proto.ListOptionValue{Option: &proto.ListOptionValue_IntValue{IntValue: v}}
+}
+
+func listValue(values ...*proto.ListOptionValue) *proto.Value {
+	return &proto.Value{Value: &proto.Value_ListValue{ListValue: &proto.ListValue{Values: values}}}
+}
+
+func TestValidateValue(t *testing.T) {
+	var scenarios = []struct {
+		name       string
+		setting    *proto.Setting
+		value      *proto.Value
+		violations []proto.ValueViolation_Rule
+	}{
+		{"int", intSetting, &proto.Value{Value: &proto.Value_IntValue{IntValue: 55}}, nil},
+		{"int below min", intSetting, &proto.Value{Value: &proto.Value_IntValue{IntValue: 5}}, []proto.ValueViolation_Rule{proto.ValueViolation_RULE_MIN}},
+		{"int above max", intSetting, &proto.Value{Value: &proto.Value_IntValue{IntValue: 105}}, []proto.ValueViolation_Rule{proto.ValueViolation_RULE_MAX}},
+		{"int off step", intSetting, &proto.Value{Value: &proto.Value_IntValue{IntValue: 12}}, []proto.ValueViolation_Rule{proto.ValueViolation_RULE_STEP}},
+		{"int as string", intSetting, &proto.Value{Value: &proto.Value_StringValue{StringValue: "12"}}, []proto.ValueViolation_Rule{proto.ValueViolation_RULE_TYPE}},
+		{"string", stringSetting, &proto.Value{Value: &proto.Value_StringValue{StringValue: "äöü"}}, nil},
+		{"empty optional string", stringSetting, &proto.Value{Value: &proto.Value_StringValue{StringValue: ""}}, nil},
+		{"string too short", stringSetting, &proto.Value{Value: &proto.Value_StringValue{StringValue: "a"}}, []proto.ValueViolation_Rule{proto.ValueViolation_RULE_MIN_LENGTH}},
+		{"string too long", stringSetting, &proto.Value{Value: &proto.Value_StringValue{StringValue: "abcde"}}, []proto.ValueViolation_Rule{proto.ValueViolation_RULE_MAX_LENGTH}},
+		{"required string", requiredStringSetting, &proto.Value{Value: &proto.Value_StringValue{StringValue: " "}}, []proto.ValueViolation_Rule{proto.ValueViolation_RULE_REQUIRED}},
+		{"single choice", singleChoiceSetting, listValue(stringOption("de")), nil},
+		{"single choice without selection", singleChoiceSetting, listValue(), nil},
+		{"single choice unknown option", singleChoiceSetting, listValue(stringOption("fr")), []proto.ValueViolation_Rule{proto.ValueViolation_RULE_OPTION}},
+		{"single choice two options", singleChoiceSetting, listValue(stringOption("de"), stringOption("en")), []proto.ValueViolation_Rule{proto.ValueViolation_RULE_OPTION}},
+		{"multi choice", multiChoiceSetting, listValue(intOption(1), intOption(2)), nil},
+		{"multi choice with duplicate", multiChoiceSetting, listValue(intOption(1), intOption(1)), []proto.ValueViolation_Rule{proto.ValueViolation_RULE_OPTION}},
+		{"multi choice wrong option type", multiChoiceSetting, listValue(stringOption("1")), []proto.ValueViolation_Rule{proto.ValueViolation_RULE_OPTION}},
+		{"permission", permissionSetting, &proto.Value{Value: &proto.Value_BoolValue{BoolValue: true}}, []proto.ValueViolation_Rule{proto.ValueViolation_RULE_TYPE}},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(t *testing.T) {
+			var rules []proto.ValueViolation_Rule
+			for _, violation := range validateValue(scenario.setting, scenario.value) {
+				rules = append(rules, violation.Rule)
+			}
+			assert.Equal(t, scenario.violations, rules)
+		})
+	}
+}
+
+func TestDefaultValue(t *testing.T) {
+	bundle := &proto.Bundle{Id: profileBundleID}
+	assert.Equal(t, int64(10), defaultValue(bundle, intSetting, userAccountUUID).GetIntValue())
+	assert.Equal(t, "abc", defaultValue(bundle, stringSetting, userAccountUUID).GetStringValue())
+	assert.Equal(t, []string{`"en"`}, optionStrings(defaultValue(bundle, singleChoiceSetting, userAccountUUID)))
+	assert.Equal(t, []string{"1", "3"}, optionStrings(defaultValue(bundle, multiChoiceSetting, userAccountUUID)))
+	assert.Nil(t, defaultValue(bundle, permissionSetting, userAccountUUID))
+}
+
+func optionStrings(value *proto.Value) []string {
+	var options []string
+	for _, v := range value.GetListValue().GetValues() {
+		options = append(options, optionString(v))
+	}
+	return options
+}
+
+func TestSaveValueValidation(t *testing.T) {
+	g := newTestService(t)
+	_, err := g.manager.AddSettingToBundle(profileBundleID, &proto.Setting{
+		Id:       "0f7d6f4c-6c50-4c3c-9b4a-5b1b4cfa1f3e",
+		Name:     "items-per-page",
+		Resource: &proto.Resource{Type: proto.Resource_TYPE_USER},
+		Value:    &proto.Setting_IntValue{IntValue: &proto.Int{Default: 20, Min: 10, Max: 100, Step: 10}},
+	})
+	require.NoError(t, err)
+
+	err = g.SaveValue(serviceCtx(t), &proto.SaveValueRequest{Value: &proto.Value{
+		BundleId:    profileBundleID,
+		SettingId:   "0f7d6f4c-6c50-4c3c-9b4a-5b1b4cfa1f3e",
+		AccountUuid: userAccountUUID,
+		Resource:    &proto.Resource{Type: proto.Resource_TYPE_USER},
+		Value:       &proto.Value_IntValue{IntValue: 25},
+	}}, &proto.SaveValueResponse{})
+	valueError := ParseValueError(err)
+	require.NotNil(t, valueError)
+	assert.Equal(t, "0f7d6f4c-6c50-4c3c-9b4a-5b1b4cfa1f3e", valueError.SettingId)
+	require.Len(t, valueError.Violations, 1)
+	assert.Equal(t, proto.ValueViolation_RULE_STEP, valueError.Violations[0].Rule)
+
+	err = g.SaveValue(serviceCtx(t), &proto.SaveValueRequest{Value: &proto.Value{
+		BundleId:    profileBundleID,
+		SettingId:   RoleManagementPermissionID,
+		AccountUuid: userAccountUUID,
+		Resource:    &proto.Resource{Type: proto.Resource_TYPE_USER},
+		Value:       &proto.Value_IntValue{IntValue: 25},
+	}}, &proto.SaveValueResponse{})
+	assert.Equal(t, int32(400), code(err))
+	assert.Nil(t, ParseValueError(err))
+}
+
+func TestListResolvedValues(t *testing.T) {
+	g := newTestService(t)
+	_, err := g.manager.AddSettingToBundle(profileBundleID, &proto.Setting{
+		Id:       "0f7d6f4c-6c50-4c3c-9b4a-5b1b4cfa1f3e",
+		Name:     "items-per-page",
+		Resource: &proto.Resource{Type: proto.Resource_TYPE_USER},
+		Value:    &proto.Setting_IntValue{IntValue: &proto.Int{Default: 20}},
+	})
+	require.NoError(t, err)
+	_, err = g.manager.WriteValue(&proto.Value{
+		BundleId:  profileBundleID,
+		SettingId: timezoneSettingID,
+		Resource:  &proto.Resource{Type: proto.Resource_TYPE_SYSTEM},
+		Value:     &proto.Value_StringValue{StringValue: "Europe/Berlin"},
+	})
+	require.NoError(t, err)
+
+	resolve := func(ctx context.Context, accountUUID string) map[string]*proto.ResolvedValue {
+		res := &proto.ListResolvedValuesResponse{}
+		require.NoError(t, g.ListResolvedValues(ctx, &proto.ListResolvedValuesRequest{
+			BundleId:    profileBundleID,
+			AccountUuid: accountUUID,
+		}, res))
+		values := map[string]*proto.ResolvedValue{}
+		for _, v := range res.Values {
+			values[v.Value.Identifier.Setting] = v
+		}
+		return values
+	}
+
+	values := resolve(user.ctx(), "me")
+	require.Len(t, values, 2)
+	assert.Equal(t, proto.ResolvedValue_SOURCE_ACCOUNT, values["language"].Source)
+	assert.Equal(t, "de", values["language"].Value.Value.GetStringValue())
+	assert.Equal(t, proto.ResolvedValue_SOURCE_SYSTEM, values["timezone"].Source)
+	assert.Equal(t, "Europe/Berlin", values["timezone"].Value.Value.GetStringValue())
+
+	// internal requests of other services see every setting, the ones without value fall back to their default
+	values = resolve(serviceCtx(t), userAccountUUID)
+	require.Len(t, values, 3)
+	assert.Equal(t, proto.ResolvedValue_SOURCE_DEFAULT, values["items-per-page"].Source)
+
+	// requests without roles see nothing
+	values = resolve(emptyCtx, userAccountUUID)
+	require.Empty(t, values)
+
+	values = resolve(serviceCtx(t), userAccountUUID)
+	require.Len(t, values, 3)
+	assert.Equal(t, proto.ResolvedValue_SOURCE_DEFAULT, values["items-per-page"].Source)
+	assert.Equal(t, int64(20), values["items-per-page"].Value.Value.GetIntValue())
+	assert.Empty(t, values["items-per-page"].Value.Value.Id)
+
+	// guests can only read their language
+	values = resolve(guest.ctx(), "me")
+	require.Len(t, values, 1)
+	assert.Equal(t, proto.ResolvedValue_SOURCE_DEFAULT, values["language"].Source)
+}
+
+// withGroups adds the groups of the authenticated user to the context like the middleware does.
+func withGroups(ctx context.Context, groupIDs ...string) context.Context {
+	groupIDsJSON, _ := json.Marshal(groupIDs)
+	return metadata.Set(ctx, middleware.GroupIDs, string(groupIDsJSON))
+}
+
+func TestScopedValues(t *testing.T) {
+	g := newTestService(t)
+	save := func(ctx context.Context, value *proto.Value) error {
+		value.BundleId = profileBundleID
+		if value.AccountUuid == "" {
+			value.AccountUuid = "me"
+		}
+		value.Value = &proto.Value_StringValue{StringValue: value.GetStringValue()}
+		return g.SaveValue(ctx, &proto.SaveValueRequest{Value: value}, &proto.SaveValueResponse{})
+	}
+	timezone := func(ctx context.Context) *proto.ResolvedValue {
+		res := &proto.ListResolvedValuesResponse{}
+		require.NoError(t, g.ListResolvedValues(ctx, &proto.ListResolvedValuesRequest{
+			BundleId:    profileBundleID,
+			AccountUuid: "me",
+		}, res))
+		for _, v := range res.Values {
+			if v.Value.Value.SettingId == timezoneSettingID {
+				return v
+			}
+		}
+		return nil
+	}
+	byIdentifiers := func(ctx context.Context) *proto.Value {
+		res := &proto.GetValueResponse{}
+		require.NoError(t, g.GetValueByUniqueIdentifiers(ctx, &proto.GetValueByUniqueIdentifiersRequest{
+			AccountUuid: "me",
+			SettingId:   timezoneSettingID,
+		}, res))
+		return res.Value.Value
+	}
+	sales := withGroups(user.ctx(), "sales")
+	support := withGroups(user.ctx(), "support", "sales")
+
+	require.NoError(t, save(admin.ctx(), &proto.Value{
+		SettingId: timezoneSettingID,
+		Resource:  &proto.Resource{Type: proto.Resource_TYPE_SYSTEM},
+		Value:     &proto.Value_StringValue{StringValue: "UTC"},
+	}))
+	require.NoError(t, save(admin.ctx(), &proto.Value{
+		SettingId: timezoneSettingID,
+		Resource:  &proto.Resource{Type: proto.Resource_TYPE_GROUP, Id: "sales"},
+		Value:     &proto.Value_StringValue{StringValue: "Europe/Berlin"},
+	}))
+	require.NoError(t, save(admin.ctx(), &proto.Value{
+		SettingId: timezoneSettingID,
+		Resource:  &proto.Resource{Type: proto.Resource_TYPE_GROUP, Id: "support"},
+		Value:     &proto.Value_StringValue{StringValue: "America/New_York"},
+	}))
+
+	t.Run("group values need an existing group", func(t *testing.T) {
+		err := save(admin.ctx(), &proto.Value{
+			SettingId: timezoneSettingID,
+			Resource:  &proto.Resource{Type: proto.Resource_TYPE_GROUP, Id: "Sales Department"},
+			Value:     &proto.Value_StringValue{StringValue: "Europe/Berlin"},
+		})
+		assert.Equal(t, int32(400), code(err))
+	})
+
+	t.Run("group values only apply to members", func(t *testing.T) {
+		assert.Equal(t, proto.ResolvedValue_SOURCE_SYSTEM, timezone(user.ctx()).Source)
+		assert.Equal(t, "UTC", timezone(user.ctx()).Value.Value.GetStringValue())
+		assert.Equal(t, proto.ResolvedValue_SOURCE_GROUP, timezone(sales).Source)
+		assert.Equal(t, "Europe/Berlin", timezone(sales).Value.Value.GetStringValue())
+		assert.Empty(t, timezone(sales).Value.Value.AccountUuid)
+		// the first group wins
+		assert.Equal(t, "America/New_York", timezone(support).Value.Value.GetStringValue())
+	})
+
+	t.Run("account values override group values", func(t *testing.T) {
+		require.NoError(t, save(sales, &proto.Value{
+			SettingId: timezoneSettingID,
+			Resource:  &proto.Resource{Type: proto.Resource_TYPE_USER},
+			Value:     &proto.Value_StringValue{StringValue: "Asia/Tokyo"},
+		}))
+		assert.Equal(t, proto.ResolvedValue_SOURCE_ACCOUNT, timezone(sales).Source)
+		assert.Equal(t, "Asia/Tokyo", timezone(sales).Value.Value.GetStringValue())
+	})
+
+	t.Run("users can't write shared values", func(t *testing.T) {
+		err := save(sales, &proto.Value{
+			SettingId: timezoneSettingID,
+			Resource:  &proto.Resource{Type: proto.Resource_TYPE_GROUP, Id: "sales"},
+			Value:     &proto.Value_StringValue{StringValue: "Asia/Tokyo"},
+		})
+		assert.Equal(t, int32(403), code(err))
+	})
+
+	t.Run("only shared values can be locked", func(t *testing.T) {
+		err := save(sales, &proto.Value{
+			SettingId: timezoneSettingID,
+			Resource:  &proto.Resource{Type: proto.Resource_TYPE_USER},
+			Value:     &proto.Value_StringValue{StringValue: "Asia/Tokyo"},
+			Locked:    true,
+		})
+		assert.Equal(t, int32(400), code(err))
+		err = save(admin.ctx(), &proto.Value{
+			SettingId: timezoneSettingID,
+			Resource:  &proto.Resource{Type: proto.Resource_TYPE_GROUP},
+			Value:     &proto.Value_StringValue{StringValue: "Asia/Tokyo"},
+		})
+		assert.Equal(t, int32(400), code(err))
+	})
+
+	t.Run("locked group values override account values", func(t *testing.T) {
+		require.NoError(t, save(admin.ctx(), &proto.Value{
+			SettingId: timezoneSettingID,
+			Resource:  &proto.Resource{Type: proto.Resource_TYPE_GROUP, Id: "sales"},
+			Value:     &proto.Value_StringValue{StringValue: "Europe/Berlin"},
+			Locked:    true,
+		}))
+		assert.Equal(t, proto.ResolvedValue_SOURCE_GROUP, timezone(sales).Source)
+		assert.Equal(t, "Europe/Berlin", timezone(sales).Value.Value.GetStringValue())
+		assert.Equal(t, "Europe/Berlin", byIdentifiers(sales).GetStringValue())
+		// the locked group wins over the first group
+		assert.Equal(t, "Europe/Berlin", timezone(support).Value.Value.GetStringValue())
+
+		err := save(sales, &proto.Value{
+			SettingId: timezoneSettingID,
+			Resource:  &proto.Resource{Type: proto.Resource_TYPE_USER},
+			Value:     &proto.Value_StringValue{StringValue: "Asia/Tokyo"},
+		})
+		assert.Equal(t, int32(403), code(err))
+		assert.NoError(t, save(withGroups(user.ctx(), "marketing"), &proto.Value{
+			SettingId: timezoneSettingID,
+			Resource:  &proto.Resource{Type: proto.Resource_TYPE_USER},
+			Value:     &proto.Value_StringValue{StringValue: "Asia/Tokyo"},
+		}))
+	})
+
+	t.Run("locked system values override all values", func(t *testing.T) {
+		require.NoError(t, save(admin.ctx(), &proto.Value{
+			SettingId: timezoneSettingID,
+			Resource:  &proto.Resource{Type: proto.Resource_TYPE_SYSTEM},
+			Value:     &proto.Value_StringValue{StringValue: "UTC"},
+			Locked:    true,
+		}))
+		for _, ctx := range []context.Context{user.ctx(), sales, support} {
+			assert.Equal(t, proto.ResolvedValue_SOURCE_SYSTEM, timezone(ctx).Source)
+			assert.Equal(t, "UTC", timezone(ctx).Value.Value.GetStringValue())
+			// the value of the account was saved before the lock
+			assert.Equal(t, "UTC", byIdentifiers(ctx).GetStringValue())
+		}
+		err := save(user.ctx(), &proto.Value{
+			SettingId: timezoneSettingID,
+			Resource:  &proto.Resource{Type: proto.Resource_TYPE_USER},
+			Value:     &proto.Value_StringValue{StringValue: "Asia/Tokyo"},
+		})
+		assert.Equal(t, int32(403), code(err))
+	})
+}
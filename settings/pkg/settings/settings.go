@@ -19,6 +19,7 @@ type Manager interface {
 	ValueManager
 	RoleAssignmentManager
 	PermissionManager
+	BackupManager
 }
 
 // BundleManager is a bundle service interface for abstraction of storage implementations
@@ -26,6 +27,7 @@ type BundleManager interface {
 	ListBundles(bundleType proto.Bundle_Type, bundleIDs []string) ([]*proto.Bundle, error)
 	ReadBundle(bundleID string) (*proto.Bundle, error)
 	WriteBundle(bundle *proto.Bundle) (*proto.Bundle, error)
+	DeleteBundle(bundleID string) error
 	ReadSetting(settingID string) (*proto.Setting, error)
 	AddSettingToBundle(bundleID string, setting *proto.Setting) (*proto.Setting, error)
 	RemoveSettingFromBundle(bundleID, settingID string) error
@@ -34,6 +36,7 @@ type BundleManager interface {
 // ValueManager is a value service interface for abstraction of storage implementations
 type ValueManager interface {
 	ListValues(bundleID, accountUUID string) ([]*proto.Value, error)
+	ListGroupValues(bundleID string, groupIDs []string) ([]*proto.Value, error)
 	ReadValue(valueID string) (*proto.Value, error)
 	ReadValueByUniqueIdentifiers(accountUUID, settingID string) (*proto.Value, error)
 	WriteValue(value *proto.Value) (*proto.Value, error)
@@ -42,7 +45,9 @@ type ValueManager interface {
 // RoleAssignmentManager is a role assignment service interface for abstraction of storage implementations
 type RoleAssignmentManager interface {
 	ListRoleAssignments(accountUUID string) ([]*proto.UserRoleAssignment, error)
+	ListRoleAssignmentsByRole(roleID string) ([]*proto.UserRoleAssignment, error)
 	WriteRoleAssignment(accountUUID, roleID string) (*proto.UserRoleAssignment, error)
+	AddRoleAssignment(accountUUID, roleID string) (*proto.UserRoleAssignment, error)
 	RemoveRoleAssignment(assignmentID string) error
 }
 
@@ -51,3 +56,11 @@ type PermissionManager interface {
 	ListPermissionsByResource(resource *proto.Resource, roleIDs []string) ([]*proto.Permission, error)
 	ReadPermissionByID(permissionID string, roleIDs []string) (*proto.Permission, error)
 }
+
+// BackupManager is a backup interface for abstraction of storage implementations
+type BackupManager interface {
+	// Snapshot copies the data of the store into the given directory, writes are blocked meanwhile.
+	Snapshot(dir string) error
+	// Restore replaces the data of the store with a snapshot from the given directory.
+	Restore(dir string) error
+}
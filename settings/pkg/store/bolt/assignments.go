@@ -0,0 +1,127 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	protobuf "github.com/golang/protobuf/proto"
+	"github.com/owncloud/ocis/settings/pkg/proto/v0"
+	bolt "go.etcd.io/bbolt"
+)
+
+// ListRoleAssignments returns all role assignments of the given account.
+func (s Store) ListRoleAssignments(accountUUID string) ([]*proto.UserRoleAssignment, error) {
+	var records []*proto.UserRoleAssignment
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var err error
+		records, err = listRoleAssignments(tx, accountUUID)
+		return err
+	})
+	return records, err
+}
+
+func listRoleAssignments(tx *bolt.Tx, accountUUID string) ([]*proto.UserRoleAssignment, error) {
+	records := make([]*proto.UserRoleAssignment, 0)
+	err := scanIndex(tx, bucketAssignmentIndex, func(assignmentID string) error {
+		record := &proto.UserRoleAssignment{}
+		if err := readRecord(tx, bucketAssignments, assignmentID, record); err != nil {
+			return err
+		}
+		records = append(records, record)
+		return nil
+	}, accountUUID)
+	return records, err
+}
+
+// ListRoleAssignmentsByRole returns all role assignments of the given role.
+func (s Store) ListRoleAssignmentsByRole(roleID string) ([]*proto.UserRoleAssignment, error) {
+	records := make([]*proto.UserRoleAssignment, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketAssignments).ForEach(func(k, v []byte) error {
+			record := &proto.UserRoleAssignment{}
+			if err := protobuf.Unmarshal(v, record); err != nil {
+				return err
+			}
+			if record.RoleId == roleID {
+				records = append(records, record)
+			}
+			return nil
+		})
+	})
+	return records, err
+}
+
+// WriteRoleAssignment replaces the existing assignments of the respective account with the given role.
+func (s Store) WriteRoleAssignment(accountUUID, roleID string) (*proto.UserRoleAssignment, error) {
+	var assignment *proto.UserRoleAssignment
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		list, err := listRoleAssignments(tx, accountUUID)
+		if err != nil {
+			return err
+		}
+		for _, existing := range list {
+			if err := tx.Bucket(bucketAssignmentIndex).Delete([]byte(indexKey(accountUUID, existing.Id))); err != nil {
+				return err
+			}
+			if err := tx.Bucket(bucketAssignments).Delete([]byte(existing.Id)); err != nil {
+				return err
+			}
+		}
+		assignment, err = writeRoleAssignment(tx, accountUUID, roleID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+// AddRoleAssignment appends the given role assignment to the existing assignments of the respective account.
+// An account can have multiple roles, assigning a role twice returns the existing assignment.
+func (s Store) AddRoleAssignment(accountUUID, roleID string) (*proto.UserRoleAssignment, error) {
+	var assignment *proto.UserRoleAssignment
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		list, err := listRoleAssignments(tx, accountUUID)
+		if err != nil {
+			return err
+		}
+		for _, existing := range list {
+			if existing.RoleId == roleID {
+				assignment = existing
+				return nil
+			}
+		}
+		assignment, err = writeRoleAssignment(tx, accountUUID, roleID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+func writeRoleAssignment(tx *bolt.Tx, accountUUID, roleID string) (*proto.UserRoleAssignment, error) {
+	assignment := &proto.UserRoleAssignment{
+		Id:          uuid.Must(uuid.NewV4()).String(),
+		AccountUuid: accountUUID,
+		RoleId:      roleID,
+	}
+	if err := writeRecord(tx, bucketAssignments, assignment.Id, assignment); err != nil {
+		return nil, err
+	}
+	return assignment, tx.Bucket(bucketAssignmentIndex).Put([]byte(indexKey(accountUUID, assignment.Id)), []byte{})
+}
+
+// RemoveRoleAssignment deletes the given role assignment from the existing assignments of the respective account.
+func (s Store) RemoveRoleAssignment(assignmentID string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		assignment := &proto.UserRoleAssignment{}
+		if err := readRecord(tx, bucketAssignments, assignmentID, assignment); err != nil {
+			return fmt.Errorf("could not remove role assignment %s: %w", assignmentID, err)
+		}
+		if err := tx.Bucket(bucketAssignmentIndex).Delete([]byte(indexKey(assignment.AccountUuid, assignmentID))); err != nil {
+			return err
+		}
+		return tx.Bucket(bucketAssignments).Delete([]byte(assignmentID))
+	})
+}
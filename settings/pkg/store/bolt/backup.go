@@ -0,0 +1,83 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Snapshot implements the BackupManager interface. The database is copied in a read transaction, so writes don't
+// need to be blocked.
+func (s *Store) Snapshot(dir string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	return s.db.View(func(tx *bolt.Tx) error {
+		return tx.CopyFile(filepath.Join(dir, fileName), 0600)
+	})
+}
+
+// Restore implements the BackupManager interface. The content of the database is replaced with the content of the
+// snapshot in a single transaction. The database itself stays open, the http and the grpc server share it.
+func (s *Store) Restore(dir string) error {
+	file := filepath.Join(dir, fileName)
+	if _, err := os.Stat(file); err != nil {
+		return fmt.Errorf("the snapshot contains no settings database: %w", err)
+	}
+	src, err := bolt.Open(file, 0600, &bolt.Options{Timeout: 5 * time.Second, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	return src.View(func(stx *bolt.Tx) error {
+		return s.db.Update(func(tx *bolt.Tx) error {
+			var existing [][]byte
+			err := tx.ForEach(func(name []byte, _ *bolt.Bucket) error {
+				existing = append(existing, append([]byte{}, name...))
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			for _, name := range existing {
+				if err := tx.DeleteBucket(name); err != nil {
+					return err
+				}
+			}
+
+			err = stx.ForEach(func(name []byte, b *bolt.Bucket) error {
+				dst, err := tx.CreateBucket(name)
+				if err != nil {
+					return err
+				}
+				return copyBucket(b, dst)
+			})
+			if err != nil {
+				return err
+			}
+			for _, bucket := range [][]byte{bucketBundles, bucketValues, bucketAssignments, bucketValueIndex, bucketAssignmentIndex} {
+				if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
+
+func copyBucket(src, dst *bolt.Bucket) error {
+	return src.ForEach(func(k, v []byte) error {
+		if v != nil {
+			return dst.Put(k, v)
+		}
+		nested, err := dst.CreateBucket(k)
+		if err != nil {
+			return err
+		}
+		return copyBucket(src.Bucket(k), nested)
+	})
+}
@@ -0,0 +1,146 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	protobuf "github.com/golang/protobuf/proto"
+	"github.com/owncloud/ocis/settings/pkg/proto/v0"
+	bolt "go.etcd.io/bbolt"
+)
+
+// ListBundles returns all bundles that match the given type.
+func (s Store) ListBundles(bundleType proto.Bundle_Type, bundleIDs []string) ([]*proto.Bundle, error) {
+	records := make([]*proto.Bundle, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketBundles).ForEach(func(k, v []byte) error {
+			record := &proto.Bundle{}
+			if err := protobuf.Unmarshal(v, record); err != nil {
+				s.Logger.Warn().Msgf("error reading bundle %s", k)
+				return nil
+			}
+			if record.Type != bundleType {
+				return nil
+			}
+			if len(bundleIDs) > 0 && !containsStr(record.Id, bundleIDs) {
+				return nil
+			}
+			records = append(records, record)
+			return nil
+		})
+	})
+	return records, err
+}
+
+// containsStr checks if the strs slice contains str
+func containsStr(str string, strs []string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadBundle tries to find a bundle by the given id.
+func (s Store) ReadBundle(bundleID string) (*proto.Bundle, error) {
+	record := &proto.Bundle{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return readRecord(tx, bucketBundles, bundleID, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// ReadSetting tries to find a setting by the given id.
+func (s Store) ReadSetting(settingID string) (*proto.Setting, error) {
+	bundles, err := s.ListBundles(proto.Bundle_TYPE_DEFAULT, []string{})
+	if err != nil {
+		return nil, err
+	}
+	for _, bundle := range bundles {
+		for _, setting := range bundle.Settings {
+			if setting.Id == settingID {
+				return setting, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("could not read setting: %v", settingID)
+}
+
+// WriteBundle writes the given record.
+func (s Store) WriteBundle(record *proto.Bundle) (*proto.Bundle, error) {
+	if record.Id == "" {
+		record.Id = uuid.Must(uuid.NewV4()).String()
+	}
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		return writeRecord(tx, bucketBundles, record.Id, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// DeleteBundle removes the bundle with the given id.
+func (s Store) DeleteBundle(bundleID string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(bucketBundles)
+		if bucket.Get([]byte(bundleID)) == nil {
+			return fmt.Errorf("could not find bundle %s", bundleID)
+		}
+		return bucket.Delete([]byte(bundleID))
+	})
+}
+
+// AddSettingToBundle adds the given setting to the bundle with the given bundleID.
+func (s Store) AddSettingToBundle(bundleID string, setting *proto.Setting) (*proto.Setting, error) {
+	if setting.Id == "" {
+		setting.Id = uuid.Must(uuid.NewV4()).String()
+	}
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bundle := &proto.Bundle{}
+		if err := readRecord(tx, bucketBundles, bundleID, bundle); err != nil {
+			return err
+		}
+		if index := indexOfSetting(bundle, setting.Id); index == -1 {
+			bundle.Settings = append(bundle.Settings, setting)
+		} else {
+			bundle.Settings[index] = setting
+		}
+		return writeRecord(tx, bucketBundles, bundleID, bundle)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return setting, nil
+}
+
+// RemoveSettingFromBundle removes the setting from the bundle with the given ids.
+func (s Store) RemoveSettingFromBundle(bundleID string, settingID string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bundle := &proto.Bundle{}
+		if err := readRecord(tx, bucketBundles, bundleID, bundle); err != nil {
+			return nil
+		}
+		index := indexOfSetting(bundle, settingID)
+		if index == -1 {
+			return nil
+		}
+		bundle.Settings = append(bundle.Settings[:index], bundle.Settings[index+1:]...)
+		return writeRecord(tx, bucketBundles, bundleID, bundle)
+	})
+}
+
+// indexOfSetting finds the index of the given setting within the given bundle.
+// returns -1 if the setting was not found.
+func indexOfSetting(bundle *proto.Bundle, settingID string) int {
+	for index := range bundle.Settings {
+		if bundle.Settings[index].Id == settingID {
+			return index
+		}
+	}
+	return -1
+}
@@ -0,0 +1,45 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	bolt "go.etcd.io/bbolt"
+)
+
+// readRecord unmarshals the record with the given key from the bucket
+func readRecord(tx *bolt.Tx, bucket []byte, key string, record proto.Message) error {
+	data := tx.Bucket(bucket).Get([]byte(key))
+	if data == nil {
+		return fmt.Errorf("could not find %s in %s", key, bucket)
+	}
+	return proto.Unmarshal(data, record)
+}
+
+// writeRecord marshals the record into the bucket under the given key
+func writeRecord(tx *bolt.Tx, bucket []byte, key string, record proto.Message) error {
+	data, err := proto.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(bucket).Put([]byte(key), data)
+}
+
+// indexKey joins the parts of a key of an index bucket. None of the parts may contain a slash, except for the last.
+func indexKey(parts ...string) string {
+	return strings.Join(parts, "/")
+}
+
+// scanIndex calls fn with the last part of every key of the index bucket that starts with the given parts.
+func scanIndex(tx *bolt.Tx, bucket []byte, fn func(id string) error, parts ...string) error {
+	prefix := []byte(indexKey(parts...) + "/")
+	c := tx.Bucket(bucket).Cursor()
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		if err := fn(string(k[bytes.LastIndexByte(k, '/')+1:])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package store
+
+import (
+	"github.com/owncloud/ocis/settings/pkg/proto/v0"
+	"github.com/owncloud/ocis/settings/pkg/util"
+)
+
+// ListPermissionsByResource collects all permissions from the provided roleIDs that match the requested resource
+func (s Store) ListPermissionsByResource(resource *proto.Resource, roleIDs []string) ([]*proto.Permission, error) {
+	records := make([]*proto.Permission, 0)
+	for _, roleID := range roleIDs {
+		role, err := s.ReadBundle(roleID)
+		if err != nil {
+			s.Logger.Debug().Str("roleID", roleID).Msg("role not found, skipping")
+			continue
+		}
+		records = append(records, util.ExtractPermissionsByResource(resource, role)...)
+	}
+	return records, nil
+}
+
+// ReadPermissionByID finds the permission in the roles, specified by the provided roleIDs.
+// If more than one of the roles grants the permission, the union of the granted permissions is returned.
+func (s Store) ReadPermissionByID(permissionID string, roleIDs []string) (*proto.Permission, error) {
+	var result *proto.Permission
+	for _, roleID := range roleIDs {
+		role, err := s.ReadBundle(roleID)
+		if err != nil {
+			s.Logger.Debug().Str("roleID", roleID).Msg("role not found, skipping")
+			continue
+		}
+		for _, permission := range role.Settings {
+			if permission.Id == permissionID {
+				if value, ok := permission.Value.(*proto.Setting_PermissionValue); ok {
+					result = util.UnionPermissions(result, value.PermissionValue)
+				}
+			}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,91 @@
+// Package store implements the settings manager on top of a bolt database
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	olog "github.com/owncloud/ocis/ocis-pkg/log"
+	"github.com/owncloud/ocis/settings/pkg/config"
+	"github.com/owncloud/ocis/settings/pkg/settings"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	managerName = "bolt"
+	fileName    = "settings.db"
+
+	bucketBundles     = []byte("bundles")
+	bucketValues      = []byte("values")
+	bucketAssignments = []byte("assignments")
+	// bucketValueIndex has a key for every value, built from the account uuid, the setting id and the value id.
+	bucketValueIndex = []byte("values-by-account")
+	// bucketAssignmentIndex has a key for every role assignment, built from the account uuid and the assignment id.
+	bucketAssignmentIndex = []byte("assignments-by-account")
+
+	// dbs holds the open database of every data path. The http and the grpc server create a store each, but a bolt
+	// database can only be opened once.
+	dbs   = map[string]*bolt.DB{}
+	dbsMu = &sync.Mutex{}
+)
+
+// Store keeps the settings in a bolt database within the data path. Every write happens in a transaction.
+type Store struct {
+	db     *bolt.DB
+	Logger olog.Logger
+}
+
+// New creates a new store
+func New(cfg *config.Config) settings.Manager {
+	s := Store{
+		Logger: olog.NewLogger(
+			olog.Color(cfg.Log.Color),
+			olog.Pretty(cfg.Log.Pretty),
+			olog.Level(cfg.Log.Level),
+		),
+	}
+
+	db, err := openDB(cfg.Service.DataPath)
+	if err != nil {
+		s.Logger.Fatal().Err(err).Msgf("could not open settings database in %v", cfg.Service.DataPath)
+	}
+	s.db = db
+	return &s
+}
+
+// openDB opens the database in the data path or returns the already opened one.
+func openDB(dataPath string) (*bolt.DB, error) {
+	dbsMu.Lock()
+	defer dbsMu.Unlock()
+	if db, ok := dbs[dataPath]; ok {
+		return db, nil
+	}
+
+	if err := os.MkdirAll(dataPath, 0700); err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(filepath.Join(dataPath, fileName), 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{bucketBundles, bucketValues, bucketAssignments, bucketValueIndex, bucketAssignmentIndex} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	dbs[dataPath] = db
+	return db, nil
+}
+
+func init() {
+	settings.Registry[managerName] = New
+}
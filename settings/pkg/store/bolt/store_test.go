@@ -0,0 +1,271 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/owncloud/ocis/settings/pkg/config"
+	"github.com/owncloud/ocis/settings/pkg/proto/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	accountUUID1 = "c4572da7-6142-4383-8fc6-efde3d463036"
+	accountUUID2 = "e11f9769-416a-427d-9441-41a0e51391d7"
+
+	bundle1 = "2f06addf-4fd2-49d5-8f71-00fbd3a3ec47"
+	role1   = "f36db5e6-a03c-40df-8413-711c67e40b47"
+	role2   = "44f1a664-0a7f-461a-b0be-5b59e46bbc7a"
+
+	setting1 = "c7ebbc8b-d15a-4f2e-9d7d-d6a4cf858d1a"
+	setting2 = "3fd9a3d9-20b7-40d4-9294-b22bb5868c10"
+)
+
+// newTestConfig configures a temporary data path and closes the database in it after the test.
+func newTestConfig(t *testing.T) *config.Config {
+	dir, err := ioutil.TempDir("", "settings-bolt")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		dbsMu.Lock()
+		if db, ok := dbs[dir]; ok {
+			db.Close()
+			delete(dbs, dir)
+		}
+		dbsMu.Unlock()
+		os.RemoveAll(dir)
+	})
+	cfg := config.New()
+	cfg.Service.DataPath = dir
+	return cfg
+}
+
+func newTestStore(t *testing.T) *Store {
+	return New(newTestConfig(t)).(*Store)
+}
+
+func TestBundles(t *testing.T) {
+	s := newTestStore(t)
+	_, err := s.WriteBundle(&proto.Bundle{
+		Id:   bundle1,
+		Name: "bundle",
+		Type: proto.Bundle_TYPE_DEFAULT,
+		Settings: []*proto.Setting{
+			{Id: setting1, Name: "setting1"},
+		},
+	})
+	require.NoError(t, err)
+	role, err := s.WriteBundle(&proto.Bundle{Name: "role", Type: proto.Bundle_TYPE_ROLE})
+	require.NoError(t, err)
+	assert.NotEmpty(t, role.Id)
+
+	bundles, err := s.ListBundles(proto.Bundle_TYPE_DEFAULT, nil)
+	require.NoError(t, err)
+	assert.Len(t, bundles, 1)
+	bundles, err = s.ListBundles(proto.Bundle_TYPE_ROLE, []string{bundle1})
+	require.NoError(t, err)
+	assert.Len(t, bundles, 0)
+
+	_, err = s.AddSettingToBundle(bundle1, &proto.Setting{Id: setting2, Name: "setting2"})
+	require.NoError(t, err)
+	setting, err := s.ReadSetting(setting2)
+	require.NoError(t, err)
+	assert.Equal(t, "setting2", setting.Name)
+
+	require.NoError(t, s.RemoveSettingFromBundle(bundle1, setting1))
+	bundle, err := s.ReadBundle(bundle1)
+	require.NoError(t, err)
+	if assert.Len(t, bundle.Settings, 1) {
+		assert.Equal(t, setting2, bundle.Settings[0].Id)
+	}
+
+	_, err = s.AddSettingToBundle(role1, &proto.Setting{Name: "missing"})
+	assert.Error(t, err)
+
+	require.NoError(t, s.DeleteBundle(bundle1))
+	assert.Error(t, s.DeleteBundle(bundle1))
+	_, err = s.ReadBundle(bundle1)
+	assert.Error(t, err)
+}
+
+func TestValues(t *testing.T) {
+	s := newTestStore(t)
+	system, err := s.WriteValue(&proto.Value{
+		BundleId:    bundle1,
+		SettingId:   setting1,
+		AccountUuid: accountUUID1,
+		Resource:    &proto.Resource{Type: proto.Resource_TYPE_SYSTEM},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, system.AccountUuid)
+	own, err := s.WriteValue(&proto.Value{
+		BundleId:    bundle1,
+		SettingId:   setting1,
+		AccountUuid: accountUUID1,
+		Resource:    &proto.Resource{Type: proto.Resource_TYPE_USER},
+	})
+	require.NoError(t, err)
+	_, err = s.WriteValue(&proto.Value{
+		BundleId:  bundle1,
+		SettingId: setting1,
+		Resource:  &proto.Resource{Type: proto.Resource_TYPE_GROUP, Id: "sales"},
+	})
+	require.NoError(t, err)
+
+	values, err := s.ListValues(bundle1, accountUUID1)
+	require.NoError(t, err)
+	assert.Len(t, values, 2)
+	values, err = s.ListValues("", accountUUID2)
+	require.NoError(t, err)
+	assert.Len(t, values, 1)
+	values, err = s.ListGroupValues(bundle1, []string{"sales"})
+	require.NoError(t, err)
+	assert.Len(t, values, 1)
+
+	value, err := s.ReadValueByUniqueIdentifiers(accountUUID1, setting1)
+	require.NoError(t, err)
+	assert.Equal(t, own.Id, value.Id)
+	value, err = s.ReadValueByUniqueIdentifiers(accountUUID2, setting1)
+	require.NoError(t, err)
+	assert.Equal(t, system.Id, value.Id)
+	_, err = s.ReadValueByUniqueIdentifiers(accountUUID2, setting2)
+	assert.Error(t, err)
+
+	// moving a value to another account updates the index
+	own.AccountUuid = accountUUID2
+	_, err = s.WriteValue(own)
+	require.NoError(t, err)
+	value, err = s.ReadValueByUniqueIdentifiers(accountUUID1, setting1)
+	require.NoError(t, err)
+	assert.Equal(t, system.Id, value.Id)
+	value, err = s.ReadValue(own.Id)
+	require.NoError(t, err)
+	assert.Equal(t, accountUUID2, value.AccountUuid)
+}
+
+func TestRoleAssignments(t *testing.T) {
+	s := newTestStore(t)
+	first, err := s.AddRoleAssignment(accountUUID1, role1)
+	require.NoError(t, err)
+	again, err := s.AddRoleAssignment(accountUUID1, role1)
+	require.NoError(t, err)
+	assert.Equal(t, first.Id, again.Id)
+	_, err = s.AddRoleAssignment(accountUUID1, role2)
+	require.NoError(t, err)
+	_, err = s.WriteRoleAssignment(accountUUID2, role1)
+	require.NoError(t, err)
+
+	assignments, err := s.ListRoleAssignments(accountUUID1)
+	require.NoError(t, err)
+	assert.Len(t, assignments, 2)
+	assignments, err = s.ListRoleAssignmentsByRole(role1)
+	require.NoError(t, err)
+	assert.Len(t, assignments, 2)
+
+	require.NoError(t, s.RemoveRoleAssignment(first.Id))
+	assert.Error(t, s.RemoveRoleAssignment(first.Id))
+	assignments, err = s.ListRoleAssignments(accountUUID1)
+	require.NoError(t, err)
+	if assert.Len(t, assignments, 1) {
+		assert.Equal(t, role2, assignments[0].RoleId)
+	}
+}
+
+func TestWriteRoleAssignmentReplaces(t *testing.T) {
+	s := newTestStore(t)
+	_, err := s.AddRoleAssignment(accountUUID1, role1)
+	require.NoError(t, err)
+	_, err = s.AddRoleAssignment(accountUUID1, role2)
+	require.NoError(t, err)
+
+	assignment, err := s.WriteRoleAssignment(accountUUID1, role2)
+	require.NoError(t, err)
+	assignments, err := s.ListRoleAssignments(accountUUID1)
+	require.NoError(t, err)
+	if assert.Len(t, assignments, 1) {
+		assert.Equal(t, assignment.Id, assignments[0].Id)
+		assert.Equal(t, role2, assignments[0].RoleId)
+	}
+	assignments, err = s.ListRoleAssignmentsByRole(role1)
+	require.NoError(t, err)
+	assert.Empty(t, assignments)
+}
+
+func TestPermissions(t *testing.T) {
+	s := newTestStore(t)
+	for roleID, operation := range map[string]proto.Permission_Operation{
+		role1: proto.Permission_OPERATION_READ,
+		role2: proto.Permission_OPERATION_WRITE,
+	} {
+		_, err := s.WriteBundle(&proto.Bundle{
+			Id:   roleID,
+			Type: proto.Bundle_TYPE_ROLE,
+			Settings: []*proto.Setting{{
+				Id:       setting1,
+				Resource: &proto.Resource{Type: proto.Resource_TYPE_SYSTEM},
+				Value: &proto.Setting_PermissionValue{PermissionValue: &proto.Permission{
+					Operation:  operation,
+					Constraint: proto.Permission_CONSTRAINT_OWN,
+				}},
+			}},
+		})
+		require.NoError(t, err)
+	}
+
+	permission, err := s.ReadPermissionByID(setting1, []string{role1, role2})
+	require.NoError(t, err)
+	assert.Equal(t, proto.Permission_OPERATION_READWRITE, permission.Operation)
+	permissions, err := s.ListPermissionsByResource(&proto.Resource{Type: proto.Resource_TYPE_SYSTEM}, []string{role1, bundle1})
+	require.NoError(t, err)
+	assert.Len(t, permissions, 1)
+}
+
+func TestSharedDatabase(t *testing.T) {
+	cfg := newTestConfig(t)
+	s := New(cfg).(*Store)
+	other := New(cfg).(*Store)
+	assert.Same(t, s.db, other.db)
+
+	_, err := s.WriteRoleAssignment(accountUUID1, role1)
+	require.NoError(t, err)
+	assignments, err := other.ListRoleAssignments(accountUUID1)
+	require.NoError(t, err)
+	assert.Len(t, assignments, 1)
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	s := newTestStore(t)
+	_, err := s.WriteBundle(&proto.Bundle{Id: bundle1, Name: "before", Type: proto.Bundle_TYPE_DEFAULT})
+	require.NoError(t, err)
+	_, err = s.WriteRoleAssignment(accountUUID1, role1)
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "settings-bolt-snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, s.Snapshot(dir))
+
+	_, err = s.WriteBundle(&proto.Bundle{Id: bundle1, Name: "after", Type: proto.Bundle_TYPE_DEFAULT})
+	require.NoError(t, err)
+	_, err = s.WriteRoleAssignment(accountUUID1, role2)
+	require.NoError(t, err)
+
+	// restore into another store, the snapshot is not tied to its data path
+	other := newTestStore(t)
+	require.NoError(t, other.Restore(dir))
+	require.NoError(t, s.Restore(dir))
+
+	for _, store := range []*Store{s, other} {
+		bundle, err := store.ReadBundle(bundle1)
+		require.NoError(t, err)
+		assert.Equal(t, "before", bundle.Name)
+		assignments, err := store.ListRoleAssignments(accountUUID1)
+		require.NoError(t, err)
+		if assert.Len(t, assignments, 1) {
+			assert.Equal(t, role1, assignments[0].RoleId)
+		}
+	}
+
+	assert.Error(t, s.Restore(t.TempDir()))
+}
@@ -0,0 +1,144 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gofrs/uuid"
+	"github.com/owncloud/ocis/settings/pkg/proto/v0"
+	bolt "go.etcd.io/bbolt"
+)
+
+// ListValues reads all values that match the given bundleId and accountUUID.
+// If the bundleId is empty, it's ignored for filtering.
+// If the accountUUID is empty, only values with empty accountUUID are returned.
+// If the accountUUID is not empty, values with an empty or with a matching accountUUID are returned.
+// Values of groups are never returned, see ListGroupValues.
+func (s Store) ListValues(bundleID, accountUUID string) ([]*proto.Value, error) {
+	accountUUIDs := []string{""}
+	if accountUUID != "" {
+		accountUUIDs = append(accountUUIDs, accountUUID)
+	}
+	return s.listValues(accountUUIDs, func(value *proto.Value) bool {
+		return (bundleID == "" || value.BundleId == bundleID) && !isGroupValue(value)
+	})
+}
+
+// ListGroupValues reads all values of the given groups that match the given bundleId.
+// If the bundleId is empty, it's ignored for filtering.
+func (s Store) ListGroupValues(bundleID string, groupIDs []string) ([]*proto.Value, error) {
+	return s.listValues([]string{""}, func(value *proto.Value) bool {
+		return (bundleID == "" || value.BundleId == bundleID) && isGroupValue(value) && containsStr(value.Resource.Id, groupIDs)
+	})
+}
+
+// listValues reads the values of the given accounts that match the filter, sorted by id.
+func (s Store) listValues(accountUUIDs []string, filter func(*proto.Value) bool) ([]*proto.Value, error) {
+	records := make([]*proto.Value, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		for _, accountUUID := range accountUUIDs {
+			err := scanIndex(tx, bucketValueIndex, func(valueID string) error {
+				record := &proto.Value{}
+				if err := readRecord(tx, bucketValues, valueID, record); err != nil {
+					s.Logger.Warn().Err(err).Msgf("error reading value %v", valueID)
+					return nil
+				}
+				if filter(record) {
+					records = append(records, record)
+				}
+				return nil
+			}, accountUUID)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	sort.Slice(records, func(i, j int) bool { return records[i].Id < records[j].Id })
+	return records, err
+}
+
+func isGroupValue(value *proto.Value) bool {
+	return value.Resource != nil && value.Resource.Type == proto.Resource_TYPE_GROUP
+}
+
+// ReadValue tries to find a value by the given valueId
+func (s Store) ReadValue(valueID string) (*proto.Value, error) {
+	record := &proto.Value{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return readRecord(tx, bucketValues, valueID, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// ReadValueByUniqueIdentifiers tries to find a value given a set of unique identifiers.
+// The value of the account wins over the value of the system.
+func (s Store) ReadValueByUniqueIdentifiers(accountUUID, settingID string) (*proto.Value, error) {
+	var record *proto.Value
+	err := s.db.View(func(tx *bolt.Tx) error {
+		accountUUIDs := []string{""}
+		if accountUUID != "" {
+			accountUUIDs = []string{accountUUID, ""}
+		}
+		for _, a := range accountUUIDs {
+			err := scanIndex(tx, bucketValueIndex, func(valueID string) error {
+				value := &proto.Value{}
+				if err := readRecord(tx, bucketValues, valueID, value); err != nil {
+					return err
+				}
+				if record == nil && !isGroupValue(value) {
+					record = value
+				}
+				return nil
+			}, a, settingID)
+			if err != nil || record != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, fmt.Errorf("could not read value by settingID=%v and accountID=%v", settingID, accountUUID)
+	}
+	return record, nil
+}
+
+// WriteValue writes the given value and updates the index of the values
+func (s Store) WriteValue(value *proto.Value) (*proto.Value, error) {
+	s.Logger.Debug().Str("value", value.String()).Msg("writing value")
+	if value.Id == "" {
+		value.Id = uuid.Must(uuid.NewV4()).String()
+	}
+
+	// modify value depending on associated resource
+	if value.Resource.Type == proto.Resource_TYPE_SYSTEM || value.Resource.Type == proto.Resource_TYPE_GROUP {
+		value.AccountUuid = ""
+	}
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		existing := &proto.Value{}
+		if err := readRecord(tx, bucketValues, value.Id, existing); err == nil {
+			if err := tx.Bucket(bucketValueIndex).Delete(valueIndexKey(existing)); err != nil {
+				return err
+			}
+		}
+		if err := writeRecord(tx, bucketValues, value.Id, value); err != nil {
+			return err
+		}
+		return tx.Bucket(bucketValueIndex).Put(valueIndexKey(value), []byte{})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func valueIndexKey(value *proto.Value) []byte {
+	return []byte(indexKey(value.AccountUuid, value.SettingId, value.Id))
+}
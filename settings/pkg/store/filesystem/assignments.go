@@ -2,50 +2,59 @@
 package store
 
 import (
-	"io/ioutil"
 	"os"
-	"path/filepath"
 
 	"github.com/gofrs/uuid"
+	protobuf "github.com/golang/protobuf/proto"
 	"github.com/owncloud/ocis/settings/pkg/proto/v0"
 )
 
-// ListRoleAssignments loads and returns all role assignments matching the given assignment identifier.
+// ListRoleAssignments returns all role assignments of the given account.
 func (s Store) ListRoleAssignments(accountUUID string) ([]*proto.UserRoleAssignment, error) {
-	var records []*proto.UserRoleAssignment
-	assignmentsFolder := s.buildFolderPathForRoleAssignments(false)
-	assignmentFiles, err := ioutil.ReadDir(assignmentsFolder)
-	if err != nil {
-		return records, nil
-	}
-
-	for _, assignmentFile := range assignmentFiles {
-		record := proto.UserRoleAssignment{}
-		err = s.parseRecordFromFile(&record, filepath.Join(assignmentsFolder, assignmentFile.Name()))
-		if err == nil {
-			if record.AccountUuid == accountUUID {
-				records = append(records, &record)
-			}
-		}
-	}
+	idx := s.index()
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.assignmentsOf(accountUUID), nil
+}
 
-	return records, nil
+// ListRoleAssignmentsByRole returns all role assignments of the given role.
+func (s Store) ListRoleAssignmentsByRole(roleID string) ([]*proto.UserRoleAssignment, error) {
+	return s.index().filterAssignments(func(a *proto.UserRoleAssignment) bool {
+		return a.RoleId == roleID
+	}), nil
 }
 
-// WriteRoleAssignment appends the given role assignment to the existing assignments of the respective account.
+// WriteRoleAssignment replaces the existing assignments of the respective account with the given role.
 func (s Store) WriteRoleAssignment(accountUUID, roleID string) (*proto.UserRoleAssignment, error) {
-	// as per https://github.com/owncloud/product/issues/103 "Each user can have exactly one role"
-	list, err := s.ListRoleAssignments(accountUUID)
-	if err != nil {
-		return nil, err
-	}
-	if len(list) > 0 {
-		filePath := s.buildFilePathForRoleAssignment(list[0].Id, true)
+	idx := s.index()
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for _, existing := range idx.assignmentsOf(accountUUID) {
+		filePath := s.buildFilePathForRoleAssignment(existing.Id, true)
 		if err := os.Remove(filePath); err != nil {
 			return nil, err
 		}
+		idx.removeAssignment(existing.Id)
 	}
+	return s.writeRoleAssignment(idx, accountUUID, roleID)
+}
+
+// AddRoleAssignment appends the given role assignment to the existing assignments of the respective account.
+// An account can have multiple roles, assigning a role twice returns the existing assignment.
+func (s Store) AddRoleAssignment(accountUUID, roleID string) (*proto.UserRoleAssignment, error) {
+	idx := s.index()
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for _, existing := range idx.assignmentsOf(accountUUID) {
+		if existing.RoleId == roleID {
+			return existing, nil
+		}
+	}
+	return s.writeRoleAssignment(idx, accountUUID, roleID)
+}
 
+// writeRoleAssignment persists a new role assignment, the caller needs to hold the write lock of the index.
+func (s Store) writeRoleAssignment(idx *index, accountUUID, roleID string) (*proto.UserRoleAssignment, error) {
 	assignment := &proto.UserRoleAssignment{
 		Id:          uuid.Must(uuid.NewV4()).String(),
 		AccountUuid: accountUUID,
@@ -55,6 +64,7 @@ func (s Store) WriteRoleAssignment(accountUUID, roleID string) (*proto.UserRoleA
 	if err := s.writeRecordToFile(assignment, filePath); err != nil {
 		return nil, err
 	}
+	idx.putAssignment(protobuf.Clone(assignment).(*proto.UserRoleAssignment))
 
 	s.Logger.Debug().Msgf("request contents written to file: %v", filePath)
 	return assignment, nil
@@ -62,6 +72,13 @@ func (s Store) WriteRoleAssignment(accountUUID, roleID string) (*proto.UserRoleA
 
 // RemoveRoleAssignment deletes the given role assignment from the existing assignments of the respective account.
 func (s Store) RemoveRoleAssignment(assignmentID string) error {
+	idx := s.index()
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
 	filePath := s.buildFilePathForRoleAssignment(assignmentID, false)
-	return os.Remove(filePath)
+	if err := os.Remove(filePath); err != nil {
+		return err
+	}
+	idx.removeAssignment(assignmentID)
+	return nil
 }
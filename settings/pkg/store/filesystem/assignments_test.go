@@ -135,6 +135,62 @@ func TestAssignmentUniqueness(t *testing.T) {
 	burnRoot()
 }
 
+func TestMultipleAssignments(t *testing.T) {
+	var scenarios = []struct {
+		name       string
+		userID     string
+		firstRole  string
+		secondRole string
+	}{
+		{
+			"roles assignments",
+			einstein,
+			"f36db5e6-a03c-40df-8413-711c67e40b47",
+			"44f1a664-0a7f-461a-b0be-5b59e46bbc7a",
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(t *testing.T) {
+			firstAssignment, err := s.AddRoleAssignment(scenario.userID, scenario.firstRole)
+			assert.NoError(t, err)
+			assert.Equal(t, firstAssignment.RoleId, scenario.firstRole)
+			assert.FileExists(t, filepath.Join(dataRoot, "assignments", firstAssignment.Id+".json"))
+
+			list, err := s.ListRoleAssignments(scenario.userID)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, len(list))
+
+			// creating another assignment adds another entry, as users can have multiple roles.
+			secondAssignment, err := s.AddRoleAssignment(scenario.userID, scenario.secondRole)
+			assert.NoError(t, err)
+			assert.Equal(t, secondAssignment.RoleId, scenario.secondRole)
+
+			list, err = s.ListRoleAssignments(scenario.userID)
+			assert.NoError(t, err)
+			assert.Equal(t, 2, len(list))
+			assert.FileExists(t, filepath.Join(dataRoot, "assignments", firstAssignment.Id+".json"))
+			assert.FileExists(t, filepath.Join(dataRoot, "assignments", secondAssignment.Id+".json"))
+
+			// assigning the first role again returns the existing assignment.
+			again, err := s.AddRoleAssignment(scenario.userID, scenario.firstRole)
+			assert.NoError(t, err)
+			assert.Equal(t, firstAssignment.Id, again.Id)
+
+			list, err = s.ListRoleAssignments(scenario.userID)
+			assert.NoError(t, err)
+			assert.Equal(t, 2, len(list))
+
+			list, err = s.ListRoleAssignmentsByRole(scenario.secondRole)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, len(list))
+			assert.Equal(t, secondAssignment.Id, list[0].Id)
+		})
+	}
+	burnRoot()
+}
+
 func TestDeleteAssignment(t *testing.T) {
 	var scenarios = []struct {
 		name       string
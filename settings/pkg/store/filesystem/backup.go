@@ -0,0 +1,60 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/owncloud/ocis/ocis-pkg/backup"
+)
+
+// folders holds the folders of the data path that make up the settings.
+var folders = []string{folderNameBundles, folderNameValues, folderNameAssignments}
+
+// Snapshot implements the BackupManager interface.
+func (s Store) Snapshot(dir string) error {
+	m.RLock()
+	defer m.RUnlock()
+	idx := s.index()
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	for _, folder := range folders {
+		if err := backup.CopyDir(filepath.Join(s.dataPath, folder), filepath.Join(dir, folder), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Restore implements the BackupManager interface. Every folder is copied next to the existing one before it replaces
+// it, the index is rebuilt from the restored files on the next use.
+func (s Store) Restore(dir string) error {
+	m.Lock()
+	defer m.Unlock()
+	idx := s.index()
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	defer dropIndex(s.dataPath)
+
+	for _, folder := range folders {
+		target := filepath.Join(s.dataPath, folder)
+		restoring := target + ".restoring"
+		if err := os.RemoveAll(restoring); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(restoring, 0700); err != nil {
+			return err
+		}
+		if err := backup.CopyDir(filepath.Join(dir, folder), restoring, nil); err != nil {
+			return err
+		}
+		if err := os.RemoveAll(target); err != nil {
+			return err
+		}
+		if err := os.Rename(restoring, target); err != nil {
+			return err
+		}
+	}
+	s.Logger.Info().Str("path", s.dataPath).Msg("restored settings")
+	return nil
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	olog "github.com/owncloud/ocis/ocis-pkg/log"
+	"github.com/owncloud/ocis/settings/pkg/proto/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	s := Store{dataPath: dataRoot, Logger: olog.NewLogger(olog.Level("info"))}
+	defer burnRoot()
+
+	_, err := s.WriteBundle(&proto.Bundle{Id: bundle1, Name: "before", Type: proto.Bundle_TYPE_DEFAULT})
+	require.NoError(t, err)
+	_, err = s.WriteRoleAssignment(accountUUID1, bundle2)
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "settings-snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, s.Snapshot(dir))
+
+	_, err = s.WriteBundle(&proto.Bundle{Id: bundle1, Name: "after", Type: proto.Bundle_TYPE_DEFAULT})
+	require.NoError(t, err)
+	_, err = s.WriteRoleAssignment(accountUUID1, bundle3)
+	require.NoError(t, err)
+	_, err = s.WriteValue(&proto.Value{
+		BundleId:    bundle1,
+		SettingId:   setting1,
+		AccountUuid: accountUUID1,
+		Resource:    &proto.Resource{Type: proto.Resource_TYPE_USER},
+		Value:       &proto.Value_StringValue{StringValue: "de"},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, s.Restore(dir))
+
+	bundle, err := s.ReadBundle(bundle1)
+	require.NoError(t, err)
+	assert.Equal(t, "before", bundle.Name)
+	assignments, err := s.ListRoleAssignments(accountUUID1)
+	require.NoError(t, err)
+	if assert.Len(t, assignments, 1) {
+		assert.Equal(t, bundle2, assignments[0].RoleId)
+	}
+	values, err := s.ListValues(bundle1, accountUUID1)
+	require.NoError(t, err)
+	assert.Empty(t, values)
+}
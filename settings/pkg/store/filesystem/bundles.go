@@ -4,6 +4,7 @@ package store
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"sync"
 
@@ -106,6 +107,20 @@ func (s Store) WriteBundle(record *proto.Bundle) (*proto.Bundle, error) {
 	return record, nil
 }
 
+// DeleteBundle removes the bundle with the given id from the dataPath.
+func (s Store) DeleteBundle(bundleID string) error {
+	m.Lock()
+	defer m.Unlock()
+
+	filePath := s.buildFilePathForBundle(bundleID, false)
+	if err := os.Remove(filePath); err != nil {
+		return err
+	}
+
+	s.Logger.Debug().Msgf("removed file: %v", filePath)
+	return nil
+}
+
 // AddSettingToBundle adds the given setting to the bundle with the given bundleID.
 func (s Store) AddSettingToBundle(bundleID string, setting *proto.Setting) (*proto.Setting, error) {
 	bundle, err := s.ReadBundle(bundleID)
@@ -151,5 +151,11 @@ func TestBundles(t *testing.T) {
 		assert.Equal(t, proto.Bundle_TYPE_ROLE, roles[i].Type)
 	}
 
+	// check that a deleted bundle can't be read anymore
+	assert.NoError(t, s.DeleteBundle(bundle2))
+	_, err = s.ReadBundle(bundle2)
+	assert.Error(t, err)
+	assert.Error(t, s.DeleteBundle(bundle2))
+
 	burnRoot()
 }
@@ -0,0 +1,196 @@
+package store
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	protobuf "github.com/golang/protobuf/proto"
+	"github.com/owncloud/ocis/settings/pkg/proto/v0"
+)
+
+var (
+	// indexes holds the index of every data path. The http and the grpc server create a store each, so they need to
+	// share the index to see the writes of each other.
+	indexes   = map[string]*index{}
+	indexesMu = &sync.Mutex{}
+)
+
+// index keeps the values and role assignments in memory, so that they don't have to be read from disk on every
+// request. It is built from the files on first use and updated on every write, so the store needs to be the only
+// writer of its data path.
+type index struct {
+	mu sync.RWMutex
+
+	values map[string]*proto.Value
+	// valueIDs maps account uuids to setting ids to the ids of the values. Values of the system and of groups are
+	// stored without account uuid.
+	valueIDs map[string]map[string][]string
+
+	assignments map[string]*proto.UserRoleAssignment
+	// assignmentIDs maps account uuids to the ids of their role assignments.
+	assignmentIDs map[string][]string
+}
+
+// index returns the index of the data path of the store, building it if necessary.
+func (s Store) index() *index {
+	indexesMu.Lock()
+	defer indexesMu.Unlock()
+	if idx, ok := indexes[s.dataPath]; ok {
+		return idx
+	}
+	idx := s.buildIndex()
+	indexes[s.dataPath] = idx
+	return idx
+}
+
+// dropIndex removes the index of the data path, it gets rebuilt on the next use.
+func dropIndex(dataPath string) {
+	indexesMu.Lock()
+	defer indexesMu.Unlock()
+	delete(indexes, dataPath)
+}
+
+// buildIndex reads all values and role assignments from the data path.
+func (s Store) buildIndex() *index {
+	idx := &index{
+		values:        map[string]*proto.Value{},
+		valueIDs:      map[string]map[string][]string{},
+		assignments:   map[string]*proto.UserRoleAssignment{},
+		assignmentIDs: map[string][]string{},
+	}
+
+	valuesFolder := s.buildFolderPathForValues(false)
+	valueFiles, _ := ioutil.ReadDir(valuesFolder)
+	for _, valueFile := range valueFiles {
+		record := &proto.Value{}
+		if err := s.parseRecordFromFile(record, filepath.Join(valuesFolder, valueFile.Name())); err != nil {
+			s.Logger.Warn().Msgf("error reading %v", valueFile)
+			continue
+		}
+		idx.putValue(record)
+	}
+
+	assignmentsFolder := s.buildFolderPathForRoleAssignments(false)
+	assignmentFiles, _ := ioutil.ReadDir(assignmentsFolder)
+	for _, assignmentFile := range assignmentFiles {
+		record := &proto.UserRoleAssignment{}
+		if err := s.parseRecordFromFile(record, filepath.Join(assignmentsFolder, assignmentFile.Name())); err != nil {
+			s.Logger.Warn().Msgf("error reading %v", assignmentFile)
+			continue
+		}
+		idx.putAssignment(record)
+	}
+
+	s.Logger.Debug().Int("values", len(idx.values)).Int("assignments", len(idx.assignments)).Msg("built index")
+	return idx
+}
+
+// putValue adds the value to the index or replaces the value with the same id. The caller needs to hold the lock.
+func (idx *index) putValue(value *proto.Value) {
+	if existing, ok := idx.values[value.Id]; ok {
+		settings := idx.valueIDs[existing.AccountUuid]
+		settings[existing.SettingId] = removeStr(settings[existing.SettingId], existing.Id)
+	}
+	idx.values[value.Id] = value
+	if idx.valueIDs[value.AccountUuid] == nil {
+		idx.valueIDs[value.AccountUuid] = map[string][]string{}
+	}
+	settings := idx.valueIDs[value.AccountUuid]
+	settings[value.SettingId] = append(settings[value.SettingId], value.Id)
+}
+
+// value returns a copy of the value with the given id.
+func (idx *index) value(valueID string) (*proto.Value, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	value, ok := idx.values[valueID]
+	if !ok {
+		return nil, false
+	}
+	return protobuf.Clone(value).(*proto.Value), true
+}
+
+// valuesOf returns copies of all values of the given accounts that match the filter, sorted by id.
+func (idx *index) valuesOf(accountUUIDs []string, filter func(*proto.Value) bool) []*proto.Value {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	values := make([]*proto.Value, 0)
+	for _, accountUUID := range accountUUIDs {
+		for _, valueIDs := range idx.valueIDs[accountUUID] {
+			for _, valueID := range valueIDs {
+				if value := idx.values[valueID]; filter(value) {
+					values = append(values, protobuf.Clone(value).(*proto.Value))
+				}
+			}
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].Id < values[j].Id })
+	return values
+}
+
+// valueOfSetting returns a copy of a value of the given account and setting that matches the filter.
+func (idx *index) valueOfSetting(accountUUID, settingID string, filter func(*proto.Value) bool) (*proto.Value, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	for _, valueID := range idx.valueIDs[accountUUID][settingID] {
+		if value := idx.values[valueID]; filter(value) {
+			return protobuf.Clone(value).(*proto.Value), true
+		}
+	}
+	return nil, false
+}
+
+// putAssignment adds the role assignment to the index. The caller needs to hold the lock.
+func (idx *index) putAssignment(assignment *proto.UserRoleAssignment) {
+	idx.assignments[assignment.Id] = assignment
+	idx.assignmentIDs[assignment.AccountUuid] = append(idx.assignmentIDs[assignment.AccountUuid], assignment.Id)
+}
+
+// removeAssignment removes the role assignment from the index. The caller needs to hold the lock.
+func (idx *index) removeAssignment(assignmentID string) {
+	if existing, ok := idx.assignments[assignmentID]; ok {
+		idx.assignmentIDs[existing.AccountUuid] = removeStr(idx.assignmentIDs[existing.AccountUuid], assignmentID)
+		delete(idx.assignments, assignmentID)
+	}
+}
+
+// assignmentsOf returns copies of the role assignments of the given account, sorted by id. The caller needs to hold
+// the lock.
+func (idx *index) assignmentsOf(accountUUID string) []*proto.UserRoleAssignment {
+	assignments := make([]*proto.UserRoleAssignment, 0, len(idx.assignmentIDs[accountUUID]))
+	for _, assignmentID := range idx.assignmentIDs[accountUUID] {
+		assignments = append(assignments, protobuf.Clone(idx.assignments[assignmentID]).(*proto.UserRoleAssignment))
+	}
+	sortAssignments(assignments)
+	return assignments
+}
+
+// filterAssignments returns copies of all role assignments that match the filter, sorted by id.
+func (idx *index) filterAssignments(filter func(*proto.UserRoleAssignment) bool) []*proto.UserRoleAssignment {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	assignments := make([]*proto.UserRoleAssignment, 0)
+	for _, assignment := range idx.assignments {
+		if filter(assignment) {
+			assignments = append(assignments, protobuf.Clone(assignment).(*proto.UserRoleAssignment))
+		}
+	}
+	sortAssignments(assignments)
+	return assignments
+}
+
+func sortAssignments(assignments []*proto.UserRoleAssignment) {
+	sort.Slice(assignments, func(i, j int) bool { return assignments[i].Id < assignments[j].Id })
+}
+
+// removeStr removes the first occurrence of str from the strs slice
+func removeStr(strs []string, str string) []string {
+	for i, s := range strs {
+		if s == str {
+			return append(strs[:i:i], strs[i+1:]...)
+		}
+	}
+	return strs
+}
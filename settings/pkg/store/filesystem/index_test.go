@@ -0,0 +1,76 @@
+package store
+
+import (
+	"testing"
+
+	olog "github.com/owncloud/ocis/ocis-pkg/log"
+	"github.com/owncloud/ocis/settings/pkg/proto/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex(t *testing.T) {
+	s := Store{dataPath: dataRoot, Logger: olog.NewLogger(olog.Level("info"))}
+	// a second store of the same data path, like the ones of the http and the grpc server
+	other := Store{dataPath: dataRoot, Logger: olog.NewLogger(olog.Level("info"))}
+	defer burnRoot()
+
+	value, err := s.WriteValue(&proto.Value{
+		BundleId:    bundle1,
+		SettingId:   setting1,
+		AccountUuid: accountUUID1,
+		Resource:    &proto.Resource{Type: proto.Resource_TYPE_USER},
+		Value:       &proto.Value_StringValue{StringValue: "de"},
+	})
+	require.NoError(t, err)
+	assignment, err := s.WriteRoleAssignment(accountUUID1, bundle2)
+	require.NoError(t, err)
+
+	t.Run("writes are visible to other stores", func(t *testing.T) {
+		read, err := other.ReadValueByUniqueIdentifiers(accountUUID1, setting1)
+		require.NoError(t, err)
+		assert.Equal(t, "de", read.GetStringValue())
+		assignments, err := other.ListRoleAssignments(accountUUID1)
+		require.NoError(t, err)
+		assert.Len(t, assignments, 1)
+	})
+
+	t.Run("returned records don't change the index", func(t *testing.T) {
+		read, err := s.ReadValue(value.Id)
+		require.NoError(t, err)
+		read.Value = &proto.Value_StringValue{StringValue: "en"}
+		read, err = s.ReadValue(value.Id)
+		require.NoError(t, err)
+		assert.Equal(t, "de", read.GetStringValue())
+	})
+
+	t.Run("rewriting a value moves it in the index", func(t *testing.T) {
+		value.SettingId = setting2
+		_, err := s.WriteValue(value)
+		require.NoError(t, err)
+		_, err = s.ReadValueByUniqueIdentifiers(accountUUID1, setting1)
+		assert.Error(t, err)
+		read, err := s.ReadValueByUniqueIdentifiers(accountUUID1, setting2)
+		require.NoError(t, err)
+		assert.Equal(t, value.Id, read.Id)
+	})
+
+	t.Run("the index is rebuilt from disk", func(t *testing.T) {
+		dropIndex(dataRoot)
+		values, err := s.ListValues(bundle1, accountUUID1)
+		require.NoError(t, err)
+		if assert.Len(t, values, 1) {
+			assert.Equal(t, setting2, values[0].SettingId)
+		}
+		assignments, err := s.ListRoleAssignmentsByRole(bundle2)
+		require.NoError(t, err)
+		assert.Len(t, assignments, 1)
+	})
+
+	t.Run("removed assignments are removed from the index", func(t *testing.T) {
+		require.NoError(t, other.RemoveRoleAssignment(assignment.Id))
+		assignments, err := s.ListRoleAssignments(accountUUID1)
+		require.NoError(t, err)
+		assert.Empty(t, assignments)
+	})
+}
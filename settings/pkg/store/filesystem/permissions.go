@@ -14,13 +14,15 @@ func (s Store) ListPermissionsByResource(resource *proto.Resource, roleIDs []str
 			s.Logger.Debug().Str("roleID", roleID).Msg("role not found, skipping")
 			continue
 		}
-		records = append(records, extractPermissionsByResource(resource, role)...)
+		records = append(records, util.ExtractPermissionsByResource(resource, role)...)
 	}
 	return records, nil
 }
 
-// ReadPermissionByID finds the permission in the roles, specified by the provided roleIDs
+// ReadPermissionByID finds the permission in the roles, specified by the provided roleIDs.
+// If more than one of the roles grants the permission, the union of the granted permissions is returned.
 func (s Store) ReadPermissionByID(permissionID string, roleIDs []string) (*proto.Permission, error) {
+	var result *proto.Permission
 	for _, roleID := range roleIDs {
 		role, err := s.ReadBundle(roleID)
 		if err != nil {
@@ -30,23 +32,10 @@ func (s Store) ReadPermissionByID(permissionID string, roleIDs []string) (*proto
 		for _, permission := range role.Settings {
 			if permission.Id == permissionID {
 				if value, ok := permission.Value.(*proto.Setting_PermissionValue); ok {
-					return value.PermissionValue, nil
+					result = util.UnionPermissions(result, value.PermissionValue)
 				}
 			}
 		}
 	}
-	return nil, nil
-}
-
-// extractPermissionsByResource collects all permissions from the provided role that match the requested resource
-func extractPermissionsByResource(resource *proto.Resource, role *proto.Bundle) []*proto.Permission {
-	permissions := make([]*proto.Permission, 0)
-	for _, setting := range role.Settings {
-		if value, ok := setting.Value.(*proto.Setting_PermissionValue); ok {
-			if util.IsResourceMatched(setting.Resource, resource) {
-				permissions = append(permissions, value.PermissionValue)
-			}
-		}
-	}
-	return permissions
+	return result, nil
 }
@@ -41,6 +41,8 @@ func New(cfg *config.Config) settings.Manager {
 	}
 
 	s.dataPath = cfg.Service.DataPath
+	// build the index of values and role assignments at startup instead of on the first request
+	s.index()
 	return &s
 }
 
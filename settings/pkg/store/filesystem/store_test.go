@@ -34,6 +34,8 @@ const (
 )
 
 func burnRoot() {
+	dropIndex(dataRoot)
 	os.RemoveAll(filepath.Join(dataRoot, "values"))
 	os.RemoveAll(filepath.Join(dataRoot, "bundles"))
+	os.RemoveAll(filepath.Join(dataRoot, "assignments"))
 }
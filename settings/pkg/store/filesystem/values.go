@@ -3,10 +3,9 @@ package store
 
 import (
 	"fmt"
-	"io/ioutil"
-	"path/filepath"
 
 	"github.com/gofrs/uuid"
+	protobuf "github.com/golang/protobuf/proto"
 	"github.com/owncloud/ocis/settings/pkg/proto/v0"
 )
 
@@ -14,77 +13,56 @@ import (
 // If the bundleId is empty, it's ignored for filtering.
 // If the accountUUID is empty, only values with empty accountUUID are returned.
 // If the accountUUID is not empty, values with an empty or with a matching accountUUID are returned.
+// Values of groups are never returned, see ListGroupValues.
 func (s Store) ListValues(bundleID, accountUUID string) ([]*proto.Value, error) {
-	valuesFolder := s.buildFolderPathForValues(false)
-	valueFiles, err := ioutil.ReadDir(valuesFolder)
-	if err != nil {
-		return []*proto.Value{}, nil
+	accountUUIDs := []string{""}
+	if accountUUID != "" {
+		accountUUIDs = append(accountUUIDs, accountUUID)
 	}
+	return s.index().valuesOf(accountUUIDs, func(value *proto.Value) bool {
+		return (bundleID == "" || value.BundleId == bundleID) && !isGroupValue(value)
+	}), nil
+}
 
-	records := make([]*proto.Value, 0, len(valueFiles))
-	for _, valueFile := range valueFiles {
-		record := proto.Value{}
-		err := s.parseRecordFromFile(&record, filepath.Join(valuesFolder, valueFile.Name()))
-		if err != nil {
-			s.Logger.Warn().Msgf("error reading %v", valueFile)
-			continue
-		}
-		if bundleID != "" && record.BundleId != bundleID {
-			continue
-		}
-		// if requested accountUUID empty -> fetch all system level values
-		if accountUUID == "" && record.AccountUuid != "" {
-			continue
-		}
-		// if requested accountUUID empty -> fetch all individual + all system level values
-		if accountUUID != "" && record.AccountUuid != "" && record.AccountUuid != accountUUID {
-			continue
-		}
-		records = append(records, &record)
+// ListGroupValues reads all values of the given groups that match the given bundleId.
+// If the bundleId is empty, it's ignored for filtering.
+func (s Store) ListGroupValues(bundleID string, groupIDs []string) ([]*proto.Value, error) {
+	groups := make(map[string]bool, len(groupIDs))
+	for _, groupID := range groupIDs {
+		groups[groupID] = true
 	}
+	return s.index().valuesOf([]string{""}, func(value *proto.Value) bool {
+		return (bundleID == "" || value.BundleId == bundleID) && isGroupValue(value) && groups[value.Resource.Id]
+	}), nil
+}
 
-	return records, nil
+func isGroupValue(value *proto.Value) bool {
+	return value.Resource != nil && value.Resource.Type == proto.Resource_TYPE_GROUP
 }
 
 // ReadValue tries to find a value by the given valueId within the dataPath
 func (s Store) ReadValue(valueID string) (*proto.Value, error) {
-	filePath := s.buildFilePathForValue(valueID, false)
-	record := proto.Value{}
-	if err := s.parseRecordFromFile(&record, filePath); err != nil {
-		return nil, err
+	if value, ok := s.index().value(valueID); ok {
+		return value, nil
 	}
-
-	s.Logger.Debug().Msgf("read contents from file: %v", filePath)
-	return &record, nil
+	return nil, fmt.Errorf("could not read value: %v", valueID)
 }
 
-// ReadValueByUniqueIdentifiers tries to find a value given a set of unique identifiers
+// ReadValueByUniqueIdentifiers tries to find a value given a set of unique identifiers.
+// The value of the account wins over the value of the system.
 func (s Store) ReadValueByUniqueIdentifiers(accountUUID, settingID string) (*proto.Value, error) {
-	valuesFolder := s.buildFolderPathForValues(false)
-	files, err := ioutil.ReadDir(valuesFolder)
-	if err != nil {
-		return nil, err
+	idx := s.index()
+	notGroupValue := func(value *proto.Value) bool {
+		return !isGroupValue(value)
 	}
-	for i := range files {
-		if !files[i].IsDir() {
-			r := proto.Value{}
-			s.Logger.Debug().Msgf("reading contents from file: %v", filepath.Join(valuesFolder, files[i].Name()))
-			if err := s.parseRecordFromFile(&r, filepath.Join(valuesFolder, files[i].Name())); err != nil {
-				s.Logger.Debug().Msgf("match found: %v", filepath.Join(valuesFolder, files[i].Name()))
-				return &proto.Value{}, nil
-			}
-
-			// if value saved without accountUUID, then it's a global value
-			if r.AccountUuid == "" && r.SettingId == settingID {
-				return &r, nil
-			}
-			// if value saved with accountUUID, then it's a user specific value
-			if r.AccountUuid == accountUUID && r.SettingId == settingID {
-				return &r, nil
-			}
+	if accountUUID != "" {
+		if value, ok := idx.valueOfSetting(accountUUID, settingID, notGroupValue); ok {
+			return value, nil
 		}
 	}
-
+	if value, ok := idx.valueOfSetting("", settingID, notGroupValue); ok {
+		return value, nil
+	}
 	return nil, fmt.Errorf("could not read value by settingID=%v and accountID=%v", settingID, accountUUID)
 }
 
@@ -96,14 +74,18 @@ func (s Store) WriteValue(value *proto.Value) (*proto.Value, error) {
 	}
 
 	// modify value depending on associated resource
-	if value.Resource.Type == proto.Resource_TYPE_SYSTEM {
+	if value.Resource.Type == proto.Resource_TYPE_SYSTEM || value.Resource.Type == proto.Resource_TYPE_GROUP {
 		value.AccountUuid = ""
 	}
 
 	// write the value
+	idx := s.index()
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
 	filePath := s.buildFilePathForValue(value.Id, true)
 	if err := s.writeRecordToFile(value, filePath); err != nil {
 		return nil, err
 	}
+	idx.putValue(protobuf.Clone(value).(*proto.Value))
 	return value, nil
 }
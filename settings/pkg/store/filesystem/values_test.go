@@ -68,3 +68,64 @@ func TestValues(t *testing.T) {
 
 	burnRoot()
 }
+
+func TestGroupValues(t *testing.T) {
+	s := Store{
+		dataPath: dataRoot,
+		Logger: olog.NewLogger(
+			olog.Color(true),
+			olog.Pretty(true),
+			olog.Level("info"),
+		),
+	}
+	defer burnRoot()
+
+	for _, value := range []*proto.Value{
+		{
+			BundleId:    bundle1,
+			SettingId:   setting1,
+			AccountUuid: accountUUID1,
+			Resource:    &proto.Resource{Type: proto.Resource_TYPE_USER},
+		},
+		{
+			BundleId:  bundle1,
+			SettingId: setting1,
+			Resource:  &proto.Resource{Type: proto.Resource_TYPE_SYSTEM},
+		},
+		{
+			BundleId:    bundle1,
+			SettingId:   setting1,
+			AccountUuid: accountUUID1,
+			Resource:    &proto.Resource{Type: proto.Resource_TYPE_GROUP, Id: "sales"},
+			Locked:      true,
+		},
+		{
+			BundleId:  bundle2,
+			SettingId: setting2,
+			Resource:  &proto.Resource{Type: proto.Resource_TYPE_GROUP, Id: "support"},
+		},
+	} {
+		_, err := s.WriteValue(value)
+		assert.NoError(t, err)
+	}
+
+	values, err := s.ListValues(bundle1, accountUUID1)
+	assert.NoError(t, err)
+	assert.Len(t, values, 2)
+
+	values, err = s.ListGroupValues(bundle1, []string{"sales", "support"})
+	assert.NoError(t, err)
+	if assert.Len(t, values, 1) {
+		assert.Equal(t, "sales", values[0].Resource.Id)
+		assert.Empty(t, values[0].AccountUuid)
+		assert.True(t, values[0].Locked)
+	}
+
+	values, err = s.ListGroupValues("", []string{"support"})
+	assert.NoError(t, err)
+	assert.Len(t, values, 1)
+
+	values, err = s.ListGroupValues("", []string{})
+	assert.NoError(t, err)
+	assert.Empty(t, values)
+}
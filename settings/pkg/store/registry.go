@@ -1,6 +1,8 @@
 package store
 
 import (
+	// init bolt store
+	_ "github.com/owncloud/ocis/settings/pkg/store/bolt"
 	// init filesystem store
 	_ "github.com/owncloud/ocis/settings/pkg/store/filesystem"
 )
@@ -0,0 +1,65 @@
+package util
+
+import (
+	"math/bits"
+
+	"github.com/owncloud/ocis/settings/pkg/proto/v0"
+)
+
+// operationSets maps the permission operations to the basic operations they consist of.
+var operationSets = map[proto.Permission_Operation]uint{
+	proto.Permission_OPERATION_UNKNOWN:   0,
+	proto.Permission_OPERATION_CREATE:    1 << 0,
+	proto.Permission_OPERATION_READ:      1 << 1,
+	proto.Permission_OPERATION_UPDATE:    1 << 2,
+	proto.Permission_OPERATION_DELETE:    1 << 3,
+	proto.Permission_OPERATION_WRITE:     1<<0 | 1<<2,
+	proto.Permission_OPERATION_READWRITE: 1<<0 | 1<<1 | 1<<2,
+}
+
+// UnionPermissions merges two permissions granted by different roles into one.
+// The operations are combined where the result can be expressed as a single operation, otherwise the
+// broader operation wins. CONSTRAINT_ALL wins over any other constraint.
+func UnionPermissions(a, b *proto.Permission) *proto.Permission {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	result := &proto.Permission{
+		Operation:  a.Operation,
+		Constraint: a.Constraint,
+	}
+
+	union := operationSets[a.Operation] | operationSets[b.Operation]
+	combined := false
+	for operation, set := range operationSets {
+		if set == union {
+			result.Operation = operation
+			combined = true
+			break
+		}
+	}
+	if !combined && bits.OnesCount(operationSets[b.Operation]) > bits.OnesCount(operationSets[a.Operation]) {
+		result.Operation = b.Operation
+	}
+
+	if a.Constraint == proto.Permission_CONSTRAINT_UNKNOWN || b.Constraint == proto.Permission_CONSTRAINT_ALL {
+		result.Constraint = b.Constraint
+	}
+	return result
+}
+
+// ExtractPermissionsByResource collects all permissions from the provided role that match the requested resource
+func ExtractPermissionsByResource(resource *proto.Resource, role *proto.Bundle) []*proto.Permission {
+	permissions := make([]*proto.Permission, 0)
+	for _, setting := range role.Settings {
+		if value, ok := setting.Value.(*proto.Setting_PermissionValue); ok {
+			if IsResourceMatched(setting.Resource, resource) {
+				permissions = append(permissions, value.PermissionValue)
+			}
+		}
+	}
+	return permissions
+}
@@ -0,0 +1,61 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/owncloud/ocis/settings/pkg/proto/v0"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnionPermissions(t *testing.T) {
+	var scenarios = []struct {
+		name     string
+		a        *proto.Permission
+		b        *proto.Permission
+		expected *proto.Permission
+	}{
+		{
+			"only one permission",
+			nil,
+			&proto.Permission{Operation: proto.Permission_OPERATION_READ, Constraint: proto.Permission_CONSTRAINT_OWN},
+			&proto.Permission{Operation: proto.Permission_OPERATION_READ, Constraint: proto.Permission_CONSTRAINT_OWN},
+		},
+		{
+			"read and write become readwrite",
+			&proto.Permission{Operation: proto.Permission_OPERATION_READ, Constraint: proto.Permission_CONSTRAINT_ALL},
+			&proto.Permission{Operation: proto.Permission_OPERATION_WRITE, Constraint: proto.Permission_CONSTRAINT_ALL},
+			&proto.Permission{Operation: proto.Permission_OPERATION_READWRITE, Constraint: proto.Permission_CONSTRAINT_ALL},
+		},
+		{
+			"create and update become write",
+			&proto.Permission{Operation: proto.Permission_OPERATION_CREATE, Constraint: proto.Permission_CONSTRAINT_OWN},
+			&proto.Permission{Operation: proto.Permission_OPERATION_UPDATE, Constraint: proto.Permission_CONSTRAINT_OWN},
+			&proto.Permission{Operation: proto.Permission_OPERATION_WRITE, Constraint: proto.Permission_CONSTRAINT_OWN},
+		},
+		{
+			"read is contained in readwrite",
+			&proto.Permission{Operation: proto.Permission_OPERATION_READWRITE, Constraint: proto.Permission_CONSTRAINT_OWN},
+			&proto.Permission{Operation: proto.Permission_OPERATION_READ, Constraint: proto.Permission_CONSTRAINT_OWN},
+			&proto.Permission{Operation: proto.Permission_OPERATION_READWRITE, Constraint: proto.Permission_CONSTRAINT_OWN},
+		},
+		{
+			"the broader operation wins if they can't be combined",
+			&proto.Permission{Operation: proto.Permission_OPERATION_DELETE, Constraint: proto.Permission_CONSTRAINT_OWN},
+			&proto.Permission{Operation: proto.Permission_OPERATION_READWRITE, Constraint: proto.Permission_CONSTRAINT_OWN},
+			&proto.Permission{Operation: proto.Permission_OPERATION_READWRITE, Constraint: proto.Permission_CONSTRAINT_OWN},
+		},
+		{
+			"constraint all wins",
+			&proto.Permission{Operation: proto.Permission_OPERATION_READ, Constraint: proto.Permission_CONSTRAINT_ALL},
+			&proto.Permission{Operation: proto.Permission_OPERATION_READ, Constraint: proto.Permission_CONSTRAINT_OWN},
+			&proto.Permission{Operation: proto.Permission_OPERATION_READ, Constraint: proto.Permission_CONSTRAINT_ALL},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(t *testing.T) {
+			assert.Equal(t, scenario.expected, UnionPermissions(scenario.a, scenario.b))
+		})
+	}
+}
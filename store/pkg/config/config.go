@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 // Log defines the available logging configuration.
 type Log struct {
 	Level  string
@@ -28,6 +30,16 @@ type Service struct {
 	Version   string
 }
 
+// Expiry defines the available expiry configuration.
+type Expiry struct {
+	SweepInterval time.Duration
+}
+
+// Watch defines the available watch configuration.
+type Watch struct {
+	History int
+}
+
 // Tracing defines the available tracing configuration.
 type Tracing struct {
 	Enabled   bool
@@ -37,15 +49,23 @@ type Tracing struct {
 	Service   string
 }
 
+// TokenManager is the config for verifying the service tokens of internal requests
+type TokenManager struct {
+	JWTSecret string
+}
+
 // Config combines all available configuration parts.
 type Config struct {
-	File     string
-	Log      Log
-	Debug    Debug
-	GRPC     GRPC
-	Tracing  Tracing
-	Datapath string
-	Service  Service
+	File         string
+	Log          Log
+	Debug        Debug
+	GRPC         GRPC
+	Tracing      Tracing
+	Datapath     string
+	Service      Service
+	Expiry       Expiry
+	Watch        Watch
+	TokenManager TokenManager
 }
 
 // New initializes a new configuration with or without defaults.
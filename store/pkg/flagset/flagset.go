@@ -1,6 +1,8 @@
 package flagset
 
 import (
+	"time"
+
 	"github.com/micro/cli/v2"
 	"github.com/owncloud/ocis/store/pkg/config"
 )
@@ -136,6 +138,27 @@ func ServerWithConfig(cfg *config.Config) []cli.Flag {
 			EnvVars:     []string{"STORE_DATA_PATH"},
 			Destination: &cfg.Datapath,
 		},
+		&cli.DurationFlag{
+			Name:        "sweep-interval",
+			Value:       time.Minute,
+			Usage:       "Interval in which expired records get deleted, 0 disables the sweeper",
+			EnvVars:     []string{"STORE_SWEEP_INTERVAL"},
+			Destination: &cfg.Expiry.SweepInterval,
+		},
+		&cli.IntFlag{
+			Name:        "watch-history",
+			Value:       1000,
+			Usage:       "Number of changes kept to resume watches",
+			EnvVars:     []string{"STORE_WATCH_HISTORY"},
+			Destination: &cfg.Watch.History,
+		},
+		&cli.StringFlag{
+			Name:        "jwt-secret",
+			Value:       "Pive-Fumkiu4",
+			Usage:       "Used to verify the service tokens of internal requests, should equal the jwt-secret of the other services",
+			EnvVars:     []string{"STORE_JWT_SECRET", "OCIS_JWT_SECRET"},
+			Destination: &cfg.TokenManager.JWTSecret,
+		},
 	}
 }
 
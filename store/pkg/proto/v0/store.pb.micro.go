@@ -40,6 +40,10 @@ type StoreService interface {
 	List(ctx context.Context, in *ListRequest, opts ...client.CallOption) (Store_ListService, error)
 	Databases(ctx context.Context, in *DatabasesRequest, opts ...client.CallOption) (*DatabasesResponse, error)
 	Tables(ctx context.Context, in *TablesRequest, opts ...client.CallOption) (*TablesResponse, error)
+	Batch(ctx context.Context, in *BatchRequest, opts ...client.CallOption) (*BatchResponse, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...client.CallOption) (Store_WatchService, error)
+	Snapshot(ctx context.Context, in *SnapshotRequest, opts ...client.CallOption) (Store_SnapshotService, error)
+	Restore(ctx context.Context, in *RestoreRequest, opts ...client.CallOption) (*RestoreResponse, error)
 }
 
 type storeService struct {
@@ -153,6 +157,124 @@ func (c *storeService) Tables(ctx context.Context, in *TablesRequest, opts ...cl
 	return out, nil
 }
 
+func (c *storeService) Batch(ctx context.Context, in *BatchRequest, opts ...client.CallOption) (*BatchResponse, error) {
+	req := c.c.NewRequest(c.name, "Store.Batch", in)
+	out := new(BatchResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeService) Watch(ctx context.Context, in *WatchRequest, opts ...client.CallOption) (Store_WatchService, error) {
+	req := c.c.NewRequest(c.name, "Store.Watch", &WatchRequest{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &storeServiceWatch{stream}, nil
+}
+
+type Store_WatchService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Recv() (*WatchEvent, error)
+}
+
+type storeServiceWatch struct {
+	stream client.Stream
+}
+
+func (x *storeServiceWatch) Close() error {
+	return x.stream.Close()
+}
+
+func (x *storeServiceWatch) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *storeServiceWatch) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *storeServiceWatch) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *storeServiceWatch) Recv() (*WatchEvent, error) {
+	m := new(WatchEvent)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storeService) Snapshot(ctx context.Context, in *SnapshotRequest, opts ...client.CallOption) (Store_SnapshotService, error) {
+	req := c.c.NewRequest(c.name, "Store.Snapshot", &SnapshotRequest{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &storeServiceSnapshot{stream}, nil
+}
+
+type Store_SnapshotService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Recv() (*SnapshotResponse, error)
+}
+
+type storeServiceSnapshot struct {
+	stream client.Stream
+}
+
+func (x *storeServiceSnapshot) Close() error {
+	return x.stream.Close()
+}
+
+func (x *storeServiceSnapshot) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *storeServiceSnapshot) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *storeServiceSnapshot) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *storeServiceSnapshot) Recv() (*SnapshotResponse, error) {
+	m := new(SnapshotResponse)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storeService) Restore(ctx context.Context, in *RestoreRequest, opts ...client.CallOption) (*RestoreResponse, error) {
+	req := c.c.NewRequest(c.name, "Store.Restore", in)
+	out := new(RestoreResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Store service
 
 type StoreHandler interface {
@@ -162,6 +284,10 @@ type StoreHandler interface {
 	List(context.Context, *ListRequest, Store_ListStream) error
 	Databases(context.Context, *DatabasesRequest, *DatabasesResponse) error
 	Tables(context.Context, *TablesRequest, *TablesResponse) error
+	Batch(context.Context, *BatchRequest, *BatchResponse) error
+	Watch(context.Context, *WatchRequest, Store_WatchStream) error
+	Snapshot(context.Context, *SnapshotRequest, Store_SnapshotStream) error
+	Restore(context.Context, *RestoreRequest, *RestoreResponse) error
 }
 
 func RegisterStoreHandler(s server.Server, hdlr StoreHandler, opts ...server.HandlerOption) error {
@@ -172,6 +298,10 @@ func RegisterStoreHandler(s server.Server, hdlr StoreHandler, opts ...server.Han
 		List(ctx context.Context, stream server.Stream) error
 		Databases(ctx context.Context, in *DatabasesRequest, out *DatabasesResponse) error
 		Tables(ctx context.Context, in *TablesRequest, out *TablesResponse) error
+		Batch(ctx context.Context, in *BatchRequest, out *BatchResponse) error
+		Watch(ctx context.Context, stream server.Stream) error
+		Snapshot(ctx context.Context, stream server.Stream) error
+		Restore(ctx context.Context, in *RestoreRequest, out *RestoreResponse) error
 	}
 	type Store struct {
 		store
@@ -243,3 +373,91 @@ func (h *storeHandler) Databases(ctx context.Context, in *DatabasesRequest, out
 func (h *storeHandler) Tables(ctx context.Context, in *TablesRequest, out *TablesResponse) error {
 	return h.StoreHandler.Tables(ctx, in, out)
 }
+
+func (h *storeHandler) Batch(ctx context.Context, in *BatchRequest, out *BatchResponse) error {
+	return h.StoreHandler.Batch(ctx, in, out)
+}
+
+func (h *storeHandler) Watch(ctx context.Context, stream server.Stream) error {
+	m := new(WatchRequest)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.StoreHandler.Watch(ctx, m, &storeWatchStream{stream})
+}
+
+type Store_WatchStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*WatchEvent) error
+}
+
+type storeWatchStream struct {
+	stream server.Stream
+}
+
+func (x *storeWatchStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *storeWatchStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *storeWatchStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *storeWatchStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *storeWatchStream) Send(m *WatchEvent) error {
+	return x.stream.Send(m)
+}
+
+func (h *storeHandler) Snapshot(ctx context.Context, stream server.Stream) error {
+	m := new(SnapshotRequest)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.StoreHandler.Snapshot(ctx, m, &storeSnapshotStream{stream})
+}
+
+type Store_SnapshotStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*SnapshotResponse) error
+}
+
+type storeSnapshotStream struct {
+	stream server.Stream
+}
+
+func (x *storeSnapshotStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *storeSnapshotStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *storeSnapshotStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *storeSnapshotStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *storeSnapshotStream) Send(m *SnapshotResponse) error {
+	return x.stream.Send(m)
+}
+
+func (h *storeHandler) Restore(ctx context.Context, in *RestoreRequest, out *RestoreResponse) error {
+	return h.StoreHandler.Restore(ctx, in, out)
+}
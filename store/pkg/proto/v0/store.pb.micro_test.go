@@ -0,0 +1,672 @@
+package proto_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	merrors "github.com/micro/go-micro/v2/errors"
+	ocislog "github.com/owncloud/ocis/ocis-pkg/log"
+	"github.com/owncloud/ocis/ocis-pkg/middleware"
+	"github.com/owncloud/ocis/ocis-pkg/service/grpc"
+	"github.com/owncloud/ocis/store/pkg/config"
+	"github.com/owncloud/ocis/store/pkg/proto/v0"
+	svc "github.com/owncloud/ocis/store/pkg/service/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/status"
+)
+
+var service = grpc.Service{}
+
+const dataPath = "/var/tmp/grpc-tests-ocis-store"
+
+const jwtSecret = "store-test-secret"
+
+func init() {
+	service = grpc.NewService(
+		grpc.Namespace("com.owncloud.api"),
+		grpc.Name("store"),
+		grpc.Address("localhost:9461"),
+	)
+
+	_ = os.RemoveAll(dataPath)
+	cfg := config.New()
+	cfg.Datapath = dataPath
+	cfg.Service.Namespace = "com.owncloud.api"
+	cfg.Service.Name = "store"
+	cfg.Expiry.SweepInterval = 100 * time.Millisecond
+	cfg.Watch.History = 50
+	cfg.TokenManager.JWTSecret = jwtSecret
+
+	hdlr, err := svc.New(svc.Logger(ocislog.NewLogger(ocislog.Color(true), ocislog.Pretty(true))), svc.Config(cfg))
+	if err != nil {
+		log.Fatalf("could not create store service: %v", err)
+	}
+	if err = proto.RegisterStoreHandler(service.Server(), hdlr); err != nil {
+		log.Fatalf("could not register StoreHandler: %v", err)
+	}
+	if err = service.Server().Start(); err != nil {
+		log.Fatalf("could not start server: %v", err)
+	}
+}
+
+func newClient() proto.StoreService {
+	return proto.NewStoreService("com.owncloud.api.store", service.Client())
+}
+
+func write(t *testing.T, table string, record *proto.Record, options *proto.WriteOptions) {
+	if options == nil {
+		options = &proto.WriteOptions{}
+	}
+	options.Database = "tests"
+	options.Table = table
+	_, err := newClient().Write(context.Background(), &proto.WriteRequest{Record: record, Options: options})
+	assert.NoError(t, err)
+}
+
+func read(table, key string) (*proto.ReadResponse, error) {
+	return newClient().Read(context.Background(), &proto.ReadRequest{
+		Key:     key,
+		Options: &proto.ReadOptions{Database: "tests", Table: table},
+	})
+}
+
+func list(t *testing.T, options *proto.ListOptions) (keys []string, messages int) {
+	options.Database = "tests"
+	stream, err := newClient().List(context.Background(), &proto.ListRequest{Options: options})
+	if !assert.NoError(t, err) {
+		return nil, 0
+	}
+	defer stream.Close()
+
+	keys = []string{}
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			return keys, messages
+		}
+		if !assert.NoError(t, err) {
+			return keys, messages
+		}
+		keys = append(keys, rsp.Keys...)
+		messages++
+	}
+}
+
+func assertNotFound(t *testing.T, err error) {
+	if assert.Error(t, err) {
+		assert.Equal(t, int32(404), merrors.FromError(err).Code)
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	past := time.Now().Add(-time.Minute).Unix()
+	scenarios := []struct {
+		name    string
+		record  *proto.Record
+		options *proto.WriteOptions
+		// expiry is the expected expiry of the read record, -1 if the record is expired
+		expiry int64
+	}{
+		{
+			name:   "record without expiry",
+			record: &proto.Record{Key: "none"},
+			expiry: 0,
+		},
+		{
+			name:   "expiry of the record",
+			record: &proto.Record{Key: "record", Expiry: 3600},
+			expiry: 3600,
+		},
+		{
+			name:    "expiry option",
+			record:  &proto.Record{Key: "option", Expiry: 3600},
+			options: &proto.WriteOptions{Expiry: time.Now().Add(time.Hour * 2).Unix()},
+			expiry:  7200,
+		},
+		{
+			name:    "expired by expiry option",
+			record:  &proto.Record{Key: "expired-option", Expiry: 3600},
+			options: &proto.WriteOptions{Expiry: past},
+			expiry:  -1,
+		},
+		{
+			name:    "ttl wins over expiry option",
+			record:  &proto.Record{Key: "ttl"},
+			options: &proto.WriteOptions{Expiry: past, Ttl: 60},
+			expiry:  60,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			write(t, "expiry", scenario.record, scenario.options)
+			rsp, err := read("expiry", scenario.record.Key)
+			if scenario.expiry < 0 {
+				assertNotFound(t, err)
+				return
+			}
+			if assert.NoError(t, err) && assert.Len(t, rsp.Records, 1) {
+				assert.InDelta(t, scenario.expiry, rsp.Records[0].Expiry, 1)
+			}
+		})
+	}
+}
+
+func TestLazyExpiry(t *testing.T) {
+	write(t, "lazy", &proto.Record{Key: "key", Value: []byte("value")}, &proto.WriteOptions{Ttl: 1})
+	rsp, err := read("lazy", "key")
+	if assert.NoError(t, err) && assert.Len(t, rsp.Records, 1) {
+		assert.Equal(t, []byte("value"), rsp.Records[0].Value)
+	}
+
+	time.Sleep(2 * time.Second)
+	_, err = read("lazy", "key")
+	assertNotFound(t, err)
+}
+
+func TestExpiredRecordsAreNotQueried(t *testing.T) {
+	where := map[string]*proto.Field{"color": {Type: "string", Value: "blue"}}
+	write(t, "query", &proto.Record{Key: "alive", Metadata: where}, nil)
+	write(t, "query", &proto.Record{Key: "expired", Metadata: where}, &proto.WriteOptions{Expiry: 1})
+
+	rsp, err := newClient().Read(context.Background(), &proto.ReadRequest{
+		Options: &proto.ReadOptions{Database: "tests", Table: "query", Where: where},
+	})
+	if assert.NoError(t, err) && assert.Len(t, rsp.Records, 1) {
+		assert.Equal(t, "alive", rsp.Records[0].Key)
+	}
+}
+
+func TestReadWherePages(t *testing.T) {
+	where := map[string]*proto.Field{"owner": {Type: "string", Value: "pages"}}
+	var expected []string
+	// written in reverse order, the matches are returned ordered by their id
+	for i := 24; i >= 0; i-- {
+		write(t, "where-pages", &proto.Record{Key: fmt.Sprintf("record-%02d", i), Metadata: where}, nil)
+	}
+	for i := 0; i < 25; i++ {
+		expected = append(expected, fmt.Sprintf("record-%02d", i))
+	}
+	// records of other tables and with other metadata don't match
+	write(t, "where-other", &proto.Record{Key: "record-99", Metadata: where}, nil)
+	write(t, "where-pages", &proto.Record{Key: "record-98", Metadata: map[string]*proto.Field{"owner": {Type: "string", Value: "other"}}}, nil)
+
+	query := func(limit, offset uint64) []string {
+		rsp, err := newClient().Read(context.Background(), &proto.ReadRequest{
+			Options: &proto.ReadOptions{Database: "tests", Table: "where-pages", Where: where, Limit: limit, Offset: offset},
+		})
+		require.NoError(t, err)
+		keys := []string{}
+		for _, rec := range rsp.Records {
+			keys = append(keys, rec.Key)
+		}
+		return keys
+	}
+
+	// without a limit all matches are returned, not only the first page of the index
+	assert.Equal(t, expected, query(0, 0))
+	assert.Equal(t, expected[20:], query(0, 20))
+
+	var paged []string
+	for offset := uint64(0); offset < 30; offset += 10 {
+		paged = append(paged, query(10, offset)...)
+	}
+	assert.Equal(t, expected, paged)
+	assert.Empty(t, query(10, 30))
+}
+
+func TestSweeper(t *testing.T) {
+	write(t, "sweep", &proto.Record{Key: "expired"}, &proto.WriteOptions{Expiry: 1})
+	write(t, "sweep", &proto.Record{Key: "alive"}, nil)
+
+	dir := filepath.Join(dataPath, "databases", "tests", "sweep")
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(filepath.Join(dir, "expired"))
+		return os.IsNotExist(err)
+	}, 2*time.Second, 50*time.Millisecond)
+	assert.FileExists(t, filepath.Join(dir, "alive"))
+}
+
+func TestList(t *testing.T) {
+	for _, key := range []string{"b1", "a2", "a1", "a3", "c1", "dir/a1"} {
+		write(t, "list", &proto.Record{Key: key}, nil)
+	}
+	write(t, "list", &proto.Record{Key: "a0"}, &proto.WriteOptions{Expiry: 1})
+
+	scenarios := []struct {
+		name    string
+		options *proto.ListOptions
+		keys    []string
+	}{
+		{
+			name:    "all keys",
+			options: &proto.ListOptions{},
+			keys:    []string{"a1", "a2", "a3", "b1", "c1", "dir/a1"},
+		},
+		{
+			name:    "prefix",
+			options: &proto.ListOptions{Prefix: "a"},
+			keys:    []string{"a1", "a2", "a3"},
+		},
+		{
+			name:    "suffix",
+			options: &proto.ListOptions{Suffix: "1"},
+			keys:    []string{"a1", "b1", "c1", "dir/a1"},
+		},
+		{
+			name:    "limit and offset",
+			options: &proto.ListOptions{Offset: 1, Limit: 3},
+			keys:    []string{"a2", "a3", "b1"},
+		},
+		{
+			name:    "offset beyond the keys",
+			options: &proto.ListOptions{Offset: 10},
+			keys:    []string{},
+		},
+		{
+			name:    "unknown table",
+			options: &proto.ListOptions{Table: "unknown"},
+			keys:    []string{},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if scenario.options.Table == "" {
+				scenario.options.Table = "list"
+			}
+			keys, _ := list(t, scenario.options)
+			assert.Equal(t, scenario.keys, keys)
+		})
+	}
+}
+
+func TestListPages(t *testing.T) {
+	for i := 0; i < 250; i++ {
+		write(t, "pages", &proto.Record{Key: fmt.Sprintf("key-%03d", i)}, nil)
+	}
+
+	keys, messages := list(t, &proto.ListOptions{Table: "pages"})
+	assert.Len(t, keys, 250)
+	assert.Equal(t, "key-000", keys[0])
+	assert.Equal(t, "key-249", keys[249])
+	assert.Equal(t, 3, messages)
+}
+
+func TestKeyEncoding(t *testing.T) {
+	keys := []string{"dir/key", "../escape", ".", "100%"}
+	for _, key := range keys {
+		write(t, "keys/with/slashes", &proto.Record{Key: key, Value: []byte(key)}, nil)
+	}
+
+	for _, key := range keys {
+		rsp, err := read("keys/with/slashes", key)
+		if assert.NoError(t, err) && assert.Len(t, rsp.Records, 1) {
+			assert.Equal(t, key, rsp.Records[0].Key)
+			assert.Equal(t, []byte(key), rsp.Records[0].Value)
+		}
+	}
+
+	listed, _ := list(t, &proto.ListOptions{Table: "keys/with/slashes"})
+	assert.Equal(t, []string{".", "../escape", "100%", "dir/key"}, listed)
+
+	tables, err := newClient().Tables(context.Background(), &proto.TablesRequest{Database: "tests"})
+	if assert.NoError(t, err) {
+		assert.Contains(t, tables.Tables, "keys/with/slashes")
+	}
+	databases, err := newClient().Databases(context.Background(), &proto.DatabasesRequest{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"tests"}, databases.Databases)
+	}
+	_, err = os.Stat(filepath.Join(dataPath, "escape"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func assertConflict(t *testing.T, err error) {
+	if assert.Error(t, err) {
+		assert.Equal(t, int32(409), merrors.FromError(err).Code)
+	}
+}
+
+func TestCompareAndSwap(t *testing.T) {
+	ctx := context.Background()
+	options := func(expected uint64, ifNotExists bool) *proto.WriteOptions {
+		return &proto.WriteOptions{Database: "tests", Table: "cas", ExpectedRevision: expected, IfNotExists: ifNotExists}
+	}
+
+	created, err := newClient().Write(ctx, &proto.WriteRequest{Record: &proto.Record{Key: "key", Value: []byte("1")}, Options: options(0, true)})
+	assert.NoError(t, err)
+	assert.NotZero(t, created.Revision)
+
+	_, err = newClient().Write(ctx, &proto.WriteRequest{Record: &proto.Record{Key: "key"}, Options: options(0, true)})
+	assertConflict(t, err)
+
+	_, err = newClient().Write(ctx, &proto.WriteRequest{Record: &proto.Record{Key: "missing"}, Options: options(created.Revision, false)})
+	assertConflict(t, err)
+
+	updated, err := newClient().Write(ctx, &proto.WriteRequest{Record: &proto.Record{Key: "key", Value: []byte("2")}, Options: options(created.Revision, false)})
+	assert.NoError(t, err)
+	assert.Greater(t, updated.Revision, created.Revision)
+
+	_, err = newClient().Write(ctx, &proto.WriteRequest{Record: &proto.Record{Key: "key", Value: []byte("3")}, Options: options(created.Revision, false)})
+	assertConflict(t, err)
+
+	rsp, err := read("cas", "key")
+	if assert.NoError(t, err) && assert.Len(t, rsp.Records, 1) {
+		assert.Equal(t, []byte("2"), rsp.Records[0].Value)
+		assert.Equal(t, updated.Revision, rsp.Records[0].Revision)
+	}
+
+	_, err = newClient().Delete(ctx, &proto.DeleteRequest{Key: "key", Options: &proto.DeleteOptions{Database: "tests", Table: "cas", ExpectedRevision: created.Revision}})
+	assertConflict(t, err)
+	_, err = newClient().Delete(ctx, &proto.DeleteRequest{Key: "key", Options: &proto.DeleteOptions{Database: "tests", Table: "cas", ExpectedRevision: updated.Revision}})
+	assert.NoError(t, err)
+}
+
+func TestConcurrentIncrements(t *testing.T) {
+	ctx := context.Background()
+	increment := func() error {
+		for {
+			var value int
+			var revision uint64
+			rsp, err := read("counters", "counter")
+			if err == nil {
+				value, _ = strconv.Atoi(string(rsp.Records[0].Value))
+				revision = rsp.Records[0].Revision
+			} else if merrors.FromError(err).Code != 404 {
+				return err
+			}
+
+			_, err = newClient().Write(ctx, &proto.WriteRequest{
+				Record:  &proto.Record{Key: "counter", Value: []byte(strconv.Itoa(value + 1))},
+				Options: &proto.WriteOptions{Database: "tests", Table: "counters", ExpectedRevision: revision, IfNotExists: revision == 0},
+			})
+			if err == nil || merrors.FromError(err).Code != 409 {
+				return err
+			}
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, increment())
+		}()
+	}
+	wg.Wait()
+
+	rsp, err := read("counters", "counter")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []byte("10"), rsp.Records[0].Value)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	ctx := context.Background()
+	writeOp := func(key string, value string, expected uint64) *proto.BatchOperation {
+		return &proto.BatchOperation{Operation: &proto.BatchOperation_Write{Write: &proto.WriteRequest{
+			Record:  &proto.Record{Key: key, Value: []byte(value)},
+			Options: &proto.WriteOptions{ExpectedRevision: expected},
+		}}}
+	}
+	deleteOp := func(key string) *proto.BatchOperation {
+		return &proto.BatchOperation{Operation: &proto.BatchOperation_Delete{Delete: &proto.DeleteRequest{Key: key}}}
+	}
+	batch := func(ops ...*proto.BatchOperation) (*proto.BatchResponse, error) {
+		return newClient().Batch(ctx, &proto.BatchRequest{
+			Options:    &proto.BatchOptions{Database: "tests", Table: "batch"},
+			Operations: ops,
+		})
+	}
+
+	first, err := batch(writeOp("a", "1", 0), writeOp("b", "1", 0), writeOp("c", "1", 0))
+	assert.NoError(t, err)
+	for _, key := range []string{"a", "b", "c"} {
+		rsp, err := read("batch", key)
+		if assert.NoError(t, err) {
+			assert.Equal(t, first.Revision, rsp.Records[0].Revision)
+		}
+	}
+
+	t.Run("all or none", func(t *testing.T) {
+		_, err := batch(writeOp("a", "2", first.Revision), deleteOp("b"), writeOp("c", "2", first.Revision-1))
+		assertConflict(t, err)
+
+		_, err = batch(writeOp("a", "2", 0), deleteOp("missing"))
+		assertNotFound(t, err)
+
+		keys, _ := list(t, &proto.ListOptions{Table: "batch"})
+		assert.Equal(t, []string{"a", "b", "c"}, keys)
+		rsp, err := read("batch", "a")
+		if assert.NoError(t, err) {
+			assert.Equal(t, []byte("1"), rsp.Records[0].Value)
+		}
+	})
+
+	t.Run("operations see the preceding operations", func(t *testing.T) {
+		second, err := batch(writeOp("a", "2", first.Revision), deleteOp("b"), writeOp("d", "1", 0), deleteOp("d"))
+		assert.NoError(t, err)
+		assert.Greater(t, second.Revision, first.Revision)
+
+		keys, _ := list(t, &proto.ListOptions{Table: "batch"})
+		assert.Equal(t, []string{"a", "c"}, keys)
+		rsp, err := read("batch", "a")
+		if assert.NoError(t, err) {
+			assert.Equal(t, []byte("2"), rsp.Records[0].Value)
+			assert.Equal(t, second.Revision, rsp.Records[0].Revision)
+		}
+	})
+
+	t.Run("operations in other tables", func(t *testing.T) {
+		op := writeOp("a", "3", 0)
+		op.GetWrite().Options.Table = "other"
+		_, err := batch(op)
+		if assert.Error(t, err) {
+			assert.Equal(t, int32(400), merrors.FromError(err).Code)
+		}
+	})
+}
+
+// watch streams the events of the table into the returned channel until the test ends. The last value sent is the
+// error that ended the stream.
+func watch(t *testing.T, options *proto.WatchOptions) <-chan interface{} {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	options.Database = "tests"
+	stream, err := newClient().Watch(ctx, &proto.WatchRequest{Options: options})
+	require.NoError(t, err)
+
+	events := make(chan interface{}, 100)
+	go func() {
+		defer stream.Close()
+		for {
+			e, err := stream.Recv()
+			if err != nil {
+				events <- err
+				return
+			}
+			events <- e
+		}
+	}()
+	return events
+}
+
+func nextEvent(t *testing.T, events <-chan interface{}) *proto.WatchEvent {
+	select {
+	case e := <-events:
+		if err, ok := e.(error); ok {
+			t.Fatalf("watch ended: %v", err)
+		}
+		return e.(*proto.WatchEvent)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event received")
+	}
+	return nil
+}
+
+func TestWatch(t *testing.T) {
+	ctx := context.Background()
+	writeRevision := func(key string, options *proto.WriteOptions) uint64 {
+		if options == nil {
+			options = &proto.WriteOptions{}
+		}
+		options.Database, options.Table = "tests", "watch"
+		rsp, err := newClient().Write(ctx, &proto.WriteRequest{Record: &proto.Record{Key: key, Value: []byte(key)}, Options: options})
+		require.NoError(t, err)
+		return rsp.Revision
+	}
+
+	// start watching after a known revision, so that no change gets lost while the watch is set up
+	start := writeRevision("start", nil)
+	events := watch(t, &proto.WatchOptions{Table: "watch", Prefix: "k", Revision: start})
+
+	writeRevision("k1", nil)
+	writeRevision("k1", nil)
+	_, err := newClient().Delete(ctx, &proto.DeleteRequest{Key: "k1", Options: &proto.DeleteOptions{Database: "tests", Table: "watch"}})
+	require.NoError(t, err)
+	writeRevision("other", nil)
+	writeRevision("k2", &proto.WriteOptions{Expiry: 1})
+	_, err = read("watch", "k2")
+	assertNotFound(t, err)
+
+	expected := []struct {
+		eventType proto.WatchEvent_Type
+		key       string
+	}{
+		{proto.WatchEvent_TYPE_CREATE, "k1"},
+		{proto.WatchEvent_TYPE_UPDATE, "k1"},
+		{proto.WatchEvent_TYPE_DELETE, "k1"},
+		{proto.WatchEvent_TYPE_CREATE, "k2"},
+		{proto.WatchEvent_TYPE_EXPIRE, "k2"},
+	}
+	received := make([]*proto.WatchEvent, 0, len(expected))
+	for _, e := range expected {
+		event := nextEvent(t, events)
+		assert.Equal(t, e.eventType, event.Type)
+		assert.Equal(t, e.key, event.Record.Key)
+		if len(received) > 0 {
+			assert.Greater(t, event.Revision, received[len(received)-1].Revision)
+		}
+		received = append(received, event)
+	}
+	assert.Equal(t, []byte("k1"), received[1].Record.Value)
+	assert.Equal(t, received[1].Revision, received[1].Record.Revision)
+
+	t.Run("resume", func(t *testing.T) {
+		resumed := watch(t, &proto.WatchOptions{Table: "watch", Prefix: "k", Revision: received[1].Revision})
+		for _, e := range received[2:] {
+			assert.Equal(t, e.Revision, nextEvent(t, resumed).Revision)
+		}
+	})
+
+	t.Run("compacted revision", func(t *testing.T) {
+		for i := 0; i < 60; i++ {
+			write(t, "compacted", &proto.Record{Key: fmt.Sprintf("key-%d", i)}, nil)
+		}
+		select {
+		case e := <-watch(t, &proto.WatchOptions{Table: "watch", Revision: start}):
+			if err, ok := e.(error); assert.True(t, ok) {
+				// the grpc client does not turn errors of streams into micro errors
+				assert.Equal(t, int32(410), merrors.Parse(status.Convert(err).Message()).Code)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("watch did not fail")
+		}
+	})
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	denied, err := newClient().Snapshot(context.Background(), &proto.SnapshotRequest{})
+	if err == nil {
+		// errors of streams are returned by the first Recv
+		_, err = denied.Recv()
+	}
+	if assert.Error(t, err, "snapshots must require a service token") {
+		assert.Equal(t, int32(403), merrors.Parse(status.Convert(err).Message()).Code)
+	}
+
+	ctx, err := middleware.ContextWithServiceToken(context.Background(), "ocis", jwtSecret)
+	require.NoError(t, err)
+	where := map[string]*proto.Field{"kind": {Type: "string", Value: "snapshot"}}
+	write(t, "snapshot", &proto.Record{Key: "kept", Value: []byte("before"), Metadata: where}, nil)
+	write(t, "snapshot", &proto.Record{Key: "deleted", Value: []byte("before")}, nil)
+
+	stream, err := newClient().Snapshot(ctx, &proto.SnapshotRequest{})
+	require.NoError(t, err)
+	var chunks []*proto.SnapshotResponse
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		chunks = append(chunks, chunk)
+	}
+	paths := map[string]bool{}
+	for _, chunk := range chunks {
+		paths[chunk.Path] = true
+	}
+	assert.True(t, paths["layout.version"])
+	assert.True(t, paths["revision"])
+	assert.True(t, paths["databases/tests/snapshot/kept"])
+
+	write(t, "snapshot", &proto.Record{Key: "kept", Value: []byte("after"), Metadata: where}, nil)
+	write(t, "snapshot", &proto.Record{Key: "created"}, nil)
+	_, err = newClient().Delete(ctx, &proto.DeleteRequest{Key: "deleted", Options: &proto.DeleteOptions{Database: "tests", Table: "snapshot"}})
+	require.NoError(t, err)
+	before, err := newClient().Write(ctx, &proto.WriteRequest{
+		Record:  &proto.Record{Key: "last"},
+		Options: &proto.WriteOptions{Database: "tests", Table: "snapshot"},
+	})
+	require.NoError(t, err)
+
+	t.Run("invalid path", func(t *testing.T) {
+		_, err := newClient().Restore(ctx, &proto.RestoreRequest{Upload: "invalid", Path: "../revision"})
+		if assert.Error(t, err) {
+			assert.Equal(t, int32(400), merrors.FromError(err).Code)
+		}
+	})
+
+	upload := strconv.FormatInt(time.Now().UnixNano(), 10)
+	for _, chunk := range chunks {
+		_, err := newClient().Restore(ctx, &proto.RestoreRequest{Upload: upload, Path: chunk.Path, Data: chunk.Data})
+		require.NoError(t, err)
+	}
+	rsp, err := newClient().Restore(ctx, &proto.RestoreRequest{Upload: upload, Commit: true})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, rsp.Revision, before.Revision)
+
+	kept, err := read("snapshot", "kept")
+	if assert.NoError(t, err) && assert.Len(t, kept.Records, 1) {
+		assert.Equal(t, []byte("before"), kept.Records[0].Value)
+	}
+	_, err = read("snapshot", "deleted")
+	assert.NoError(t, err)
+	_, err = read("snapshot", "created")
+	assertNotFound(t, err)
+
+	// the index was rebuilt from the restored records
+	query, err := newClient().Read(ctx, &proto.ReadRequest{Options: &proto.ReadOptions{Database: "tests", Table: "snapshot", Where: where}})
+	if assert.NoError(t, err) && assert.Len(t, query.Records, 1) {
+		assert.Equal(t, []byte("before"), query.Records[0].Value)
+	}
+
+	next, err := newClient().Write(ctx, &proto.WriteRequest{
+		Record:  &proto.Record{Key: "next"},
+		Options: &proto.WriteOptions{Database: "tests", Table: "snapshot"},
+	})
+	require.NoError(t, err)
+	assert.Greater(t, next.Revision, before.Revision)
+}
@@ -22,6 +22,7 @@ func Server(opts ...Option) grpc.Service {
 
 	hdlr, err := svc.New(
 		svc.Logger(options.Logger),
+		svc.Context(options.Context),
 		svc.Config(options.Config),
 	)
 	if err != nil {
@@ -0,0 +1,304 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	merrors "github.com/micro/go-micro/v2/errors"
+	"github.com/owncloud/ocis/store/pkg/proto/v0"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// journalEntry is the state of a record after a commit, the data is empty if the record was deleted.
+type journalEntry struct {
+	ID   string `json:"id"`
+	Data []byte `json:"data,omitempty"`
+}
+
+// Batch implements the StoreHandler interface.
+func (s *Service) Batch(c context.Context, breq *proto.BatchRequest, bres *proto.BatchResponse) error {
+	opts := breq.Options
+	if opts == nil {
+		opts = &proto.BatchOptions{}
+	}
+	if len(breq.Operations) == 0 {
+		return merrors.BadRequest(s.id, "batch contains no operations")
+	}
+	for i, op := range breq.Operations {
+		var database, table string
+		switch o := op.Operation.(type) {
+		case *proto.BatchOperation_Write:
+			if o.Write.Record == nil {
+				return merrors.BadRequest(s.id, "operation %d: missing record", i)
+			}
+			database, table = o.Write.GetOptions().GetDatabase(), o.Write.GetOptions().GetTable()
+		case *proto.BatchOperation_Delete:
+			database, table = o.Delete.GetOptions().GetDatabase(), o.Delete.GetOptions().GetTable()
+		default:
+			return merrors.BadRequest(s.id, "operation %d: neither write nor delete", i)
+		}
+		if (database != "" && database != opts.Database) || (table != "" && table != opts.Table) {
+			return merrors.BadRequest(s.id, "operation %d: all operations need to be in the table of the batch", i)
+		}
+	}
+
+	revision, err := s.commit(opts.Database, opts.Table, breq.Operations)
+	if err != nil {
+		return err
+	}
+	bres.Revision = revision
+	return nil
+}
+
+// commit applies the operations to the given table all or none. The preconditions of all operations are checked
+// before any record is changed, every changed record gets the same new revision. Commits of more than one record are
+// journaled, so that an interrupted commit is completed on the next start.
+func (s *Service) commit(database, table string, ops []*proto.BatchOperation) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	revision := s.revision + 1
+
+	// pending holds the records changed by the preceding operations, nil for deleted records
+	pending := map[string]*proto.Record{}
+	ids := make([]string, 0, len(ops))
+	// existed tells if a record existed before the commit, keys holds the keys of the changed records
+	existed := map[string]bool{}
+	keys := map[string]string{}
+	current := func(id string) (*proto.Record, error) {
+		if rec, ok := pending[id]; ok {
+			return rec, nil
+		}
+		rec, err := s.readRecordFile(id)
+		if os.IsNotExist(err) || (err == nil && isExpired(rec, now)) {
+			return nil, nil
+		}
+		return rec, err
+	}
+
+	for _, op := range ops {
+		var (
+			id       string
+			key      string
+			expected uint64
+			rec      *proto.Record
+		)
+		switch o := op.Operation.(type) {
+		case *proto.BatchOperation_Write:
+			key = o.Write.Record.Key
+			id = getID(database, table, key)
+			expected = o.Write.GetOptions().GetExpectedRevision()
+			// records are persisted with the time they expire at, the expiry sent to clients is relative to the time
+			// of the request.
+			rec = &proto.Record{
+				Key:      o.Write.Record.Key,
+				Value:    o.Write.Record.Value,
+				Expiry:   expiresAt(o.Write.Record, o.Write.Options, now),
+				Metadata: o.Write.Record.Metadata,
+				Revision: revision,
+			}
+		case *proto.BatchOperation_Delete:
+			key = o.Delete.Key
+			id = getID(database, table, key)
+			expected = o.Delete.GetOptions().GetExpectedRevision()
+		}
+
+		existing, err := current(id)
+		if err != nil {
+			s.log.Error().Err(err).Str("id", id).Msg("could not read record")
+			return 0, merrors.InternalServerError(s.id, "could not read record")
+		}
+		switch {
+		case rec == nil && existing == nil:
+			return 0, merrors.NotFound(s.id, "could not find record")
+		case expected > 0 && existing == nil:
+			return 0, merrors.Conflict(s.id, "record does not exist, expected revision %d", expected)
+		case expected > 0 && existing.Revision != expected:
+			return 0, merrors.Conflict(s.id, "record has revision %d, expected revision %d", existing.Revision, expected)
+		}
+		if w, ok := op.Operation.(*proto.BatchOperation_Write); ok && w.Write.GetOptions().GetIfNotExists() && existing != nil {
+			return 0, merrors.Conflict(s.id, "record already exists")
+		}
+
+		if _, ok := pending[id]; !ok {
+			ids = append(ids, id)
+			existed[id] = existing != nil
+			keys[id] = key
+		}
+		pending[id] = rec
+	}
+
+	entries := make([]journalEntry, 0, len(ids))
+	for _, id := range ids {
+		entry := journalEntry{ID: id}
+		if rec := pending[id]; rec != nil {
+			data, err := protojson.Marshal(rec)
+			if err != nil {
+				return 0, merrors.InternalServerError(s.id, "could not marshal record")
+			}
+			entry.Data = data
+		}
+		entries = append(entries, entry)
+	}
+
+	// the revision is stored before it is used, so that it is never handed out twice
+	if err := writeRevision(s.revisionFile(), revision); err != nil {
+		s.log.Error().Err(err).Msg("could not store revision")
+		return 0, merrors.InternalServerError(s.id, "could not store revision")
+	}
+	s.revision = revision
+
+	if len(entries) > 1 {
+		data, err := json.Marshal(entries)
+		if err != nil {
+			return 0, merrors.InternalServerError(s.id, "could not marshal journal")
+		}
+		if err := writeFileAtomic(s.journalFile(), data); err != nil {
+			s.log.Error().Err(err).Msg("could not write journal")
+			return 0, merrors.InternalServerError(s.id, "could not write journal")
+		}
+	}
+
+	for _, entry := range entries {
+		if err := s.applyEntry(entry); err != nil {
+			s.log.Error().Err(err).Str("id", entry.ID).Msg("could not apply change")
+			return 0, merrors.InternalServerError(s.id, "could not write record")
+		}
+		if err := s.indexEntry(database, table, entry.ID, pending[entry.ID]); err != nil {
+			s.log.Error().Err(err).Str("id", entry.ID).Msg("could not update index")
+			return 0, merrors.InternalServerError(s.id, "could not update index")
+		}
+	}
+
+	if len(entries) > 1 {
+		if err := os.Remove(s.journalFile()); err != nil {
+			s.log.Error().Err(err).Msg("could not remove journal")
+		}
+	}
+
+	events := make([]event, 0, len(ids))
+	for _, id := range ids {
+		e := event{database: database, table: table, WatchEvent: &proto.WatchEvent{Record: pending[id], Revision: revision}}
+		switch {
+		case pending[id] != nil && existed[id]:
+			e.Type = proto.WatchEvent_TYPE_UPDATE
+		case pending[id] != nil:
+			e.Type = proto.WatchEvent_TYPE_CREATE
+		case existed[id]:
+			e.Type = proto.WatchEvent_TYPE_DELETE
+			e.Record = &proto.Record{Key: keys[id], Revision: revision}
+		default:
+			// created and deleted by the same commit
+			continue
+		}
+		events = append(events, e)
+	}
+	s.publish(events...)
+	return revision, nil
+}
+
+// applyEntry writes or deletes the record of a journal entry. It can be applied repeatedly.
+func (s *Service) applyEntry(entry journalEntry) error {
+	file := filepath.Join(s.Config.Datapath, "databases", entry.ID)
+	if entry.Data == nil {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+	return writeFileAtomic(file, entry.Data)
+}
+
+// indexEntry updates the index after a record was written or deleted.
+func (s *Service) indexEntry(database, table, id string, rec *proto.Record) error {
+	if rec == nil {
+		return s.index.Delete(id)
+	}
+	return s.index.Index(id, BleveDocument{
+		Metadata: rec.Metadata,
+		Database: database,
+		Table:    table,
+	})
+}
+
+// replayJournal completes a commit that was interrupted. It returns true if the journal was replayed, the index does
+// not contain the changes then.
+func (s *Service) replayJournal() (bool, error) {
+	data, err := ioutil.ReadFile(s.journalFile())
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	var entries []journalEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return false, err
+	}
+	s.log.Info().Int("records", len(entries)).Msg("completing interrupted commit")
+	for _, entry := range entries {
+		if err := s.applyEntry(entry); err != nil {
+			return false, err
+		}
+	}
+	return true, os.Remove(s.journalFile())
+}
+
+func (s *Service) journalFile() string {
+	return filepath.Join(s.Config.Datapath, "journal.json")
+}
+
+func (s *Service) revisionFile() string {
+	return filepath.Join(s.Config.Datapath, "revision")
+}
+
+// readRevision reads the last revision handed out, it returns 0 if no revision was handed out yet.
+func readRevision(file string) (uint64, error) {
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
+
+func writeRevision(file string, revision uint64) error {
+	return writeFileAtomic(file, []byte(strconv.FormatUint(revision, 10)+"\n"))
+}
+
+// writeFileAtomic writes the file by renaming a temporary file, so that readers never see a partially written file.
+// The temporary file starts with a '.', encodeName never creates such names.
+func writeFileAtomic(file string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(file), ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
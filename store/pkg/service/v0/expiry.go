@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/owncloud/ocis/store/pkg/proto/v0"
+)
+
+// expiresAt returns the unix timestamp in seconds at which the written record expires, 0 if it never expires. The ttl
+// of the options takes precedence over the expiry of the options, which takes precedence over the expiry of the record.
+func expiresAt(rec *proto.Record, opts *proto.WriteOptions, now time.Time) int64 {
+	switch {
+	case opts.GetTtl() > 0:
+		return now.Unix() + opts.GetTtl()
+	case opts.GetExpiry() > 0:
+		return opts.GetExpiry()
+	case rec.Expiry > 0:
+		return now.Unix() + rec.Expiry
+	}
+	return 0
+}
+
+// isExpired checks if the record read from disk is expired at the given time.
+func isExpired(rec *proto.Record, now time.Time) bool {
+	return rec.Expiry > 0 && rec.Expiry <= now.Unix()
+}
+
+// withRemainingExpiry turns the expiry of the record read from disk into the seconds until it expires.
+func withRemainingExpiry(rec *proto.Record, now time.Time) *proto.Record {
+	if rec.Expiry > 0 {
+		rec.Expiry -= now.Unix()
+	}
+	return rec
+}
+
+// expire deletes the record with the given id if it is still expired. The record might have been rewritten since it
+// was read, so it is read again while holding the lock.
+func (s *Service) expire(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expireLocked(id)
+}
+
+// expireLocked is expire for callers that hold the lock.
+func (s *Service) expireLocked(id string) {
+	rec, err := s.readRecordFile(id)
+	if err != nil || !isExpired(rec, time.Now()) {
+		return
+	}
+	database, table, err := splitID(id)
+	if err != nil {
+		s.log.Error().Err(err).Str("id", id).Msg("could not decode id of expired record")
+		return
+	}
+
+	revision := s.revision + 1
+	if err := writeRevision(s.revisionFile(), revision); err != nil {
+		s.log.Error().Err(err).Msg("could not store revision")
+		return
+	}
+	s.revision = revision
+
+	if err := os.Remove(filepath.Join(s.Config.Datapath, "databases", id)); err != nil && !os.IsNotExist(err) {
+		s.log.Error().Err(err).Str("id", id).Msg("could not delete expired record")
+		return
+	}
+	if err := s.index.Delete(id); err != nil {
+		s.log.Error().Err(err).Str("id", id).Msg("could not remove expired record from index")
+		return
+	}
+	s.publish(event{database: database, table: table, WatchEvent: &proto.WatchEvent{
+		Type:     proto.WatchEvent_TYPE_EXPIRE,
+		Record:   &proto.Record{Key: rec.Key, Revision: revision},
+		Revision: revision,
+	}})
+	s.log.Debug().Str("id", id).Msg("deleted expired record")
+}
+
+// sweep deletes all expired records in the given interval until the context is done. Records are also expired
+// lazily when they are read, the sweeper makes sure they don't pile up on disk if nobody reads them.
+func (s *Service) sweep(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			now := time.Now()
+			err := s.walkRecords("", func(id string, rec *proto.Record) {
+				if isExpired(rec, now) {
+					s.expire(id)
+				}
+			})
+			if err != nil {
+				s.log.Error().Err(err).Msg("could not sweep expired records")
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/blevesearch/bleve"
+	"github.com/blevesearch/bleve/analysis/analyzer/keyword"
+	"github.com/blevesearch/bleve/mapping"
+)
+
+// indexVersion is the version of the index. It needs to be increased whenever the mapping or the BleveDocument
+// changes, so that existing indexes get rebuilt.
+const indexVersion = 1
+
+// indexBatchSize is the number of records indexed at once when the index is rebuilt.
+const indexBatchSize = 1000
+
+func newIndexMapping() mapping.IndexMapping {
+	indexMapping := bleve.NewIndexMapping()
+	// keep all symbols in terms to allow exact matching, eg. emails
+	indexMapping.DefaultAnalyzer = keyword.Name
+	return indexMapping
+}
+
+// openIndex opens the index of the records. The index is rebuilt if its version is outdated or if it does not pass
+// the integrity check.
+func (s *Service) openIndex() error {
+	indexDir := filepath.Join(s.Config.Datapath, "index.bleve")
+	versionFile := filepath.Join(s.Config.Datapath, "index.version")
+
+	if err := s.checkIndex(indexDir, versionFile); err != nil {
+		s.log.Info().Str("reason", err.Error()).Msg("rebuilding the index")
+		return s.rebuildIndex(indexDir, versionFile)
+	}
+	s.log.Debug().Msg("using the existing index")
+	return nil
+}
+
+// checkIndex opens the existing index and checks that it contains a document for every record. It catches indexes
+// that missed records because the service stopped between writing a record and indexing it.
+func (s *Service) checkIndex(indexDir, versionFile string) error {
+	version, err := readVersion(versionFile)
+	if err != nil {
+		return err
+	}
+	if version != indexVersion {
+		return fmt.Errorf("index version is %d, expected %d", version, indexVersion)
+	}
+
+	index, err := bleve.Open(indexDir)
+	if err != nil {
+		return fmt.Errorf("could not open index: %v", err)
+	}
+	documents, err := index.DocCount()
+	if err != nil {
+		index.Close()
+		return fmt.Errorf("could not count documents: %v", err)
+	}
+	records, err := s.countRecords()
+	if err != nil {
+		index.Close()
+		return fmt.Errorf("could not count records: %v", err)
+	}
+	if documents != records {
+		index.Close()
+		return fmt.Errorf("index contains %d documents for %d records", documents, records)
+	}
+
+	s.index = index
+	return nil
+}
+
+// rebuildIndex creates a new index of all records. The version marker is removed until the index is complete, so an
+// interrupted rebuild is repeated on the next start.
+func (s *Service) rebuildIndex(indexDir, versionFile string) (err error) {
+	if err = os.Remove(versionFile); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err = os.RemoveAll(indexDir); err != nil {
+		return err
+	}
+	if s.index, err = bleve.New(indexDir, newIndexMapping()); err != nil {
+		return err
+	}
+	if err = s.indexRecords(filepath.Join(s.Config.Datapath, "databases")); err != nil {
+		return err
+	}
+	return writeVersion(versionFile, indexVersion)
+}
+
+// countRecords returns the number of records on disk.
+func (s *Service) countRecords() (uint64, error) {
+	var records uint64
+	err := filepath.Walk(filepath.Join(s.Config.Datapath, "databases"), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && !strings.HasPrefix(info.Name(), ".") {
+			records++
+		}
+		return nil
+	})
+	return records, err
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/owncloud/ocis/store/pkg/proto/v0"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// layoutVersion is the version of the layout of the databases directory. Version 1 joined database, table and key
+// into a path, version 2 encodes them with encodeName.
+const layoutVersion = 2
+
+// encodeName encodes a database, table or key into a file name. All bytes except letters, digits, '-', '_' and '.'
+// are percent encoded, so the name can't contain a path separator. A leading '.' is encoded as well, so the name
+// can't refer to the current or parent directory. The empty name is encoded as "%".
+func encodeName(name string) string {
+	if name == "" {
+		return "%"
+	}
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '-', c == '_', c == '.' && i > 0:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// decodeName decodes a file name created by encodeName.
+func decodeName(name string) (string, error) {
+	if name == "%" {
+		return "", nil
+	}
+	return url.PathUnescape(name)
+}
+
+// migrateLayout moves the records of an older layout of the databases directory to the current layout. The records
+// are copied into a new directory which then replaces the databases directory, so an interrupted migration can be
+// restarted. Files that can't be migrated are copied into the databases.unmigrated directory with their path, which is
+// kept for manual recovery. The index needs to be rebuilt afterwards, the ids of the records change.
+func (s *Service) migrateLayout() error {
+	versionFile := filepath.Join(s.Config.Datapath, "layout.version")
+	recordsDir := filepath.Join(s.Config.Datapath, "databases")
+	migratingDir := recordsDir + ".migrating"
+	unmigratedDir := recordsDir + ".unmigrated"
+	oldDir := recordsDir + ".old"
+
+	version, err := readVersion(versionFile)
+	if err != nil {
+		return err
+	}
+	if version == 0 {
+		// data directories without a marker predate the marker
+		version = 1
+	}
+	if version == layoutVersion {
+		return nil
+	}
+	if version > layoutVersion {
+		return fmt.Errorf("layout version %d of %s is newer than the supported version %d", version, recordsDir, layoutVersion)
+	}
+
+	// a previous migration was interrupted after the old records were moved away
+	if _, err := os.Stat(oldDir); err == nil {
+		if _, err := os.Stat(recordsDir); os.IsNotExist(err) {
+			if err := os.Rename(migratingDir, recordsDir); err != nil {
+				return err
+			}
+		}
+		return s.finishMigration(versionFile, oldDir)
+	}
+
+	entries, err := ioutil.ReadDir(recordsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(entries) == 0 {
+		if err := os.MkdirAll(s.Config.Datapath, 0700); err != nil {
+			return err
+		}
+		return writeVersion(versionFile, layoutVersion)
+	}
+
+	s.log.Info().Int("from", version).Int("to", layoutVersion).Msg("migrating the layout of the records")
+	if err := os.RemoveAll(migratingDir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(migratingDir, 0700); err != nil {
+		return err
+	}
+
+	unmigrated := 0
+	err = filepath.Walk(recordsDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(recordsDir, path)
+		if err != nil {
+			return err
+		}
+
+		rec := &proto.Record{}
+		if err := protojson.Unmarshal(data, rec); err != nil {
+			s.log.Error().Err(err).Str("path", path).Str("dir", unmigratedDir).Msg("could not unmarshal record, keeping it unmigrated")
+			unmigrated++
+			return keepUnmigrated(filepath.Join(unmigratedDir, rel), data)
+		}
+		database, table, ok := splitLegacyID(filepath.ToSlash(rel), rec.Key)
+		if !ok {
+			s.log.Error().Str("path", path).Str("key", rec.Key).Str("dir", unmigratedDir).Msg("could not determine database and table of record, keeping it unmigrated")
+			unmigrated++
+			return keepUnmigrated(filepath.Join(unmigratedDir, rel), data)
+		}
+
+		dest := filepath.Join(migratingDir, getID(database, table, rec.Key))
+		if err := os.MkdirAll(filepath.Dir(dest), 0700); err != nil {
+			return err
+		}
+		return ioutil.WriteFile(dest, data, 0600)
+	})
+	if err != nil {
+		return err
+	}
+	if unmigrated > 0 {
+		s.log.Warn().Int("count", unmigrated).Str("dir", unmigratedDir).Msg("some files could not be migrated, they are kept for manual recovery")
+	}
+
+	if err := os.Rename(recordsDir, oldDir); err != nil {
+		return err
+	}
+	if err := os.Rename(migratingDir, recordsDir); err != nil {
+		return err
+	}
+	return s.finishMigration(versionFile, oldDir)
+}
+
+func (s *Service) finishMigration(versionFile, oldDir string) error {
+	// the ids of the records changed, force a rebuild of the index
+	if err := os.Remove(filepath.Join(s.Config.Datapath, "index.version")); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := writeVersion(versionFile, layoutVersion); err != nil {
+		return err
+	}
+	s.log.Info().Int("version", layoutVersion).Msg("migrated the layout of the records")
+	return os.RemoveAll(oldDir)
+}
+
+// keepUnmigrated copies a file that could not be migrated to dest, so it survives the removal of the old records.
+func keepUnmigrated(dest string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(dest, data, 0600)
+}
+
+// splitLegacyID determines the database and table of a record in the layout version 1 by removing the key from the
+// path of the record. Empty databases or tables left no directory, a single directory is taken as the table.
+func splitLegacyID(rel, key string) (database, table string, ok bool) {
+	if key == "" || !strings.HasSuffix(rel, key) {
+		return "", "", false
+	}
+	prefix := strings.TrimSuffix(rel, key)
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		return "", "", false
+	}
+	parts := strings.Split(strings.Trim(prefix, "/"), "/")
+	switch {
+	case len(parts) == 1 && parts[0] == "":
+		return "", "", true
+	case len(parts) == 1:
+		return "", parts[0], true
+	case len(parts) == 2:
+		return parts[0], parts[1], true
+	}
+	return "", "", false
+}
+
+// readVersion reads a version marker, it returns 0 if the marker does not exist.
+func readVersion(file string) (int, error) {
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	version, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid version marker %s: %v", file, err)
+	}
+	return version, nil
+}
+
+func writeVersion(file string, version int) error {
+	return ioutil.WriteFile(file, []byte(strconv.Itoa(version)+"\n"), 0600)
+}
@@ -1,7 +1,11 @@
 package service
 
 import (
+	"context"
+
 	"github.com/owncloud/ocis/ocis-pkg/log"
+	"github.com/owncloud/ocis/ocis-pkg/roles"
+	settings "github.com/owncloud/ocis/settings/pkg/proto/v0"
 	"github.com/owncloud/ocis/store/pkg/config"
 )
 
@@ -10,11 +14,15 @@ type Option func(o *Options)
 
 // Options defines the available options for this package.
 type Options struct {
-	Logger log.Logger
-	Config *config.Config
+	Logger  log.Logger
+	Context context.Context
+	Config  *config.Config
 
 	Database, Table string
 	Nodes           []string
+
+	RoleService settings.RoleService
+	RoleManager *roles.Manager
 }
 
 func newOptions(opts ...Option) Options {
@@ -34,6 +42,13 @@ func Logger(val log.Logger) Option {
 	}
 }
 
+// Context provides a function to set the context option.
+func Context(val context.Context) Option {
+	return func(o *Options) {
+		o.Context = val
+	}
+}
+
 // Database configures the database option.
 func Database(val *config.Config) Option {
 	return func(o *Options) {
@@ -61,3 +76,17 @@ func Config(val *config.Config) Option {
 		o.Config = val
 	}
 }
+
+// RoleService provides a function to set the RoleService option.
+func RoleService(val settings.RoleService) Option {
+	return func(o *Options) {
+		o.RoleService = val
+	}
+}
+
+// RoleManager provides a function to set the RoleManager option.
+func RoleManager(val *roles.Manager) Option {
+	return func(o *Options) {
+		o.RoleManager = val
+	}
+}
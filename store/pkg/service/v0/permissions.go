@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+
+	"github.com/owncloud/ocis/ocis-pkg/middleware"
+	"github.com/owncloud/ocis/ocis-pkg/roles"
+)
+
+// SettingsManagementPermissionID is the id of the settings management permission of the settings service. Only
+// accounts with this permission, which is part of the admin role, and internal requests of other services, e.g. of the
+// backup command, may take snapshots and restore them.
+const SettingsManagementPermissionID = "79e13b30-3e22-11eb-bc51-0b9f0bad9a58"
+
+func (s *Service) hasBackupPermission(ctx context.Context) bool {
+	if _, ok := middleware.ServiceFromContext(ctx, s.Config.TokenManager.JWTSecret); ok {
+		return true
+	}
+	roleIDs, ok := roles.ReadRoleIDsFromContext(ctx)
+	if !ok {
+		return false
+	}
+	return s.RoleManager.FindPermissionByID(ctx, roleIDs, SettingsManagementPermissionID) != nil
+}
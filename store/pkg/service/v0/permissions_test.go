@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/micro/go-micro/v2/client"
+	merrors "github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/owncloud/ocis/ocis-pkg/log"
+	"github.com/owncloud/ocis/ocis-pkg/middleware"
+	"github.com/owncloud/ocis/ocis-pkg/roles"
+	settings "github.com/owncloud/ocis/settings/pkg/proto/v0"
+	"github.com/owncloud/ocis/store/pkg/config"
+	"github.com/owncloud/ocis/store/pkg/proto/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	adminRoleID = "71881883-1768-46bd-a24d-a356a2afdf7f"
+	userRoleID  = "d7beeea8-8ff4-406b-8fb6-ab2dd81e6b11"
+
+	testJWTSecret = "store-test-secret"
+)
+
+// newPermissionTestService returns a service whose admin role has the settings management permission.
+func newPermissionTestService(t *testing.T) *Service {
+	dataPath, err := ioutil.TempDir("", "ocis-store-test-")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dataPath) })
+
+	bundles := map[string]*settings.Bundle{
+		adminRoleID: {Id: adminRoleID, Settings: []*settings.Setting{{Id: SettingsManagementPermissionID}}},
+		userRoleID:  {Id: userRoleID, Settings: []*settings.Setting{}},
+	}
+	roleManager := roles.NewManager(roles.RoleService(settings.MockRoleService{
+		ListRolesFunc: func(ctx context.Context, req *settings.ListBundlesRequest, opts ...client.CallOption) (*settings.ListBundlesResponse, error) {
+			res := &settings.ListBundlesResponse{}
+			for _, id := range req.BundleIds {
+				if b, ok := bundles[id]; ok {
+					res.Bundles = append(res.Bundles, b)
+				}
+			}
+			return res, nil
+		},
+	}))
+
+	cfg := config.New()
+	cfg.Datapath = dataPath
+	cfg.Service.Namespace = "com.owncloud.api"
+	cfg.Service.Name = "store"
+	cfg.TokenManager.JWTSecret = testJWTSecret
+	s, err := New(Logger(log.NewLogger(log.Level("error"))), Config(cfg), RoleManager(&roleManager))
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func roleCtx(t *testing.T, roleIDs ...string) context.Context {
+	if roleIDs == nil {
+		return context.Background()
+	}
+	data, err := json.Marshal(roleIDs)
+	require.NoError(t, err)
+	return metadata.Set(context.Background(), middleware.RoleIDs, string(data))
+}
+
+func serviceCtx(t *testing.T, secret string) context.Context {
+	ctx, err := middleware.ContextWithServiceToken(context.Background(), "ocis", secret)
+	require.NoError(t, err)
+	return ctx
+}
+
+// snapshotStream collects the chunks of a snapshot.
+type snapshotStream struct {
+	proto.Store_SnapshotStream
+	chunks []*proto.SnapshotResponse
+}
+
+func (s *snapshotStream) Send(res *proto.SnapshotResponse) error {
+	s.chunks = append(s.chunks, res)
+	return nil
+}
+
+func TestBackupPermissions(t *testing.T) {
+	s := newPermissionTestService(t)
+	scenarios := []struct {
+		name      string
+		ctx       func(t *testing.T) context.Context
+		forbidden bool
+	}{
+		{"internal request", func(t *testing.T) context.Context { return serviceCtx(t, testJWTSecret) }, false},
+		{"service token signed with another secret", func(t *testing.T) context.Context { return serviceCtx(t, "other") }, true},
+		{"request without roles", func(t *testing.T) context.Context { return roleCtx(t) }, true},
+		{"admin", func(t *testing.T) context.Context { return roleCtx(t, adminRoleID) }, false},
+		{"user", func(t *testing.T) context.Context { return roleCtx(t, userRoleID) }, true},
+		{"no roles", func(t *testing.T) context.Context { return roleCtx(t, []string{}...) }, true},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			ctx := scenario.ctx(t)
+
+			stream := &snapshotStream{}
+			err := s.Snapshot(ctx, &proto.SnapshotRequest{}, stream)
+			if scenario.forbidden {
+				assert.Equal(t, int32(http.StatusForbidden), merrors.FromError(err).Code)
+				assert.Empty(t, stream.chunks)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, stream.chunks)
+			}
+
+			err = s.Restore(ctx, &proto.RestoreRequest{Upload: "permissions", Path: "revision", Data: []byte("1\n")}, &proto.RestoreResponse{})
+			dir, _ := s.uploads.Take("permissions")
+			defer os.RemoveAll(dir)
+			if scenario.forbidden {
+				assert.Equal(t, int32(http.StatusForbidden), merrors.FromError(err).Code)
+				files, _ := ioutil.ReadDir(dir)
+				assert.Empty(t, files)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
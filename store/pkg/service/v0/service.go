@@ -6,11 +6,18 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/blevesearch/bleve"
-	"github.com/blevesearch/bleve/analysis/analyzer/keyword"
 	merrors "github.com/micro/go-micro/v2/errors"
+	"github.com/owncloud/ocis/ocis-pkg/backup"
 	"github.com/owncloud/ocis/ocis-pkg/log"
+	"github.com/owncloud/ocis/ocis-pkg/roles"
+	"github.com/owncloud/ocis/ocis-pkg/service/grpc"
+	settings "github.com/owncloud/ocis/settings/pkg/proto/v0"
 	"github.com/owncloud/ocis/store/pkg/config"
 	"github.com/owncloud/ocis/store/pkg/proto/v0"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -29,6 +36,35 @@ func New(opts ...Option) (s *Service, err error) {
 	logger := options.Logger
 	cfg := options.Config
 
+	roleManager := options.RoleManager
+	if roleManager == nil {
+		roleService := options.RoleService
+		if roleService == nil {
+			roleService = settings.NewRoleService("com.owncloud.api.settings", grpc.DefaultClient)
+		}
+		m := roles.NewManager(
+			roles.CacheSize(1024),
+			roles.CacheTTL(time.Hour*24*7),
+			roles.Logger(logger),
+			roles.RoleService(roleService),
+		)
+		roleManager = &m
+	}
+
+	s = &Service{
+		id:          cfg.Service.Namespace + "." + cfg.Service.Name,
+		log:         logger,
+		Config:      cfg,
+		RoleManager: roleManager,
+		watchers:    map[*watcher]struct{}{},
+		uploads:     backup.NewUploads(cfg.Service.Name),
+	}
+
+	// migrate before creating the databases directory, an interrupted migration might have moved it away
+	if err = s.migrateLayout(); err != nil {
+		return nil, err
+	}
+
 	recordsDir := filepath.Join(cfg.Datapath, "databases")
 	{
 		var fi os.FileInfo
@@ -44,56 +80,82 @@ func New(opts ...Option) (s *Service, err error) {
 		}
 	}
 
-	indexMapping := bleve.NewIndexMapping()
-	// keep all symbols in terms to allow exact matching, eg. emails
-	indexMapping.DefaultAnalyzer = keyword.Name
-
-	s = &Service{
-		id:     cfg.Service.Namespace + "." + cfg.Service.Name,
-		log:    logger,
-		Config: cfg,
+	if s.revision, err = readRevision(s.revisionFile()); err != nil {
+		return nil, err
 	}
-
-	indexDir := filepath.Join(cfg.Datapath, "index.bleve")
-	// for now recreate index on every start
-	if err = os.RemoveAll(indexDir); err != nil {
+	s.compacted = s.revision
+	var replayed bool
+	if replayed, err = s.replayJournal(); err != nil {
 		return nil, err
 	}
-	if s.index, err = bleve.New(indexDir, indexMapping); err != nil {
-		return
+	if replayed {
+		// the index missed the changes of the interrupted commit
+		if err = os.Remove(filepath.Join(cfg.Datapath, "index.version")); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
 	}
-	if err = s.indexRecords(recordsDir); err != nil {
+	if err = s.openIndex(); err != nil {
 		return nil, err
 	}
+
+	if cfg.Expiry.SweepInterval > 0 {
+		ctx := options.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		go s.sweep(ctx, cfg.Expiry.SweepInterval)
+	}
 	return
 }
 
 // Service implements the AccountsServiceHandler interface
 type Service struct {
-	id     string
-	log    log.Logger
-	Config *config.Config
-	index  bleve.Index
+	id          string
+	log         log.Logger
+	Config      *config.Config
+	RoleManager *roles.Manager
+	index       bleve.Index
+
+	// mu guards the records, the index and the revision, expired records must not be deleted while they are rewritten.
+	mu sync.RWMutex
+	// revision is the last revision handed out.
+	revision uint64
+
+	// watchers receive the changes of the records, the history keeps the latest changes to resume watches. Changes
+	// up to the compacted revision are not in the history anymore.
+	watchers  map[*watcher]struct{}
+	history   []event
+	compacted uint64
+
+	// uploads are the restore uploads that are not committed yet.
+	uploads *backup.Uploads
+}
+
+// Close closes the index of the service.
+func (s *Service) Close() error {
+	return s.index.Close()
 }
 
 // Read implements the StoreHandler interface.
 func (s *Service) Read(c context.Context, rreq *proto.ReadRequest, rres *proto.ReadResponse) error {
 	if len(rreq.Key) != 0 {
 		id := getID(rreq.Options.Database, rreq.Options.Table, rreq.Key)
-		file := filepath.Join(s.Config.Datapath, "databases", id)
 
-		var data []byte
-		rec := &proto.Record{}
-		data, err := ioutil.ReadFile(file)
+		rec, err := s.readRecord(id)
 		if err != nil {
-			return merrors.NotFound(s.id, "could not read record")
+			if os.IsNotExist(err) {
+				return merrors.NotFound(s.id, "could not read record")
+			}
+			return merrors.InternalServerError(s.id, "could not unmarshal record")
 		}
 
-		if err = protojson.Unmarshal(data, rec); err != nil {
-			return merrors.InternalServerError(s.id, "could not unmarshal record")
+		now := time.Now()
+		if isExpired(rec, now) {
+			s.expire(id)
+			return merrors.NotFound(s.id, "could not read record")
 		}
 
-		rres.Records = append(rres.Records, rec)
+		rres.Records = append(rres.Records, withRemainingExpiry(rec, now))
 		return nil
 	}
 
@@ -114,7 +176,19 @@ func (s *Service) Read(c context.Context, rreq *proto.ReadRequest, rres *proto.R
 			query.AddQuery(ntq)
 		}
 
-		searchRequest := bleve.NewSearchRequest(query)
+		// bleve returns 10 hits by default, without a limit all matching records are returned
+		size := int(rreq.Options.Limit)
+		if size == 0 {
+			count, err := s.index.Search(bleve.NewSearchRequestOptions(query, 0, 0, false))
+			if err != nil {
+				s.log.Error().Err(err).Msg("could not execute bleve search")
+				return merrors.InternalServerError(s.id, "could not execute bleve search: %v", err.Error())
+			}
+			size = int(count.Total)
+		}
+		searchRequest := bleve.NewSearchRequestOptions(query, size, int(rreq.Options.Offset), false)
+		// a stable order makes paging with limit and offset possible
+		searchRequest.SortBy([]string{"_id"})
 		var searchResult *bleve.SearchResult
 		searchResult, err := s.index.Search(searchRequest)
 		if err != nil {
@@ -122,24 +196,25 @@ func (s *Service) Read(c context.Context, rreq *proto.ReadRequest, rres *proto.R
 			return merrors.InternalServerError(s.id, "could not execute bleve search: %v", err.Error())
 		}
 
+		now := time.Now()
 		for _, hit := range searchResult.Hits {
-			rec := &proto.Record{}
-
-			dest := filepath.Join(s.Config.Datapath, "databases", hit.ID)
-
-			var data []byte
-			data, err := ioutil.ReadFile(dest)
-			s.log.Info().Str("path", dest).Interface("hit", hit).Msgf("hit info")
+			rec, err := s.readRecord(hit.ID)
+			s.log.Info().Str("id", hit.ID).Interface("hit", hit).Msgf("hit info")
 			if err != nil {
-				s.log.Info().Str("path", dest).Interface("hit", hit).Msgf("file not found")
-				return merrors.NotFound(s.id, "could not read record")
+				if os.IsNotExist(err) {
+					s.log.Info().Str("id", hit.ID).Interface("hit", hit).Msgf("file not found")
+					return merrors.NotFound(s.id, "could not read record")
+				}
+				return merrors.InternalServerError(s.id, "could not unmarshal record")
 			}
 
-			if err = protojson.Unmarshal(data, rec); err != nil {
-				return merrors.InternalServerError(s.id, "could not unmarshal record")
+			// expired records are not part of the result, even if the sweeper did not remove them yet
+			if isExpired(rec, now) {
+				s.expire(hit.ID)
+				continue
 			}
 
-			rres.Records = append(rres.Records, rec)
+			rres.Records = append(rres.Records, withRemainingExpiry(rec, now))
 		}
 		return nil
 	}
@@ -149,59 +224,71 @@ func (s *Service) Read(c context.Context, rreq *proto.ReadRequest, rres *proto.R
 
 // Write implements the StoreHandler interface.
 func (s *Service) Write(c context.Context, wreq *proto.WriteRequest, wres *proto.WriteResponse) error {
-	id := getID(wreq.Options.Database, wreq.Options.Table, wreq.Record.Key)
-	file := filepath.Join(s.Config.Datapath, "databases", id)
-
-	var bytes []byte
-	bytes, err := protojson.Marshal(wreq.Record)
-	if err != nil {
-		return merrors.InternalServerError(s.id, "could not marshal record")
+	if wreq.Record == nil {
+		return merrors.BadRequest(s.id, "missing record")
 	}
-
-	err = os.MkdirAll(filepath.Dir(file), 0700)
-	if err != nil {
-		return err
-	}
-	err = ioutil.WriteFile(file, bytes, 0600)
+	op := &proto.BatchOperation{Operation: &proto.BatchOperation_Write{Write: wreq}}
+	revision, err := s.commit(wreq.GetOptions().GetDatabase(), wreq.GetOptions().GetTable(), []*proto.BatchOperation{op})
 	if err != nil {
-		return merrors.InternalServerError(s.id, "could not write record")
-	}
-
-	doc := BleveDocument{
-		Metadata: wreq.Record.Metadata,
-		Database: wreq.Options.Database,
-		Table:    wreq.Options.Table,
-	}
-	if err := s.index.Index(id, doc); err != nil {
-		s.log.Error().Err(err).Interface("document", doc).Msg("could not index record metadata")
 		return err
 	}
-
+	wres.Revision = revision
 	return nil
 }
 
 // Delete implements the StoreHandler interface.
 func (s *Service) Delete(c context.Context, dreq *proto.DeleteRequest, dres *proto.DeleteResponse) error {
-	id := getID(dreq.Options.Database, dreq.Options.Table, dreq.Key)
-	file := filepath.Join(s.Config.Datapath, "databases", id)
-	if err := os.Remove(file); err != nil {
-		if os.IsNotExist(err) {
-			return merrors.NotFound(s.id, "could not find record")
-		}
+	op := &proto.BatchOperation{Operation: &proto.BatchOperation_Delete{Delete: dreq}}
+	_, err := s.commit(dreq.GetOptions().GetDatabase(), dreq.GetOptions().GetTable(), []*proto.BatchOperation{op})
+	return err
+}
+
+// listPageSize is the maximum number of keys sent in a single message of the list stream.
+const listPageSize = 100
 
-		return merrors.InternalServerError(s.id, "could not delete record")
+// List implements the StoreHandler interface. It streams the keys of all records of the table that are not expired,
+// sorted alphabetically and filtered by the prefix and suffix of the options.
+func (s *Service) List(c context.Context, lreq *proto.ListRequest, stream proto.Store_ListStream) error {
+	opts := lreq.Options
+	if opts == nil {
+		opts = &proto.ListOptions{}
 	}
 
-	if err := s.index.Delete(id); err != nil {
-		s.log.Error().Err(err).Str("id", id).Msg("could not remove record from index")
-		return merrors.InternalServerError(s.id, "could not remove record from index")
+	keys := make([]string, 0)
+	now := time.Now()
+	err := s.walkRecords(getTableID(opts.Database, opts.Table), func(id string, rec *proto.Record) {
+		if !strings.HasPrefix(rec.Key, opts.Prefix) || !strings.HasSuffix(rec.Key, opts.Suffix) {
+			return
+		}
+		if isExpired(rec, now) {
+			s.expire(id)
+			return
+		}
+		keys = append(keys, rec.Key)
+	})
+	if err != nil {
+		return merrors.InternalServerError(s.id, "could not read table directory")
 	}
+	sort.Strings(keys)
 
-	return nil
-}
+	if opts.Offset >= uint64(len(keys)) {
+		return nil
+	}
+	keys = keys[opts.Offset:]
+	if opts.Limit > 0 && opts.Limit < uint64(len(keys)) {
+		keys = keys[:opts.Limit]
+	}
 
-// List implements the StoreHandler interface.
-func (s *Service) List(context.Context, *proto.ListRequest, proto.Store_ListStream) error {
+	for len(keys) > 0 {
+		n := listPageSize
+		if n > len(keys) {
+			n = len(keys)
+		}
+		if err := stream.Send(&proto.ListResponse{Keys: keys[:n]}); err != nil {
+			return merrors.InternalServerError(s.id, "could not send keys: %v", err.Error())
+		}
+		keys = keys[n:]
+	}
 	return nil
 }
 
@@ -219,13 +306,13 @@ func (s *Service) Databases(c context.Context, dbreq *proto.DatabasesRequest, db
 		return merrors.InternalServerError(s.id, "could not read database directory")
 	}
 
-	dbres.Databases = dnames
+	dbres.Databases = s.decodeNames(dnames)
 	return nil
 }
 
 // Tables implements the StoreHandler interface.
 func (s *Service) Tables(ctx context.Context, in *proto.TablesRequest, out *proto.TablesResponse) error {
-	file := filepath.Join(s.Config.Datapath, "databases", in.Database)
+	file := filepath.Join(s.Config.Datapath, "databases", encodeName(in.Database))
 	f, err := os.Open(file)
 	if err != nil {
 		return merrors.InternalServerError(s.id, "could not open tables directory")
@@ -237,96 +324,155 @@ func (s *Service) Tables(ctx context.Context, in *proto.TablesRequest, out *prot
 		return merrors.InternalServerError(s.id, "could not read tables directory")
 	}
 
-	out.Tables = tnames
+	out.Tables = s.decodeNames(tnames)
 	return nil
 }
 
-// TODO sanitize key. As it may contain invalid characters, such as slashes.
+// decodeNames decodes the names of database or table directories, names that can't be decoded are skipped.
+func (s *Service) decodeNames(names []string) []string {
+	decoded := make([]string, 0, len(names))
+	for _, name := range names {
+		n, err := decodeName(name)
+		if err != nil {
+			s.log.Error().Err(err).Str("name", name).Msg("could not decode name")
+			continue
+		}
+		decoded = append(decoded, n)
+	}
+	return decoded
+}
+
+// getID returns the path of a record relative to the databases directory, the names are encoded with encodeName.
 // file: /var/tmp/ocis/store/databases/{database}/{table}/{record.key}.
 func getID(database string, table string, key string) string {
-	// TODO sanitize input.
-	return filepath.Join(database, table, key)
+	return filepath.Join(getTableID(database, table), encodeName(key))
 }
 
-func (s Service) indexRecords(recordsDir string) (err error) {
-
-	// TODO use filepath.Walk to clean up code
-	rh, err := os.Open(recordsDir)
-	if err != nil {
-		return merrors.InternalServerError(s.id, "could not open database directory")
+// splitID returns the database and table of the record with the given id.
+func splitID(id string) (database, table string, err error) {
+	parts := strings.Split(filepath.ToSlash(id), "/")
+	if len(parts) != 3 {
+		return "", "", fmt.Errorf("record %s is not part of a table", id)
+	}
+	if database, err = decodeName(parts[0]); err != nil {
+		return "", "", err
+	}
+	if table, err = decodeName(parts[1]); err != nil {
+		return "", "", err
 	}
-	defer rh.Close()
+	return database, table, nil
+}
 
-	dbs, err := rh.Readdirnames(0)
-	if err != nil {
-		return merrors.InternalServerError(s.id, "could not read databases directory")
+// getTableID returns the path of a table relative to the databases directory.
+func getTableID(database string, table string) string {
+	return filepath.Join(encodeName(database), encodeName(table))
+}
+
+// indexRecords adds all records to the index and deletes the expired ones. The caller needs to hold the lock, unless
+// the service is not serving yet.
+func (s *Service) indexRecords(recordsDir string) (err error) {
+	if _, err = os.Stat(recordsDir); err != nil {
+		return merrors.InternalServerError(s.id, "could not open database directory")
 	}
 
-	for i := range dbs {
-		tp := filepath.Join(s.Config.Datapath, "databases", dbs[i])
-		th, err := os.Open(tp)
-		if err != nil {
-			s.log.Error().Err(err).Str("database", dbs[i]).Msg("could not open database directory")
-			continue
+	// documents are indexed in batches, indexing them one by one commits the index for every record
+	batch := s.index.NewBatch()
+	now := time.Now()
+	err = s.walkRecordsLocked("", func(id string, rec *proto.Record) {
+		if isExpired(rec, now) {
+			s.expireLocked(id)
+			return
 		}
-		defer th.Close()
 
-		tables, err := th.Readdirnames(0)
+		// index record
+		database, table, err := splitID(id)
 		if err != nil {
-			s.log.Error().Err(err).Str("database", dbs[i]).Msg("could not read database directory")
-			continue
+			s.log.Error().Err(err).Str("id", id).Msg("could not decode id")
+			return
 		}
-
-		for j := range tables {
-
-			tp := filepath.Join(s.Config.Datapath, "databases", dbs[i], tables[j])
-			kh, err := os.Open(tp)
-			if err != nil {
-				s.log.Error().Err(err).Str("database", dbs[i]).Str("table", tables[i]).Msg("could not open table directory")
-				continue
-			}
-			defer kh.Close()
-
-			keys, err := kh.Readdirnames(0)
-			if err != nil {
-				s.log.Error().Err(err).Str("database", dbs[i]).Str("table", tables[i]).Msg("could not read table directory")
-				continue
+		doc := BleveDocument{
+			Metadata: rec.Metadata,
+			Database: database,
+			Table:    table,
+		}
+		if err := batch.Index(id, doc); err != nil {
+			s.log.Error().Err(err).Interface("document", doc).Str("id", id).Msg("could not index record metadata")
+			return
+		}
+		if batch.Size() >= indexBatchSize {
+			if err := s.index.Batch(batch); err != nil {
+				s.log.Error().Err(err).Msg("could not index records")
 			}
+			batch.Reset()
+		}
 
-			for k := range keys {
+		s.log.Debug().Str("id", id).Msg("indexed record")
+	})
+	if err != nil {
+		return err
+	}
+	return s.index.Batch(batch)
+}
 
-				id := getID(dbs[i], tables[j], keys[k])
-				kp := filepath.Join(s.Config.Datapath, "databases", id)
+// readRecord reads the record with the given id from disk, the expiry of the record is the time it expires at.
+func (s *Service) readRecord(id string) (*proto.Record, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.readRecordFile(id)
+}
 
-				// read record
-				var data []byte
-				rec := &proto.Record{}
-				data, err = ioutil.ReadFile(kp)
-				if err != nil {
-					s.log.Error().Err(err).Str("id", id).Msg("could not read record")
-					continue
-				}
+// readRecordFile reads the record with the given id from disk. The caller needs to hold the lock.
+func (s *Service) readRecordFile(id string) (*proto.Record, error) {
+	data, err := ioutil.ReadFile(filepath.Join(s.Config.Datapath, "databases", id))
+	if err != nil {
+		return nil, err
+	}
+	rec := &proto.Record{}
+	if err = protojson.Unmarshal(data, rec); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
 
-				if err = protojson.Unmarshal(data, rec); err != nil {
-					s.log.Error().Err(err).Str("id", id).Msg("could not unmarshal record")
-					continue
-				}
+// walkRecords calls fn for every record below the given directory of the databases directory. Records that can't be
+// read are logged and skipped.
+func (s *Service) walkRecords(dir string, fn func(id string, rec *proto.Record)) error {
+	return s.walk(dir, s.readRecord, fn)
+}
 
-				// index record
-				doc := BleveDocument{
-					Metadata: rec.Metadata,
-					Database: dbs[i],
-					Table:    tables[j],
-				}
-				if err := s.index.Index(id, doc); err != nil {
-					s.log.Error().Err(err).Interface("document", doc).Str("id", id).Msg("could not index record metadata")
-					continue
-				}
+// walkRecordsLocked is walkRecords for callers that hold the lock.
+func (s *Service) walkRecordsLocked(dir string, fn func(id string, rec *proto.Record)) error {
+	return s.walk(dir, s.readRecordFile, fn)
+}
 
-				s.log.Debug().Str("id", id).Msg("indexed record")
+func (s *Service) walk(dir string, read func(id string) (*proto.Record, error), fn func(id string, rec *proto.Record)) error {
+	root := filepath.Join(s.Config.Datapath, "databases")
+	err := filepath.Walk(filepath.Join(root, dir), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
 			}
+			return err
+		}
+		// skip directories and temporary files
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".") {
+			return nil
 		}
-	}
 
-	return
+		id, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rec, err := read(id)
+		if err != nil {
+			// the record might have been deleted in the meantime
+			if !os.IsNotExist(err) {
+				s.log.Error().Err(err).Str("id", id).Msg("could not read record")
+			}
+			return nil
+		}
+		fn(id, rec)
+		return nil
+	})
+	return err
 }
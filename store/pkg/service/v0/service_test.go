@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/owncloud/ocis/ocis-pkg/log"
+	"github.com/owncloud/ocis/store/pkg/config"
+	"github.com/owncloud/ocis/store/pkg/proto/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func newTestService(t *testing.T, dataPath string) *Service {
+	cfg := config.New()
+	cfg.Datapath = dataPath
+	cfg.Service.Namespace = "com.owncloud.api"
+	cfg.Service.Name = "store"
+	s, err := New(Logger(log.NewLogger(log.Level("error"))), Config(cfg))
+	require.NoError(t, err)
+	return s
+}
+
+func writeRecordFile(t *testing.T, path string, rec *proto.Record) {
+	data, err := protojson.Marshal(rec)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, ioutil.WriteFile(path, data, 0600))
+}
+
+func readKey(s *Service, database, table, key string) (*proto.ReadResponse, error) {
+	rsp := &proto.ReadResponse{}
+	err := s.Read(context.Background(), &proto.ReadRequest{
+		Key:     key,
+		Options: &proto.ReadOptions{Database: database, Table: table},
+	}, rsp)
+	return rsp, err
+}
+
+func TestEncodeName(t *testing.T) {
+	for _, name := range []string{"", "key", "a/b", "..", ".", ".hidden", "file.txt", "100%", "ümlaut", `a\b`} {
+		encoded := encodeName(name)
+		assert.NotContains(t, encoded, "/")
+		assert.NotContains(t, encoded, `\`)
+		assert.NotEqual(t, ".", encoded)
+		assert.NotEqual(t, "..", encoded)
+		assert.NotEmpty(t, encoded)
+
+		decoded, err := decodeName(encoded)
+		assert.NoError(t, err)
+		assert.Equal(t, name, decoded)
+	}
+	assert.Equal(t, "file.txt", encodeName("file.txt"))
+}
+
+func TestSplitLegacyID(t *testing.T) {
+	scenarios := []struct {
+		rel, key        string
+		database, table string
+		ok              bool
+	}{
+		{rel: "db/tbl/key", key: "key", database: "db", table: "tbl", ok: true},
+		{rel: "db/tbl/dir/key", key: "dir/key", database: "db", table: "tbl", ok: true},
+		{rel: "tbl/key", key: "key", table: "tbl", ok: true},
+		{rel: "key", key: "key", ok: true},
+		{rel: "db/tblkey", key: "key"},
+		{rel: "db/tbl/key", key: "other"},
+		{rel: "a/b/c/key", key: "key"},
+	}
+	for _, scenario := range scenarios {
+		database, table, ok := splitLegacyID(scenario.rel, scenario.key)
+		assert.Equal(t, scenario.ok, ok, scenario.rel)
+		assert.Equal(t, scenario.database, database, scenario.rel)
+		assert.Equal(t, scenario.table, table, scenario.rel)
+	}
+}
+
+func TestMigrateLayout(t *testing.T) {
+	dataPath, err := ioutil.TempDir("", "ocis-store-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dataPath)
+
+	recordsDir := filepath.Join(dataPath, "databases")
+	writeRecordFile(t, filepath.Join(recordsDir, "accounts", "users", "alice"), &proto.Record{Key: "alice", Value: []byte("a")})
+	writeRecordFile(t, filepath.Join(recordsDir, "accounts", "users", "dir", "bob"), &proto.Record{Key: "dir/bob", Value: []byte("b")})
+
+	s := newTestService(t, dataPath)
+	defer s.Close()
+
+	assert.FileExists(t, filepath.Join(recordsDir, "accounts", "users", "alice"))
+	assert.FileExists(t, filepath.Join(recordsDir, "accounts", "users", "dir%2Fbob"))
+	assert.NoDirExists(t, filepath.Join(recordsDir, "accounts", "users", "dir"))
+	assert.NoDirExists(t, recordsDir+".old")
+	assert.NoDirExists(t, recordsDir+".migrating")
+
+	version, err := readVersion(filepath.Join(dataPath, "layout.version"))
+	assert.NoError(t, err)
+	assert.Equal(t, layoutVersion, version)
+
+	rsp, err := readKey(s, "accounts", "users", "dir/bob")
+	if assert.NoError(t, err) && assert.Len(t, rsp.Records, 1) {
+		assert.Equal(t, []byte("b"), rsp.Records[0].Value)
+	}
+}
+
+func TestMigrateLayoutKeepsUnmigratedFiles(t *testing.T) {
+	dataPath, err := ioutil.TempDir("", "ocis-store-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dataPath)
+
+	recordsDir := filepath.Join(dataPath, "databases")
+	writeRecordFile(t, filepath.Join(recordsDir, "accounts", "users", "alice"), &proto.Record{Key: "alice", Value: []byte("a")})
+	// the key does not match the path of the record
+	writeRecordFile(t, filepath.Join(recordsDir, "accounts", "users", "bob"), &proto.Record{Key: "robert", Value: []byte("b")})
+	require.NoError(t, ioutil.WriteFile(filepath.Join(recordsDir, "accounts", "users", "broken"), []byte("{"), 0600))
+
+	s := newTestService(t, dataPath)
+	defer s.Close()
+
+	unmigratedDir := recordsDir + ".unmigrated"
+	data, err := ioutil.ReadFile(filepath.Join(unmigratedDir, "accounts", "users", "broken"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, []byte("{"), data)
+	}
+	assert.FileExists(t, filepath.Join(unmigratedDir, "accounts", "users", "bob"))
+	assert.NoFileExists(t, filepath.Join(unmigratedDir, "accounts", "users", "alice"))
+	assert.NoDirExists(t, recordsDir+".old")
+
+	_, err = readKey(s, "accounts", "users", "alice")
+	assert.NoError(t, err)
+	_, err = readKey(s, "accounts", "users", "robert")
+	assert.Error(t, err)
+}
+
+func TestResumeInterruptedMigration(t *testing.T) {
+	dataPath, err := ioutil.TempDir("", "ocis-store-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dataPath)
+
+	// the old records were moved away, but the migrated ones were not moved into place yet
+	recordsDir := filepath.Join(dataPath, "databases")
+	writeRecordFile(t, filepath.Join(recordsDir+".old", "accounts", "users", "alice"), &proto.Record{Key: "alice"})
+	writeRecordFile(t, filepath.Join(recordsDir+".migrating", "accounts", "users", "alice"), &proto.Record{Key: "alice"})
+
+	s := newTestService(t, dataPath)
+	defer s.Close()
+
+	assert.FileExists(t, filepath.Join(recordsDir, "accounts", "users", "alice"))
+	assert.NoDirExists(t, recordsDir+".old")
+	_, err = readKey(s, "accounts", "users", "alice")
+	assert.NoError(t, err)
+}
+
+func TestPersistentIndex(t *testing.T) {
+	dataPath, err := ioutil.TempDir("", "ocis-store-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dataPath)
+
+	where := map[string]*proto.Field{"mail": {Type: "string", Value: "alice@example.com"}}
+	query := func(s *Service) []*proto.Record {
+		rsp := &proto.ReadResponse{}
+		err := s.Read(context.Background(), &proto.ReadRequest{
+			Options: &proto.ReadOptions{Database: "accounts", Table: "users", Where: where},
+		}, rsp)
+		assert.NoError(t, err)
+		return rsp.Records
+	}
+
+	s := newTestService(t, dataPath)
+	err = s.Write(context.Background(), &proto.WriteRequest{
+		Record:  &proto.Record{Key: "alice", Metadata: where},
+		Options: &proto.WriteOptions{Database: "accounts", Table: "users"},
+	}, &proto.WriteResponse{})
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+
+	t.Run("existing index is used", func(t *testing.T) {
+		s := newTestService(t, dataPath)
+		defer s.Close()
+		assert.Len(t, query(s), 1)
+	})
+
+	t.Run("index missing a record is rebuilt", func(t *testing.T) {
+		// a record that was written, but not indexed
+		writeRecordFile(t, filepath.Join(dataPath, "databases", "accounts", "users", "alice2"), &proto.Record{Key: "alice2", Metadata: where})
+
+		s := newTestService(t, dataPath)
+		defer s.Close()
+		assert.Len(t, query(s), 2)
+	})
+
+	t.Run("outdated index is rebuilt", func(t *testing.T) {
+		require.NoError(t, writeVersion(filepath.Join(dataPath, "index.version"), indexVersion-1))
+
+		s := newTestService(t, dataPath)
+		defer s.Close()
+		assert.Len(t, query(s), 2)
+
+		version, err := readVersion(filepath.Join(dataPath, "index.version"))
+		assert.NoError(t, err)
+		assert.Equal(t, indexVersion, version)
+	})
+
+	t.Run("broken index is rebuilt", func(t *testing.T) {
+		require.NoError(t, os.RemoveAll(filepath.Join(dataPath, "index.bleve")))
+
+		s := newTestService(t, dataPath)
+		defer s.Close()
+		assert.Len(t, query(s), 2)
+	})
+}
+
+func TestReadWhereReturnsAllMatches(t *testing.T) {
+	dataPath, err := ioutil.TempDir("", "ocis-store-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dataPath)
+
+	s := newTestService(t, dataPath)
+	defer s.Close()
+
+	owner := map[string]*proto.Field{"account_id": {Type: "string", Value: "einstein"}}
+	for i := 0; i < 25; i++ {
+		err := s.Write(context.Background(), &proto.WriteRequest{
+			Record:  &proto.Record{Key: fmt.Sprintf("token-%02d", i), Metadata: owner},
+			Options: &proto.WriteOptions{Database: "proxy", Table: "app-tokens"},
+		}, &proto.WriteResponse{})
+		require.NoError(t, err)
+	}
+
+	read := func(limit, offset uint64) []string {
+		rsp := &proto.ReadResponse{}
+		err := s.Read(context.Background(), &proto.ReadRequest{
+			Options: &proto.ReadOptions{Database: "proxy", Table: "app-tokens", Where: owner, Limit: limit, Offset: offset},
+		}, rsp)
+		require.NoError(t, err)
+		keys := make([]string, 0, len(rsp.Records))
+		for _, rec := range rsp.Records {
+			keys = append(keys, rec.Key)
+		}
+		return keys
+	}
+
+	assert.Len(t, read(0, 0), 25)
+
+	page := read(10, 20)
+	assert.Len(t, page, 5)
+	for _, key := range read(20, 0) {
+		assert.NotContains(t, page, key)
+	}
+}
+
+func TestReplayJournal(t *testing.T) {
+	dataPath, err := ioutil.TempDir("", "ocis-store-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dataPath)
+
+	s := newTestService(t, dataPath)
+	err = s.Write(context.Background(), &proto.WriteRequest{
+		Record:  &proto.Record{Key: "deleted"},
+		Options: &proto.WriteOptions{Database: "db", Table: "tbl"},
+	}, &proto.WriteResponse{})
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+
+	// a commit that was interrupted after writing the journal
+	data, err := protojson.Marshal(&proto.Record{Key: "written", Revision: 2})
+	require.NoError(t, err)
+	journal, err := json.Marshal([]journalEntry{
+		{ID: getID("db", "tbl", "written"), Data: data},
+		{ID: getID("db", "tbl", "deleted")},
+	})
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dataPath, "journal.json"), journal, 0600))
+	require.NoError(t, writeRevision(filepath.Join(dataPath, "revision"), 2))
+
+	s = newTestService(t, dataPath)
+	defer s.Close()
+	assert.NoFileExists(t, filepath.Join(dataPath, "journal.json"))
+
+	rsp, err := readKey(s, "db", "tbl", "written")
+	if assert.NoError(t, err) && assert.Len(t, rsp.Records, 1) {
+		assert.Equal(t, uint64(2), rsp.Records[0].Revision)
+	}
+	_, err = readKey(s, "db", "tbl", "deleted")
+	assert.Error(t, err)
+
+	wres := &proto.WriteResponse{}
+	err = s.Write(context.Background(), &proto.WriteRequest{
+		Record:  &proto.Record{Key: "next"},
+		Options: &proto.WriteOptions{Database: "db", Table: "tbl"},
+	}, wres)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), wres.Revision)
+}
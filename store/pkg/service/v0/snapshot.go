@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	merrors "github.com/micro/go-micro/v2/errors"
+	"github.com/owncloud/ocis/ocis-pkg/backup"
+	"github.com/owncloud/ocis/store/pkg/proto/v0"
+)
+
+// Snapshot implements the StoreHandler interface. It streams a consistent copy of the records, the layout version and
+// the revision. The index is left out, it is rebuilt on restore.
+func (s *Service) Snapshot(c context.Context, sreq *proto.SnapshotRequest, stream proto.Store_SnapshotStream) error {
+	if !s.hasBackupPermission(c) {
+		return merrors.Forbidden(s.id, "no permission for Snapshot")
+	}
+
+	dir, err := ioutil.TempDir("", "ocis-store-snapshot-")
+	if err != nil {
+		return merrors.InternalServerError(s.id, "could not create snapshot directory")
+	}
+	defer os.RemoveAll(dir)
+
+	if err := s.copyData(dir); err != nil {
+		s.log.Error().Err(err).Msg("could not copy records")
+		return merrors.InternalServerError(s.id, "could not copy records")
+	}
+	return backup.Send(dir, func(path string, data []byte) error {
+		return stream.Send(&proto.SnapshotResponse{Path: path, Data: data})
+	})
+}
+
+// copyData copies the records into dir, writes are blocked meanwhile.
+func (s *Service) copyData(dir string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	err := backup.CopyDir(filepath.Join(s.Config.Datapath, "databases"), filepath.Join(dir, "databases"), func(rel string) bool {
+		// temporary files of writeFileAtomic
+		return strings.HasPrefix(path.Base(rel), ".")
+	})
+	if err != nil {
+		return err
+	}
+	if err := writeVersion(filepath.Join(dir, "layout.version"), layoutVersion); err != nil {
+		return err
+	}
+	return writeRevision(filepath.Join(dir, "revision"), s.revision)
+}
+
+// Restore implements the StoreHandler interface. The files of a snapshot are staged until the restore is committed,
+// then they replace all records and the index is rebuilt.
+func (s *Service) Restore(c context.Context, rreq *proto.RestoreRequest, rres *proto.RestoreResponse) error {
+	if !s.hasBackupPermission(c) {
+		return merrors.Forbidden(s.id, "no permission for Restore")
+	}
+
+	if !rreq.Commit {
+		if err := s.uploads.Stage(rreq.Upload, rreq.Path, rreq.Data); err != nil {
+			return merrors.BadRequest(s.id, "could not stage %v: %v", rreq.Path, err)
+		}
+		return nil
+	}
+
+	dir, err := s.uploads.Take(rreq.Upload)
+	if err != nil {
+		return merrors.BadRequest(s.id, "%v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	revision, err := s.restore(dir)
+	if e, ok := err.(*merrors.Error); ok {
+		return e
+	}
+	if err != nil {
+		s.log.Error().Err(err).Msg("could not restore records")
+		return merrors.InternalServerError(s.id, "could not restore records: %v", err)
+	}
+	s.log.Info().Uint64("revision", revision).Msg("restored records")
+	rres.Revision = revision
+	return nil
+}
+
+// restore replaces the records with the snapshot in dir. The revision never decreases, so the revisions handed out
+// before stay unique. Watchers are closed, their revisions are meaningless after the restore.
+func (s *Service) restore(dir string) (_ uint64, err error) {
+	version, err := readVersion(filepath.Join(dir, "layout.version"))
+	if err != nil {
+		return 0, err
+	}
+	if version > layoutVersion {
+		return 0, merrors.BadRequest(s.id, "layout version %d of the snapshot is newer than the supported version %d", version, layoutVersion)
+	}
+	revision, err := readRevision(filepath.Join(dir, "revision"))
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	recordsDir := filepath.Join(s.Config.Datapath, "databases")
+	restoringDir := recordsDir + ".restoring"
+	oldDir := recordsDir + ".old"
+	if err := os.RemoveAll(restoringDir); err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(restoringDir, 0700); err != nil {
+		return 0, err
+	}
+	if err := backup.CopyDir(filepath.Join(dir, "databases"), restoringDir, nil); err != nil {
+		return 0, err
+	}
+
+	// the index is rebuilt from scratch, also on the next start if the restore is interrupted
+	if err := s.index.Close(); err != nil {
+		return 0, err
+	}
+	defer func() {
+		if ierr := s.openIndex(); ierr != nil && err == nil {
+			err = ierr
+		}
+	}()
+	if err := os.Remove(filepath.Join(s.Config.Datapath, "index.version")); err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	if err := os.RemoveAll(oldDir); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(recordsDir, oldDir); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(restoringDir, recordsDir); err != nil {
+		return 0, err
+	}
+	if err := os.RemoveAll(oldDir); err != nil {
+		return 0, err
+	}
+
+	// snapshots of an older layout are migrated like an existing data directory
+	if version == 0 {
+		version = 1
+	}
+	if err := writeVersion(filepath.Join(s.Config.Datapath, "layout.version"), version); err != nil {
+		return 0, err
+	}
+	if err := s.migrateLayout(); err != nil {
+		return 0, err
+	}
+
+	if revision < s.revision {
+		revision = s.revision
+	}
+	if err := writeRevision(s.revisionFile(), revision); err != nil {
+		return 0, err
+	}
+	s.revision = revision
+	s.compacted = revision
+	s.history = nil
+	for w := range s.watchers {
+		delete(s.watchers, w)
+		close(w.events)
+	}
+	return revision, nil
+}
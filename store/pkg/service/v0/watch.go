@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	merrors "github.com/micro/go-micro/v2/errors"
+	"github.com/owncloud/ocis/store/pkg/proto/v0"
+)
+
+// watchBuffer is the number of events buffered per watcher. Watchers that fall further behind are closed and need to
+// resume from the last revision they received.
+const watchBuffer = 100
+
+// event is a change of a record of a table. The expiry of the record is the time it expires at, like on disk.
+type event struct {
+	database string
+	table    string
+	*proto.WatchEvent
+}
+
+type watcher struct {
+	options *proto.WatchOptions
+	events  chan event
+}
+
+func (w *watcher) matches(e event) bool {
+	return e.database == w.options.Database && e.table == w.options.Table && strings.HasPrefix(e.Record.Key, w.options.Prefix)
+}
+
+// Watch implements the StoreHandler interface. It streams the changes of the records of a table until the client
+// goes away. With a revision in the options it first streams the changes after that revision, as long as they are
+// still in the history.
+func (s *Service) Watch(c context.Context, wreq *proto.WatchRequest, stream proto.Store_WatchStream) error {
+	opts := wreq.Options
+	if opts == nil {
+		opts = &proto.WatchOptions{}
+	}
+	w := &watcher{options: opts, events: make(chan event, watchBuffer)}
+
+	s.mu.Lock()
+	if opts.Revision > 0 && opts.Revision < s.compacted {
+		s.mu.Unlock()
+		return merrors.New(s.id, fmt.Sprintf("changes before revision %d are not available anymore", s.compacted+1), http.StatusGone)
+	}
+	replay := make([]event, 0)
+	if opts.Revision > 0 {
+		for _, e := range s.history {
+			if e.Revision > opts.Revision && w.matches(e) {
+				replay = append(replay, e)
+			}
+		}
+	}
+	s.watchers[w] = struct{}{}
+	s.mu.Unlock()
+	defer s.unwatch(w)
+
+	for _, e := range replay {
+		if err := stream.Send(forClient(e, time.Now())); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-c.Done():
+			return nil
+		case e, ok := <-w.events:
+			if !ok {
+				return merrors.InternalServerError(s.id, "watch fell behind, resume from the last received revision")
+			}
+			if err := stream.Send(forClient(e, time.Now())); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Service) unwatch(w *watcher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.watchers[w]; ok {
+		delete(s.watchers, w)
+		close(w.events)
+	}
+}
+
+// publish adds the events to the history and sends them to the matching watchers. Watchers that can't keep up are
+// closed. The caller needs to hold the lock.
+func (s *Service) publish(events ...event) {
+	for _, e := range events {
+		s.history = append(s.history, e)
+		for w := range s.watchers {
+			if !w.matches(e) {
+				continue
+			}
+			select {
+			case w.events <- e:
+			default:
+				delete(s.watchers, w)
+				close(w.events)
+			}
+		}
+	}
+
+	if over := len(s.history) - s.Config.Watch.History; over > 0 {
+		s.compacted = s.history[over-1].Revision
+		s.history = append(make([]event, 0, len(s.history)-over), s.history[over:]...)
+	}
+}
+
+// forClient turns the expiry of the record of the event into the seconds until it expires.
+func forClient(e event, now time.Time) *proto.WatchEvent {
+	rec := &proto.Record{
+		Key:      e.Record.Key,
+		Value:    e.Record.Value,
+		Expiry:   e.Record.Expiry,
+		Metadata: e.Record.Metadata,
+		Revision: e.Record.Revision,
+	}
+	return &proto.WatchEvent{
+		Type:     e.Type,
+		Record:   withRemainingExpiry(rec, now),
+		Revision: e.Revision,
+	}
+}